@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IAnalyticsController interface {
+	GetWasteSummary(c echo.Context) error
+	GetWasteByTag(c echo.Context) error
+	GetWasteByMonth(c echo.Context) error
+	GetWasteByStorageLocation(c echo.Context) error
+	GetTopWastedItems(c echo.Context) error
+}
+
+type analyticsController struct {
+	au usecase.IAnalyticsUsecase
+}
+
+func NewAnalyticsController(au usecase.IAnalyticsUsecase) IAnalyticsController {
+	return &analyticsController{au}
+}
+
+// wasteQueryParams parses the period of the waste analytics.
+func wasteQueryParams(c echo.Context) (model.WasteQuery, error) {
+	query := model.WasteQuery{From: c.QueryParam("from"), To: c.QueryParam("to")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return model.WasteQuery{}, errors.New("invalid limit")
+		}
+		query.Limit = n
+	}
+	return query, nil
+}
+
+func analyticsErrorResponse(c echo.Context, err error) error {
+	var verrs validation.Errors
+	switch {
+	case errors.As(err, &verrs):
+		return c.JSON(http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "the role cannot read foods"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
+
+// GetWasteSummary godoc
+// @Summary Get food waste summary
+// @Description Get how many of the foods of the active household archived in the period were discarded or expired, the money wasted on those with a price, and the average days from adding to eating up foods. Days are in the user's time zone
+// @ID get-waste-summary
+// @Accept  json
+// @Produce  json
+// @Param from query string false "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day"
+// @Param to query string false "Last day (YYYY-MM-DD); by default today"
+// @Success 200 {object} model.WasteSummary
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/analytics/waste [get]
+// @Tags analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (ac *analyticsController) GetWasteSummary(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+	query, err := wasteQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	summary, err := ac.au.GetWasteSummary(user, query)
+	if err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetWasteByTag godoc
+// @Summary Get food waste by tag
+// @Description Get the waste rate and money wasted of the foods of the active household archived in the period, by tag
+// @ID get-waste-by-tag
+// @Accept  json
+// @Produce  json
+// @Param from query string false "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day"
+// @Param to query string false "Last day (YYYY-MM-DD); by default today"
+// @Success 200 {object} model.WasteGroupResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/analytics/waste/tags [get]
+// @Tags analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (ac *analyticsController) GetWasteByTag(c echo.Context) error {
+	return ac.wasteGroups(c, model.WasteGroupTag)
+}
+
+// GetWasteByMonth godoc
+// @Summary Get food waste by month
+// @Description Get the waste rate and money wasted of the foods of the active household archived in the period, by month in the user's time zone
+// @ID get-waste-by-month
+// @Accept  json
+// @Produce  json
+// @Param from query string false "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day"
+// @Param to query string false "Last day (YYYY-MM-DD); by default today"
+// @Success 200 {object} model.WasteGroupResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/analytics/waste/months [get]
+// @Tags analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (ac *analyticsController) GetWasteByMonth(c echo.Context) error {
+	return ac.wasteGroups(c, model.WasteGroupMonth)
+}
+
+// GetWasteByStorageLocation godoc
+// @Summary Get food waste by storage location
+// @Description Get the waste rate and money wasted of the foods of the active household archived in the period, by where they were kept
+// @ID get-waste-by-storage-location
+// @Accept  json
+// @Produce  json
+// @Param from query string false "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day"
+// @Param to query string false "Last day (YYYY-MM-DD); by default today"
+// @Success 200 {object} model.WasteGroupResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/analytics/waste/storage-locations [get]
+// @Tags analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (ac *analyticsController) GetWasteByStorageLocation(c echo.Context) error {
+	return ac.wasteGroups(c, model.WasteGroupStorageLocation)
+}
+
+func (ac *analyticsController) wasteGroups(c echo.Context, groupBy string) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+	query, err := wasteQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	groups, err := ac.au.GetWasteGroups(user, groupBy, query)
+	if err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, groups)
+}
+
+// GetTopWastedItems godoc
+// @Summary Get most wasted foods
+// @Description Get the names of the foods of the active household discarded or expired most often in the period
+// @ID get-top-wasted-items
+// @Accept  json
+// @Produce  json
+// @Param from query string false "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day"
+// @Param to query string false "Last day (YYYY-MM-DD); by default today"
+// @Param limit query int false "Maximum number of names (default 10, max 100)"
+// @Success 200 {object} model.WastedItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/analytics/waste/top-items [get]
+// @Tags analytics
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (ac *analyticsController) GetTopWastedItems(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+	query, err := wasteQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	items, err := ac.au.GetTopWastedItems(user, query)
+	if err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, items)
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_analyticsController_GetWasteSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAnalyticsUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		target     string
+		query      *model.WasteQuery
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：期間を指定して集計できる",
+			target:     "/foods/analytics/waste?from=2024-01-01&to=2024-12-31",
+			query:      &model.WasteQuery{From: "2024-01-01", To: "2024-12-31"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：バリデーションエラー",
+			target:     "/foods/analytics/waste?from=2024-13-01",
+			query:      &model.WasteQuery{From: "2024-13-01"},
+			mockErr:    validation.Errors{"from": validation.NewError("validation_date_invalid", "must be a valid date")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "異常系：食材を見られない役割",
+			target:     "/foods/analytics/waste",
+			query:      &model.WasteQuery{},
+			mockErr:    model.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().GetWasteSummary(model.AuthUser{ID: 1}, *tt.query).Return(model.WasteSummary{}, tt.mockErr).Times(1)
+
+			ac := NewAnalyticsController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := ac.GetWasteSummary(c); err != nil {
+				t.Errorf("analyticsController.GetWasteSummary() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("analyticsController.GetWasteSummary() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_analyticsController_GetWasteGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAnalyticsUsecase(ctrl)
+	ac := NewAnalyticsController(mockUsecase)
+
+	tests := []struct {
+		name    string
+		groupBy string
+		handler func(c echo.Context) error
+	}{
+		{name: "正常系：タグごとに集計できる", groupBy: model.WasteGroupTag, handler: ac.GetWasteByTag},
+		{name: "正常系：月ごとに集計できる", groupBy: model.WasteGroupMonth, handler: ac.GetWasteByMonth},
+		{name: "正常系：保存場所ごとに集計できる", groupBy: model.WasteGroupStorageLocation, handler: ac.GetWasteByStorageLocation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().GetWasteGroups(model.AuthUser{ID: 1}, tt.groupBy, model.WasteQuery{To: "2024-12-31"}).Return(model.WasteGroupResponse{Groups: []model.WasteGroup{}}, nil).Times(1)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/foods/analytics/waste/x?to=2024-12-31", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := tt.handler(c); err != nil {
+				t.Errorf("handler error = %v", err)
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %v, want %v", rec.Code, http.StatusOK)
+			}
+		})
+	}
+}
+
+func Test_analyticsController_GetTopWastedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAnalyticsUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		target     string
+		query      *model.WasteQuery
+		wantStatus int
+	}{
+		{
+			name:       "正常系：件数を指定できる",
+			target:     "/foods/analytics/waste/top-items?limit=5",
+			query:      &model.WasteQuery{Limit: 5},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：件数が不正",
+			target:     "/foods/analytics/waste/top-items?limit=x",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.query != nil {
+				mockUsecase.EXPECT().GetTopWastedItems(model.AuthUser{ID: 1}, *tt.query).Return(model.WastedItemResponse{Items: []model.WastedItem{}}, nil).Times(1)
+			}
+
+			ac := NewAnalyticsController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := ac.GetTopWastedItems(c); err != nil {
+				t.Errorf("analyticsController.GetTopWastedItems() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("analyticsController.GetTopWastedItems() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IAPIKeyController interface {
+	GetAPIKeys(c echo.Context) error
+	CreateAPIKey(c echo.Context) error
+	RevokeAPIKey(c echo.Context) error
+}
+
+type apiKeyController struct {
+	ku usecase.IAPIKeyUsecase
+}
+
+func NewAPIKeyController(ku usecase.IAPIKeyUsecase) IAPIKeyController {
+	return &apiKeyController{ku}
+}
+
+// GetAPIKeys godoc
+// @Summary Get API keys
+// @Description Get the device API keys of the household, including revoked ones. Requires the household:manage permission
+// @ID get-api-keys
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Success 200 {array} model.APIKeyResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/api-keys [get]
+// @Tags api-keys
+// @Security BearerAuth
+func (kc *apiKeyController) GetAPIKeys(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	keys, err := kc.ku.GetAPIKeys(id, user.ID)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey godoc
+// @Summary Create API key
+// @Description Create a device API key for the household. The key is returned only once; send it as "Authorization: ApiKey <key>". Requires the household:manage permission
+// @ID create-api-key
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param key body model.APIKeyRequest true "API key"
+// @Success 200 {object} model.APIKeyCreatedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/api-keys [post]
+// @Tags api-keys
+// @Security BearerAuth
+func (kc *apiKeyController) CreateAPIKey(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.APIKeyRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	key, err := kc.ku.CreateAPIKey(id, user.ID, req)
+	if err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, key)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke API key
+// @Description Revoke a device API key of the household. Requires the household:manage permission
+// @ID revoke-api-key
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param keyID path int true "API key ID"
+// @Success 200 {string} string "api key revoked"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/api-keys/{keyID} [delete]
+// @Tags api-keys
+// @Security BearerAuth
+func (kc *apiKeyController) RevokeAPIKey(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	keyID, err := strconv.Atoi(c.Param("keyID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := kc.ku.RevokeAPIKey(id, user.ID, keyID); err != nil {
+		return apiKeyErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "api key revoked")
+}
+
+// apiKeyErrorResponse maps errors returned by the API key usecase to HTTP responses.
+func apiKeyErrorResponse(c echo.Context, err error) error {
+	var verrs validation.Errors
+	switch {
+	case errors.As(err, &verrs):
+		return c.JSON(http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrHouseholdNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "household not found"})
+	case errors.Is(err, model.ErrAPIKeyNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "api key not found"})
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "not allowed in the household"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
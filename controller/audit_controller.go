@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IAuditController interface {
+	GetAuditLogs(c echo.Context) error
+}
+
+type auditController struct {
+	au usecase.IAuditUsecase
+}
+
+func NewAuditController(au usecase.IAuditUsecase) IAuditController {
+	return &auditController{au}
+}
+
+// GetAuditLogs godoc
+// @Summary Get audit log
+// @Description Get changes to foods and images of the active household, newest first, together with the changes to the caller's own account. Household entries require the audit:read permission
+// @ID get-audit-logs
+// @Accept  json
+// @Produce  json
+// @Param entity query string false "Entity type (food, image or user)"
+// @Param entity_id query string false "Entity ID; requires entity"
+// @Param actor query int false "ID of the user who made the changes"
+// @Param since query string false "Only changes at or after the time (RFC 3339)"
+// @Param limit query int false "Maximum number of entries (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} model.AuditLogPage
+// @Failure 400 {object} map[string]string
+// @Router /audit [get]
+// @Tags audit
+// @Security BearerAuth
+func (ac *auditController) GetAuditLogs(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	query := model.AuditLogQuery{
+		EntityType: c.QueryParam("entity"),
+		EntityID:   c.QueryParam("entity_id"),
+		Cursor:     c.QueryParam("cursor"),
+	}
+	if query.EntityID != "" && query.EntityType == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "entity_id requires entity"})
+	}
+	if actor := c.QueryParam("actor"); actor != "" {
+		actorID, err := strconv.Atoi(actor)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid actor"})
+		}
+		query.ActorID = &actorID
+	}
+	if since := c.QueryParam("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid since"})
+		}
+		query.Since = &t
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid limit"})
+		}
+		query.Limit = n
+	}
+
+	page, err := ac.au.GetAuditLogs(user, query)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid cursor"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, page)
+}
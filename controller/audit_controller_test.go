@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_auditController_GetAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAuditUsecase(ctrl)
+	since := time.Date(2024, 9, 25, 0, 0, 0, 0, time.UTC)
+	actorID := 3
+
+	tests := []struct {
+		name       string
+		query      string
+		wantQuery  *model.AuditLogQuery
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：絞り込み条件を渡す",
+			query:      "?entity=food&entity_id=12&actor=3&since=2024-09-25T00:00:00Z&limit=20&cursor=MTIz",
+			wantQuery:  &model.AuditLogQuery{EntityType: "food", EntityID: "12", ActorID: &actorID, Since: &since, Limit: 20, Cursor: "MTIz"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "正常系：条件なし",
+			wantQuery:  &model.AuditLogQuery{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：不正なカーソル",
+			query:      "?cursor=abc",
+			wantQuery:  &model.AuditLogQuery{Cursor: "abc"},
+			mockErr:    model.ErrInvalidCursor,
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "異常系：entity なしの entity_id", query: "?entity_id=12", wantStatus: http.StatusBadRequest},
+		{name: "異常系：不正な since", query: "?since=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "異常系：不正な actor", query: "?actor=abc", wantStatus: http.StatusBadRequest},
+		{name: "異常系：不正な limit", query: "?limit=0", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantQuery != nil {
+				mockUsecase.EXPECT().GetAuditLogs(gomock.Any(), *tt.wantQuery).Return(model.AuditLogPage{}, tt.mockErr).Times(1)
+			}
+
+			ac := NewAuditController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := ac.GetAuditLogs(c); err != nil {
+				t.Errorf("auditController.GetAuditLogs() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("auditController.GetAuditLogs() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
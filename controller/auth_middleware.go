@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authUserKey = "authUser"
+
+// NewAuthMiddleware returns a middleware that authenticates requests by the
+// "Authorization: Bearer <token>" header and stores the caller in echo.Context.
+func NewAuthMiddleware(au usecase.IAuthUsecase) echo.MiddlewareFunc {
+	return newAuthMiddleware(au, nil)
+}
+
+// NewDeviceAuthMiddleware returns a middleware like NewAuthMiddleware that
+// also accepts the "Authorization: ApiKey <key>" header used by devices. It
+// is meant for the routes devices call; the household and permissions of the
+// key are enforced by IHouseholdUsecase.AuthorizeActive.
+func NewDeviceAuthMiddleware(au usecase.IAuthUsecase, ku usecase.IAPIKeyUsecase) echo.MiddlewareFunc {
+	return newAuthMiddleware(au, ku)
+}
+
+func newAuthMiddleware(au usecase.IAuthUsecase, ku usecase.IAPIKeyUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, token, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			if !ok || token == "" {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "missing access token"})
+			}
+
+			var user model.AuthUser
+			var err error
+			switch {
+			case strings.EqualFold(scheme, "Bearer"):
+				user, err = au.Authenticate(token)
+				if err != nil {
+					return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid access token"})
+				}
+			case ku != nil && strings.EqualFold(scheme, "ApiKey"):
+				user, err = ku.Authenticate(token)
+				if err != nil {
+					return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid api key"})
+				}
+			default:
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "missing access token"})
+			}
+
+			user.Meta = requestMeta(c)
+			c.Set(authUserKey, user)
+			return next(c)
+		}
+	}
+}
+
+// currentUser returns the authenticated caller stored by NewAuthMiddleware.
+func currentUser(c echo.Context) (model.AuthUser, bool) {
+	user, ok := c.Get(authUserKey).(model.AuthUser)
+	return user, ok
+}
+
+// requestMeta returns where the request came from, for the audit log. The
+// request ID is the one set by the RequestID middleware.
+func requestMeta(c echo.Context) model.RequestMeta {
+	return model.RequestMeta{
+		IP:        c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+		RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+}
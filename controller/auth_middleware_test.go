@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_NewAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAuthUsecase(ctrl)
+	mockUsecase.EXPECT().Authenticate("valid").Return(model.AuthUser{ID: 1, Email: "sample@test.com"}, nil).AnyTimes()
+	mockUsecase.EXPECT().Authenticate("invalid").Return(model.AuthUser{}, model.ErrUnauthorized).AnyTimes()
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "正常系：有効なトークン", authorization: "Bearer valid", wantStatus: http.StatusOK},
+		{name: "異常系：ヘッダーがない", authorization: "", wantStatus: http.StatusUnauthorized},
+		{name: "異常系：Bearer以外のスキーム", authorization: "Basic valid", wantStatus: http.StatusUnauthorized},
+		{name: "異常系：無効なトークン", authorization: "Bearer invalid", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/foods", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := NewAuthMiddleware(mockUsecase)(func(c echo.Context) error {
+				user, ok := currentUser(c)
+				if !ok || user.ID != 1 {
+					t.Errorf("currentUser() = %v, %v", user, ok)
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(c); err != nil {
+				t.Errorf("NewAuthMiddleware() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("NewAuthMiddleware() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_NewDeviceAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthUsecase := mocks.NewMockIAuthUsecase(ctrl)
+	mockAuthUsecase.EXPECT().Authenticate("valid").Return(model.AuthUser{ID: 1, Email: "sample@test.com"}, nil).AnyTimes()
+	mockAPIKeyUsecase := mocks.NewMockIAPIKeyUsecase(ctrl)
+	mockAPIKeyUsecase.EXPECT().Authenticate("rwk_valid").Return(model.AuthUser{ID: 1, APIKey: &model.APIKeyScope{ID: 1, HouseholdID: 2}}, nil).AnyTimes()
+	mockAPIKeyUsecase.EXPECT().Authenticate("rwk_revoked").Return(model.AuthUser{}, model.ErrUnauthorized).AnyTimes()
+
+	tests := []struct {
+		name          string
+		device        bool
+		authorization string
+		wantStatus    int
+		wantAPIKey    bool
+	}{
+		{name: "正常系：アクセストークンも受け付ける", device: true, authorization: "Bearer valid", wantStatus: http.StatusOK},
+		{name: "正常系：有効なAPIキー", device: true, authorization: "ApiKey rwk_valid", wantStatus: http.StatusOK, wantAPIKey: true},
+		{name: "異常系：失効したAPIキー", device: true, authorization: "ApiKey rwk_revoked", wantStatus: http.StatusUnauthorized},
+		{name: "異常系：デバイス用以外のルートではAPIキーを受け付けない", device: false, authorization: "ApiKey rwk_valid", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/foods", nil)
+			req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			middleware := NewAuthMiddleware(mockAuthUsecase)
+			if tt.device {
+				middleware = NewDeviceAuthMiddleware(mockAuthUsecase, mockAPIKeyUsecase)
+			}
+			handler := middleware(func(c echo.Context) error {
+				user, ok := currentUser(c)
+				if !ok || user.ID != 1 || (user.APIKey != nil) != tt.wantAPIKey {
+					t.Errorf("currentUser() = %v, %v", user, ok)
+				}
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(c); err != nil {
+				t.Errorf("NewDeviceAuthMiddleware() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("NewDeviceAuthMiddleware() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
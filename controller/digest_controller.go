@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IDigestController interface {
+	Preview(c echo.Context) error
+}
+
+type digestController struct {
+	du usecase.IDigestUsecase
+}
+
+func NewDigestController(du usecase.IDigestUsecase) IDigestController {
+	return &digestController{du}
+}
+
+// Preview godoc
+// @Summary Preview digest
+// @Description Render the digest of the caller as it would be sent now, without sending it. The digest lists the foods expiring today and this week, added and expired since the previous digest, in the caller's time zone
+// @ID preview-digest
+// @Accept  json
+// @Produce  json
+// @Param frequency query string false "daily or weekly; the notification preferences by default"
+// @Param language query string false "ja or en; the notification preferences by default"
+// @Success 200 {object} model.DigestPreviewResponse
+// @Failure 400 {object} map[string]string
+// @Router /users/me/digest/preview [get]
+// @Tags notifications
+// @Security BearerAuth
+func (dc *digestController) Preview(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	query := model.DigestPreviewQuery{
+		Frequency: c.QueryParam("frequency"),
+		Language:  c.QueryParam("language"),
+	}
+	preview, err := dc.du.Preview(user.ID, query)
+	if err != nil {
+		var verrs validation.Errors
+		if errors.As(err, &verrs) {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_digestController_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIDigestUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		target     string
+		query      model.DigestPreviewQuery
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：設定どおりのまとめを確認できる", target: "/users/me/digest/preview", wantStatus: http.StatusOK},
+		{
+			name:       "正常系：頻度と言語を指定できる",
+			target:     "/users/me/digest/preview?frequency=weekly&language=en",
+			query:      model.DigestPreviewQuery{Frequency: "weekly", Language: "en"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：バリデーションエラー",
+			target:     "/users/me/digest/preview?frequency=monthly",
+			query:      model.DigestPreviewQuery{Frequency: "monthly"},
+			mockErr:    validation.Errors{"frequency": validation.NewError("validation_in_invalid", "must be a valid value")},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().Preview(1, tt.query).Return(model.DigestPreviewResponse{}, tt.mockErr).Times(1)
+
+			dc := NewDigestController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := dc.Preview(c); err != nil {
+				t.Errorf("digestController.Preview() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("digestController.Preview() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -3,17 +3,32 @@ package controller
 import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/usecase"
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 )
 
 type IFoodController interface {
 	GetFoodsByUserID(c echo.Context) error
+	GetFood(c echo.Context) error
 	CreateFood(c echo.Context) error
 	UpdateFood(c echo.Context) error
+	PatchFood(c echo.Context) error
 	DeleteFood(c echo.Context) error
+	GetTrashedFoods(c echo.Context) error
+	RestoreFood(c echo.Context) error
+	ConsumeFood(c echo.Context) error
+	DisposeFood(c echo.Context) error
+	GetFoodHistory(c echo.Context) error
+	GetExpiringFoods(c echo.Context) error
+	GetExpiredFoods(c echo.Context) error
 }
 type foodController struct {
 	fu usecase.IFoodUsecase
@@ -24,29 +39,189 @@ func NewFoodController(fu usecase.IFoodUsecase) IFoodController {
 }
 
 // GetFoodsByUserID godoc
-// @Summary Get foods by user id
-// @Description Get foods by user id
+// @Summary Get foods of the active household
+// @Description Get a page of the foods of the authenticated user's active household. Foods without an expiration date come last when sorted by it
 // @ID get-foods-by-user-id
 // @Accept  json
 // @Produce  json
-// @Param id path int true "UserID"
-// @Success 200 {array} model.FoodResponse
-// @Router /foods/{id} [get]
+// @Param tag query string false "Only foods with the tag"
+// @Param name query string false "Only foods whose name contains the string"
+// @Param expires_before query string false "Only foods expiring before the time (RFC 3339 or YYYY-MM-DD)"
+// @Param expires_after query string false "Only foods expiring at or after the time (RFC 3339 or YYYY-MM-DD)"
+// @Param sort query string false "Sort key" Enums(expiration_date, created_at, name) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param limit query int false "Maximum number of foods (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} model.FoodPage
+// @Failure 400 {object} map[string]string
+// @Router /foods [get]
 // @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (fc *foodController) GetFoodsByUserID(c echo.Context) error {
-	userID, err := strconv.Atoi(c.Param("id"))
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	query, err := foodQueryParams(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, err)
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
 	}
 
-	foods, err := fc.fu.GetFoodsByUserID(uint(userID))
+	foods, err := fc.fu.GetFoodsByUserID(user, query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return foodErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, foods)
 }
 
+// GetFoodHistory godoc
+// @Summary Get food history
+// @Description Get a page of the archived foods of the authenticated user's active household, which were eaten, discarded, expired or given away, with the quantity that was left
+// @ID get-food-history
+// @Accept  json
+// @Produce  json
+// @Param disposition query string false "Only foods with the disposition" Enums(eaten, discarded, expired, given_away)
+// @Param tag query string false "Only foods with the tag"
+// @Param name query string false "Only foods whose name contains the string"
+// @Param expires_before query string false "Only foods expiring before the time (RFC 3339 or YYYY-MM-DD)"
+// @Param expires_after query string false "Only foods expiring at or after the time (RFC 3339 or YYYY-MM-DD)"
+// @Param sort query string false "Sort key" Enums(archived_at, expiration_date, created_at, name) default(archived_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param limit query int false "Maximum number of foods (default 50, max 200)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} model.FoodPage
+// @Failure 400 {object} map[string]string
+// @Router /foods/history [get]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) GetFoodHistory(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	query, err := foodQueryParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	query.Disposition = c.QueryParam("disposition")
+
+	foods, err := fc.fu.GetFoodHistory(user, query)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, foods)
+}
+
+// foodQueryParams parses the filters, order and page shared by the food
+// listings.
+func foodQueryParams(c echo.Context) (model.FoodQuery, error) {
+	query := model.FoodQuery{
+		Tag:    c.QueryParam("tag"),
+		Name:   c.QueryParam("name"),
+		Sort:   c.QueryParam("sort"),
+		Order:  c.QueryParam("order"),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if before := c.QueryParam("expires_before"); before != "" {
+		t, err := parseDateParam(before)
+		if err != nil {
+			return model.FoodQuery{}, errors.New("invalid expires_before")
+		}
+		query.ExpiresBefore = &t
+	}
+	if after := c.QueryParam("expires_after"); after != "" {
+		t, err := parseDateParam(after)
+		if err != nil {
+			return model.FoodQuery{}, errors.New("invalid expires_after")
+		}
+		query.ExpiresAfter = &t
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return model.FoodQuery{}, errors.New("invalid limit")
+		}
+		query.Limit = n
+	}
+	return query, nil
+}
+
+// parseDateParam parses a time in RFC 3339 or a date, which is taken as
+// midnight UTC like the expiration dates of foods.
+func parseDateParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// GetFood godoc
+// @Summary Get food
+// @Description Get a food of the active household, archived ones included. The ETag is the version of the food, which If-Match of changes names
+// @ID get-food
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Food ID"
+// @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /foods/{id} [get]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) GetFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	food, err := fc.fu.GetFood(uint(id), user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return foodJSON(c, food)
+}
+
+// foodJSON responds with the food and its version as the ETag.
+func foodJSON(c echo.Context, food model.FoodResponse) error {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.Itoa(food.Version)))
+	return c.JSON(http.StatusOK, food)
+}
+
+// foodVersionMatch parses the If-Match header, returning nil if there is none.
+// Entity tags that are not versions of a food, weak ones included, are
+// ignored as they match no food.
+func foodVersionMatch(c echo.Context) *model.FoodVersionMatch {
+	header := c.Request().Header.Get(headerIfMatch)
+	if header == "" {
+		return nil
+	}
+	match := &model.FoodVersionMatch{Versions: []int{}}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			match.Any = true
+			continue
+		}
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			match.Versions = append(match.Versions, version)
+		}
+	}
+	return match
+}
 
 // CreateFood godoc
 // @Summary Create food
@@ -56,20 +231,30 @@ func (fc *foodController) GetFoodsByUserID(c echo.Context) error {
 // @Produce  json
 // @Param food body model.FoodRequest true "Food"
 // @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 403 {object} map[string]string
 // @Router /foods [post]
 // @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (fc *foodController) CreateFood(c echo.Context) error {
-	food := model.Food{}
-	if err := c.Bind(&food); err != nil {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	// ID や所有者などはリクエストから受け取らない
+	req := model.FoodRequest{}
+	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	createdFood, err := fc.fu.CreateFood(food)
+	createdFood, err := fc.fu.CreateFood(req.Food(), user)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return foodErrorResponse(c, err)
 	}
 
-	return c.JSON(http.StatusOK, createdFood)
+	return foodJSON(c, createdFood)
 }
 
 // UpdateFood godoc
@@ -79,46 +264,378 @@ func (fc *foodController) CreateFood(c echo.Context) error {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "Food ID"
+// @Param If-Match header string false "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false"
 // @Param food body model.FoodRequest true "Food"
 // @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 428 {object} map[string]string
 // @Router /foods/{id} [put]
 // @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (fc *foodController) UpdateFood(c echo.Context) error {
-	food := model.Food{}
-	if err := c.Bind(&food); err != nil {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	// ID やバージョンなどはリクエストから受け取らない
+	req := model.FoodRequest{}
+	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, err)
 	}
-	updatedFood, err := fc.fu.UpdateFood(food, uint(id))
+	updatedFood, err := fc.fu.UpdateFood(req.Food(), uint(id), foodVersionMatch(c), user)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return foodErrorResponse(c, err)
 	}
-	return c.JSON(http.StatusOK, updatedFood)
+	return foodJSON(c, updatedFood)
+}
+
+// mergePatchMIMEType is the media type of JSON Merge Patch (RFC 7396).
+const mergePatchMIMEType = "application/merge-patch+json"
+
+// Headers of conditional requests, which echo has no constants for.
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// PatchFood godoc
+// @Summary Patch food
+// @Description Change some fields of a food with a JSON Merge Patch (RFC 7396) of the food request. Absent members are left unchanged and null resets a member: no expiration date or price, an empty memo or image URL, 0 for the original code and quantity, the tag 'その他' and the storage location '冷蔵'. Only the members in the patch are validated, and the food is returned as saved
+// @ID patch-food
+// @Accept  json
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path int true "Food ID"
+// @Param If-Match header string false "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false"
+// @Param patch body model.FoodRequest true "Members to change"
+// @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 428 {object} map[string]string
+// @Router /foods/{id} [patch]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) PatchFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, mergePatchMIMEType) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		return c.JSON(http.StatusUnsupportedMediaType, echo.Map{"error": "content type must be " + mergePatchMIMEType})
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	// null や配列は食材全体の置き換えになるため、オブジェクトだけを受け付ける
+	patch := model.FoodPatch{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&patch); err != nil || patch == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "patch must be a JSON object"})
+	}
+
+	patchedFood, err := fc.fu.PatchFood(uint(id), patch, foodVersionMatch(c), user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return foodJSON(c, patchedFood)
 }
 
 // DeleteFood godoc
 // @Summary Delete food
-// @Description Delete food
+// @Description Move a food that was added by mistake to the trash, from which it can be restored until it is deleted permanently after the retention period. Foods that were eaten or thrown away should be disposed of instead, which keeps them in the history
 // @ID delete-food
 // @Accept  json
 // @Produce  json
 // @Param id path int true "Food ID"
+// @Param If-Match header string false "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false"
 // @Success 200 {string} string "deleted"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 428 {object} map[string]string
 // @Router /foods/{id} [delete]
 // @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (fc *foodController) DeleteFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	err = fc.fu.DeleteFood(uint(id))
+	err = fc.fu.DeleteFood(uint(id), foodVersionMatch(c), user)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err)
+		return foodErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusOK, "deleted")
 }
+
+// GetTrashedFoods godoc
+// @Summary Get foods in the trash
+// @Description Get the deleted foods of the active household that can still be restored, most recently deleted first
+// @ID get-trashed-foods
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.FoodTrashResponse
+// @Failure 403 {object} map[string]string
+// @Router /foods/trash [get]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) GetTrashedFoods(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	foods, err := fc.fu.GetTrashedFoods(user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, foods)
+}
+
+// RestoreFood godoc
+// @Summary Restore food
+// @Description Move a deleted food out of the trash
+// @ID restore-food
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Food ID"
+// @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /foods/{id}/restore [post]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) RestoreFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	food, err := fc.fu.RestoreFood(uint(id), user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return foodJSON(c, food)
+}
+
+// ConsumeFood godoc
+// @Summary Consume food
+// @Description Use up part of a food, such as half a carton of milk. The quantity is decreased by the amount and the consumption is recorded. The food is archived as eaten, and moves to the history, when none is left
+// @ID consume-food
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Food ID"
+// @Param consumption body model.ConsumeFoodRequest true "Amount consumed"
+// @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /foods/{id}/consume [post]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) ConsumeFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.ConsumeFoodRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	food, err := fc.fu.ConsumeFood(uint(id), req, user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return foodJSON(c, food)
+}
+
+// DisposeFood godoc
+// @Summary Dispose of food
+// @Description Archive a food that was eaten, discarded, expired or given away. It moves from the foods of the household to the history with the quantity that was left
+// @ID dispose-food
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Food ID"
+// @Param disposition body model.DisposeFoodRequest true "Disposition"
+// @Success 200 {object} model.FoodResponse
+// @Header 200 {string} ETag "Version of the food"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /foods/{id}/dispose [post]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) DisposeFood(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.DisposeFoodRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	food, err := fc.fu.DisposeFood(uint(id), req, user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return foodJSON(c, food)
+}
+
+const (
+	defaultExpiringWithinDays = 3
+	maxExpiringWithinDays     = 365
+)
+
+// GetExpiringFoods godoc
+// @Summary Get foods expiring soon
+// @Description Get the foods of the active household that expire from today up to the given number of days later, grouped by day. Days are counted in the user's time zone
+// @ID get-expiring-foods
+// @Accept  json
+// @Produce  json
+// @Param within query string false "Number of days after today, such as 3d or 1w (default 3d, max 365d)"
+// @Success 200 {object} model.FoodExpirationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /foods/expiring [get]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) GetExpiringFoods(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	days := defaultExpiringWithinDays
+	if within := c.QueryParam("within"); within != "" {
+		var err error
+		days, err = parseDays(within)
+		if err != nil || days > maxExpiringWithinDays {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid within"})
+		}
+	}
+
+	foods, err := fc.fu.GetExpiringFoods(user, days)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, foods)
+}
+
+// GetExpiredFoods godoc
+// @Summary Get expired foods
+// @Description Get the foods of the active household that expired before today, grouped by day, most recent first. Days are counted in the user's time zone
+// @ID get-expired-foods
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.FoodExpirationResponse
+// @Failure 403 {object} map[string]string
+// @Router /foods/expired [get]
+// @Tags foods
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (fc *foodController) GetExpiredFoods(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	foods, err := fc.fu.GetExpiredFoods(user)
+	if err != nil {
+		return foodErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, foods)
+}
+
+// parseDays parses a number of days such as "3", "3d" or "2w".
+func parseDays(value string) (int, error) {
+	unit := 1
+	switch {
+	case strings.HasSuffix(value, "d"):
+		value = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		value = strings.TrimSuffix(value, "w")
+		unit = 7
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > math.MaxInt32 {
+		return 0, errors.New("invalid number of days")
+	}
+	return n * unit, nil
+}
+
+// foodErrorResponse maps errors returned by the food usecase to HTTP responses.
+func foodErrorResponse(c echo.Context, err error) error {
+	var verrs validation.Errors
+	switch {
+	case errors.As(err, &verrs):
+		return c.JSON(http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrInvalidCursor):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid cursor"})
+	case errors.Is(err, model.ErrFoodNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "food not found"})
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "food does not belong to the active household or the role cannot edit foods"})
+	case errors.Is(err, model.ErrEmailNotVerified):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "email address is not verified"})
+	case errors.Is(err, model.ErrInsufficientQuantity):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "less than the amount is left"})
+	case errors.Is(err, model.ErrFoodArchived):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "food is archived"})
+	case errors.Is(err, model.ErrFoodNotTrashed):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "food is not in the trash"})
+	case errors.Is(err, model.ErrFoodVersionMismatch):
+		return c.JSON(http.StatusPreconditionFailed, echo.Map{"error": "food has been changed since the version in If-Match"})
+	case errors.Is(err, model.ErrPreconditionRequired):
+		return c.JSON(http.StatusPreconditionRequired, echo.Map{"error": "If-Match with the ETag of the food is required"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
@@ -4,17 +4,22 @@ import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/usecase/mocks" 
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/mock/gomock"
 )
 
+var expirationDate = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
 func Test_foodController_GetFoodsByUserID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -23,14 +28,20 @@ func Test_foodController_GetFoodsByUserID(t *testing.T) {
 	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
 
 	UserID := uint(1)
+	expiresAfter := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	expiresBefore := time.Date(2024, 12, 15, 9, 0, 0, 0, time.UTC)
 
 	type args struct {
 		userID uint
+		query  string
 	}
 	tests := []struct {
 		name        string
 		args        args
-		mockReturns []model.FoodResponse
+		wantQuery   *model.FoodQuery
+		mockReturns model.FoodPage
+		mockErr     error
+		wantStatus  int
 		wantErr     bool
 	}{
 		{
@@ -38,58 +49,123 @@ func Test_foodController_GetFoodsByUserID(t *testing.T) {
 			args: args{
 				userID: UserID,
 			},
-			mockReturns: []model.FoodResponse{
-				{
-					ID:             1,
-					Name:           "food1",
-					UserID:         1,
-					OriginalCode:   123,
-					Quantity:       1,
-					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ImageURL:       "https://example.com",
-					Memo:           "memo",
-				},
-				{
-					ID:             2,
-					Name:           "food2",
-					UserID:         1,
-					OriginalCode:   123,
-					Quantity:       1,
-					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ImageURL:       "https://example.com",
-					Memo:           "memo",
+			wantQuery: &model.FoodQuery{},
+			mockReturns: model.FoodPage{
+				Items: []model.FoodResponse{
+					{
+						ID:             1,
+						Name:           "food1",
+						UserID:         1,
+						OriginalCode:   123,
+						Quantity:       1,
+						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpirationDate: &expirationDate,
+						ImageURL:       "https://example.com",
+						Memo:           "memo",
+					},
+					{
+						ID:             2,
+						Name:           "food2",
+						UserID:         1,
+						OriginalCode:   123,
+						Quantity:       1,
+						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpirationDate: &expirationDate,
+						ImageURL:       "https://example.com",
+						Memo:           "memo",
+					},
 				},
+				NextCursor: "eyJpZCI6Mn0",
 			},
-			wantErr: false,
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "正常系：絞り込みと並び替えの条件を渡す",
+			args: args{
+				userID: UserID,
+				query:  "?tag=%E6%9E%9C%E7%89%A9&name=orange&expires_after=2024-12-01&expires_before=2024-12-15T09:00:00Z&sort=expiration_date&order=desc&limit=20&cursor=abc",
+			},
+			wantQuery: &model.FoodQuery{
+				Tag:           "果物",
+				Name:          "orange",
+				ExpiresAfter:  &expiresAfter,
+				ExpiresBefore: &expiresBefore,
+				Sort:          model.FoodSortExpirationDate,
+				Order:         model.SortOrderDesc,
+				Cursor:        "abc",
+				Limit:         20,
+			},
+			mockReturns: model.FoodPage{Items: []model.FoodResponse{}},
+			wantStatus:  http.StatusOK,
 		},
 		{
 			name: "異常系：ユーザーIDに紐づく食材が取得できない",
 			args: args{
 				userID: 0,
 			},
-			mockReturns: []model.FoodResponse{},
+			wantQuery:  &model.FoodQuery{},
+			mockErr:    model.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "異常系：不正なカーソル",
+			args: args{
+				userID: UserID,
+				query:  "?cursor=abc",
+			},
+			wantQuery:  &model.FoodQuery{Cursor: "abc"},
+			mockErr:    model.ErrInvalidCursor,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系：不正な期限",
+			args: args{
+				userID: UserID,
+				query:  "?expires_before=tomorrow",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系：不正な件数",
+			args: args{
+				userID: UserID,
+				query:  "?limit=-1",
+			},
+			wantStatus: http.StatusBadRequest,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// モック設定：GetFoodsByUserID の引数 userID に対して、mockReturns を返す
-			mockUsecase.EXPECT().GetFoodsByUserID(tt.args.userID).Return(tt.mockReturns, nil)
+			// モック設定：解析した条件に対して、mockReturns を返す
+			if tt.wantQuery != nil {
+				mockUsecase.EXPECT().GetFoodsByUserID(model.AuthUser{ID: int(tt.args.userID)}, *tt.wantQuery).Return(tt.mockReturns, tt.mockErr)
+			}
 
 			fc := NewFoodController(mockUsecase)
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, "/foods/"+strconv.Itoa(int(tt.args.userID)), nil)
+			req := httptest.NewRequest(http.MethodGet, "/foods"+tt.args.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetPath("/foods/:id")
-			c.SetParamNames("id")
-			c.SetParamValues(strconv.Itoa(int(tt.args.userID)))
+			c.SetPath("/foods")
+			c.Set(authUserKey, model.AuthUser{ID: int(tt.args.userID)})
 
 			// メソッド呼び出し
 			if err := fc.GetFoodsByUserID(c); (err != nil) != tt.wantErr {
 				t.Errorf("foodController.GetFoodsByUserID() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.GetFoodsByUserID() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var got model.FoodPage
+				if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+					t.Fatal(err)
+				}
+				if got.NextCursor != tt.mockReturns.NextCursor || len(got.Items) != len(tt.mockReturns.Items) {
+					t.Errorf("foodController.GetFoodsByUserID() = %v, want %v", got, tt.mockReturns)
+				}
+			}
 		})
 	}
 }
@@ -119,7 +195,7 @@ func Test_foodController_CreateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
@@ -131,7 +207,7 @@ func Test_foodController_CreateFood(t *testing.T) {
 				OriginalCode:   123,
 				Quantity:       1,
 				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-				ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpirationDate: &expirationDate,
 				ImageURL:       "https://example.com",
 				Memo:           "memo",
 			},
@@ -146,7 +222,7 @@ func Test_foodController_CreateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),	
+					ExpirationDate: &expirationDate,	
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
@@ -159,7 +235,10 @@ func Test_foodController_CreateFood(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// モック設定：CreateFood の引数 food に対して、mockReturns を返す
-			mockUsecase.EXPECT().CreateFood(tt.args.food).Return(tt.mockReturns, nil)
+			// 本文の ID・登録者・作成日時などは使わない
+			want := tt.args.food
+			want.ID, want.UserID, want.HouseholdID, want.CreatedAt = 0, 0, 0, time.Time{}
+			mockUsecase.EXPECT().CreateFood(want, model.AuthUser{ID: tt.args.food.UserID}).Return(tt.mockReturns, nil)
 
 			fc := NewFoodController(mockUsecase)
 			e := echo.New()
@@ -176,6 +255,7 @@ func Test_foodController_CreateFood(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/foods")
+			c.Set(authUserKey, model.AuthUser{ID: tt.args.food.UserID})
 
 			if err := fc.CreateFood(c); (err != nil) != tt.wantErr {
 				t.Errorf("foodController.CreateFood() error = %v, wantErr %v", err, tt.wantErr)
@@ -200,6 +280,8 @@ func Test_foodController_UpdateFood(t *testing.T) {
 		name        string
 		args        args
 		mockReturns model.FoodResponse
+		mockErr     error
+		wantStatus  int
 		wantErr     bool
 	}{
 		{
@@ -211,7 +293,7 @@ func Test_foodController_UpdateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
@@ -224,35 +306,72 @@ func Test_foodController_UpdateFood(t *testing.T) {
 				OriginalCode:   123,
 				Quantity:       1,
 				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-				ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpirationDate: &expirationDate,
 				ImageURL:       "https://example.com",
 				Memo:           "memo",
 			},
-			wantErr: false,
+			wantStatus: http.StatusOK,
+			wantErr:    false,
 		},
 		{
 			name: "異常系：食材を更新できない",
 			args: args{
 				food: model.Food{
+					ID:             99,
 					Name:           "food1",
 					UserID:         1,
+					HouseholdID:    5,
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
 				id: 1,
 			},
 			mockReturns: model.FoodResponse{},
+			wantStatus:  http.StatusOK,
 			wantErr: false,
 		},
+		{
+			name: "異常系：他のユーザーの食材は更新できない",
+			args: args{
+				food: model.Food{
+					Name:     "food1",
+					UserID:   2,
+					Quantity: 1,
+				},
+				id: 1,
+			},
+			mockReturns: model.FoodResponse{},
+			mockErr:     model.ErrForbidden,
+			wantStatus:  http.StatusForbidden,
+			wantErr:     false,
+		},
+		{
+			name: "異常系：存在しない食材は更新できない",
+			args: args{
+				food: model.Food{
+					Name:     "food1",
+					UserID:   1,
+					Quantity: 1,
+				},
+				id: 100,
+			},
+			mockReturns: model.FoodResponse{},
+			mockErr:     model.ErrFoodNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantErr:     false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// モック設定：UpdateFood の引数 food に対して、mockReturns を返す
-			mockUsecase.EXPECT().UpdateFood(tt.args.food, tt.args.id).Return(tt.mockReturns, nil)
+			// 本文の ID・世帯・登録者・作成日時は使わず、パスの ID の食材を更新する
+			want := tt.args.food
+			want.ID, want.UserID, want.HouseholdID, want.CreatedAt = 0, 0, 0, time.Time{}
+			mockUsecase.EXPECT().UpdateFood(want, tt.args.id, nil, model.AuthUser{ID: tt.args.food.UserID}).Return(tt.mockReturns, tt.mockErr)
 
 			fc := NewFoodController(mockUsecase)
 			e := echo.New()
@@ -271,10 +390,219 @@ func Test_foodController_UpdateFood(t *testing.T) {
 			c.SetPath("/foods/:id")
 			c.SetParamNames("id")
 			c.SetParamValues(strconv.Itoa(int(tt.args.id)))
+			c.Set(authUserKey, model.AuthUser{ID: tt.args.food.UserID})
 
 			if err := fc.UpdateFood(c); (err != nil) != tt.wantErr {
 				t.Errorf("foodController.UpdateFood() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.UpdateFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_PatchFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantPatch   model.FoodPatch
+		mockErr     error
+		wantStatus  int
+	}{
+		{
+			name:        "正常系：JSON Merge Patch で変更できる",
+			contentType: "application/merge-patch+json",
+			body:        `{"quantity":0,"expiration_date":null}`,
+			wantPatch:   model.FoodPatch{"quantity": json.RawMessage(`0`), "expiration_date": json.RawMessage(`null`)},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "正常系：application/json も受け付ける",
+			contentType: echo.MIMEApplicationJSONCharsetUTF8,
+			body:        `{"memo":""}`,
+			wantPatch:   model.FoodPatch{"memo": json.RawMessage(`""`)},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "異常系：バリデーションエラー",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":null}`,
+			wantPatch:   model.FoodPatch{"name": json.RawMessage(`null`)},
+			mockErr:     validation.Errors{"name": errors.New("cannot be blank")},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "異常系：履歴の食材は変更できない",
+			contentType: "application/merge-patch+json",
+			body:        `{"quantity":1}`,
+			wantPatch:   model.FoodPatch{"quantity": json.RawMessage(`1`)},
+			mockErr:     model.ErrFoodArchived,
+			wantStatus:  http.StatusConflict,
+		},
+		{
+			name:        "異常系：オブジェクト以外のパッチ",
+			contentType: "application/merge-patch+json",
+			body:        `null`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "異常系：不正な JSON",
+			contentType: "application/merge-patch+json",
+			body:        `{"quantity":`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "異常系：対応していないメディアタイプ",
+			contentType: echo.MIMEApplicationForm,
+			body:        `quantity=0`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPatch != nil {
+				mockUsecase.EXPECT().PatchFood(uint(1), tt.wantPatch, nil, model.AuthUser{ID: 1}).Return(model.FoodResponse{ID: 1}, tt.mockErr)
+			}
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/foods/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.PatchFood(c); err != nil {
+				t.Errorf("foodController.PatchFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.PatchFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_GetFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+		wantETag   string
+	}{
+		{name: "正常系：バージョンを ETag で返す", wantStatus: http.StatusOK, wantETag: `"3"`},
+		{name: "異常系：存在しない食材", mockErr: model.ErrFoodNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().GetFood(uint(1), model.AuthUser{ID: 1}).Return(model.FoodResponse{ID: 1, Version: 3}, tt.mockErr)
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/foods/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.GetFood(c); err != nil {
+				t.Errorf("foodController.GetFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.GetFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("foodController.GetFood() ETag = %v, want %v", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+func Test_foodVersionMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    *model.FoodVersionMatch
+	}{
+		{name: "正常系：If-Match がなければ条件なし", ifMatch: "", want: nil},
+		{name: "正常系：ETag のバージョン", ifMatch: `"3"`, want: &model.FoodVersionMatch{Versions: []int{3}}},
+		{name: "正常系：複数の ETag", ifMatch: `"3", "4"`, want: &model.FoodVersionMatch{Versions: []int{3, 4}}},
+		{name: "正常系：* はどのバージョンにも一致する", ifMatch: "*", want: &model.FoodVersionMatch{Any: true, Versions: []int{}}},
+		{name: "異常系：弱い ETag は一致しない", ifMatch: `W/"3"`, want: &model.FoodVersionMatch{Versions: []int{}}},
+		{name: "異常系：バージョンでない ETag は一致しない", ifMatch: `"abc"`, want: &model.FoodVersionMatch{Versions: []int{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/foods/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			if got := foodVersionMatch(c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("foodVersionMatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_foodController_DeleteFood_Precondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		ifMatch    string
+		match      *model.FoodVersionMatch
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：一致するバージョンなら削除できる", ifMatch: `"3"`, match: &model.FoodVersionMatch{Versions: []int{3}}, wantStatus: http.StatusOK},
+		{name: "異常系：変更されていれば 412", ifMatch: `"2"`, match: &model.FoodVersionMatch{Versions: []int{2}}, mockErr: model.ErrFoodVersionMismatch, wantStatus: http.StatusPreconditionFailed},
+		{name: "異常系：If-Match がなければ 428", mockErr: model.ErrPreconditionRequired, wantStatus: http.StatusPreconditionRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().DeleteFood(uint(1), tt.match, model.AuthUser{ID: 1}).Return(tt.mockErr)
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/foods/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.DeleteFood(c); err != nil {
+				t.Errorf("foodController.DeleteFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.DeleteFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
 		})
 	}
 }
@@ -287,32 +615,50 @@ func Test_foodController_DeleteFood(t *testing.T) {
 	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
 
 	type args struct {
-		id uint
+		id     uint
+		userID uint
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
+		name       string
+		args       args
+		mockErr    error
+		wantStatus int
+		wantErr    bool
 	}{
 		{
 			name: "正常系：食材を削除できる",
 			args: args{
-				id: 1,
+				id:     1,
+				userID: 1,
 			},
-			wantErr: false,
+			wantStatus: http.StatusOK,
+			wantErr:    false,
 		},
 		{
 			name: "異常系：食材を削除できない",
 			args: args{
-				id: 0,
+				id:     0,
+				userID: 1,
 			},
-			wantErr: false,
+			mockErr:    model.ErrFoodNotFound,
+			wantStatus: http.StatusNotFound,
+			wantErr:    false,
+		},
+		{
+			name: "異常系：他のユーザーの食材は削除できない",
+			args: args{
+				id:     1,
+				userID: 2,
+			},
+			mockErr:    model.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantErr:    false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// モック設定：DeleteFood の引数 id に対して、nil を返す
-			mockUsecase.EXPECT().DeleteFood(tt.args.id).Return(nil)
+			// モック設定：DeleteFood の引数 id と userID に対して、mockErr を返す
+			mockUsecase.EXPECT().DeleteFood(tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)}).Return(tt.mockErr)
 
 			fc := NewFoodController(mockUsecase)
 			e := echo.New()
@@ -322,10 +668,292 @@ func Test_foodController_DeleteFood(t *testing.T) {
 			c.SetPath("/foods/:id")
 			c.SetParamNames("id")
 			c.SetParamValues(strconv.Itoa(int(tt.args.id)))
+			c.Set(authUserKey, model.AuthUser{ID: int(tt.args.userID)})
 
 			if err := fc.DeleteFood(c); (err != nil) != tt.wantErr {
 				t.Errorf("foodController.DeleteFood() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.DeleteFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_ConsumeFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockRes    model.FoodResponse
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：食材を消費できる", body: `{"amount":0.5}`, mockRes: model.FoodResponse{ID: 1, Quantity: 1.5}, wantStatus: http.StatusOK},
+		{name: "異常系：残りより多くは消費できない", body: `{"amount":3}`, mockErr: model.ErrInsufficientQuantity, wantStatus: http.StatusConflict},
+		{name: "異常系：使い切った食材は消費できない", body: `{"amount":1}`, mockErr: model.ErrFoodArchived, wantStatus: http.StatusConflict},
+		{name: "異常系：存在しない食材は消費できない", body: `{"amount":1}`, mockErr: model.ErrFoodNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：量が不正", body: `{"amount":0}`, mockErr: validation.Errors{"amount": errors.New("cannot be blank")}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().ConsumeFood(uint(1), gomock.Any(), model.AuthUser{ID: 1}).Return(tt.mockRes, tt.mockErr)
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/foods/1/consume", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id/consume")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.ConsumeFood(c); err != nil {
+				t.Errorf("foodController.ConsumeFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.ConsumeFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_DisposeFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：食材を片付けられる", body: `{"disposition":"eaten"}`, wantStatus: http.StatusOK},
+		{name: "異常系：不明な片付け方", body: `{"disposition":"lost"}`, mockErr: validation.Errors{"disposition": errors.New("must be a valid value")}, wantStatus: http.StatusBadRequest},
+		{name: "異常系：片付け済みの食材", body: `{"disposition":"eaten"}`, mockErr: model.ErrFoodArchived, wantStatus: http.StatusConflict},
+		{name: "異常系：他の世帯の食材", body: `{"disposition":"eaten"}`, mockErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().DisposeFood(uint(1), gomock.Any(), model.AuthUser{ID: 1}).Return(model.FoodResponse{ID: 1}, tt.mockErr)
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/foods/1/dispose", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id/dispose")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.DisposeFood(c); err != nil {
+				t.Errorf("foodController.DisposeFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.DisposeFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
 		})
 	}
 }
+
+func Test_foodController_GetFoodHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		target     string
+		wantQuery  *model.FoodQuery
+		wantStatus int
+	}{
+		{
+			name:       "正常系：片付け方で絞り込める",
+			target:     "/foods/history?disposition=discarded&tag=%E9%87%8E%E8%8F%9C&limit=10",
+			wantQuery:  &model.FoodQuery{Disposition: model.DispositionDiscarded, Tag: "野菜", Limit: 10},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：件数が不正",
+			target:     "/foods/history?limit=0",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantQuery != nil {
+				mockUsecase.EXPECT().GetFoodHistory(model.AuthUser{ID: 1}, *tt.wantQuery).Return(model.FoodPage{Items: []model.FoodResponse{}}, nil)
+			}
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.GetFoodHistory(c); err != nil {
+				t.Errorf("foodController.GetFoodHistory() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.GetFoodHistory() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_GetTrashedFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+	mockUsecase.EXPECT().GetTrashedFoods(model.AuthUser{ID: 1}).Return(model.FoodTrashResponse{RetentionDays: 30, Items: []model.FoodResponse{{ID: 1}}}, nil)
+
+	fc := NewFoodController(mockUsecase)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/foods/trash", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(authUserKey, model.AuthUser{ID: 1})
+
+	if err := fc.GetTrashedFoods(c); err != nil {
+		t.Errorf("foodController.GetTrashedFoods() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("foodController.GetTrashedFoods() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	var got model.FoodTrashResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.RetentionDays != 30 || len(got.Items) != 1 {
+		t.Errorf("foodController.GetTrashedFoods() = %+v", got)
+	}
+}
+
+func Test_foodController_RestoreFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：食材を復元できる", wantStatus: http.StatusOK},
+		{name: "異常系：ゴミ箱にない食材", mockErr: model.ErrFoodNotTrashed, wantStatus: http.StatusConflict},
+		{name: "異常系：完全に削除された食材", mockErr: model.ErrFoodNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：他の世帯の食材", mockErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().RestoreFood(uint(1), model.AuthUser{ID: 1}).Return(model.FoodResponse{ID: 1}, tt.mockErr)
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/foods/1/restore", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/foods/:id/restore")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.RestoreFood(c); err != nil {
+				t.Errorf("foodController.RestoreFood() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.RestoreFood() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_GetExpiringFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantDays   int
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：既定は3日", wantDays: 3, wantStatus: http.StatusOK},
+		{name: "正常系：日数を指定できる", query: "?within=5d", wantDays: 5, wantStatus: http.StatusOK},
+		{name: "正常系：週数を指定できる", query: "?within=2w", wantDays: 14, wantStatus: http.StatusOK},
+		{name: "正常系：単位なしは日数", query: "?within=0", wantDays: 0, wantStatus: http.StatusOK},
+		{name: "異常系：権限がない", wantDays: 3, mockErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "異常系：不正な期間", query: "?within=3h", wantStatus: http.StatusBadRequest},
+		{name: "異常系：負の期間", query: "?within=-1d", wantStatus: http.StatusBadRequest},
+		{name: "異常系：長すぎる期間", query: "?within=366d", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantStatus != http.StatusBadRequest {
+				mockUsecase.EXPECT().GetExpiringFoods(model.AuthUser{ID: 1}, tt.wantDays).Return(model.FoodExpirationResponse{}, tt.mockErr)
+			}
+
+			fc := NewFoodController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/foods/expiring"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := fc.GetExpiringFoods(c); err != nil {
+				t.Errorf("foodController.GetExpiringFoods() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("foodController.GetExpiringFoods() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_foodController_GetExpiredFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIFoodUsecase(ctrl)
+	date := "2024-12-14"
+	days := -1
+	mockUsecase.EXPECT().GetExpiredFoods(model.AuthUser{ID: 1}).Return(model.FoodExpirationResponse{
+		TimeZone: "Asia/Tokyo",
+		Today:    "2024-12-15",
+		Groups:   []model.FoodExpirationGroup{{Date: &date, DaysRemaining: &days, Items: []model.FoodResponse{{ID: 1}}}},
+	}, nil)
+
+	fc := NewFoodController(mockUsecase)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/foods/expired", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(authUserKey, model.AuthUser{ID: 1})
+
+	if err := fc.GetExpiredFoods(c); err != nil {
+		t.Errorf("foodController.GetExpiredFoods() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("foodController.GetExpiredFoods() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), `"days_remaining":-1`) {
+		t.Errorf("foodController.GetExpiredFoods() body = %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,537 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IHouseholdController interface {
+	GetHouseholds(c echo.Context) error
+	CreateHousehold(c echo.Context) error
+	GetHousehold(c echo.Context) error
+	UpdateHousehold(c echo.Context) error
+	SwitchHousehold(c echo.Context) error
+	InviteMember(c echo.Context) error
+	AcceptInvitation(c echo.Context) error
+	DeclineInvitation(c echo.Context) error
+	UpdateMemberRole(c echo.Context) error
+	RemoveMember(c echo.Context) error
+	GetRoles(c echo.Context) error
+	CreateRole(c echo.Context) error
+	UpdateRole(c echo.Context) error
+	DeleteRole(c echo.Context) error
+}
+
+type householdController struct {
+	hu usecase.IHouseholdUsecase
+}
+
+func NewHouseholdController(hu usecase.IHouseholdUsecase) IHouseholdController {
+	return &householdController{hu}
+}
+
+// GetHouseholds godoc
+// @Summary Get households
+// @Description Get the households the authenticated user belongs to
+// @ID get-households
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} model.HouseholdResponse
+// @Router /households [get]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) GetHouseholds(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	households, err := hc.hu.GetHouseholds(user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, households)
+}
+
+// CreateHousehold godoc
+// @Summary Create household
+// @Description Create a household owned by the authenticated user and make it active
+// @ID create-household
+// @Accept  json
+// @Produce  json
+// @Param household body model.HouseholdRequest true "Household"
+// @Success 200 {object} model.HouseholdResponse
+// @Failure 400 {object} map[string]string
+// @Router /households [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) CreateHousehold(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.HouseholdRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	household, err := hc.hu.CreateHousehold(model.Household{Name: req.Name}, user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, household)
+}
+
+// GetHousehold godoc
+// @Summary Get household
+// @Description Get a household with its members
+// @ID get-household
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Success 200 {object} model.HouseholdDetailResponse
+// @Failure 404 {object} map[string]string
+// @Router /households/{id} [get]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) GetHousehold(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	household, err := hc.hu.GetHousehold(id, user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, household)
+}
+
+// UpdateHousehold godoc
+// @Summary Rename household
+// @Description Rename a household. Requires the household:manage permission
+// @ID update-household
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param household body model.HouseholdRequest true "Household"
+// @Success 200 {object} model.HouseholdResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id} [put]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) UpdateHousehold(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.HouseholdRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	household, err := hc.hu.UpdateHousehold(model.Household{Name: req.Name}, id, user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, household)
+}
+
+// SwitchHousehold godoc
+// @Summary Switch active household
+// @Description Make the household the one the food endpoints operate on
+// @ID switch-household
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Success 200 {string} string "household activated"
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/activate [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) SwitchHousehold(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.SwitchHousehold(id, user.ID); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "household activated")
+}
+
+// InviteMember godoc
+// @Summary Invite member
+// @Description Invite a user to the household by email. Requires the member:invite permission, and only owners can invite with a role that has permissions they do not hold
+// @ID invite-household-member
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param invitation body model.HouseholdInvitationRequest true "Invitation"
+// @Success 200 {string} string "invitation sent"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/{id}/invitations [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) InviteMember(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.HouseholdInvitationRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.InviteMember(id, user.ID, req); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "invitation sent")
+}
+
+// AcceptInvitation godoc
+// @Summary Accept invitation
+// @Description Join the household with the token received by email and make it active
+// @ID accept-household-invitation
+// @Accept  json
+// @Produce  json
+// @Param request body model.HouseholdInvitationTokenRequest true "Invitation token"
+// @Success 200 {object} model.HouseholdResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/invitations/accept [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) AcceptInvitation(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.HouseholdInvitationTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	household, err := hc.hu.AcceptInvitation(req.Token, user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, household)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline invitation
+// @Description Decline the invitation with the token received by email
+// @ID decline-household-invitation
+// @Accept  json
+// @Produce  json
+// @Param request body model.HouseholdInvitationTokenRequest true "Invitation token"
+// @Success 200 {string} string "invitation declined"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /households/invitations/decline [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) DeclineInvitation(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.HouseholdInvitationTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.DeclineInvitation(req.Token, user.ID); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "invitation declined")
+}
+
+// UpdateMemberRole godoc
+// @Summary Change member role
+// @Description Change the role of a member. Requires the member:manage permission, and only owners can appoint or demote owners or give a role with permissions they do not hold
+// @ID update-household-member-role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param userID path int true "User ID of the member"
+// @Param request body model.HouseholdMemberRoleRequest true "Role"
+// @Success 200 {string} string "role updated"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/{id}/members/{userID} [put]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) UpdateMemberRole(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	memberID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.HouseholdMemberRoleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.UpdateMemberRole(id, user.ID, memberID, req.Role); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "role updated")
+}
+
+// RemoveMember godoc
+// @Summary Remove member
+// @Description Remove a member from the household. Removing others requires the member:manage permission and members can leave by removing themselves
+// @ID remove-household-member
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param userID path int true "User ID of the member"
+// @Success 200 {string} string "member removed"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/{id}/members/{userID} [delete]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) RemoveMember(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	memberID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.RemoveMember(id, user.ID, memberID); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "member removed")
+}
+
+// GetRoles godoc
+// @Summary Get roles
+// @Description Get the built-in and custom roles of the household with their permissions
+// @ID get-household-roles
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Success 200 {array} model.HouseholdRoleResponse
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/roles [get]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) GetRoles(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	roles, err := hc.hu.GetRoles(id, user.ID)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, roles)
+}
+
+// CreateRole godoc
+// @Summary Create custom role
+// @Description Create a custom role of the household. Requires the household:manage permission, and only owners can create a role with permissions they do not hold
+// @ID create-household-role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param role body model.HouseholdRoleRequest true "Role"
+// @Success 200 {object} model.HouseholdRoleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/{id}/roles [post]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) CreateRole(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.HouseholdRoleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	role, err := hc.hu.CreateRole(id, user.ID, req)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, role)
+}
+
+// UpdateRole godoc
+// @Summary Update custom role
+// @Description Replace the permissions of a custom role. The name cannot be changed. Requires the household:manage permission, and only owners can change their own role or give a role permissions they do not hold
+// @ID update-household-role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param roleID path int true "Role ID"
+// @Param role body model.HouseholdRoleRequest true "Role"
+// @Success 200 {object} model.HouseholdRoleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/roles/{roleID} [put]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) UpdateRole(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	roleID, err := strconv.Atoi(c.Param("roleID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.HouseholdRoleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	role, err := hc.hu.UpdateRole(id, user.ID, roleID, req)
+	if err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole godoc
+// @Summary Delete custom role
+// @Description Delete a custom role that no member or pending invitation uses. Requires the household:manage permission
+// @ID delete-household-role
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param roleID path int true "Role ID"
+// @Success 200 {string} string "role deleted"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /households/{id}/roles/{roleID} [delete]
+// @Tags households
+// @Security BearerAuth
+func (hc *householdController) DeleteRole(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	roleID, err := strconv.Atoi(c.Param("roleID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := hc.hu.DeleteRole(id, user.ID, roleID); err != nil {
+		return householdErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "role deleted")
+}
+
+// householdErrorResponse maps errors returned by the household usecase to HTTP responses.
+func householdErrorResponse(c echo.Context, err error) error {
+	var verrs validation.Errors
+	switch {
+	case errors.As(err, &verrs):
+		return c.JSON(http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrHouseholdNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "household not found"})
+	case errors.Is(err, model.ErrHouseholdMemberNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "member not found"})
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "not allowed in the household"})
+	case errors.Is(err, model.ErrInvalidToken):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid or expired token"})
+	case errors.Is(err, model.ErrAlreadyHouseholdMember):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "user is already a member of the household"})
+	case errors.Is(err, model.ErrLastHouseholdOwner):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "household must have at least one owner"})
+	case errors.Is(err, model.ErrHouseholdRoleNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "role not found"})
+	case errors.Is(err, model.ErrHouseholdRoleExists):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "role already exists"})
+	case errors.Is(err, model.ErrHouseholdRoleInUse):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "role is assigned to a member or a pending invitation"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
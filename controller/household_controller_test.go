@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_householdController_InviteMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：招待を送信できる", wantStatus: http.StatusOK},
+		{name: "異常系：バリデーションエラー", mockErr: validation.Errors{"email": validation.ErrRequired}, wantStatus: http.StatusBadRequest},
+		{name: "異常系：オーナー以外は招待できない", mockErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "異常系：所属していない世帯には招待できない", mockErr: model.ErrHouseholdNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：既にメンバーのユーザーは招待できない", mockErr: model.ErrAlreadyHouseholdMember, wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation := model.HouseholdInvitationRequest{Email: "invitee@test.com", Role: model.HouseholdRoleMember}
+			mockUsecase.EXPECT().InviteMember(1, 1, invitation).Return(tt.mockErr)
+
+			hc := NewHouseholdController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/households/1/invitations", strings.NewReader(`{"email":"invitee@test.com","role":"member"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/households/:id/invitations")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := hc.InviteMember(c); err != nil {
+				t.Errorf("householdController.InviteMember() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("householdController.InviteMember() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_householdController_RemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		memberID   string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：メンバーを削除できる", memberID: "2", wantStatus: http.StatusOK},
+		{name: "異常系：存在しないメンバーは削除できない", memberID: "3", mockErr: model.ErrHouseholdMemberNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：最後のオーナーは抜けられない", memberID: "1", mockErr: model.ErrLastHouseholdOwner, wantStatus: http.StatusConflict},
+		{name: "異常系：不正なユーザーID", memberID: "abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantStatus != http.StatusBadRequest {
+				mockUsecase.EXPECT().RemoveMember(1, 1, gomock.Any()).Return(tt.mockErr)
+			}
+
+			hc := NewHouseholdController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/households/1/members/"+tt.memberID, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/households/:id/members/:userID")
+			c.SetParamNames("id", "userID")
+			c.SetParamValues("1", tt.memberID)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := hc.RemoveMember(c); err != nil {
+				t.Errorf("householdController.RemoveMember() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("householdController.RemoveMember() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
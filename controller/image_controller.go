@@ -3,6 +3,7 @@ package controller
 import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/usecase"
+	"errors"
 	"io"
 	"net/http"
 
@@ -31,7 +32,14 @@ func NewImageController(iu usecase.IImageUsecase) IImageController {
 // @Param image formData file true "image"
 // @Success 200 {string} string "image url"
 // @Router /images [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (ic *imageController) UploadImage(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	imageFile, err := c.FormFile("image")
 	if err != nil {
 		return c.JSON(400, err)
@@ -49,8 +57,11 @@ func (ic *imageController) UploadImage(c echo.Context) error {
 		}
 	}()
 
-	image, err := ic.iu.UploadImage(file)
+	image, err := ic.iu.UploadImage(file, user)
 	if err != nil {
+		if errors.Is(err, model.ErrForbidden) {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
+		}
 		return c.JSON(400, err)
 	}
 	return c.JSON(200, "images/"+image.Filename)
@@ -65,10 +76,20 @@ func (ic *imageController) UploadImage(c echo.Context) error {
 // @Param imageURL path string true "image URL（URLとは書いていますが、画像の名前のみで大丈夫です）"
 // @Router /images/{imageURL} [get]
 // @Success 200 {file} nil "Successfully fetched image"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 func (ic *imageController) FetchImage(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	imageURL := c.Param("imageURL")
-	image, err := ic.iu.FetchImage(imageURL)
+	image, err := ic.iu.FetchImage(imageURL, user)
 	if err != nil {
+		if errors.Is(err, model.ErrForbidden) {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "image does not belong to the user"})
+		}
 		return c.JSON(400, err)
 	}
 
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/analytics_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/analytics_controller.go -destination controller/mocks/analytics_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAnalyticsController is a mock of IAnalyticsController interface.
+type MockIAnalyticsController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnalyticsControllerMockRecorder
+}
+
+// MockIAnalyticsControllerMockRecorder is the mock recorder for MockIAnalyticsController.
+type MockIAnalyticsControllerMockRecorder struct {
+	mock *MockIAnalyticsController
+}
+
+// NewMockIAnalyticsController creates a new mock instance.
+func NewMockIAnalyticsController(ctrl *gomock.Controller) *MockIAnalyticsController {
+	mock := &MockIAnalyticsController{ctrl: ctrl}
+	mock.recorder = &MockIAnalyticsControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnalyticsController) EXPECT() *MockIAnalyticsControllerMockRecorder {
+	return m.recorder
+}
+
+// GetTopWastedItems mocks base method.
+func (m *MockIAnalyticsController) GetTopWastedItems(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopWastedItems", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTopWastedItems indicates an expected call of GetTopWastedItems.
+func (mr *MockIAnalyticsControllerMockRecorder) GetTopWastedItems(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopWastedItems", reflect.TypeOf((*MockIAnalyticsController)(nil).GetTopWastedItems), c)
+}
+
+// GetWasteByMonth mocks base method.
+func (m *MockIAnalyticsController) GetWasteByMonth(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteByMonth", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteByMonth indicates an expected call of GetWasteByMonth.
+func (mr *MockIAnalyticsControllerMockRecorder) GetWasteByMonth(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteByMonth", reflect.TypeOf((*MockIAnalyticsController)(nil).GetWasteByMonth), c)
+}
+
+// GetWasteByStorageLocation mocks base method.
+func (m *MockIAnalyticsController) GetWasteByStorageLocation(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteByStorageLocation", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteByStorageLocation indicates an expected call of GetWasteByStorageLocation.
+func (mr *MockIAnalyticsControllerMockRecorder) GetWasteByStorageLocation(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteByStorageLocation", reflect.TypeOf((*MockIAnalyticsController)(nil).GetWasteByStorageLocation), c)
+}
+
+// GetWasteByTag mocks base method.
+func (m *MockIAnalyticsController) GetWasteByTag(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteByTag", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteByTag indicates an expected call of GetWasteByTag.
+func (mr *MockIAnalyticsControllerMockRecorder) GetWasteByTag(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteByTag", reflect.TypeOf((*MockIAnalyticsController)(nil).GetWasteByTag), c)
+}
+
+// GetWasteSummary mocks base method.
+func (m *MockIAnalyticsController) GetWasteSummary(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteSummary", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteSummary indicates an expected call of GetWasteSummary.
+func (mr *MockIAnalyticsControllerMockRecorder) GetWasteSummary(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteSummary", reflect.TypeOf((*MockIAnalyticsController)(nil).GetWasteSummary), c)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/api_key_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/api_key_controller.go -destination controller/mocks/api_key_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAPIKeyController is a mock of IAPIKeyController interface.
+type MockIAPIKeyController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyControllerMockRecorder
+}
+
+// MockIAPIKeyControllerMockRecorder is the mock recorder for MockIAPIKeyController.
+type MockIAPIKeyControllerMockRecorder struct {
+	mock *MockIAPIKeyController
+}
+
+// NewMockIAPIKeyController creates a new mock instance.
+func NewMockIAPIKeyController(ctrl *gomock.Controller) *MockIAPIKeyController {
+	mock := &MockIAPIKeyController{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyController) EXPECT() *MockIAPIKeyControllerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockIAPIKeyController) CreateAPIKey(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockIAPIKeyControllerMockRecorder) CreateAPIKey(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockIAPIKeyController)(nil).CreateAPIKey), c)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockIAPIKeyController) GetAPIKeys(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockIAPIKeyControllerMockRecorder) GetAPIKeys(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockIAPIKeyController)(nil).GetAPIKeys), c)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockIAPIKeyController) RevokeAPIKey(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockIAPIKeyControllerMockRecorder) RevokeAPIKey(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockIAPIKeyController)(nil).RevokeAPIKey), c)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/audit_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/audit_controller.go -destination controller/mocks/audit_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditController is a mock of IAuditController interface.
+type MockIAuditController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditControllerMockRecorder
+}
+
+// MockIAuditControllerMockRecorder is the mock recorder for MockIAuditController.
+type MockIAuditControllerMockRecorder struct {
+	mock *MockIAuditController
+}
+
+// NewMockIAuditController creates a new mock instance.
+func NewMockIAuditController(ctrl *gomock.Controller) *MockIAuditController {
+	mock := &MockIAuditController{ctrl: ctrl}
+	mock.recorder = &MockIAuditControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditController) EXPECT() *MockIAuditControllerMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockIAuditController) GetAuditLogs(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockIAuditControllerMockRecorder) GetAuditLogs(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIAuditController)(nil).GetAuditLogs), c)
+}
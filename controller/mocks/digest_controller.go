@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/digest_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/digest_controller.go -destination controller/mocks/digest_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDigestController is a mock of IDigestController interface.
+type MockIDigestController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDigestControllerMockRecorder
+}
+
+// MockIDigestControllerMockRecorder is the mock recorder for MockIDigestController.
+type MockIDigestControllerMockRecorder struct {
+	mock *MockIDigestController
+}
+
+// NewMockIDigestController creates a new mock instance.
+func NewMockIDigestController(ctrl *gomock.Controller) *MockIDigestController {
+	mock := &MockIDigestController{ctrl: ctrl}
+	mock.recorder = &MockIDigestControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDigestController) EXPECT() *MockIDigestControllerMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockIDigestController) Preview(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockIDigestControllerMockRecorder) Preview(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockIDigestController)(nil).Preview), c)
+}
@@ -39,6 +39,20 @@ func (m *MockIFoodController) EXPECT() *MockIFoodControllerMockRecorder {
 	return m.recorder
 }
 
+// ConsumeFood mocks base method.
+func (m *MockIFoodController) ConsumeFood(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeFood", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeFood indicates an expected call of ConsumeFood.
+func (mr *MockIFoodControllerMockRecorder) ConsumeFood(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeFood", reflect.TypeOf((*MockIFoodController)(nil).ConsumeFood), c)
+}
+
 // CreateFood mocks base method.
 func (m *MockIFoodController) CreateFood(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -67,6 +81,76 @@ func (mr *MockIFoodControllerMockRecorder) DeleteFood(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFood", reflect.TypeOf((*MockIFoodController)(nil).DeleteFood), c)
 }
 
+// DisposeFood mocks base method.
+func (m *MockIFoodController) DisposeFood(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisposeFood", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisposeFood indicates an expected call of DisposeFood.
+func (mr *MockIFoodControllerMockRecorder) DisposeFood(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisposeFood", reflect.TypeOf((*MockIFoodController)(nil).DisposeFood), c)
+}
+
+// GetExpiredFoods mocks base method.
+func (m *MockIFoodController) GetExpiredFoods(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredFoods", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetExpiredFoods indicates an expected call of GetExpiredFoods.
+func (mr *MockIFoodControllerMockRecorder) GetExpiredFoods(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredFoods", reflect.TypeOf((*MockIFoodController)(nil).GetExpiredFoods), c)
+}
+
+// GetExpiringFoods mocks base method.
+func (m *MockIFoodController) GetExpiringFoods(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringFoods", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetExpiringFoods indicates an expected call of GetExpiringFoods.
+func (mr *MockIFoodControllerMockRecorder) GetExpiringFoods(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringFoods", reflect.TypeOf((*MockIFoodController)(nil).GetExpiringFoods), c)
+}
+
+// GetFood mocks base method.
+func (m *MockIFoodController) GetFood(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFood", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFood indicates an expected call of GetFood.
+func (mr *MockIFoodControllerMockRecorder) GetFood(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFood", reflect.TypeOf((*MockIFoodController)(nil).GetFood), c)
+}
+
+// GetFoodHistory mocks base method.
+func (m *MockIFoodController) GetFoodHistory(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodHistory", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodHistory indicates an expected call of GetFoodHistory.
+func (mr *MockIFoodControllerMockRecorder) GetFoodHistory(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodHistory", reflect.TypeOf((*MockIFoodController)(nil).GetFoodHistory), c)
+}
+
 // GetFoodsByUserID mocks base method.
 func (m *MockIFoodController) GetFoodsByUserID(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -81,6 +165,48 @@ func (mr *MockIFoodControllerMockRecorder) GetFoodsByUserID(c any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsByUserID", reflect.TypeOf((*MockIFoodController)(nil).GetFoodsByUserID), c)
 }
 
+// GetTrashedFoods mocks base method.
+func (m *MockIFoodController) GetTrashedFoods(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedFoods", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTrashedFoods indicates an expected call of GetTrashedFoods.
+func (mr *MockIFoodControllerMockRecorder) GetTrashedFoods(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedFoods", reflect.TypeOf((*MockIFoodController)(nil).GetTrashedFoods), c)
+}
+
+// PatchFood mocks base method.
+func (m *MockIFoodController) PatchFood(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchFood", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchFood indicates an expected call of PatchFood.
+func (mr *MockIFoodControllerMockRecorder) PatchFood(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFood", reflect.TypeOf((*MockIFoodController)(nil).PatchFood), c)
+}
+
+// RestoreFood mocks base method.
+func (m *MockIFoodController) RestoreFood(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFood", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFood indicates an expected call of RestoreFood.
+func (mr *MockIFoodControllerMockRecorder) RestoreFood(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFood", reflect.TypeOf((*MockIFoodController)(nil).RestoreFood), c)
+}
+
 // UpdateFood mocks base method.
 func (m *MockIFoodController) UpdateFood(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,236 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/household_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/household_controller.go -destination controller/mocks/household_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHouseholdController is a mock of IHouseholdController interface.
+type MockIHouseholdController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdControllerMockRecorder
+}
+
+// MockIHouseholdControllerMockRecorder is the mock recorder for MockIHouseholdController.
+type MockIHouseholdControllerMockRecorder struct {
+	mock *MockIHouseholdController
+}
+
+// NewMockIHouseholdController creates a new mock instance.
+func NewMockIHouseholdController(ctrl *gomock.Controller) *MockIHouseholdController {
+	mock := &MockIHouseholdController{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdController) EXPECT() *MockIHouseholdControllerMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockIHouseholdController) AcceptInvitation(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockIHouseholdControllerMockRecorder) AcceptInvitation(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockIHouseholdController)(nil).AcceptInvitation), c)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockIHouseholdController) CreateHousehold(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockIHouseholdControllerMockRecorder) CreateHousehold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockIHouseholdController)(nil).CreateHousehold), c)
+}
+
+// CreateRole mocks base method.
+func (m *MockIHouseholdController) CreateRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockIHouseholdControllerMockRecorder) CreateRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockIHouseholdController)(nil).CreateRole), c)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockIHouseholdController) DeclineInvitation(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockIHouseholdControllerMockRecorder) DeclineInvitation(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockIHouseholdController)(nil).DeclineInvitation), c)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIHouseholdController) DeleteRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIHouseholdControllerMockRecorder) DeleteRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIHouseholdController)(nil).DeleteRole), c)
+}
+
+// GetHousehold mocks base method.
+func (m *MockIHouseholdController) GetHousehold(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHousehold", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetHousehold indicates an expected call of GetHousehold.
+func (mr *MockIHouseholdControllerMockRecorder) GetHousehold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHousehold", reflect.TypeOf((*MockIHouseholdController)(nil).GetHousehold), c)
+}
+
+// GetHouseholds mocks base method.
+func (m *MockIHouseholdController) GetHouseholds(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholds", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetHouseholds indicates an expected call of GetHouseholds.
+func (mr *MockIHouseholdControllerMockRecorder) GetHouseholds(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholds", reflect.TypeOf((*MockIHouseholdController)(nil).GetHouseholds), c)
+}
+
+// GetRoles mocks base method.
+func (m *MockIHouseholdController) GetRoles(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockIHouseholdControllerMockRecorder) GetRoles(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockIHouseholdController)(nil).GetRoles), c)
+}
+
+// InviteMember mocks base method.
+func (m *MockIHouseholdController) InviteMember(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockIHouseholdControllerMockRecorder) InviteMember(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*MockIHouseholdController)(nil).InviteMember), c)
+}
+
+// RemoveMember mocks base method.
+func (m *MockIHouseholdController) RemoveMember(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockIHouseholdControllerMockRecorder) RemoveMember(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockIHouseholdController)(nil).RemoveMember), c)
+}
+
+// SwitchHousehold mocks base method.
+func (m *MockIHouseholdController) SwitchHousehold(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchHousehold", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwitchHousehold indicates an expected call of SwitchHousehold.
+func (mr *MockIHouseholdControllerMockRecorder) SwitchHousehold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchHousehold", reflect.TypeOf((*MockIHouseholdController)(nil).SwitchHousehold), c)
+}
+
+// UpdateHousehold mocks base method.
+func (m *MockIHouseholdController) UpdateHousehold(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHousehold", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHousehold indicates an expected call of UpdateHousehold.
+func (mr *MockIHouseholdControllerMockRecorder) UpdateHousehold(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHousehold", reflect.TypeOf((*MockIHouseholdController)(nil).UpdateHousehold), c)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockIHouseholdController) UpdateMemberRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockIHouseholdControllerMockRecorder) UpdateMemberRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockIHouseholdController)(nil).UpdateMemberRole), c)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIHouseholdController) UpdateRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIHouseholdControllerMockRecorder) UpdateRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIHouseholdController)(nil).UpdateRole), c)
+}
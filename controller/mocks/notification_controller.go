@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/notification_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/notification_controller.go -destination controller/mocks/notification_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockINotificationController is a mock of INotificationController interface.
+type MockINotificationController struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationControllerMockRecorder
+}
+
+// MockINotificationControllerMockRecorder is the mock recorder for MockINotificationController.
+type MockINotificationControllerMockRecorder struct {
+	mock *MockINotificationController
+}
+
+// NewMockINotificationController creates a new mock instance.
+func NewMockINotificationController(ctrl *gomock.Controller) *MockINotificationController {
+	mock := &MockINotificationController{ctrl: ctrl}
+	mock.recorder = &MockINotificationControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationController) EXPECT() *MockINotificationControllerMockRecorder {
+	return m.recorder
+}
+
+// DeletePreference mocks base method.
+func (m *MockINotificationController) DeletePreference(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreference", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePreference indicates an expected call of DeletePreference.
+func (mr *MockINotificationControllerMockRecorder) DeletePreference(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreference", reflect.TypeOf((*MockINotificationController)(nil).DeletePreference), c)
+}
+
+// GetPreference mocks base method.
+func (m *MockINotificationController) GetPreference(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockINotificationControllerMockRecorder) GetPreference(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockINotificationController)(nil).GetPreference), c)
+}
+
+// UpdatePreference mocks base method.
+func (m *MockINotificationController) UpdatePreference(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockINotificationControllerMockRecorder) UpdatePreference(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockINotificationController)(nil).UpdatePreference), c)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/oidc_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/oidc_controller.go -destination controller/mocks/oidc_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOIDCController is a mock of IOIDCController interface.
+type MockIOIDCController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOIDCControllerMockRecorder
+}
+
+// MockIOIDCControllerMockRecorder is the mock recorder for MockIOIDCController.
+type MockIOIDCControllerMockRecorder struct {
+	mock *MockIOIDCController
+}
+
+// NewMockIOIDCController creates a new mock instance.
+func NewMockIOIDCController(ctrl *gomock.Controller) *MockIOIDCController {
+	mock := &MockIOIDCController{ctrl: ctrl}
+	mock.recorder = &MockIOIDCControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOIDCController) EXPECT() *MockIOIDCControllerMockRecorder {
+	return m.recorder
+}
+
+// CompleteLogin mocks base method.
+func (m *MockIOIDCController) CompleteLogin(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLogin", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteLogin indicates an expected call of CompleteLogin.
+func (mr *MockIOIDCControllerMockRecorder) CompleteLogin(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLogin", reflect.TypeOf((*MockIOIDCController)(nil).CompleteLogin), c)
+}
+
+// GetIdentities mocks base method.
+func (m *MockIOIDCController) GetIdentities(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentities", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetIdentities indicates an expected call of GetIdentities.
+func (mr *MockIOIDCControllerMockRecorder) GetIdentities(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentities", reflect.TypeOf((*MockIOIDCController)(nil).GetIdentities), c)
+}
+
+// GetProviders mocks base method.
+func (m *MockIOIDCController) GetProviders(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviders", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetProviders indicates an expected call of GetProviders.
+func (mr *MockIOIDCControllerMockRecorder) GetProviders(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviders", reflect.TypeOf((*MockIOIDCController)(nil).GetProviders), c)
+}
+
+// StartLogin mocks base method.
+func (m *MockIOIDCController) StartLogin(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLogin", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartLogin indicates an expected call of StartLogin.
+func (mr *MockIOIDCControllerMockRecorder) StartLogin(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLogin", reflect.TypeOf((*MockIOIDCController)(nil).StartLogin), c)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockIOIDCController) UnlinkIdentity(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockIOIDCControllerMockRecorder) UnlinkIdentity(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockIOIDCController)(nil).UnlinkIdentity), c)
+}
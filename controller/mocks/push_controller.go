@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/push_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/push_controller.go -destination controller/mocks/push_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPushController is a mock of IPushController interface.
+type MockIPushController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPushControllerMockRecorder
+}
+
+// MockIPushControllerMockRecorder is the mock recorder for MockIPushController.
+type MockIPushControllerMockRecorder struct {
+	mock *MockIPushController
+}
+
+// NewMockIPushController creates a new mock instance.
+func NewMockIPushController(ctrl *gomock.Controller) *MockIPushController {
+	mock := &MockIPushController{ctrl: ctrl}
+	mock.recorder = &MockIPushControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPushController) EXPECT() *MockIPushControllerMockRecorder {
+	return m.recorder
+}
+
+// GetVAPIDPublicKey mocks base method.
+func (m *MockIPushController) GetVAPIDPublicKey(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVAPIDPublicKey", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetVAPIDPublicKey indicates an expected call of GetVAPIDPublicKey.
+func (mr *MockIPushControllerMockRecorder) GetVAPIDPublicKey(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVAPIDPublicKey", reflect.TypeOf((*MockIPushController)(nil).GetVAPIDPublicKey), c)
+}
+
+// Subscribe mocks base method.
+func (m *MockIPushController) Subscribe(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIPushControllerMockRecorder) Subscribe(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIPushController)(nil).Subscribe), c)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockIPushController) Unsubscribe(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockIPushControllerMockRecorder) Unsubscribe(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockIPushController)(nil).Unsubscribe), c)
+}
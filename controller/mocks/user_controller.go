@@ -39,6 +39,20 @@ func (m *MockIUserController) EXPECT() *MockIUserControllerMockRecorder {
 	return m.recorder
 }
 
+// ConfirmTOTP mocks base method.
+func (m *MockIUserController) ConfirmTOTP(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockIUserControllerMockRecorder) ConfirmTOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockIUserController)(nil).ConfirmTOTP), c)
+}
+
 // CreateUser mocks base method.
 func (m *MockIUserController) CreateUser(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -67,6 +81,48 @@ func (mr *MockIUserControllerMockRecorder) DeleteUser(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserController)(nil).DeleteUser), c)
 }
 
+// DisableTwoFactor mocks base method.
+func (m *MockIUserController) DisableTwoFactor(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockIUserControllerMockRecorder) DisableTwoFactor(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockIUserController)(nil).DisableTwoFactor), c)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockIUserController) EnrollTOTP(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockIUserControllerMockRecorder) EnrollTOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockIUserController)(nil).EnrollTOTP), c)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockIUserController) ForgotPassword(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockIUserControllerMockRecorder) ForgotPassword(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockIUserController)(nil).ForgotPassword), c)
+}
+
 // GetUser mocks base method.
 func (m *MockIUserController) GetUser(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -81,6 +137,118 @@ func (mr *MockIUserControllerMockRecorder) GetUser(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserController)(nil).GetUser), c)
 }
 
+// LoginTwoFactor mocks base method.
+func (m *MockIUserController) LoginTwoFactor(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginTwoFactor", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoginTwoFactor indicates an expected call of LoginTwoFactor.
+func (mr *MockIUserControllerMockRecorder) LoginTwoFactor(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginTwoFactor", reflect.TypeOf((*MockIUserController)(nil).LoginTwoFactor), c)
+}
+
+// LoginUser mocks base method.
+func (m *MockIUserController) LoginUser(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockIUserControllerMockRecorder) LoginUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIUserController)(nil).LoginUser), c)
+}
+
+// Logout mocks base method.
+func (m *MockIUserController) Logout(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIUserControllerMockRecorder) Logout(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIUserController)(nil).Logout), c)
+}
+
+// LogoutAll mocks base method.
+func (m *MockIUserController) LogoutAll(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockIUserControllerMockRecorder) LogoutAll(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockIUserController)(nil).LogoutAll), c)
+}
+
+// RefreshToken mocks base method.
+func (m *MockIUserController) RefreshToken(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockIUserControllerMockRecorder) RefreshToken(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockIUserController)(nil).RefreshToken), c)
+}
+
+// ResendVerificationEmail mocks base method.
+func (m *MockIUserController) ResendVerificationEmail(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockIUserControllerMockRecorder) ResendVerificationEmail(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockIUserController)(nil).ResendVerificationEmail), c)
+}
+
+// ResetPassword mocks base method.
+func (m *MockIUserController) ResetPassword(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIUserControllerMockRecorder) ResetPassword(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIUserController)(nil).ResetPassword), c)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockIUserController) UnlockAccount(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockIUserControllerMockRecorder) UnlockAccount(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockIUserController)(nil).UnlockAccount), c)
+}
+
 // UpdateUser mocks base method.
 func (m *MockIUserController) UpdateUser(c echo.Context) error {
 	m.ctrl.T.Helper()
@@ -94,3 +262,17 @@ func (mr *MockIUserControllerMockRecorder) UpdateUser(c any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserController)(nil).UpdateUser), c)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockIUserController) VerifyEmail(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockIUserControllerMockRecorder) VerifyEmail(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockIUserController)(nil).VerifyEmail), c)
+}
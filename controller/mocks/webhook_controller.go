@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./controller/webhook_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./controller/webhook_controller.go -destination controller/mocks/webhook_controller.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookController is a mock of IWebhookController interface.
+type MockIWebhookController struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookControllerMockRecorder
+}
+
+// MockIWebhookControllerMockRecorder is the mock recorder for MockIWebhookController.
+type MockIWebhookControllerMockRecorder struct {
+	mock *MockIWebhookController
+}
+
+// NewMockIWebhookController creates a new mock instance.
+func NewMockIWebhookController(ctrl *gomock.Controller) *MockIWebhookController {
+	mock := &MockIWebhookController{ctrl: ctrl}
+	mock.recorder = &MockIWebhookControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookController) EXPECT() *MockIWebhookControllerMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockIWebhookController) CreateWebhook(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockIWebhookControllerMockRecorder) CreateWebhook(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockIWebhookController)(nil).CreateWebhook), c)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockIWebhookController) DeleteWebhook(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockIWebhookControllerMockRecorder) DeleteWebhook(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockIWebhookController)(nil).DeleteWebhook), c)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockIWebhookController) GetDeliveries(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockIWebhookControllerMockRecorder) GetDeliveries(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockIWebhookController)(nil).GetDeliveries), c)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockIWebhookController) GetWebhooks(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockIWebhookControllerMockRecorder) GetWebhooks(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockIWebhookController)(nil).GetWebhooks), c)
+}
+
+// SendTestEvent mocks base method.
+func (m *MockIWebhookController) SendTestEvent(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTestEvent", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendTestEvent indicates an expected call of SendTestEvent.
+func (mr *MockIWebhookControllerMockRecorder) SendTestEvent(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTestEvent", reflect.TypeOf((*MockIWebhookController)(nil).SendTestEvent), c)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockIWebhookController) UpdateWebhook(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockIWebhookControllerMockRecorder) UpdateWebhook(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockIWebhookController)(nil).UpdateWebhook), c)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type INotificationController interface {
+	GetPreference(c echo.Context) error
+	UpdatePreference(c echo.Context) error
+	DeletePreference(c echo.Context) error
+}
+
+type notificationController struct {
+	nu usecase.INotificationUsecase
+}
+
+func NewNotificationController(nu usecase.INotificationUsecase) INotificationController {
+	return &notificationController{nu}
+}
+
+// GetPreference godoc
+// @Summary Get notification preferences
+// @Description Get when and how the caller is notified about foods that are about to expire. Users who have not saved any are notified the day before by email and push, immediately
+// @ID get-notification-preferences
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.NotificationPreferenceResponse
+// @Router /users/me/notification-preferences [get]
+// @Tags notifications
+// @Security BearerAuth
+func (nc *notificationController) GetPreference(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	pref, err := nc.nu.GetPreference(user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, pref)
+}
+
+// UpdatePreference godoc
+// @Summary Update notification preferences
+// @Description Replace the notification preferences of the caller. lead_days are up to 5 lead times between 0 and 30 days before the expiration date, in the caller's time zone. channels are email, push and webhook; webhook requires webhook_url, and a secret to verify the requests is generated when the URL changes. With the digest delivery a daily or weekly digest in the language is sent at digest_hour, on digest_weekday for weekly digests, instead of alerts about each food; immediate alerts are held back during quiet_hours. Foods with muted_tags are never notified about. An empty list of lead days or channels turns the notifications off
+// @ID update-notification-preferences
+// @Accept  json
+// @Produce  json
+// @Param preference body model.NotificationPreferenceRequest true "Notification preferences"
+// @Success 200 {object} model.NotificationPreferenceResponse
+// @Failure 400 {object} map[string]string
+// @Router /users/me/notification-preferences [put]
+// @Tags notifications
+// @Security BearerAuth
+func (nc *notificationController) UpdatePreference(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.NotificationPreferenceRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	pref, err := nc.nu.UpdatePreference(user.ID, req)
+	if err != nil {
+		var verrs validation.Errors
+		if errors.As(err, &verrs) {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, pref)
+}
+
+// DeletePreference godoc
+// @Summary Reset notification preferences
+// @Description Delete the notification preferences of the caller, who is then notified with the defaults
+// @ID delete-notification-preferences
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.NotificationPreferenceResponse
+// @Router /users/me/notification-preferences [delete]
+// @Tags notifications
+// @Security BearerAuth
+func (nc *notificationController) DeletePreference(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	if err := nc.nu.DeletePreference(user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	pref, err := nc.nu.GetPreference(user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, pref)
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_notificationController_UpdatePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINotificationUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockCall   bool
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：通知の設定を更新できる", body: `{"lead_days":[3,1]}`, mockCall: true, wantStatus: http.StatusOK},
+		{
+			name:       "異常系：バリデーションエラー",
+			body:       `{"lead_days":[31]}`,
+			mockCall:   true,
+			mockErr:    validation.Errors{"lead_days": validation.NewError("validation_max_less_equal_than_required", "must be no greater than 30")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "異常系：不正なJSON", body: `{"lead_days":"3"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockCall {
+				mockUsecase.EXPECT().UpdatePreference(1, gomock.Any()).Return(model.NotificationPreferenceResponse{}, tt.mockErr).Times(1)
+			}
+
+			nc := NewNotificationController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/users/me/notification-preferences", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := nc.UpdatePreference(c); err != nil {
+				t.Errorf("notificationController.UpdatePreference() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("notificationController.UpdatePreference() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_notificationController_DeletePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINotificationUsecase(ctrl)
+	mockUsecase.EXPECT().DeletePreference(1).Return(nil).Times(1)
+	mockUsecase.EXPECT().GetPreference(1).Return(model.NotificationPreferenceResponse{LeadDays: model.DefaultNotificationLeadDays}, nil).Times(1)
+
+	nc := NewNotificationController(mockUsecase)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/users/me/notification-preferences", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(authUserKey, model.AuthUser{ID: 1})
+
+	if err := nc.DeletePreference(c); err != nil {
+		t.Errorf("notificationController.DeletePreference() error = %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("notificationController.DeletePreference() status = %v, want %v", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type IOIDCController interface {
+	GetProviders(c echo.Context) error
+	StartLogin(c echo.Context) error
+	CompleteLogin(c echo.Context) error
+	GetIdentities(c echo.Context) error
+	UnlinkIdentity(c echo.Context) error
+}
+
+// oidcBindingCookie holds the binding value of a started login, so that only
+// the browser that started it can complete it.
+const oidcBindingCookie = "oidc_binding"
+
+type oidcController struct {
+	ou usecase.IOIDCUsecase
+}
+
+func NewOIDCController(ou usecase.IOIDCUsecase) IOIDCController {
+	return &oidcController{ou}
+}
+
+// GetProviders godoc
+// @Summary Get OpenID Connect providers
+// @Description Get the providers users can log in with
+// @ID get-oidc-providers
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} model.OIDCProviderResponse
+// @Router /users/oidc/providers [get]
+// @Tags users
+func (oc *oidcController) GetProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, oc.ou.GetProviders())
+}
+
+// StartLogin godoc
+// @Summary Start login with an OpenID Connect provider
+// @Description Start the authorization code flow with PKCE. Send the user to authorization_url and keep state to check it against the state returned to the redirect URI. An HttpOnly oidc_binding cookie is set and must be sent with the callback.
+// @ID start-oidc-login
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "Provider"
+// @Success 200 {object} model.OIDCAuthorizationResponse
+// @Failure 404 {object} map[string]string
+// @Router /users/oidc/{provider}/authorize [get]
+// @Tags users
+func (oc *oidcController) StartLogin(c echo.Context) error {
+	res, err := oc.ou.StartLogin(c.Param("provider"))
+	if err != nil {
+		return oidcErrorResponse(c, err)
+	}
+	setOIDCBindingCookie(c, res.Binding, 0)
+	return c.JSON(http.StatusOK, res)
+}
+
+// CompleteLogin godoc
+// @Summary Complete login with an OpenID Connect provider
+// @Description Exchange the authorization code returned to the redirect URI for tokens. The oidc_binding cookie set when the login started is required and cleared. The provider account is linked to the user with the same verified email address, or a new user is created. When two-factor authentication is enabled, mfa_token must be sent to POST /users/login/2fa.
+// @ID complete-oidc-login
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "Provider"
+// @Param request body model.OIDCCallbackRequest true "Authorization code and state"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/oidc/{provider}/callback [post]
+// @Tags users
+func (oc *oidcController) CompleteLogin(c echo.Context) error {
+	req := model.OIDCCallbackRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	if req.Code == "" || req.State == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "code and state are required"})
+	}
+	binding := ""
+	if cookie, err := c.Cookie(oidcBindingCookie); err == nil {
+		binding = cookie.Value
+	}
+	// 成否にかかわらず使い終わった cookie は消す
+	setOIDCBindingCookie(c, "", -1)
+
+	res, err := oc.ou.CompleteLogin(c.Param("provider"), req.Code, req.State, binding)
+	if err != nil {
+		return oidcErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// GetIdentities godoc
+// @Summary Get linked identities
+// @Description Get the OpenID Connect provider accounts linked to the authenticated user
+// @ID get-identities
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} model.UserIdentityResponse
+// @Router /users/identities [get]
+// @Tags users
+// @Security BearerAuth
+func (oc *oidcController) GetIdentities(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	identities, err := oc.ou.GetIdentities(user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, identities)
+}
+
+// UnlinkIdentity godoc
+// @Summary Unlink identity
+// @Description Unlink the provider account from the authenticated user. The last identity of a user without a password cannot be unlinked.
+// @ID unlink-identity
+// @Accept  json
+// @Produce  json
+// @Param provider path string true "Provider"
+// @Success 200 {string} string "identity unlinked"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/identities/{provider} [delete]
+// @Tags users
+// @Security BearerAuth
+func (oc *oidcController) UnlinkIdentity(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	if err := oc.ou.UnlinkIdentity(user.ID, c.Param("provider")); err != nil {
+		return oidcErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "identity unlinked")
+}
+
+// setOIDCBindingCookie sets the binding cookie, or deletes it when maxAge is
+// negative. The cookie is only sent back to the OpenID Connect routes.
+func setOIDCBindingCookie(c echo.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     oidcBindingCookie,
+		Value:    value,
+		Path:     "/users/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// oidcErrorResponse maps errors of the OpenID Connect usecase to responses.
+func oidcErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, model.ErrOIDCProviderNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "provider not found"})
+	case errors.Is(err, model.ErrUserIdentityNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "identity not found"})
+	case errors.Is(err, model.ErrInvalidToken):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid or expired state"})
+	case errors.Is(err, model.ErrUnauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "login with the provider failed"})
+	case errors.Is(err, model.ErrOIDCEmailNotVerified):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "email address is not verified by the provider"})
+	case errors.Is(err, model.ErrLastLoginMethod):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "cannot unlink the last way to log in"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
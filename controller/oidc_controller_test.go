@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_oidcController_CompleteLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIOIDCUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		binding    string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：ログインできる", body: `{"code":"code","state":"state"}`, binding: "binding", wantStatus: http.StatusOK},
+		{name: "異常系：ログインを始めたブラウザの cookie がない", body: `{"code":"code","state":"state"}`, mockErr: model.ErrInvalidToken, wantStatus: http.StatusBadRequest},
+		{name: "異常系：codeがない", body: `{"state":"state"}`, wantStatus: http.StatusBadRequest},
+		{name: "異常系：stateが不正または期限切れ", body: `{"code":"code","state":"state"}`, binding: "binding", mockErr: model.ErrInvalidToken, wantStatus: http.StatusBadRequest},
+		{name: "異常系：プロバイダでの認証に失敗", body: `{"code":"code","state":"state"}`, binding: "binding", mockErr: model.ErrUnauthorized, wantStatus: http.StatusUnauthorized},
+		{name: "異常系：メールアドレスが未確認", body: `{"code":"code","state":"state"}`, binding: "binding", mockErr: model.ErrOIDCEmailNotVerified, wantStatus: http.StatusForbidden},
+		{name: "異常系：未設定のプロバイダ", body: `{"code":"code","state":"state"}`, binding: "binding", mockErr: model.ErrOIDCProviderNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantStatus != http.StatusBadRequest || tt.mockErr != nil {
+				mockUsecase.EXPECT().CompleteLogin("google", "code", "state", tt.binding).Return(model.LoginResponse{}, tt.mockErr)
+			}
+
+			oc := NewOIDCController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/users/oidc/google/callback", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.binding != "" {
+				req.AddCookie(&http.Cookie{Name: oidcBindingCookie, Value: tt.binding})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/users/oidc/:provider/callback")
+			c.SetParamNames("provider")
+			c.SetParamValues("google")
+
+			if err := oc.CompleteLogin(c); err != nil {
+				t.Errorf("oidcController.CompleteLogin() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("oidcController.CompleteLogin() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusBadRequest && tt.mockErr == nil {
+				return
+			}
+			if cookies := rec.Result().Cookies(); len(cookies) != 1 || cookies[0].Name != oidcBindingCookie || cookies[0].MaxAge >= 0 {
+				t.Errorf("oidcController.CompleteLogin() cookies = %v, want the binding cookie cleared", cookies)
+			}
+		})
+	}
+}
+
+func Test_oidcController_StartLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIOIDCUsecase(ctrl)
+	mockUsecase.EXPECT().StartLogin("google").Return(model.OIDCAuthorizationResponse{AuthorizationURL: "https://accounts.example.com/auth", State: "state", Binding: "binding"}, nil)
+
+	oc := NewOIDCController(mockUsecase)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/users/oidc/google/authorize", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/users/oidc/:provider/authorize")
+	c.SetParamNames("provider")
+	c.SetParamValues("google")
+
+	if err := oc.StartLogin(c); err != nil {
+		t.Fatalf("oidcController.StartLogin() error = %v", err)
+	}
+	// binding は HttpOnly の cookie でだけ渡し、本文には含めない
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcBindingCookie || cookies[0].Value != "binding" || !cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Errorf("oidcController.StartLogin() cookies = %v", cookies)
+	}
+	if strings.Contains(rec.Body.String(), "binding") {
+		t.Errorf("oidcController.StartLogin() body = %s", rec.Body.String())
+	}
+}
+
+func Test_oidcController_UnlinkIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIOIDCUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：連携を解除できる", wantStatus: http.StatusOK},
+		{name: "異常系：連携していないプロバイダ", mockErr: model.ErrUserIdentityNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：最後のログイン手段は解除できない", mockErr: model.ErrLastLoginMethod, wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().UnlinkIdentity(1, "google").Return(tt.mockErr)
+
+			oc := NewOIDCController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/users/identities/google", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/users/identities/:provider")
+			c.SetParamNames("provider")
+			c.SetParamValues("google")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := oc.UnlinkIdentity(c); err != nil {
+				t.Errorf("oidcController.UnlinkIdentity() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("oidcController.UnlinkIdentity() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPermissionMiddleware builds middlewares that reject callers lacking a
+// household permission before the handler runs. They must be placed after
+// the middleware returned by NewAuthMiddleware.
+type IPermissionMiddleware interface {
+	// RequireActive checks the permission in the caller's active household.
+	RequireActive(permission model.Permission) echo.MiddlewareFunc
+	// RequireHousehold checks the permission in the household whose ID is the
+	// path parameter param.
+	RequireHousehold(param string, permission model.Permission) echo.MiddlewareFunc
+}
+
+type permissionMiddleware struct {
+	hu usecase.IHouseholdUsecase
+}
+
+func NewPermissionMiddleware(hu usecase.IHouseholdUsecase) IPermissionMiddleware {
+	return &permissionMiddleware{hu}
+}
+
+func (pm *permissionMiddleware) RequireActive(permission model.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := currentUser(c)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+			}
+			if _, err := pm.hu.AuthorizeActive(user, permission); err != nil {
+				return permissionErrorResponse(c, permission, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (pm *permissionMiddleware) RequireHousehold(param string, permission model.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := currentUser(c)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+			}
+			id, err := strconv.Atoi(c.Param(param))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, err)
+			}
+			if _, err := pm.hu.Authorize(id, user.ID, permission); err != nil {
+				return permissionErrorResponse(c, permission, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+func permissionErrorResponse(c echo.Context, permission model.Permission, err error) error {
+	switch {
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "missing permission " + string(permission)})
+	case errors.Is(err, model.ErrHouseholdNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "household not found"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_permissionMiddleware_RequireActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		authErr    error
+		wantStatus int
+	}{
+		{name: "正常系：権限があればハンドラーを実行する", wantStatus: http.StatusOK},
+		{name: "異常系：権限がなければ403", authErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "異常系：世帯に属していなければ404", authErr: model.ErrHouseholdNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().AuthorizeActive(model.AuthUser{ID: 1}, model.PermissionFoodDelete).Return(model.HouseholdMember{}, tt.authErr).Times(1)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/foods/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			handler := NewPermissionMiddleware(mockUsecase).RequireActive(model.PermissionFoodDelete)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(c); err != nil {
+				t.Errorf("RequireActive() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("RequireActive() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_permissionMiddleware_RequireHousehold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		id         string
+		authErr    error
+		wantStatus int
+	}{
+		{name: "正常系：権限があればハンドラーを実行する", id: "2", wantStatus: http.StatusOK},
+		{name: "異常系：権限がなければ403", id: "2", authErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "異常系：不正な世帯ID", id: "abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantStatus != http.StatusBadRequest {
+				mockUsecase.EXPECT().Authorize(2, 1, model.PermissionMemberInvite).Return(model.HouseholdMember{}, tt.authErr).Times(1)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/households/"+tt.id+"/invitations", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/households/:id/invitations")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			handler := NewPermissionMiddleware(mockUsecase).RequireHousehold("id", model.PermissionMemberInvite)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			if err := handler(c); err != nil {
+				t.Errorf("RequireHousehold() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("RequireHousehold() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
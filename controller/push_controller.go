@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IPushController interface {
+	GetVAPIDPublicKey(c echo.Context) error
+	Subscribe(c echo.Context) error
+	Unsubscribe(c echo.Context) error
+}
+
+type pushController struct {
+	pu usecase.IPushUsecase
+}
+
+func NewPushController(pu usecase.IPushUsecase) IPushController {
+	return &pushController{pu}
+}
+
+// GetVAPIDPublicKey godoc
+// @Summary Get VAPID public key
+// @Description Get the applicationServerKey to pass to PushManager.subscribe() in the browser
+// @ID get-vapid-public-key
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.VAPIDPublicKeyResponse
+// @Router /push/vapid-public-key [get]
+// @Tags push
+func (pc *pushController) GetVAPIDPublicKey(c echo.Context) error {
+	return c.JSON(http.StatusOK, pc.pu.GetVAPIDPublicKey())
+}
+
+// Subscribe godoc
+// @Summary Register push subscription
+// @Description Register the push subscription of the browser, as returned by PushSubscription.toJSON(), to receive notifications about expiring foods even when the tab is closed. Registering the same endpoint again replaces the subscription
+// @ID subscribe-push
+// @Accept  json
+// @Produce  json
+// @Param subscription body model.PushSubscriptionRequest true "Push subscription"
+// @Success 200 {string} string "push subscription registered"
+// @Failure 400 {object} map[string]string
+// @Router /users/me/push-subscriptions [post]
+// @Tags push
+// @Security BearerAuth
+func (pc *pushController) Subscribe(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.PushSubscriptionRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := pc.pu.Subscribe(user.ID, req, c.Request().UserAgent()); err != nil {
+		var verrs validation.Errors
+		if errors.As(err, &verrs) {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, "push subscription registered")
+}
+
+// Unsubscribe godoc
+// @Summary Unregister push subscription
+// @Description Stop sending notifications to the browser with the endpoint
+// @ID unsubscribe-push
+// @Accept  json
+// @Produce  json
+// @Param subscription body model.PushUnsubscribeRequest true "Endpoint of the subscription"
+// @Success 200 {string} string "push subscription removed"
+// @Failure 404 {object} map[string]string
+// @Router /users/me/push-subscriptions [delete]
+// @Tags push
+// @Security BearerAuth
+func (pc *pushController) Unsubscribe(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.PushUnsubscribeRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := pc.pu.Unsubscribe(user.ID, req.Endpoint); err != nil {
+		if errors.Is(err, model.ErrPushSubscriptionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": "push subscription not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, "push subscription removed")
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_pushController_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIPushUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockCall   bool
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：購読を登録できる",
+			body:       `{"endpoint":"https://push.example.com/abc","keys":{"p256dh":"BCVx","auth":"BTBZ"}}`,
+			mockCall:   true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：バリデーションエラー",
+			body:       `{"endpoint":"http://push.example.com/abc","keys":{"p256dh":"BCVx","auth":"BTBZ"}}`,
+			mockCall:   true,
+			mockErr:    validation.Errors{"endpoint": validation.NewError("validation_push_endpoint", "must be an https URL")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "異常系：不正なJSON", body: `{"endpoint":1}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockCall {
+				mockUsecase.EXPECT().Subscribe(1, gomock.Any(), "Firefox").Return(tt.mockErr).Times(1)
+			}
+
+			pc := NewPushController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/users/me/push-subscriptions", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("User-Agent", "Firefox")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := pc.Subscribe(c); err != nil {
+				t.Errorf("pushController.Subscribe() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("pushController.Subscribe() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_pushController_Unsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIPushUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：購読を解除できる", wantStatus: http.StatusOK},
+		{name: "異常系：購読が存在しない", mockErr: model.ErrPushSubscriptionNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().Unsubscribe(1, "https://push.example.com/abc").Return(tt.mockErr).Times(1)
+
+			pc := NewPushController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/users/me/push-subscriptions", strings.NewReader(`{"endpoint":"https://push.example.com/abc"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := pc.Unsubscribe(c); err != nil {
+				t.Errorf("pushController.Unsubscribe() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("pushController.Unsubscribe() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
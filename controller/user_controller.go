@@ -4,8 +4,10 @@ import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/usecase"
 	"errors"
+	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
@@ -17,14 +19,28 @@ type IUserController interface {
 	UpdateUser(c echo.Context) error
 	DeleteUser(c echo.Context) error
 	LoginUser(c echo.Context) error
+	RefreshToken(c echo.Context) error
+	Logout(c echo.Context) error
+	LogoutAll(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+	VerifyEmail(c echo.Context) error
+	ResendVerificationEmail(c echo.Context) error
+	UnlockAccount(c echo.Context) error
+	LoginTwoFactor(c echo.Context) error
+	EnrollTOTP(c echo.Context) error
+	ConfirmTOTP(c echo.Context) error
+	DisableTwoFactor(c echo.Context) error
 }
 
 type userController struct {
 	uu usecase.IUserUsecase
+	au usecase.IAuthUsecase
+	tu usecase.ITwoFactorUsecase
 }
 
-func NewUserController(uu usecase.IUserUsecase) IUserController {
-	return &userController{uu}
+func NewUserController(uu usecase.IUserUsecase, au usecase.IAuthUsecase, tu usecase.ITwoFactorUsecase) IUserController {
+	return &userController{uu, au, tu}
 }
 
 // GetUser godoc
@@ -35,9 +51,16 @@ func NewUserController(uu usecase.IUserUsecase) IUserController {
 // @Produce  json
 // @Param email path string true "Email"
 // @Success 200 {object} model.UserResponse
+// @Failure 403 {object} map[string]string
 // @Router /users/{email} [get]
 // @Tags users
+// @Security BearerAuth
 func (uc *userController) GetUser(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	// URLパラメータからメールアドレスを取得
 	email := c.Param("email")
 
@@ -50,12 +73,16 @@ func (uc *userController) GetUser(c echo.Context) error {
 	// デコードしたメールアドレスを使ってユーザーを取得
 	user, err := uc.uu.GetUserByEmail(decodedEmail)
 	if err != nil {
-		// ユーザーが見つからない場合、404エラーを返すことも考慮
+		// 存在しないメールアドレスも他のユーザーと同じく 403 にして、登録の有無を漏らさない
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return c.JSON(http.StatusNotFound, echo.Map{"error": "user not found"})
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
 		}
 		return c.JSON(http.StatusInternalServerError, err)
 	}
+	// 他のユーザーの情報は参照できない
+	if user.ID != authUser.ID {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
+	}
 	return c.JSON(http.StatusOK, user)
 }
 
@@ -75,7 +102,7 @@ func (uc *userController) CreateUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	createdUser, err := uc.uu.CreateUser(user)
+	createdUser, err := uc.uu.CreateUser(user, requestMeta(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err)
 	}
@@ -85,16 +112,23 @@ func (uc *userController) CreateUser(c echo.Context) error {
 
 // UpdateUser godoc
 // @Summary Update user
-// @Description Update user
+// @Description Update user. Changing the email address marks it as unverified and sends a verification email to the new address, and changing the password revokes every refresh token of the user
 // @ID update-user
 // @Accept  json
 // @Produce  json
 // @Param email path string true "Email"
 // @Param user body model.UserRequest true "User"
 // @Success 200 {object} model.UserResponse
+// @Failure 403 {object} map[string]string
 // @Router /users/{email} [put]
 // @Tags users
+// @Security BearerAuth
 func (uc *userController) UpdateUser(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	user := model.User{}
 	if err := c.Bind(&user); err != nil {
 		return c.JSON(http.StatusBadRequest, err)
@@ -107,8 +141,15 @@ func (uc *userController) UpdateUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid email format"})
 	}
 
+	// 他のユーザーの情報は更新できない
+	if owns, err := uc.ownsEmail(authUser, decodedEmail); err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	} else if !owns {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
+	}
+
 	// デコードしたメールアドレスを使用してユーザーを更新
-	updatedUser, err := uc.uu.UpdateUser(user, decodedEmail)
+	updatedUser, err := uc.uu.UpdateUser(user, decodedEmail, requestMeta(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, err)
 	}
@@ -124,16 +165,39 @@ func (uc *userController) UpdateUser(c echo.Context) error {
 // @Produce  json
 // @Param user body model.UserRequest true "User"
 // @Success 200 {string} string "deleted"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /users [delete]
 // @Tags users
+// @Security BearerAuth
 func (uc *userController) DeleteUser(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
 	user := model.User{}
 	if err := c.Bind(&user); err != nil {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	err := uc.uu.DeleteUser(user)
+	// 他のユーザーは削除できない
+	if owns, err := uc.ownsEmail(authUser, user.Email); err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	} else if !owns {
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "forbidden"})
+	}
+
+	err := uc.uu.DeleteUser(user, requestMeta(c))
 	if err != nil {
+		var locked *model.LoginLockedError
+		if errors.As(err, &locked) {
+			return loginLockedResponse(c, locked)
+		}
+		if errors.Is(err, model.ErrInvalidPassword) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid email or password"})
+		}
 		return c.JSON(http.StatusInternalServerError, err)
 	}
 
@@ -142,14 +206,30 @@ func (uc *userController) DeleteUser(c echo.Context) error {
 
 
 
+// ownsEmail reports whether the email address belongs to the authenticated
+// user. The user is compared by ID, since the email in an access token is
+// stale once the user changes their address.
+func (uc *userController) ownsEmail(authUser model.AuthUser, email string) (bool, error) {
+	user, err := uc.uu.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.ID == authUser.ID, nil
+}
+
 // LoginUser godoc
 // @Summary Login user
-// @Description Login user
+// @Description Login user. When two-factor authentication is enabled, no tokens are returned; mfa_required is true and mfa_token must be sent to POST /users/login/2fa.
 // @ID login-user
 // @Accept  json
 // @Produce  json
 // @Param user body model.UserRequest true "User"
-// @Success 200 {object} model.UserResponse
+// @Success 200 {object} model.LoginResponse
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /users/login [post]
 // @Tags users
 func (uc *userController) LoginUser(c echo.Context)error{
@@ -164,10 +244,395 @@ func (uc *userController) LoginUser(c echo.Context)error{
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid email format"})
 	}
 
-	response ,err := uc.uu.LoginUser(user, decodedEmail)
+	response ,err := uc.uu.LoginUser(user, decodedEmail, c.RealIP())
 	if err != nil {
+		var locked *model.LoginLockedError
+		if errors.As(err, &locked) {
+			return loginLockedResponse(c, locked)
+		}
+		if errors.Is(err, model.ErrInvalidPassword) || errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid email or password"})
+		}
 		return c.JSON(http.StatusInternalServerError, err)
 	}
 
 	return c.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// RefreshToken godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and a rotated refresh token
+// @ID refresh-token
+// @Accept  json
+// @Produce  json
+// @Param token body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} model.TokenResponse
+// @Failure 401 {object} map[string]string
+// @Router /users/token/refresh [post]
+// @Tags users
+func (uc *userController) RefreshToken(c echo.Context) error {
+	req := model.RefreshTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	if req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "refresh_token is required"})
+	}
+
+	tokens, err := uc.au.RefreshTokens(req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, model.ErrUnauthorized) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid refresh token"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the refresh token and every token rotated from the same login
+// @ID logout
+// @Accept  json
+// @Produce  json
+// @Param token body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {string} string "logged out"
+// @Failure 401 {object} map[string]string
+// @Router /users/logout [post]
+// @Tags users
+func (uc *userController) Logout(c echo.Context) error {
+	req := model.RefreshTokenRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	if req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "refresh_token is required"})
+	}
+
+	if err := uc.au.Logout(req.RefreshToken); err != nil {
+		if errors.Is(err, model.ErrUnauthorized) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid refresh token"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "logged out")
+}
+
+// LogoutAll godoc
+// @Summary Logout from all devices
+// @Description Revoke every refresh token of the authenticated user
+// @ID logout-all
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string "logged out"
+// @Router /users/logout-all [post]
+// @Tags users
+// @Security BearerAuth
+func (uc *userController) LogoutAll(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	if err := uc.au.LogoutAll(authUser.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "logged out")
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Email a single-use password reset link. Always succeeds so that registered emails cannot be probed.
+// @ID forgot-password
+// @Accept  json
+// @Produce  json
+// @Param request body model.PasswordForgotRequest true "Email"
+// @Success 200 {string} string "reset email sent"
+// @Router /users/password/forgot [post]
+// @Tags users
+func (uc *userController) ForgotPassword(c echo.Context) error {
+	req := model.PasswordForgotRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	if req.Email == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "email is required"})
+	}
+
+	if err := uc.uu.ForgotPassword(req.Email); err != nil {
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "reset email sent")
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Reset the password with the token received by email
+// @ID reset-password
+// @Accept  json
+// @Produce  json
+// @Param request body model.PasswordResetRequest true "Token and new password"
+// @Success 200 {string} string "password reset"
+// @Failure 400 {object} map[string]string
+// @Router /users/password/reset [post]
+// @Tags users
+func (uc *userController) ResetPassword(c echo.Context) error {
+	req := model.PasswordResetRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := uc.uu.ResetPassword(req.Token, req.Password, requestMeta(c)); err != nil {
+		if errors.Is(err, model.ErrInvalidToken) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid or expired token"})
+		}
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusOK, "password reset")
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Verify the email address with the token received by email
+// @ID verify-email
+// @Accept  json
+// @Produce  json
+// @Param token query string true "Verification token"
+// @Success 200 {string} string "email verified"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/verify [get]
+// @Tags users
+func (uc *userController) VerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "token is required"})
+	}
+
+	if err := uc.uu.VerifyEmail(token, requestMeta(c)); err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidToken):
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid or expired token"})
+		case errors.Is(err, model.ErrEmailAlreadyVerified):
+			return c.JSON(http.StatusConflict, echo.Map{"error": "email already verified"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "email verified")
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description Send the verification email of the authenticated user again
+// @ID resend-verification-email
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} string "verification email sent"
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /users/verify/resend [post]
+// @Tags users
+// @Security BearerAuth
+func (uc *userController) ResendVerificationEmail(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	if err := uc.uu.ResendVerificationEmail(authUser.ID); err != nil {
+		switch {
+		case errors.Is(err, model.ErrEmailAlreadyVerified):
+			return c.JSON(http.StatusConflict, echo.Map{"error": "email already verified"})
+		case errors.Is(err, model.ErrTooManyRequests):
+			return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "verification email was sent recently"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "verification email sent")
+}
+
+// UnlockAccount godoc
+// @Summary Unlock account
+// @Description Unlock an account locked by repeated login failures with the token received by email
+// @ID unlock-account
+// @Accept  json
+// @Produce  json
+// @Param token query string true "Unlock token"
+// @Success 200 {string} string "account unlocked"
+// @Failure 400 {object} map[string]string
+// @Router /users/unlock [get]
+// @Tags users
+func (uc *userController) UnlockAccount(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "token is required"})
+	}
+
+	if err := uc.uu.UnlockAccount(token, requestMeta(c)); err != nil {
+		if errors.Is(err, model.ErrInvalidToken) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid or expired token"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "account unlocked")
+}
+
+// loginLockedResponse responds 429 with the seconds until the lock expires.
+func loginLockedResponse(c echo.Context, locked *model.LoginLockedError) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "too many failed login attempts"})
+}
+
+// LoginTwoFactor godoc
+// @Summary Complete login with a second factor
+// @Description Exchange the mfa_token returned by POST /users/login and a TOTP or recovery code for tokens
+// @ID login-two-factor
+// @Accept  json
+// @Produce  json
+// @Param request body model.TwoFactorLoginRequest true "MFA token and code"
+// @Success 200 {object} model.LoginResponse
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /users/login/2fa [post]
+// @Tags users
+func (uc *userController) LoginTwoFactor(c echo.Context) error {
+	req := model.TwoFactorLoginRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	response, err := uc.uu.LoginUserTwoFactor(req.MFAToken, req.Code, c.RealIP())
+	if err != nil {
+		var locked *model.LoginLockedError
+		if errors.As(err, &locked) {
+			return loginLockedResponse(c, locked)
+		}
+		if errors.Is(err, model.ErrUnauthorized) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid or expired mfa token"})
+		}
+		if errors.Is(err, model.ErrInvalidTOTPCode) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid two-factor code"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generate a TOTP secret for the authenticated user. Two-factor authentication is enabled once the secret is confirmed.
+// @ID enroll-totp
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.TOTPEnrollResponse
+// @Failure 409 {object} map[string]string
+// @Router /users/2fa/totp [post]
+// @Tags users
+// @Security BearerAuth
+func (uc *userController) EnrollTOTP(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	response, err := uc.tu.EnrollTOTP(authUser.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrTwoFactorAlreadyEnabled) {
+			return c.JSON(http.StatusConflict, echo.Map{"error": "two-factor authentication is already enabled"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enable two-factor authentication with a code from the authenticator app. The returned recovery codes are shown only once.
+// @ID confirm-totp
+// @Accept  json
+// @Produce  json
+// @Param request body model.TOTPCodeRequest true "TOTP code"
+// @Success 200 {object} model.RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /users/2fa/totp/confirm [post]
+// @Tags users
+// @Security BearerAuth
+func (uc *userController) ConfirmTOTP(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.TOTPCodeRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	response, err := uc.tu.ConfirmTOTP(authUser.ID, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidTOTPCode):
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid two-factor code"})
+		case errors.Is(err, model.ErrTwoFactorNotEnrolled):
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "two-factor enrollment has not been started"})
+		case errors.Is(err, model.ErrTwoFactorAlreadyEnabled):
+			return c.JSON(http.StatusConflict, echo.Map{"error": "two-factor authentication is already enabled"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication and delete the recovery codes after re-entering the password
+// @ID disable-two-factor
+// @Accept  json
+// @Produce  json
+// @Param request body model.TwoFactorDisableRequest true "Current password"
+// @Success 200 {string} string "two-factor authentication disabled"
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /users/2fa [delete]
+// @Tags users
+// @Security BearerAuth
+func (uc *userController) DisableTwoFactor(c echo.Context) error {
+	authUser, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	req := model.TwoFactorDisableRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := uc.uu.DisableTwoFactor(authUser.ID, req.Password, requestMeta(c)); err != nil {
+		var locked *model.LoginLockedError
+		if errors.As(err, &locked) {
+			return loginLockedResponse(c, locked)
+		}
+		switch {
+		case errors.Is(err, model.ErrInvalidPassword):
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "invalid password"})
+		case errors.Is(err, model.ErrTwoFactorNotEnabled):
+			return c.JSON(http.StatusConflict, echo.Map{"error": "two-factor authentication is not enabled"})
+		}
+		return c.JSON(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, "two-factor authentication disabled")
+}
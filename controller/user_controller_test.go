@@ -57,7 +57,7 @@ func Test_userController_GetUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase.EXPECT().GetUserByEmail(tt.args.email).Return(tt.mockReturns, nil)
 
-			uc := NewUserController(mockUsecase)
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
 			e := echo.New()
 
 			req := httptest.NewRequest(http.MethodGet, "/user/"+tt.args.email, nil)
@@ -66,6 +66,7 @@ func Test_userController_GetUser(t *testing.T) {
 			c.SetPath("/user/:email")
 			c.SetParamNames("email")
 			c.SetParamValues(tt.args.email)
+			c.Set(authUserKey, model.AuthUser{ID: 1, Email: tt.args.email})
 
 			if err := uc.GetUser(c); (err != nil) != tt.wantErr {
 				t.Errorf("userController.GetUser() error = %v, wantErr %v", err, tt.wantErr)
@@ -74,6 +75,51 @@ func Test_userController_GetUser(t *testing.T) {
 	}
 }
 
+func Test_userController_OtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+	uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+
+	// 変更前のメールアドレスを持つアクセストークンでも、今そのアドレスを持つ他のユーザーは操作できない
+	authUser := model.AuthUser{ID: 1, Email: "sample@test.com"}
+	other := model.UserResponse{ID: 2, Email: "sample@test.com"}
+	body := `{"username":"test","email":"sample@test.com","password":"password"}`
+
+	tests := []struct {
+		name    string
+		method  string
+		handler func(c echo.Context) error
+	}{
+		{name: "異常系：他のユーザーを取得できない", method: http.MethodGet, handler: uc.GetUser},
+		{name: "異常系：他のユーザーを更新できない", method: http.MethodPut, handler: uc.UpdateUser},
+		{name: "異常系：他のユーザーを削除できない", method: http.MethodDelete, handler: uc.DeleteUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().GetUserByEmail(other.Email).Return(other, nil).Times(1)
+
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/users/"+other.Email, strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/users/:email")
+			c.SetParamNames("email")
+			c.SetParamValues(other.Email)
+			c.Set(authUserKey, authUser)
+
+			if err := tt.handler(c); err != nil {
+				t.Fatalf("handler error = %v", err)
+			}
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
+
 func Test_userController_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -128,9 +174,9 @@ func Test_userController_CreateUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// モックの戻り値として model.UserResponse を返す
-			mockUsecase.EXPECT().CreateUser(tt.args.user).Return(tt.mockReturns, nil).AnyTimes()
+			mockUsecase.EXPECT().CreateUser(tt.args.user, gomock.Any()).Return(tt.mockReturns, nil).AnyTimes()
 
-			uc := NewUserController(mockUsecase)
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
 			e := echo.New()
 
 			bodyBytes, err := json.Marshal(tt.args.user)
@@ -211,9 +257,10 @@ func Test_userController_UpdateUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockUsecase.EXPECT().UpdateUser(tt.args.user, tt.args.email).Return(tt.mockReturns, nil).AnyTimes()
+			mockUsecase.EXPECT().GetUserByEmail(tt.args.email).Return(model.UserResponse{ID: 1, Email: tt.args.email}, nil).Times(1)
+			mockUsecase.EXPECT().UpdateUser(tt.args.user, tt.args.email, gomock.Any()).Return(tt.mockReturns, nil).AnyTimes()
 
-			uc := NewUserController(mockUsecase)
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
 			e := echo.New()
 
 			bodyBytes, err := json.Marshal(tt.args.user)
@@ -230,6 +277,7 @@ func Test_userController_UpdateUser(t *testing.T) {
 			c.SetPath("/user/:email")
 			c.SetParamNames("email")
 			c.SetParamValues(tt.args.email)
+			c.Set(authUserKey, model.AuthUser{ID: 1, Email: tt.args.email})
 
 			if err := uc.UpdateUser(c); (err != nil) != tt.wantErr {
 				t.Errorf("userController.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
@@ -278,9 +326,10 @@ func Test_userController_DeleteUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockUsecase.EXPECT().DeleteUser(tt.args.user).Return(nil).AnyTimes()
+			mockUsecase.EXPECT().GetUserByEmail(tt.args.user.Email).Return(model.UserResponse{ID: 1, Email: tt.args.user.Email}, nil).Times(1)
+			mockUsecase.EXPECT().DeleteUser(tt.args.user, gomock.Any()).Return(nil).AnyTimes()
 
-			uc := NewUserController(mockUsecase)
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
 			e := echo.New()
 
 			bodyBytes, err := json.Marshal(tt.args.user)
@@ -295,6 +344,7 @@ func Test_userController_DeleteUser(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/user")
+			c.Set(authUserKey, model.AuthUser{ID: 1, Email: tt.args.user.Email})
 
 			if err := uc.DeleteUser(c); (err != nil) != tt.wantErr {
 				t.Errorf("userController.DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
@@ -302,3 +352,380 @@ func Test_userController_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_userController_LoginUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+
+	user := model.User{Username: "test", Email: "sample@test.com", Password: "password"}
+
+	tests := []struct {
+		name           string
+		mockErr        error
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{
+			name:       "正常系：ログインできる",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：パスワードが間違っている",
+			mockErr:    model.ErrInvalidPassword,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系：ロック中はログインできない",
+			mockErr:        &model.LoginLockedError{RetryAfter: 1500 * time.Millisecond},
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().LoginUser(user, user.Email, "192.0.2.1").Return(model.LoginResponse{}, tt.mockErr)
+
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+
+			bodyBytes, err := json.Marshal(user)
+			if err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/login", strings.NewReader(string(bodyBytes)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := uc.LoginUser(c); err != nil {
+				t.Errorf("userController.LoginUser() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.LoginUser() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(echo.HeaderRetryAfter); got != tt.wantRetryAfter {
+				t.Errorf("userController.LoginUser() Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func Test_userController_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockAuthUsecase := mocks.NewMockIAuthUsecase(ctrl)
+
+	tests := []struct {
+		name        string
+		token       string
+		mockReturns model.TokenResponse
+		mockErr     error
+		wantStatus  int
+	}{
+		{
+			name:        "正常系：トークンを更新できる",
+			token:       "refresh",
+			mockReturns: model.TokenResponse{AccessToken: "access", TokenType: "Bearer", ExpiresIn: 900, RefreshToken: "rotated"},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "異常系：無効なリフレッシュトークン",
+			token:      "reused",
+			mockErr:    model.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUsecase.EXPECT().RefreshTokens(tt.token).Return(tt.mockReturns, tt.mockErr)
+
+			uc := NewUserController(mocks.NewMockIUserUsecase(ctrl), mockAuthUsecase, mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+
+			bodyBytes, err := json.Marshal(model.RefreshTokenRequest{RefreshToken: tt.token})
+			if err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/token/refresh", strings.NewReader(string(bodyBytes)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := uc.RefreshToken(c); err != nil {
+				t.Errorf("userController.RefreshToken() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.RefreshToken() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_userController_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		token      string
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：メールアドレスを確認できる",
+			token:      "token",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：無効なトークン",
+			token:      "invalid",
+			mockErr:    model.ErrInvalidToken,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "異常系：確認済みのメールアドレス",
+			token:      "token",
+			mockErr:    model.ErrEmailAlreadyVerified,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().VerifyEmail(tt.token, gomock.Any()).Return(tt.mockErr)
+
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/users/verify?token="+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := uc.VerifyEmail(c); err != nil {
+				t.Errorf("userController.VerifyEmail() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.VerifyEmail() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_userController_ResendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：確認メールを再送信できる",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：短時間に再送信できない",
+			mockErr:    model.ErrTooManyRequests,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "異常系：確認済みのユーザー",
+			mockErr:    model.ErrEmailAlreadyVerified,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().ResendVerificationEmail(1).Return(tt.mockErr)
+
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/users/verify/resend", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := uc.ResendVerificationEmail(c); err != nil {
+				t.Errorf("userController.ResendVerificationEmail() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.ResendVerificationEmail() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_userController_UnlockAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		token      string
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：アカウントのロックを解除できる",
+			token:      "token",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：無効なトークン",
+			token:      "invalid",
+			mockErr:    model.ErrInvalidToken,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().UnlockAccount(tt.token, gomock.Any()).Return(tt.mockErr)
+
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/users/unlock?token="+tt.token, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := uc.UnlockAccount(c); err != nil {
+				t.Errorf("userController.UnlockAccount() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.UnlockAccount() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_userController_LoginTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockUsecase := mocks.NewMockIUserUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		mockErr    error
+		wantStatus int
+	}{
+		{
+			name:       "正常系：2段階目のログインができる",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系：コードが間違っている",
+			mockErr:    model.ErrInvalidTOTPCode,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "異常系：MFA トークンが無効",
+			mockErr:    model.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "異常系：ロック中",
+			mockErr:    &model.LoginLockedError{RetryAfter: time.Minute},
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase.EXPECT().LoginUserTwoFactor("mfa", "123456", "192.0.2.1").Return(model.LoginResponse{}, tt.mockErr)
+
+			uc := NewUserController(mockUsecase, mocks.NewMockIAuthUsecase(ctrl), mocks.NewMockITwoFactorUsecase(ctrl))
+			e := echo.New()
+
+			bodyBytes, err := json.Marshal(model.TwoFactorLoginRequest{MFAToken: "mfa", Code: "123456"})
+			if err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/login/2fa", strings.NewReader(string(bodyBytes)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := uc.LoginTwoFactor(c); err != nil {
+				t.Errorf("userController.LoginTwoFactor() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.LoginTwoFactor() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_userController_ConfirmTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックユースケースの作成
+	mockTwoFactor := mocks.NewMockITwoFactorUsecase(ctrl)
+
+	tests := []struct {
+		name        string
+		mockReturns model.RecoveryCodesResponse
+		mockErr     error
+		wantStatus  int
+	}{
+		{
+			name:        "正常系：二要素認証を有効にできる",
+			mockReturns: model.RecoveryCodesResponse{RecoveryCodes: []string{"abcd-efgh"}},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "異常系：コードが間違っている",
+			mockErr:    model.ErrInvalidTOTPCode,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "異常系：有効化済み",
+			mockErr:    model.ErrTwoFactorAlreadyEnabled,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTwoFactor.EXPECT().ConfirmTOTP(1, "123456").Return(tt.mockReturns, tt.mockErr)
+
+			uc := NewUserController(mocks.NewMockIUserUsecase(ctrl), mocks.NewMockIAuthUsecase(ctrl), mockTwoFactor)
+			e := echo.New()
+
+			bodyBytes, err := json.Marshal(model.TOTPCodeRequest{Code: "123456"})
+			if err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/2fa/totp/confirm", strings.NewReader(string(bodyBytes)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := uc.ConfirmTOTP(c); err != nil {
+				t.Errorf("userController.ConfirmTOTP() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("userController.ConfirmTOTP() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+)
+
+type IWebhookController interface {
+	GetWebhooks(c echo.Context) error
+	CreateWebhook(c echo.Context) error
+	UpdateWebhook(c echo.Context) error
+	DeleteWebhook(c echo.Context) error
+	GetDeliveries(c echo.Context) error
+	SendTestEvent(c echo.Context) error
+}
+
+type webhookController struct {
+	wu usecase.IWebhookUsecase
+}
+
+func NewWebhookController(wu usecase.IWebhookUsecase) IWebhookController {
+	return &webhookController{wu}
+}
+
+// GetWebhooks godoc
+// @Summary Get webhooks
+// @Description Get the webhooks of the household. Requires the household:manage permission
+// @ID get-webhooks
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Success 200 {array} model.WebhookResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks [get]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) GetWebhooks(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	webhooks, err := wc.wu.GetWebhooks(id, user.ID)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// CreateWebhook godoc
+// @Summary Create webhook
+// @Description Subscribe an endpoint to inventory events of the household. Events are posted as JSON (model.WebhookEvent) and signed with the returned secret: X-Watchdog-Signature is "sha256=" followed by the hex HMAC-SHA256 of "<X-Watchdog-Timestamp>.<body>". Failed deliveries are retried with exponential backoff. The secret is returned only once. Requires the household:manage permission
+// @ID create-webhook
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param webhook body model.WebhookRequest true "Webhook"
+// @Success 200 {object} model.WebhookCreatedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks [post]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) CreateWebhook(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.WebhookRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	webhook, err := wc.wu.CreateWebhook(id, user.ID, req)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook
+// @Description Change the endpoint and the events of a webhook. The secret is kept. Requires the household:manage permission
+// @ID update-webhook
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param webhookID path int true "Webhook ID"
+// @Param webhook body model.WebhookRequest true "Webhook"
+// @Success 200 {object} model.WebhookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks/{webhookID} [put]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) UpdateWebhook(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, webhookID, err := webhookParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+	req := model.WebhookRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	webhook, err := wc.wu.UpdateWebhook(id, user.ID, webhookID, req)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook
+// @Description Delete a webhook and its delivery log. Requires the household:manage permission
+// @ID delete-webhook
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {string} string "webhook deleted"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks/{webhookID} [delete]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) DeleteWebhook(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, webhookID, err := webhookParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	if err := wc.wu.DeleteWebhook(id, user.ID, webhookID); err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, "webhook deleted")
+}
+
+// GetDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description Get the latest 50 deliveries of a webhook with the status codes of the responses, newest first. Requires the household:manage permission
+// @ID get-webhook-deliveries
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {array} model.WebhookDeliveryResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks/{webhookID}/deliveries [get]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) GetDeliveries(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, webhookID, err := webhookParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	deliveries, err := wc.wu.GetDeliveries(id, user.ID, webhookID)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// SendTestEvent godoc
+// @Summary Send test event
+// @Description Post a ping event to the webhook right away and return the result. The event is not retried. Requires the household:manage permission
+// @ID send-webhook-test-event
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Household ID"
+// @Param webhookID path int true "Webhook ID"
+// @Success 200 {object} model.WebhookDeliveryResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /households/{id}/webhooks/{webhookID}/test [post]
+// @Tags webhooks
+// @Security BearerAuth
+func (wc *webhookController) SendTestEvent(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "unauthorized"})
+	}
+
+	id, webhookID, err := webhookParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
+	delivery, err := wc.wu.SendTestEvent(id, user.ID, webhookID)
+	if err != nil {
+		return webhookErrorResponse(c, err)
+	}
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// webhookParams returns the household ID and the webhook ID in the path.
+func webhookParams(c echo.Context) (int, int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	webhookID, err := strconv.Atoi(c.Param("webhookID"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return id, webhookID, nil
+}
+
+// webhookErrorResponse maps errors returned by the webhook usecase to HTTP responses.
+func webhookErrorResponse(c echo.Context, err error) error {
+	var verrs validation.Errors
+	switch {
+	case errors.As(err, &verrs):
+		return c.JSON(http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrHouseholdNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "household not found"})
+	case errors.Is(err, model.ErrWebhookNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "webhook not found"})
+	case errors.Is(err, model.ErrForbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "not allowed in the household"})
+	}
+	return c.JSON(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_webhookController_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIWebhookUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockCall   bool
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：Webhookを作成できる", body: `{"url":"https://example.com/hook","events":["food.created"]}`, mockCall: true, wantStatus: http.StatusOK},
+		{
+			name:       "異常系：バリデーションエラー",
+			body:       `{"url":"example.com","events":["food.created"]}`,
+			mockCall:   true,
+			mockErr:    validation.Errors{"url": validation.NewError("validation_invalid_webhook_url", "無効なURLです")},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "異常系：権限がない", body: `{"url":"https://example.com/hook","events":["food.created"]}`, mockCall: true, mockErr: model.ErrForbidden, wantStatus: http.StatusForbidden},
+		{name: "異常系：不正なJSON", body: `{"events":"food.created"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockCall {
+				mockUsecase.EXPECT().CreateWebhook(1, 1, gomock.Any()).Return(model.WebhookCreatedResponse{}, tt.mockErr).Times(1)
+			}
+
+			wc := NewWebhookController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/households/1/webhooks", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := wc.CreateWebhook(c); err != nil {
+				t.Errorf("webhookController.CreateWebhook() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("webhookController.CreateWebhook() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func Test_webhookController_SendTestEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIWebhookUsecase(ctrl)
+
+	tests := []struct {
+		name       string
+		webhookID  string
+		mockCall   bool
+		mockErr    error
+		wantStatus int
+	}{
+		{name: "正常系：テストイベントを送信できる", webhookID: "2", mockCall: true, wantStatus: http.StatusOK},
+		{name: "異常系：存在しないWebhook", webhookID: "2", mockCall: true, mockErr: model.ErrWebhookNotFound, wantStatus: http.StatusNotFound},
+		{name: "異常系：不正なID", webhookID: "abc", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockCall {
+				mockUsecase.EXPECT().SendTestEvent(1, 1, 2).Return(model.WebhookDeliveryResponse{}, tt.mockErr).Times(1)
+			}
+
+			wc := NewWebhookController(mockUsecase)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/households/1/webhooks/"+tt.webhookID+"/test", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "webhookID")
+			c.SetParamValues("1", tt.webhookID)
+			c.Set(authUserKey, model.AuthUser{ID: 1})
+
+			if err := wc.SendTestEvent(c); err != nil {
+				t.Errorf("webhookController.SendTestEvent() error = %v", err)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("webhookController.SendTestEvent() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
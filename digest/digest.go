@@ -0,0 +1,177 @@
+// Package digest renders the digest emails that summarize the foods of a
+// user instead of alerting about each of them.
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Frequencies of digests.
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+)
+
+// Languages digests are rendered in.
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+)
+
+// Item is a food listed in a digest.
+type Item struct {
+	Name           string
+	Tag            string
+	HouseholdName  string     // Shown when the user belongs to more than one household
+	ExpirationDate *time.Time // In the time zone of the user
+	DaysRemaining  int        // Days from the day of the digest to the expiration date
+}
+
+// Digest is the data of a digest.
+type Digest struct {
+	UserName  string
+	Frequency string    // FrequencyDaily or FrequencyWeekly
+	Language  string    // LanguageJapanese or LanguageEnglish; Japanese otherwise
+	Date      time.Time // Day of the digest in the time zone of the user
+	URL       string    // Frontend the digest links to
+
+	ExpiringToday    []Item // Expire on the day of the digest
+	ExpiringThisWeek []Item // Expire within the next 6 days
+	Added            []Item // Added since the previous digest
+	Wasted           []Item // Expired since the previous digest and were not used up
+}
+
+// Empty reports whether the digest has nothing to tell.
+func (d Digest) Empty() bool {
+	return len(d.ExpiringToday) == 0 && len(d.ExpiringThisWeek) == 0 && len(d.Added) == 0 && len(d.Wasted) == 0
+}
+
+// Rendered is a rendered digest.
+type Rendered struct {
+	Subject string
+	Summary string // One line for channels with little space, like push
+	Text    string
+	HTML    string
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(texttemplate.FuncMap(funcs("ja"))).ParseFS(templateFS, "templates/digest.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(htmltemplate.FuncMap(funcs("ja"))).ParseFS(templateFS, "templates/digest.html.tmpl"))
+)
+
+// funcs returns the template functions localized for the language.
+func funcs(lang string) map[string]interface{} {
+	m, ok := messages[lang]
+	if !ok {
+		m = messages[LanguageJapanese]
+	}
+	return map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			return fmt.Sprintf(m[key], args...)
+		},
+		"section": func(title string, items []Item, showHousehold bool) section {
+			return section{title, items, showHousehold}
+		},
+		"when": func(item Item) string {
+			if item.ExpirationDate == nil {
+				return ""
+			}
+			return fmt.Sprintf(m["when"], formatDate(lang, *item.ExpirationDate), remaining(m, item.DaysRemaining))
+		},
+	}
+}
+
+func formatDate(lang string, t time.Time) string {
+	if lang == LanguageEnglish {
+		return t.Format("Mon, Jan 2")
+	}
+	return fmt.Sprintf("%d月%d日(%s)", t.Month(), t.Day(), japaneseWeekdays[t.Weekday()])
+}
+
+func remaining(m map[string]string, days int) string {
+	switch {
+	case days == 0:
+		return m["today"]
+	case days == 1:
+		return m["tomorrow"]
+	case days > 0:
+		return fmt.Sprintf(m["in_days"], days)
+	case days == -1:
+		return m["yesterday"]
+	}
+	return fmt.Sprintf(m["days_ago"], -days)
+}
+
+// section is a list of items in the templates.
+type section struct {
+	Title         string
+	Items         []Item
+	ShowHousehold bool
+}
+
+var japaneseWeekdays = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// Render renders the digest as plain text and HTML.
+func Render(d Digest) (Rendered, error) {
+	lang := d.Language
+	if _, ok := messages[lang]; !ok {
+		lang = LanguageJapanese
+	}
+	d.Language = lang
+	if d.Frequency != FrequencyWeekly {
+		d.Frequency = FrequencyDaily
+	}
+	f := funcs(lang)
+	m := messages[lang]
+	rendered := Rendered{
+		Subject: fmt.Sprintf(m["subject_"+d.Frequency], formatDate(lang, d.Date)),
+		Summary: fmt.Sprintf(m["summary"], len(d.ExpiringToday), len(d.ExpiringThisWeek), len(d.Wasted)),
+	}
+
+	data := struct {
+		Digest
+		Subject       string
+		Weekly        bool
+		ShowHousehold bool
+	}{d, rendered.Subject, d.Frequency == FrequencyWeekly, showHousehold(d)}
+
+	var text bytes.Buffer
+	tt, err := textTemplate.Clone()
+	if err != nil {
+		return Rendered{}, err
+	}
+	if err := tt.Funcs(texttemplate.FuncMap(f)).Execute(&text, data); err != nil {
+		return Rendered{}, err
+	}
+	var html bytes.Buffer
+	ht, err := htmlTemplate.Clone()
+	if err != nil {
+		return Rendered{}, err
+	}
+	if err := ht.Funcs(htmltemplate.FuncMap(f)).Execute(&html, data); err != nil {
+		return Rendered{}, err
+	}
+	rendered.Text = strings.TrimLeft(text.String(), "\n")
+	rendered.HTML = html.String()
+	return rendered, nil
+}
+
+// showHousehold reports whether the items belong to more than one household.
+func showHousehold(d Digest) bool {
+	households := map[string]bool{}
+	for _, items := range [][]Item{d.ExpiringToday, d.ExpiringThisWeek, d.Added, d.Wasted} {
+		for _, item := range items {
+			households[item.HouseholdName] = true
+		}
+	}
+	return len(households) > 1
+}
@@ -0,0 +1,85 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	date := func(day int) *time.Time {
+		d := time.Date(2024, 10, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	d := Digest{
+		UserName:         "山田太郎",
+		Date:             *date(2),
+		URL:              "http://localhost:3000",
+		ExpiringToday:    []Item{{Name: "牛乳", HouseholdName: "山田家", ExpirationDate: date(2), DaysRemaining: 0}},
+		ExpiringThisWeek: []Item{{Name: "卵", HouseholdName: "山田家", ExpirationDate: date(5), DaysRemaining: 3}},
+		Added:            []Item{{Name: "<b>豆腐</b>", HouseholdName: "実家"}},
+	}
+
+	tests := []struct {
+		name        string
+		language    string
+		frequency   string
+		wantSubject string
+		wantText    []string
+		wantHTML    []string
+	}{
+		{
+			name:        "正常系：日本語の毎日のまとめ",
+			language:    LanguageJapanese,
+			frequency:   FrequencyDaily,
+			wantSubject: "冷蔵庫のまとめ（10月2日(水)）",
+			wantText:    []string{"山田太郎 様", "■ 今日が期限の食材 (1)\n・牛乳（10月2日(水)、今日まで） - 山田家", "・卵（10月5日(土)、あと3日）", "・<b>豆腐</b> - 実家", "■ 昨日期限が切れた食材 (0)\n  ありません"},
+			wantHTML:    []string{`<html lang="ja">`, "&lt;b&gt;豆腐&lt;/b&gt;", `<a href="http://localhost:3000">`},
+		},
+		{
+			name:        "正常系：英語の週間まとめ",
+			language:    LanguageEnglish,
+			frequency:   FrequencyWeekly,
+			wantSubject: "Your weekly fridge digest for Wed, Oct 2",
+			wantText:    []string{"Hi 山田太郎,", "・牛乳 (Wed, Oct 2, today) - 山田家", "■ Added in the last 7 days (1)", "■ Expired in the last 7 days (0)\n  Nothing"},
+			wantHTML:    []string{`<html lang="en">`, "Expiring this week (1)"},
+		},
+		{
+			name:        "正常系：未知の言語は日本語になる",
+			language:    "fr",
+			wantSubject: "冷蔵庫のまとめ（10月2日(水)）",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := d
+			d.Language, d.Frequency = tt.language, tt.frequency
+			got, err := Render(d)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got.Subject != tt.wantSubject {
+				t.Errorf("Render() subject = %v, want %v", got.Subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(got.Text, want) {
+					t.Errorf("Render() text does not contain %q:\n%s", want, got.Text)
+				}
+			}
+			for _, want := range tt.wantHTML {
+				if !strings.Contains(got.HTML, want) {
+					t.Errorf("Render() html does not contain %q:\n%s", want, got.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestDigest_Empty(t *testing.T) {
+	if !(Digest{}).Empty() {
+		t.Errorf("Digest.Empty() = false, want true")
+	}
+	if (Digest{Wasted: []Item{{Name: "牛乳"}}}).Empty() {
+		t.Errorf("Digest.Empty() = true, want false")
+	}
+}
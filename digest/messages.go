@@ -0,0 +1,49 @@
+package digest
+
+// messages are the strings of the templates by language.
+var messages = map[string]map[string]string{
+	LanguageJapanese: {
+		"subject_daily":  "冷蔵庫のまとめ（%s）",
+		"subject_weekly": "冷蔵庫の週間まとめ（%s）",
+		"summary":        "今日が期限 %d件、今週が期限 %d件、期限切れ %d件",
+		"greeting":       "%s 様",
+		"intro_daily":    "今日の冷蔵庫のまとめです。",
+		"intro_weekly":   "今週の冷蔵庫のまとめです。",
+		"expiring_today": "今日が期限の食材",
+		"expiring_week":  "今週中に期限が来る食材",
+		"added_daily":    "昨日から追加された食材",
+		"added_weekly":   "この1週間に追加された食材",
+		"wasted_daily":   "昨日期限が切れた食材",
+		"wasted_weekly":  "この1週間に期限が切れた食材",
+		"none":           "ありません",
+		"footer":         "食材の一覧と通知の設定: %s",
+		"when":           "（%s、%s）",
+		"today":          "今日まで",
+		"tomorrow":       "明日まで",
+		"in_days":        "あと%d日",
+		"yesterday":      "昨日期限切れ",
+		"days_ago":       "%d日前に期限切れ",
+	},
+	LanguageEnglish: {
+		"subject_daily":  "Your fridge digest for %s",
+		"subject_weekly": "Your weekly fridge digest for %s",
+		"summary":        "%d expiring today, %d this week, %d expired",
+		"greeting":       "Hi %s,",
+		"intro_daily":    "Here is what is going on in your fridge today.",
+		"intro_weekly":   "Here is what is going on in your fridge this week.",
+		"expiring_today": "Expiring today",
+		"expiring_week":  "Expiring this week",
+		"added_daily":    "Added since yesterday",
+		"added_weekly":   "Added in the last 7 days",
+		"wasted_daily":   "Expired yesterday",
+		"wasted_weekly":  "Expired in the last 7 days",
+		"none":           "Nothing",
+		"footer":         "Your foods and notification settings: %s",
+		"when":           " (%s, %s)",
+		"today":          "today",
+		"tomorrow":       "tomorrow",
+		"in_days":        "in %d days",
+		"yesterday":      "expired yesterday",
+		"days_ago":       "expired %d days ago",
+	},
+}
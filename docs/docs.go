@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -16,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/foods": {
-            "post": {
-                "description": "Create food",
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get changes to foods and images of the active household, newest first, together with the changes to the caller's own account. Household entries require the audit:read permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -26,34 +30,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "audit"
                 ],
-                "summary": "Create food",
-                "operationId": "create-food",
+                "summary": "Get audit log",
+                "operationId": "get-audit-logs",
                 "parameters": [
                     {
-                        "description": "Food",
-                        "name": "food",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.FoodRequest"
-                        }
+                        "type": "string",
+                        "description": "Entity type (food, image or user)",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID; requires entity",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the user who made the changes",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes at or after the time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FoodResponse"
+                            "$ref": "#/definitions/model.AuditLogPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/foods/{id}": {
+        "/foods": {
             "get": {
-                "description": "Get foods by user id",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a page of the foods of the authenticated user's active household. Foods without an expiration date come last when sorted by it",
                 "consumes": [
                     "application/json"
                 ],
@@ -63,31 +111,97 @@ const docTemplate = `{
                 "tags": [
                     "foods"
                 ],
-                "summary": "Get foods by user id",
+                "summary": "Get foods of the active household",
                 "operationId": "get-foods-by-user-id",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only foods with the tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods whose name contains the string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods expiring before the time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "expires_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods expiring at or after the time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "expires_after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "expiration_date",
+                            "created_at",
+                            "name"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "UserID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Maximum number of foods (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.FoodResponse"
+                            "$ref": "#/definitions/model.FoodPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update food",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create food",
                 "consumes": [
                     "application/json"
                 ],
@@ -97,16 +211,9 @@ const docTemplate = `{
                 "tags": [
                     "foods"
                 ],
-                "summary": "Update food",
-                "operationId": "update-food",
+                "summary": "Create food",
+                "operationId": "create-food",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Food ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Food",
                         "name": "food",
@@ -122,12 +229,37 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete food",
+            }
+        },
+        "/foods/analytics/waste": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get how many of the foods of the active household archived in the period were discarded or expired, the money wasted on those with a price, and the average days from adding to eating up foods. Days are in the user's time zone",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,64 +267,127 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "analytics"
                 ],
-                "summary": "Delete food",
-                "operationId": "delete-food",
+                "summary": "Get food waste summary",
+                "operationId": "get-waste-summary",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Food ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD); by default today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.WasteSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/images": {
-            "post": {
-                "description": "Upload image",
+        "/foods/analytics/waste/months": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the waste rate and money wasted of the foods of the active household archived in the period, by month in the user's time zone",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "image"
+                    "analytics"
                 ],
-                "summary": "Upload image",
+                "summary": "Get food waste by month",
+                "operationId": "get-waste-by-month",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "image",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD); by default today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "image url",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.WasteGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/images/{imageURL}": {
+        "/foods/analytics/waste/storage-locations": {
             "get": {
-                "description": "Fetch image",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the waste rate and money wasted of the foods of the active household archived in the period, by where they were kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,31 +395,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "image"
+                    "analytics"
                 ],
-                "summary": "Fetch image",
+                "summary": "Get food waste by storage location",
+                "operationId": "get-waste-by-storage-location",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "image URL（URLとは書いていますが、画像の名前のみで大丈夫です）",
-                        "name": "imageURL",
-                        "in": "path",
-                        "required": true
+                        "description": "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD); by default today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully fetched image",
+                        "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/model.WasteGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Create user",
+        "/foods/analytics/waste/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the waste rate and money wasted of the foods of the active household archived in the period, by tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,32 +459,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "analytics"
                 ],
-                "summary": "Create user",
-                "operationId": "create-user",
+                "summary": "Get food waste by tag",
+                "operationId": "get-waste-by-tag",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserRequest"
-                        }
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD); by default today",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.WasteGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete user",
+            }
+        },
+        "/foods/analytics/waste/top-items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the names of the foods of the active household discarded or expired most often in the period",
                 "consumes": [
                     "application/json"
                 ],
@@ -265,34 +523,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "analytics"
                 ],
-                "summary": "Delete user",
-                "operationId": "delete-user",
+                "summary": "Get most wasted foods",
+                "operationId": "get-top-wasted-items",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserRequest"
-                        }
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD); by default the first day of the month 11 months before the last day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD); by default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of names (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "deleted",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.WastedItemResponse"
                         }
-                    }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "/users/login": {
-            "post": {
-                "description": "Login user",
+        "/foods/expired": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the foods of the active household that expired before today, grouped by day, most recent first. Days are counted in the user's time zone",
                 "consumes": [
                     "application/json"
                 ],
@@ -300,34 +593,213 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "foods"
                 ],
-                "summary": "Login user",
-                "operationId": "login-user",
+                "summary": "Get expired foods",
+                "operationId": "get-expired-foods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodExpirationResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the foods of the active household that expire from today up to the given number of days later, grouped by day. Days are counted in the user's time zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get foods expiring soon",
+                "operationId": "get-expiring-foods",
                 "parameters": [
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Number of days after today, such as 3d or 1w (default 3d, max 365d)",
+                        "name": "within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserRequest"
+                            "$ref": "#/definitions/model.FoodExpirationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a page of the archived foods of the authenticated user's active household, which were eaten, discarded, expired or given away, with the quantity that was left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get food history",
+                "operationId": "get-food-history",
+                "parameters": [
+                    {
+                        "enum": [
+                            "eaten",
+                            "discarded",
+                            "expired",
+                            "given_away"
+                        ],
+                        "type": "string",
+                        "description": "Only foods with the disposition",
+                        "name": "disposition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods with the tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods whose name contains the string",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods expiring before the time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "expires_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only foods expiring at or after the time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "expires_after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "archived_at",
+                            "expiration_date",
+                            "created_at",
+                            "name"
+                        ],
+                        "type": "string",
+                        "default": "archived_at",
+                        "description": "Sort key",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of foods (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.FoodPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/{email}": {
+        "/foods/trash": {
             "get": {
-                "description": "Get user by email",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the deleted foods of the active household that can still be restored, most recently deleted first",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,15 +807,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "foods"
                 ],
-                "summary": "Get user by email",
-                "operationId": "get-user",
+                "summary": "Get foods in the trash",
+                "operationId": "get-trashed-foods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodTrashResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a food of the active household, archived ones included. The ETag is the version of the food, which If-Match of changes names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get food",
+                "operationId": "get-food",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Email",
-                        "name": "email",
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -352,13 +865,45 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             },
             "put": {
-                "description": "Update user",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update food",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,25 +911,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "foods"
                 ],
-                "summary": "Update user",
-                "operationId": "update-user",
+                "summary": "Update food",
+                "operationId": "update-food",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Email",
-                        "name": "email",
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User",
-                        "name": "user",
+                        "type": "string",
+                        "description": "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Food",
+                        "name": "food",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserRequest"
+                            "$ref": "#/definitions/model.FoodRequest"
                         }
                     }
                 ],
@@ -392,111 +943,4521 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "model.FoodRequest": {
-            "type": "object",
-            "properties": {
-                "expiration_date": {
-                    "description": "Expiration date",
-                    "type": "string",
-                    "example": "2024-12-15T00:00:00Z"
-                },
-                "image_url": {
-                    "description": "URL of the food item image",
-                    "type": "string",
-                    "example": "images/orange.jpg"
-                },
-                "memo": {
-                    "description": "Additional notes or memo",
-                    "type": "string",
-                    "example": "新鮮なオレンジだったものです"
-                },
-                "name": {
-                    "description": "Name of the food item",
-                    "type": "string",
-                    "example": "オレンジ"
-                },
-                "original_code": {
-                    "description": "Original code of the food item",
-                    "type": "integer",
-                    "example": 12456456
-                },
-                "quantity": {
-                    "description": "Quantity of the food item",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a food that was added by mistake to the trash, from which it can be restored until it is deleted permanently after the retention period. Foods that were eaten or thrown away should be disposed of instead, which keeps them in the history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Delete food",
+                "operationId": "delete-food",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change some fields of a food with a JSON Merge Patch (RFC 7396) of the food request. Absent members are left unchanged and null resets a member: no expiration date or price, an empty memo or image URL, 0 for the original code and quantity, the tag 'その他' and the storage location '冷蔵'. Only the members in the patch are validated, and the food is returned as saved",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Patch food",
+                "operationId": "patch-food",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the food to change; required unless FOOD_REQUIRE_IF_MATCH is false",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Members to change",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{id}/consume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Use up part of a food, such as half a carton of milk. The quantity is decreased by the amount and the consumption is recorded. The food is archived as eaten, and moves to the history, when none is left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Consume food",
+                "operationId": "consume-food",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount consumed",
+                        "name": "consumption",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ConsumeFoodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{id}/dispose": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Archive a food that was eaten, discarded, expired or given away. It moves from the foods of the household to the history with the quantity that was left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Dispose of food",
+                "operationId": "dispose-food",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Disposition",
+                        "name": "disposition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DisposeFoodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a deleted food out of the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Restore food",
+                "operationId": "restore-food",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Food ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FoodResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the food"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the households the authenticated user belongs to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get households",
+                "operationId": "get-households",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HouseholdResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a household owned by the authenticated user and make it active",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Create household",
+                "operationId": "create-household",
+                "parameters": [
+                    {
+                        "description": "Household",
+                        "name": "household",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/invitations/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join the household with the token received by email and make it active",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Accept invitation",
+                "operationId": "accept-household-invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdInvitationTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/invitations/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decline the invitation with the token received by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Decline invitation",
+                "operationId": "decline-household-invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdInvitationTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "invitation declined",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a household with its members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get household",
+                "operationId": "get-household",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdDetailResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a household. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Rename household",
+                "operationId": "update-household",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Household",
+                        "name": "household",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make the household the one the food endpoints operate on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Switch active household",
+                "operationId": "switch-household",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "household activated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the device API keys of the household, including revoked ones. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Get API keys",
+                "operationId": "get-api-keys",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.APIKeyResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a device API key for the household. The key is returned only once; send it as \"Authorization: ApiKey \u003ckey\u003e\". Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create API key",
+                "operationId": "create-api-key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API key",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIKeyCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/api-keys/{keyID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a device API key of the household. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke API key",
+                "operationId": "revoke-api-key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "keyID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "api key revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite a user to the household by email. Requires the member:invite permission, and only owners can invite with a role that has permissions they do not hold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Invite member",
+                "operationId": "invite-household-member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "invitation sent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/members/{userID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of a member. Requires the member:manage permission, and only owners can appoint or demote owners or give a role with permissions they do not hold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Change member role",
+                "operationId": "update-household-member-role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "role updated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the household. Removing others requires the member:manage permission and members can leave by removing themselves",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Remove member",
+                "operationId": "remove-household-member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID of the member",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "member removed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the built-in and custom roles of the household with their permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Get roles",
+                "operationId": "get-household-roles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HouseholdRoleResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a custom role of the household. Requires the household:manage permission, and only owners can create a role with permissions they do not hold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Create custom role",
+                "operationId": "create-household-role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/roles/{roleID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the permissions of a custom role. The name cannot be changed. Requires the household:manage permission, and only owners can change their own role or give a role permissions they do not hold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Update custom role",
+                "operationId": "update-household-role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HouseholdRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a custom role that no member or pending invitation uses. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "households"
+                ],
+                "summary": "Delete custom role",
+                "operationId": "delete-household-role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "role deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the webhooks of the household. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "operationId": "get-webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WebhookResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe an endpoint to inventory events of the household. Events are posted as JSON (model.WebhookEvent) and signed with the returned secret: X-Watchdog-Signature is \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003cX-Watchdog-Timestamp\u003e.\u003cbody\u003e\". Failed deliveries are retried with exponential backoff. The secret is returned only once. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "operationId": "create-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/webhooks/{webhookID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the endpoint and the events of a webhook. The secret is kept. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook",
+                "operationId": "update-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook and its delivery log. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "operationId": "delete-webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "webhook deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/webhooks/{webhookID}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the latest 50 deliveries of a webhook with the status codes of the responses, newest first. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "operationId": "get-webhook-deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/households/{id}/webhooks/{webhookID}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Post a ping event to the webhook right away and return the result. The event is not retried. Requires the household:manage permission",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send test event",
+                "operationId": "send-webhook-test-event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Household ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upload image",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Upload image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "image url",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{imageURL}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fetch image",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "image"
+                ],
+                "summary": "Fetch image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "image URL（URLとは書いていますが、画像の名前のみで大丈夫です）",
+                        "name": "imageURL",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetched image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/push/vapid-public-key": {
+            "get": {
+                "description": "Get the applicationServerKey to pass to PushManager.subscribe() in the browser",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "Get VAPID public key",
+                "operationId": "get-vapid-public-key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.VAPIDPublicKeyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Create user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create user",
+                "operationId": "create-user",
+                "parameters": [
+                    {
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "operationId": "delete-user",
+                "parameters": [
+                    {
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication and delete the recovery codes after re-entering the password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Disable two-factor authentication",
+                "operationId": "disable-two-factor",
+                "parameters": [
+                    {
+                        "description": "Current password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorDisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "two-factor authentication disabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/totp": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret for the authenticated user. Two-factor authentication is enabled once the secret is confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Start TOTP enrollment",
+                "operationId": "enroll-totp",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TOTPEnrollResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enable two-factor authentication with a code from the authenticator app. The returned recovery codes are shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Confirm TOTP enrollment",
+                "operationId": "confirm-totp",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/identities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the OpenID Connect provider accounts linked to the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get linked identities",
+                "operationId": "get-identities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserIdentityResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/identities/{provider}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlink the provider account from the authenticated user. The last identity of a user without a password cannot be unlinked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unlink identity",
+                "operationId": "unlink-identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "identity unlinked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Login user. When two-factor authentication is enabled, no tokens are returned; mfa_required is true and mfa_token must be sent to POST /users/login/2fa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login user",
+                "operationId": "login-user",
+                "parameters": [
+                    {
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login/2fa": {
+            "post": {
+                "description": "Exchange the mfa_token returned by POST /users/login and a TOTP or recovery code for tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Complete login with a second factor",
+                "operationId": "login-two-factor",
+                "parameters": [
+                    {
+                        "description": "MFA token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/logout": {
+            "post": {
+                "description": "Revoke the refresh token and every token rotated from the same login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Logout",
+                "operationId": "logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "logged out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke every refresh token of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Logout from all devices",
+                "operationId": "logout-all",
+                "responses": {
+                    "200": {
+                        "description": "logged out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/digest/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the digest of the caller as it would be sent now, without sending it. The digest lists the foods expiring today and this week, added and expired since the previous digest, in the caller's time zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Preview digest",
+                "operationId": "preview-digest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "daily or weekly; the notification preferences by default",
+                        "name": "frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ja or en; the notification preferences by default",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DigestPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get when and how the caller is notified about foods that are about to expire. Users who have not saved any are notified the day before by email and push, immediately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification preferences",
+                "operationId": "get-notification-preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationPreferenceResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the notification preferences of the caller. lead_days are up to 5 lead times between 0 and 30 days before the expiration date, in the caller's time zone. channels are email, push and webhook; webhook requires webhook_url, and a secret to verify the requests is generated when the URL changes. With the digest delivery a daily or weekly digest in the language is sent at digest_hour, on digest_weekday for weekly digests, instead of alerts about each food; immediate alerts are held back during quiet_hours. Foods with muted_tags are never notified about. An empty list of lead days or channels turns the notifications off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification preferences",
+                "operationId": "update-notification-preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "preference",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the notification preferences of the caller, who is then notified with the defaults",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Reset notification preferences",
+                "operationId": "delete-notification-preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationPreferenceResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/push-subscriptions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register the push subscription of the browser, as returned by PushSubscription.toJSON(), to receive notifications about expiring foods even when the tab is closed. Registering the same endpoint again replaces the subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "Register push subscription",
+                "operationId": "subscribe-push",
+                "parameters": [
+                    {
+                        "description": "Push subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PushSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "push subscription registered",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop sending notifications to the browser with the endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "push"
+                ],
+                "summary": "Unregister push subscription",
+                "operationId": "unsubscribe-push",
+                "parameters": [
+                    {
+                        "description": "Endpoint of the subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PushUnsubscribeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "push subscription removed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/oidc/providers": {
+            "get": {
+                "description": "Get the providers users can log in with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get OpenID Connect providers",
+                "operationId": "get-oidc-providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OIDCProviderResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/oidc/{provider}/authorize": {
+            "get": {
+                "description": "Start the authorization code flow with PKCE. Send the user to authorization_url and keep state to check it against the state returned to the redirect URI. An HttpOnly oidc_binding cookie is set and must be sent with the callback.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Start login with an OpenID Connect provider",
+                "operationId": "start-oidc-login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OIDCAuthorizationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/oidc/{provider}/callback": {
+            "post": {
+                "description": "Exchange the authorization code returned to the redirect URI for tokens. The oidc_binding cookie set when the login started is required and cleared. The provider account is linked to the user with the same verified email address, or a new user is created. When two-factor authentication is enabled, mfa_token must be sent to POST /users/login/2fa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Complete login with an OpenID Connect provider",
+                "operationId": "complete-oidc-login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Authorization code and state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OIDCCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password/forgot": {
+            "post": {
+                "description": "Email a single-use password reset link. Always succeeds so that registered emails cannot be probed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Request password reset",
+                "operationId": "forgot-password",
+                "parameters": [
+                    {
+                        "description": "Email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordForgotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reset email sent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password/reset": {
+            "post": {
+                "description": "Reset the password with the token received by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reset password",
+                "operationId": "reset-password",
+                "parameters": [
+                    {
+                        "description": "Token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "password reset",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a rotated refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Refresh tokens",
+                "operationId": "refresh-token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/unlock": {
+            "get": {
+                "description": "Unlock an account locked by repeated login failures with the token received by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Unlock account",
+                "operationId": "unlock-account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unlock token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "account unlocked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify": {
+            "get": {
+                "description": "Verify the email address with the token received by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Verify email address",
+                "operationId": "verify-email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "email verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send the verification email of the authenticated user again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Resend verification email",
+                "operationId": "resend-verification-email",
+                "responses": {
+                    "200": {
+                        "description": "verification email sent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{email}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by email",
+                "operationId": "get-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user. Changing the email address marks it as unverified and sends a verification email to the new address, and changing the password revokes every refresh token of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "operationId": "update-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.APIKeyCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "id": {
+                    "description": "ID of the key",
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "description": "Send as \"Authorization: ApiKey \u003ckey\u003e\"",
+                    "type": "string",
+                    "example": "rwk_1a2b3c4d_q8V2c1mX0kq3Jb9..."
+                },
+                "last_used_at": {
+                    "description": "Last time the key was used",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "name": {
+                    "description": "Name of the device",
+                    "type": "string",
+                    "example": "冷蔵庫センサー"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                },
+                "prefix": {
+                    "description": "Visible prefix of the key",
+                    "type": "string",
+                    "example": "rwk_1a2b3c4d"
+                },
+                "revoked_at": {
+                    "description": "Time the key was revoked",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                }
+            }
+        },
+        "model.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the device",
+                    "type": "string",
+                    "example": "冷蔵庫センサー"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                }
+            }
+        },
+        "model.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "id": {
+                    "description": "ID of the key",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "description": "Last time the key was used",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "name": {
+                    "description": "Name of the device",
+                    "type": "string",
+                    "example": "冷蔵庫センサー"
+                },
+                "permissions": {
+                    "description": "Permissions granted to the key",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                },
+                "prefix": {
+                    "description": "Visible prefix of the key",
+                    "type": "string",
+                    "example": "rwk_1a2b3c4d"
+                },
+                "revoked_at": {
+                    "description": "Time the key was revoked",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                }
+            }
+        },
+        "model.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "Value after the change; null if the field no longer exists"
+                },
+                "before": {
+                    "description": "Value before the change; null if the field did not exist"
+                }
+            }
+        },
+        "model.AuditLogPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Entries of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditLogResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page; omitted on the last page",
+                    "type": "string",
+                    "example": "MTIz"
+                }
+            }
+        },
+        "model.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What was done",
+                    "type": "string",
+                    "example": "food.delete"
+                },
+                "actor_id": {
+                    "description": "User who made the change",
+                    "type": "integer",
+                    "example": 1
+                },
+                "api_key_id": {
+                    "description": "API key the change was made with",
+                    "type": "integer",
+                    "example": 1
+                },
+                "changes": {
+                    "description": "Changed fields",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/model.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "description": "Time of the change",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "entity_id": {
+                    "description": "ID of the changed entity",
+                    "type": "string",
+                    "example": "12"
+                },
+                "entity_type": {
+                    "description": "Type of the changed entity",
+                    "type": "string",
+                    "example": "food"
+                },
+                "household_id": {
+                    "description": "Household of the entity; null for account changes",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID of the entry",
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "description": "IP address of the request",
+                    "type": "string",
+                    "example": "192.0.2.1"
+                },
+                "request_id": {
+                    "description": "X-Request-Id of the request",
+                    "type": "string",
+                    "example": "vVmEGhNLgMNjTK5lNuSFVOqbm0yANVmR"
+                },
+                "user_agent": {
+                    "description": "User agent of the request",
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "model.ConsumeFoodRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Quantity consumed; the food is archived as eaten when none is left",
+                    "type": "number",
+                    "example": 0.5
+                }
+            }
+        },
+        "model.DigestPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "empty": {
+                    "description": "Digests without any food are not sent",
+                    "type": "boolean",
+                    "example": false
+                },
+                "html": {
+                    "description": "HTML body of the email",
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "冷蔵庫のまとめ（10月2日(水)）"
+                },
+                "summary": {
+                    "description": "Body of push notifications",
+                    "type": "string",
+                    "example": "今日が期限 1件、今週が期限 2件、期限切れ 0件"
+                },
+                "text": {
+                    "description": "Plain text body of the email",
+                    "type": "string"
+                }
+            }
+        },
+        "model.DisposeFoodRequest": {
+            "type": "object",
+            "properties": {
+                "disposition": {
+                    "description": "eaten, discarded, expired or given_away",
+                    "type": "string",
+                    "example": "eaten"
+                }
+            }
+        },
+        "model.FoodExpirationGroup": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Day in the user's time zone; null for foods without an expiration date",
+                    "type": "string",
+                    "example": "2024-12-15"
+                },
+                "days_remaining": {
+                    "description": "Days from today to the day; negative once expired, null without an expiration date",
+                    "type": "integer",
+                    "example": 2
+                },
+                "items": {
+                    "description": "Foods that expire on the day",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FoodResponse"
+                    }
+                }
+            }
+        },
+        "model.FoodExpirationResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "Groups ordered by day",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FoodExpirationGroup"
+                    }
+                },
+                "time_zone": {
+                    "description": "Time zone the days are counted in",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "today": {
+                    "description": "Today in the time zone",
+                    "type": "string",
+                    "example": "2024-12-13"
+                }
+            }
+        },
+        "model.FoodPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Foods of the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FoodResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page; omitted on the last page",
+                    "type": "string",
+                    "example": "eyJpZCI6MTJ9"
+                }
+            }
+        },
+        "model.FoodRequest": {
+            "type": "object",
+            "properties": {
+                "expiration_date": {
+                    "description": "Expiration date",
+                    "type": "string",
+                    "example": "2024-12-15T00:00:00Z"
+                },
+                "image_url": {
+                    "description": "URL of the food item image",
+                    "type": "string",
+                    "example": "images/orange.jpg"
+                },
+                "memo": {
+                    "description": "Additional notes or memo",
+                    "type": "string",
+                    "example": "新鮮なオレンジだったものです"
+                },
+                "name": {
+                    "description": "Name of the food item",
+                    "type": "string",
+                    "example": "オレンジ"
+                },
+                "original_code": {
+                    "description": "Original code of the food item",
+                    "type": "integer",
+                    "example": 12456456
+                },
+                "price": {
+                    "description": "Price paid for the food as added, if known",
+                    "type": "number",
+                    "example": 298
+                },
+                "quantity": {
+                    "description": "Quantity of the food item",
+                    "type": "number",
+                    "example": 5.5
+                },
+                "storage_location": {
+                    "description": "Where the food is kept: '冷蔵', '冷凍', '野菜室', '常温' (default '冷蔵')",
+                    "type": "string",
+                    "example": "冷蔵"
+                },
+                "tag": {
+                    "description": "Tag of the food item'野菜', '肉', '魚', '乳製品','調味料','卵','飲料','果物','加工食品','その他'",
+                    "type": "string",
+                    "example": "果物"
+                }
+            }
+        },
+        "model.FoodResponse": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "description": "When the food was disposed of",
+                    "type": "string",
+                    "example": "2024-12-14T08:30:00Z"
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "deleted_at": {
+                    "description": "When the food was moved to the trash",
+                    "type": "string",
+                    "example": "2024-12-14T08:30:00Z"
+                },
+                "disposition": {
+                    "description": "How the food was disposed of: eaten, discarded, expired or given_away",
+                    "type": "string",
+                    "example": "eaten"
+                },
+                "expiration_date": {
+                    "description": "Expiration date",
+                    "type": "string",
+                    "example": "2024-12-15T00:00:00Z"
+                },
+                "household_id": {
+                    "description": "Household that owns the food item",
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "description": "ID of the food item",
+                    "type": "integer",
+                    "example": 1
+                },
+                "image_url": {
+                    "description": "URL of the food item image",
+                    "type": "string",
+                    "example": "images/orange.jpg"
+                },
+                "memo": {
+                    "description": "Additional notes or memo",
+                    "type": "string",
+                    "example": "新鮮なオレンジだったものです"
+                },
+                "name": {
+                    "description": "Name of the food item",
+                    "type": "string",
+                    "example": "オレンジ"
+                },
+                "original_code": {
+                    "description": "Original code of the food item",
+                    "type": "integer",
+                    "example": 12456456
+                },
+                "price": {
+                    "description": "Price paid for the food as added, if known",
+                    "type": "number",
+                    "example": 298
+                },
+                "quantity": {
+                    "description": "Quantity of the food item",
                     "type": "number",
                     "example": 5.5
                 },
-                "tag": {
-                    "description": "Tag of the food item'野菜', '肉', '魚', '乳製品','調味料','卵','飲料','果物','加工食品','その他'",
+                "storage_location": {
+                    "description": "Where the food is kept",
+                    "type": "string",
+                    "example": "冷蔵"
+                },
+                "tag": {
+                    "description": "Tag of the food item",
+                    "type": "string",
+                    "example": "果物"
+                },
+                "user_id": {
+                    "description": "ID of the user who added the food item",
+                    "type": "integer",
+                    "example": 1
+                },
+                "version": {
+                    "description": "Version of the food, also sent as its ETag; If-Match of changes names it",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.FoodTrashResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Most recently deleted first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FoodResponse"
+                    }
+                },
+                "retention_days": {
+                    "description": "Days after which trashed foods are deleted permanently",
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "model.HouseholdDetailResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether this is the caller's active household",
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "id": {
+                    "description": "ID of the household",
+                    "type": "integer",
+                    "example": 1
+                },
+                "members": {
+                    "description": "Members of the household",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HouseholdMemberResponse"
+                    }
+                },
+                "name": {
+                    "description": "Name of the household",
+                    "type": "string",
+                    "example": "山田家の冷蔵庫"
+                },
+                "permissions": {
+                    "description": "Permissions of the caller",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                },
+                "role": {
+                    "description": "Role of the caller",
+                    "type": "string",
+                    "example": "owner"
+                }
+            }
+        },
+        "model.HouseholdInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email of the invited user",
+                    "type": "string",
+                    "example": "sample@gmail.com"
+                },
+                "role": {
+                    "description": "Role given on acceptance, any role except owner",
+                    "type": "string",
+                    "example": "member"
+                }
+            }
+        },
+        "model.HouseholdInvitationTokenRequest": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Token received by email",
+                    "type": "string",
+                    "example": "q8V2c1mX0kq3Jb9..."
+                }
+            }
+        },
+        "model.HouseholdMemberResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email of the user",
+                    "type": "string",
+                    "example": "sample@gmail.com"
+                },
+                "joined_at": {
+                    "description": "Time the user joined",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "role": {
+                    "description": "Role in the household",
+                    "type": "string",
+                    "example": "member"
+                },
+                "user_id": {
+                    "description": "ID of the user",
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "description": "Username of the user",
+                    "type": "string",
+                    "example": "山田太郎"
+                }
+            }
+        },
+        "model.HouseholdMemberRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "New role, built-in or custom",
+                    "type": "string",
+                    "example": "viewer"
+                }
+            }
+        },
+        "model.HouseholdRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the household",
+                    "type": "string",
+                    "example": "山田家の冷蔵庫"
+                }
+            }
+        },
+        "model.HouseholdResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether this is the caller's active household",
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "id": {
+                    "description": "ID of the household",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name of the household",
+                    "type": "string",
+                    "example": "山田家の冷蔵庫"
+                },
+                "permissions": {
+                    "description": "Permissions of the caller",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                },
+                "role": {
+                    "description": "Role of the caller",
+                    "type": "string",
+                    "example": "owner"
+                }
+            }
+        },
+        "model.HouseholdRoleRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the role",
+                    "type": "string",
+                    "example": "shopper"
+                },
+                "permissions": {
+                    "description": "Permissions of the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                }
+            }
+        },
+        "model.HouseholdRoleResponse": {
+            "type": "object",
+            "properties": {
+                "builtin": {
+                    "description": "Whether the role is built in",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "description": "ID of the custom role, 0 for built-in roles",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "description": "Name of the role",
+                    "type": "string",
+                    "example": "shopper"
+                },
+                "permissions": {
+                    "description": "Permissions of the role",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food:read",
+                        "food:write"
+                    ]
+                }
+            }
+        },
+        "model.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "Signed access token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_in": {
+                    "description": "Lifetime of the access token in seconds",
+                    "type": "integer",
+                    "example": 900
+                },
+                "mfa_required": {
+                    "description": "Whether a second factor is required",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_token": {
+                    "description": "Short-lived token for the second login step",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6..."
+                },
+                "refresh_token": {
+                    "description": "Single-use refresh token",
+                    "type": "string",
+                    "example": "q8V2c1mX0kq3Jb9..."
+                },
+                "token_type": {
+                    "description": "Type of the access token",
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user": {
+                    "description": "Logged in user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.NotificationPreferenceRequest": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "email, push or webhook; an empty list turns the notifications off",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "email",
+                        "push"
+                    ]
+                },
+                "delivery": {
+                    "description": "immediate or digest",
+                    "type": "string",
+                    "example": "immediate"
+                },
+                "digest_frequency": {
+                    "description": "daily or weekly",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "digest_hour": {
+                    "description": "Hour of the day the digest is sent at, 0 to 23",
+                    "type": "integer",
+                    "example": 8
+                },
+                "digest_weekday": {
+                    "description": "Day of the week weekly digests are sent on, 0 (Sunday) to 6",
+                    "type": "integer",
+                    "example": 1
+                },
+                "language": {
+                    "description": "Language of notifications, ja or en",
+                    "type": "string",
+                    "example": "ja"
+                },
+                "lead_days": {
+                    "description": "Days before the expiration date to notify on; 0 is the day itself",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        0
+                    ]
+                },
+                "muted_tags": {
+                    "description": "Tags of foods not to notify about",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "調味料"
+                    ]
+                },
+                "quiet_hours": {
+                    "description": "No quiet hours when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.QuietHours"
+                        }
+                    ]
+                },
+                "webhook_url": {
+                    "description": "Required with the webhook channel",
+                    "type": "string",
+                    "example": "https://example.com/hooks/fridge"
+                }
+            }
+        },
+        "model.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "email",
+                        "push"
+                    ]
+                },
+                "delivery": {
+                    "type": "string",
+                    "example": "immediate"
+                },
+                "digest_frequency": {
+                    "type": "string",
+                    "example": "daily"
+                },
+                "digest_hour": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "digest_weekday": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "language": {
+                    "type": "string",
+                    "example": "ja"
+                },
+                "lead_days": {
+                    "description": "Days before the expiration date to notify on, largest first",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        0
+                    ]
+                },
+                "muted_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "調味料"
+                    ]
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/model.QuietHours"
+                },
+                "webhook_secret": {
+                    "description": "Verifies the X-Watchdog-Signature header of the requests to the webhook URL",
+                    "type": "string",
+                    "example": "whsec_5Jx0..."
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/fridge"
+                }
+            }
+        },
+        "model.OIDCAuthorizationResponse": {
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "description": "URL to send the user to",
+                    "type": "string",
+                    "example": "https://accounts.google.com/o/oauth2/v2/auth?..."
+                },
+                "state": {
+                    "description": "State echoed back to the redirect URI",
+                    "type": "string",
+                    "example": "q8V2c1mX0kq3Jb9..."
+                }
+            }
+        },
+        "model.OIDCCallbackRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Authorization code",
+                    "type": "string",
+                    "example": "4/0AY0e-g7..."
+                },
+                "state": {
+                    "description": "State returned with the code",
+                    "type": "string",
+                    "example": "q8V2c1mX0kq3Jb9..."
+                }
+            }
+        },
+        "model.OIDCProviderResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name of the provider used in URLs",
+                    "type": "string",
+                    "example": "google"
+                }
+            }
+        },
+        "model.PasswordForgotRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email of the user",
+                    "type": "string",
+                    "example": "sample@gmail.com"
+                }
+            }
+        },
+        "model.PasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "New password",
+                    "type": "string",
+                    "example": "newpassword"
+                },
+                "token": {
+                    "description": "Reset token received by email",
+                    "type": "string",
+                    "example": "q8V2c1mX0kq3Jb9..."
+                }
+            }
+        },
+        "model.PushSubscriptionKeys": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "type": "string",
+                    "example": "BTBZMqHH6r4Tts7J_aSIgg"
+                },
+                "p256dh": {
+                    "type": "string",
+                    "example": "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+                }
+            }
+        },
+        "model.PushSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "Push service URL",
+                    "type": "string",
+                    "example": "https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."
+                },
+                "keys": {
+                    "$ref": "#/definitions/model.PushSubscriptionKeys"
+                }
+            }
+        },
+        "model.PushUnsubscribeRequest": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "Endpoint of the subscription to remove",
+                    "type": "string",
+                    "example": "https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."
+                }
+            }
+        },
+        "model.QuietHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "description": "\"HH:MM\"; before the start when the range spans midnight",
                     "type": "string",
-                    "example": "果物"
+                    "example": "07:00"
                 },
-                "user_id": {
-                    "description": "User ID associated with the food item",
-                    "type": "integer",
-                    "example": 1
+                "start": {
+                    "description": "\"HH:MM\"",
+                    "type": "string",
+                    "example": "22:00"
                 }
             }
         },
-        "model.FoodResponse": {
+        "model.RecoveryCodesResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Creation timestamp",
+                "recovery_codes": {
+                    "description": "One-time recovery codes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7m2-q9xd",
+                        "p3nf-8w2c"
+                    ]
+                }
+            }
+        },
+        "model.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token issued at login",
                     "type": "string",
-                    "example": "2024-09-25T11:46:43Z"
+                    "example": "q8V2c1mX0kq3Jb9..."
+                }
+            }
+        },
+        "model.TOTPCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "6-digit TOTP code",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "model.TOTPEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "description": "URI to render as a QR code",
+                    "type": "string",
+                    "example": "otpauth://totp/RefrigeratorWatchdog:sample@gmail.com?secret=JBSWY3DPEHPK3PXP"
                 },
-                "expiration_date": {
-                    "description": "Expiration date",
+                "secret": {
+                    "description": "Base32 encoded secret",
                     "type": "string",
-                    "example": "2024-12-15T00:00:00Z"
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "model.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "Signed access token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "id": {
-                    "description": "ID of the food item",
+                "expires_in": {
+                    "description": "Lifetime of the access token in seconds",
                     "type": "integer",
-                    "example": 1
+                    "example": 900
                 },
-                "image_url": {
-                    "description": "URL of the food item image",
+                "refresh_token": {
+                    "description": "Single-use refresh token",
                     "type": "string",
-                    "example": "images/orange.jpg"
+                    "example": "q8V2c1mX0kq3Jb9..."
                 },
-                "memo": {
-                    "description": "Additional notes or memo",
+                "token_type": {
+                    "description": "Type of the access token",
                     "type": "string",
-                    "example": "新鮮なオレンジだったものです"
-                },
-                "name": {
-                    "description": "Name of the food item",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "model.TwoFactorDisableRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "Current password of the user",
                     "type": "string",
-                    "example": "オレンジ"
-                },
-                "original_code": {
-                    "description": "Original code of the food item",
-                    "type": "integer",
-                    "example": 12456456
+                    "example": "password"
+                }
+            }
+        },
+        "model.TwoFactorLoginRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "example": "123456"
                 },
-                "quantity": {
-                    "description": "Quantity of the food item",
-                    "type": "number",
-                    "example": 5.5
+                "mfa_token": {
+                    "description": "Token returned by POST /users/login",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6..."
+                }
+            }
+        },
+        "model.UserIdentityResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Time the identity was linked",
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
                 },
-                "tag": {
-                    "description": "Tag of the food item",
+                "email": {
+                    "description": "Email address at the provider",
                     "type": "string",
-                    "example": "果物"
+                    "example": "sample@gmail.com"
                 },
-                "user_id": {
-                    "description": "User ID associated with the food item",
-                    "type": "integer",
-                    "example": 1
+                "provider": {
+                    "description": "Name of the provider",
+                    "type": "string",
+                    "example": "google"
                 }
             }
         },
@@ -513,6 +5474,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "password"
                 },
+                "time_zone": {
+                    "description": "IANA time zone; optional",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
                 "username": {
                     "description": "Username of the user",
                     "type": "string",
@@ -538,13 +5504,330 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "time_zone": {
+                    "description": "IANA time zone; empty for the server default",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "two_factor_enabled": {
+                    "description": "Whether two-factor authentication is enabled",
+                    "type": "boolean",
+                    "example": false
+                },
                 "username": {
                     "description": "Username of the user",
                     "type": "string",
                     "example": "山田太郎"
+                },
+                "verified_at": {
+                    "description": "Time the email address was verified",
+                    "type": "string",
+                    "example": "2024-09-25T12:00:00Z"
+                }
+            }
+        },
+        "model.VAPIDPublicKeyResponse": {
+            "type": "object",
+            "properties": {
+                "public_key": {
+                    "description": "applicationServerKey, base64url encoded",
+                    "type": "string",
+                    "example": "BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"
+                }
+            }
+        },
+        "model.WasteGroup": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "Tag, month (YYYY-MM) or storage location",
+                    "type": "string",
+                    "example": "野菜"
+                },
+                "money_wasted": {
+                    "type": "number",
+                    "example": 820
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "waste_rate": {
+                    "type": "number",
+                    "example": 0.2
+                },
+                "wasted": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "model.WasteGroupResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+09:00"
+                },
+                "groups": {
+                    "description": "Groups ordered by key",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WasteGroup"
+                    }
+                },
+                "time_zone": {
+                    "description": "Time zone the months are counted in",
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00+09:00"
+                }
+            }
+        },
+        "model.WasteSummary": {
+            "type": "object",
+            "properties": {
+                "average_days_to_consumption": {
+                    "description": "Days from adding to eating up foods; null if none was eaten",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "from": {
+                    "description": "Start of the period",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+09:00"
+                },
+                "money_wasted": {
+                    "description": "Price of the quantity left of wasted foods",
+                    "type": "number",
+                    "example": 2480
+                },
+                "priced_wasted": {
+                    "description": "Wasted foods with a price, which money_wasted is estimated from",
+                    "type": "integer",
+                    "example": 12
+                },
+                "to": {
+                    "description": "End of the period, exclusive",
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00+09:00"
+                },
+                "total": {
+                    "description": "Foods archived in the period",
+                    "type": "integer",
+                    "example": 120
+                },
+                "waste_rate": {
+                    "description": "Wasted divided by total; 0 without foods",
+                    "type": "number",
+                    "example": 0.15
+                },
+                "wasted": {
+                    "description": "Foods discarded or expired",
+                    "type": "integer",
+                    "example": 18
+                }
+            }
+        },
+        "model.WastedItem": {
+            "type": "object",
+            "properties": {
+                "money_wasted": {
+                    "type": "number",
+                    "example": 150
+                },
+                "name": {
+                    "type": "string",
+                    "example": "もやし"
+                },
+                "wasted": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "model.WastedItemResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00+09:00"
+                },
+                "items": {
+                    "description": "Most often wasted first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WastedItem"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00+09:00"
+                }
+            }
+        },
+        "model.WebhookCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food.created",
+                        "food.expiring"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "secret": {
+                    "description": "Key of the X-Watchdog-Signature HMAC-SHA256 signatures",
+                    "type": "string",
+                    "example": "whsec_q8V2c1mX0kq3Jb9..."
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://homeassistant.local/api/webhook/fridge"
+                }
+            }
+        },
+        "model.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:44Z"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string",
+                    "example": "webhook responded with status 500"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_q8V2c1mX0kq3Jb9"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "food.created"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "next_attempt_at": {
+                    "description": "Set while the delivery is pending",
+                    "type": "string",
+                    "example": "2024-09-25T11:47:14Z"
+                },
+                "response_code": {
+                    "description": "Status code of the last response",
+                    "type": "integer",
+                    "example": 200
+                },
+                "status": {
+                    "description": "pending, succeeded or failed",
+                    "type": "string",
+                    "example": "succeeded"
+                }
+            }
+        },
+        "model.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events to deliver",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food.created",
+                        "food.expiring"
+                    ]
+                },
+                "url": {
+                    "description": "Endpoint that receives the events",
+                    "type": "string",
+                    "example": "https://homeassistant.local/api/webhook/fridge"
+                }
+            }
+        },
+        "model.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "food.created",
+                        "food.expiring"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-09-25T11:46:43Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://homeassistant.local/api/webhook/fridge"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "\"ApiKey \u003ckey\u003e\" created by POST /households/{id}/api-keys",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \u003caccess token\u003e\" issued by POST /users/login",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -558,6 +5841,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MemoryMailer keeps sent mails in memory. It is intended for tests.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	sent []Mail
+}
+
+// NewMemoryMailer creates a new instance of the MemoryMailer struct.
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (mm *MemoryMailer) Send(mail Mail) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.sent = append(mm.sent, mail)
+	return nil
+}
+
+// Sent returns the mails sent so far.
+func (mm *MemoryMailer) Sent() []Mail {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	return append([]Mail(nil), mm.sent...)
+}
+
+type fileMailer struct {
+	dir string
+}
+
+// NewFileMailer creates a mailer that writes each mail to a file in dir
+// instead of sending it. It is intended for local development.
+func NewFileMailer(dir string) IMailer {
+	return &fileMailer{dir}
+}
+
+func (fm *fileMailer) Send(mail Mail) error {
+	if err := os.MkdirAll(fm.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), filepath.Base(mail.To))
+	return os.WriteFile(filepath.Join(fm.dir, name), buildMessage("noreply@localhost", mail), 0o644)
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"os"
+)
+
+// Mail represents an email message. Body is plain text; when HTML is set the
+// message also has an HTML version of the body.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+	HTML    string
+}
+
+// IMailer is an interface for delivering emails.
+type IMailer interface {
+	Send(mail Mail) error
+}
+
+// NewMailer creates a mailer selected by the MAILER environment variable.
+// "smtp" delivers through the SMTP server configured by SMTP_* variables,
+// "memory" keeps messages in memory, and anything else writes them to MAIL_DIR.
+func NewMailer() IMailer {
+	switch os.Getenv("MAILER") {
+	case "smtp":
+		return NewSMTPMailer(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
+	case "memory":
+		return NewMemoryMailer()
+	}
+	dir := os.Getenv("MAIL_DIR")
+	if dir == "" {
+		dir = "mails"
+	}
+	return NewFileMailer(dir)
+}
@@ -0,0 +1,99 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_fileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	fm := NewFileMailer(dir)
+
+	if err := fm.Send(Mail{To: "sample@test.com", Subject: "パスワード再設定", Body: "本文"}); err != nil {
+		t.Fatalf("fileMailer.Send() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("os.ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("fileMailer.Send() wrote %d files, want 1", len(entries))
+	}
+	content, err := os.ReadFile(dir + "/" + entries[0].Name())
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(content), "To: sample@test.com") || !strings.Contains(string(content), "本文") {
+		t.Errorf("fileMailer.Send() wrote %q", content)
+	}
+}
+
+func Test_MemoryMailer_Send(t *testing.T) {
+	mm := NewMemoryMailer()
+	if err := mm.Send(Mail{To: "sample@test.com", Subject: "subject", Body: "body"}); err != nil {
+		t.Fatalf("MemoryMailer.Send() error = %v", err)
+	}
+	if sent := mm.Sent(); len(sent) != 1 || sent[0].To != "sample@test.com" {
+		t.Errorf("MemoryMailer.Sent() = %v", sent)
+	}
+}
+
+func Test_buildMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		mail      Mail
+		wantParts map[string]string // Content-Type -> body
+	}{
+		{
+			name:      "正常系：テキストだけのメール",
+			mail:      Mail{To: "sample@test.com", Subject: "まとめ", Body: "本文\n2行目"},
+			wantParts: map[string]string{"text/plain; charset=UTF-8": "本文\r\n2行目"},
+		},
+		{
+			name: "正常系：HTML を含むメールは multipart/alternative になる",
+			mail: Mail{To: "sample@test.com", Subject: "まとめ", Body: "本文", HTML: "<p>本文</p>"},
+			wantParts: map[string]string{
+				"text/plain; charset=UTF-8": "本文",
+				"text/html; charset=UTF-8":  "<p>本文</p>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := mail.ReadMessage(bytes.NewReader(buildMessage("noreply@test.com", tt.mail)))
+			if err != nil {
+				t.Fatalf("mail.ReadMessage() error = %v", err)
+			}
+			got := map[string]string{}
+			mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			if mediaType != "multipart/alternative" {
+				body, _ := io.ReadAll(msg.Body)
+				got[msg.Header.Get("Content-Type")] = string(body)
+			} else {
+				r := multipart.NewReader(msg.Body, params["boundary"])
+				for {
+					part, err := r.NextPart()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("NextPart() error = %v", err)
+					}
+					body, _ := io.ReadAll(part)
+					got[part.Header.Get("Content-Type")] = string(body)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantParts) {
+				t.Errorf("buildMessage() parts = %q, want %q", got, tt.wantParts)
+			}
+		})
+	}
+}
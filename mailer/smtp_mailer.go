@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a mailer that delivers through an SMTP server.
+// Authentication is skipped when username is empty.
+func NewSMTPMailer(host, port, username, password, from string) IMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpMailer{net.JoinHostPort(host, port), auth, from}
+}
+
+func (sm *smtpMailer) Send(mail Mail) error {
+	return smtp.SendMail(sm.addr, sm.auth, sm.from, []string{mail.To}, buildMessage(sm.from, mail))
+}
+
+// buildMessage encodes the mail as an RFC 5322 message with a UTF-8 body.
+// Mails with HTML are sent as multipart/alternative with the plain text first.
+func buildMessage(from string, mail Mail) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", mail.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	if mail.HTML == "" {
+		writePart(&b, "text/plain", mail.Body)
+		return []byte(b.String())
+	}
+
+	boundary := multipart.NewWriter(nil).Boundary()
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%q\r\n", boundary)
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	writePart(&b, "text/plain", mail.Body)
+	fmt.Fprintf(&b, "\r\n--%s\r\n", boundary)
+	writePart(&b, "text/html", mail.HTML)
+	fmt.Fprintf(&b, "\r\n--%s--\r\n", boundary)
+	return []byte(b.String())
+}
+
+// writePart writes the headers and the body of a UTF-8 text part.
+func writePart(b *strings.Builder, contentType string, body string) {
+	fmt.Fprintf(b, "Content-Type: %s; charset=UTF-8\r\n", contentType)
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+}
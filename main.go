@@ -3,32 +3,284 @@ package main
 import (
 	"RefrigeratorWatchdog-server/controller"
 	"RefrigeratorWatchdog-server/db"
+	"RefrigeratorWatchdog-server/mailer"
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/oidc"
 	"RefrigeratorWatchdog-server/repository"
 	"RefrigeratorWatchdog-server/router"
 	"RefrigeratorWatchdog-server/usecase"
 	"RefrigeratorWatchdog-server/validator"
+	"RefrigeratorWatchdog-server/webhook"
 	"fmt"
+	"log"
 	"os"
+	"time"
+
+	// ユーザーのタイムゾーンを、tzdata のないコンテナでも読み込めるようにする
+	_ "time/tzdata"
+)
+
+const (
+	sweepInterval              = time.Hour
+	expiryNotificationInterval = 15 * time.Minute
+	webhookDeliveryInterval    = 5 * time.Second
 )
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <access token>" issued by POST /users/login
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description "ApiKey <key>" created by POST /households/{id}/api-keys
 func main() {
 	db := db.NewDB()
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	authUsecase := usecase.NewAuthUsecase(refreshTokenRepository)
+	mailer := mailer.NewMailer()
+
+	userRepository := repository.NewUserRepository(db)
+
+	householdRepository := repository.NewHouseholdRepository(db)
+	householdInvitationRepository := repository.NewHouseholdInvitationRepository(db)
+	householdRoleRepository := repository.NewHouseholdRoleRepository(db)
+	householdValidator := validator.NewHouseholdValidator()
+	householdUsecase := usecase.NewHouseholdUsecase(householdRepository, householdInvitationRepository, householdRoleRepository, userRepository, householdValidator, mailer)
+	householdController := controller.NewHouseholdController(householdUsecase)
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	auditUsecase := usecase.NewAuditUsecase(auditLogRepository, householdUsecase)
+	auditController := controller.NewAuditController(auditUsecase)
+	permissionMiddleware := controller.NewPermissionMiddleware(householdUsecase)
+
+	apiKeyRepository := repository.NewAPIKeyRepository(db)
+	apiKeyValidator := validator.NewAPIKeyValidator()
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepository, apiKeyValidator, householdUsecase)
+	apiKeyController := controller.NewAPIKeyController(apiKeyUsecase)
+
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookValidator := validator.NewWebhookValidator()
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository, webhookValidator, householdUsecase, webhook.NewHTTPSender(nil), usecase.SystemClock{})
+	webhookController := controller.NewWebhookController(webhookUsecase)
+
 	foodValidator := validator.NewFoodValidator()
 	foodRepository := repository.NewFoodRepository(db)
-	foodUsecase := usecase.NewFoodUsecase(foodRepository, foodValidator)
+	imageRepository := repository.NewImageRepository()
+	foodUsecase := usecase.NewFoodUsecase(foodRepository, foodValidator, userRepository, imageRepository, householdUsecase, auditUsecase, webhookUsecase)
 	foodController := controller.NewFoodController(foodUsecase)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(foodRepository, foodValidator, userRepository, householdUsecase, usecase.SystemClock{})
+	analyticsController := controller.NewAnalyticsController(analyticsUsecase)
 
 	userValidator := validator.NewUserValidator()
-	userRepository := repository.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepository, userValidator)
-	userController := controller.NewUserController(userUsecase)
+	userTokenRepository := repository.NewUserTokenRepository(db)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(db)
+	loginThrottleUsecase := usecase.NewLoginThrottleUsecase(loginAttemptRepository)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(db)
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(userRepository, recoveryCodeRepository)
+	userUsecase := usecase.NewUserUsecase(userRepository, userValidator, authUsecase, userTokenRepository, mailer, loginThrottleUsecase, twoFactorUsecase, auditUsecase)
+	userController := controller.NewUserController(userUsecase, authUsecase, twoFactorUsecase)
 
-	imageRepository := repository.NewImageRepository()
-	imageUsecase := usecase.NewImageUsecase(imageRepository)
+	userIdentityRepository := repository.NewUserIdentityRepository(db)
+	oidcUsecase := usecase.NewOIDCUsecase(userRepository, userIdentityRepository, authUsecase, oidc.LoadProviders())
+	oidcController := controller.NewOIDCController(oidcUsecase)
+
+	pushSubscriptionRepository := repository.NewPushSubscriptionRepository(db)
+	pushValidator := validator.NewPushValidator()
+	pushUsecase := usecase.NewPushUsecase(pushSubscriptionRepository, pushValidator)
+	pushController := controller.NewPushController(pushUsecase)
+
+	notificationRepository := repository.NewNotificationRepository(db)
+	leaseRepository := repository.NewLeaseRepository(db)
+	notificationValidator := validator.NewNotificationValidator()
+	notificationChannels := notifier.NewChannelNotifier(map[string]notifier.INotifier{
+		model.NotificationChannelEmail:   notifier.NewMailNotifier(mailer),
+		model.NotificationChannelPush:    pushUsecase,
+		model.NotificationChannelWebhook: notifier.NewWebhookNotifier(webhook.NewHTTPSender(nil)),
+	})
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepository, leaseRepository, foodRepository, householdUsecase, webhookUsecase, notificationValidator, notificationChannels, usecase.SystemClock{})
+	notificationController := controller.NewNotificationController(notificationUsecase)
+	digestUsecase := usecase.NewDigestUsecase(notificationRepository, leaseRepository, foodRepository, userRepository, householdUsecase, notificationValidator, notificationChannels, usecase.SystemClock{})
+	digestController := controller.NewDigestController(digestUsecase)
+
+	imageUsecase := usecase.NewImageUsecase(imageRepository, householdRepository, householdUsecase, auditUsecase)
 	imageController := controller.NewImageController(imageUsecase)
 
+	authMiddleware := controller.NewAuthMiddleware(authUsecase)
+	deviceAuthMiddleware := controller.NewDeviceAuthMiddleware(authUsecase, apiKeyUsecase)
+
+	go sweepExpiredRefreshTokens(authUsecase, sweepInterval)
+	go sweepLoginAttempts(loginThrottleUsecase, sweepInterval)
+	go sweepOIDCLoginStates(oidcUsecase, sweepInterval)
+	go sweepExpiryAlerts(notificationUsecase, sweepInterval)
+	go notifyExpiringFoods(notificationUsecase, expiryNotificationInterval)
+	go sweepDigestDeliveries(digestUsecase, sweepInterval)
+	go purgeTrashedFoods(foodUsecase, sweepInterval)
+	go sendDigests(digestUsecase, expiryNotificationInterval)
+	go sweepWebhookDeliveries(webhookUsecase, sweepInterval)
+	go deliverWebhooks(webhookUsecase, webhookDeliveryInterval)
 
-	e := router.NewRouter(foodController, userController, imageController)
+	e := router.NewRouter(foodController, userController, imageController, householdController, apiKeyController, oidcController, auditController, notificationController, webhookController, pushController, digestController, analyticsController, authMiddleware, deviceAuthMiddleware, permissionMiddleware)
 
 	e.Logger.Fatal(e.Start(fmt.Sprintf(":%s", os.Getenv("PORT"))))
 }
+
+// sweepExpiredRefreshTokens periodically deletes refresh tokens that have expired.
+func sweepExpiredRefreshTokens(au usecase.IAuthUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := au.SweepExpiredRefreshTokens()
+		if err != nil {
+			log.Println("failed to sweep expired refresh tokens:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d expired refresh tokens\n", deleted)
+		}
+	}
+}
+
+// sweepLoginAttempts periodically deletes failed login counters that are no longer relevant.
+func sweepLoginAttempts(lu usecase.ILoginThrottleUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := lu.SweepLoginAttempts()
+		if err != nil {
+			log.Println("failed to sweep login attempts:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d login attempts\n", deleted)
+		}
+	}
+}
+
+// sweepOIDCLoginStates periodically deletes OpenID Connect logins that were never completed.
+func sweepOIDCLoginStates(ou usecase.IOIDCUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := ou.SweepExpiredLoginStates()
+		if err != nil {
+			log.Println("failed to sweep oidc login states:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d oidc login states\n", deleted)
+		}
+	}
+}
+
+// sweepExpiryAlerts periodically deletes the records of alerts about foods that expired long ago.
+func sweepExpiryAlerts(nu usecase.INotificationUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := nu.SweepExpiryAlerts()
+		if err != nil {
+			log.Println("failed to sweep expiry alerts:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d expiry alerts\n", deleted)
+		}
+	}
+}
+
+// notifyExpiringFoods periodically notifies users of foods that reached their
+// lead times. Every replica runs it, and the lease lets only one of them scan.
+func notifyExpiringFoods(nu usecase.INotificationUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		sent, err := nu.NotifyExpiringFoods()
+		if err != nil {
+			log.Println("failed to notify expiring foods:", err)
+			continue
+		}
+		if sent > 0 {
+			log.Printf("sent %d expiry notifications\n", sent)
+		}
+	}
+}
+
+// purgeTrashedFoods periodically deletes the foods that have been in the trash for longer than the retention period.
+func purgeTrashedFoods(fu usecase.IFoodUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		purged, err := fu.PurgeTrashedFoods()
+		if err != nil {
+			log.Println("failed to purge trashed foods:", err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("purged %d trashed foods\n", purged)
+		}
+	}
+}
+
+// sweepDigestDeliveries periodically deletes the records of digests whose periods are over.
+func sweepDigestDeliveries(du usecase.IDigestUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := du.SweepDigestDeliveries()
+		if err != nil {
+			log.Println("failed to sweep digest deliveries:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d digest deliveries\n", deleted)
+		}
+	}
+}
+
+// sendDigests periodically sends the digests of the users whose digest hour
+// has come. Every replica runs it, and the lease lets only one of them send.
+// The interval must be shorter than an hour.
+func sendDigests(du usecase.IDigestUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		sent, err := du.SendDigests()
+		if err != nil {
+			log.Println("failed to send digests:", err)
+			continue
+		}
+		if sent > 0 {
+			log.Printf("sent %d digests\n", sent)
+		}
+	}
+}
+
+// sweepWebhookDeliveries periodically deletes old entries of the webhook delivery logs.
+func sweepWebhookDeliveries(wu usecase.IWebhookUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deleted, err := wu.SweepWebhookDeliveries()
+		if err != nil {
+			log.Println("failed to sweep webhook deliveries:", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("swept %d webhook deliveries\n", deleted)
+		}
+	}
+}
+
+// deliverWebhooks periodically delivers queued webhook events and retries
+// failed deliveries. Every replica runs it; each delivery is claimed by one.
+func deliverWebhooks(wu usecase.IWebhookUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := wu.DeliverPendingWebhooks(); err != nil {
+			log.Println("failed to deliver webhooks:", err)
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"RefrigeratorWatchdog-server/db"
 	"RefrigeratorWatchdog-server/model"
 	"fmt"
+	"log"
+
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -12,4 +15,60 @@ func main() {
 	defer db.CloseDB(dbConn)
 	dbConn.AutoMigrate(&model.User{})
 	dbConn.AutoMigrate(&model.Food{})
+	dbConn.AutoMigrate(&model.RefreshToken{})
+	dbConn.AutoMigrate(&model.UserToken{})
+	dbConn.AutoMigrate(&model.LoginAttempt{})
+	dbConn.AutoMigrate(&model.RecoveryCode{})
+	dbConn.AutoMigrate(&model.Household{})
+	dbConn.AutoMigrate(&model.HouseholdMember{})
+	dbConn.AutoMigrate(&model.HouseholdInvitation{})
+	dbConn.AutoMigrate(&model.HouseholdRole{})
+	dbConn.AutoMigrate(&model.APIKey{})
+	dbConn.AutoMigrate(&model.UserIdentity{})
+	dbConn.AutoMigrate(&model.OIDCLoginState{})
+	dbConn.AutoMigrate(&model.AuditLog{})
+	dbConn.AutoMigrate(&model.NotificationPreference{})
+	dbConn.AutoMigrate(&model.ExpiryAlert{})
+	dbConn.AutoMigrate(&model.Lease{})
+	dbConn.AutoMigrate(&model.Webhook{})
+	dbConn.AutoMigrate(&model.WebhookDelivery{})
+	dbConn.AutoMigrate(&model.PushSubscription{})
+	dbConn.AutoMigrate(&model.VAPIDKey{})
+	dbConn.AutoMigrate(&model.DigestDelivery{})
+	dbConn.AutoMigrate(&model.FoodConsumption{})
+	if err := migrateHouseholds(dbConn); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// migrateHouseholds creates a personal household for every user who belongs
+// to none and moves the foods of the user that have no household into it.
+func migrateHouseholds(dbConn *gorm.DB) error {
+	users := []model.User{}
+	if err := dbConn.Where("id NOT IN (?)", dbConn.Model(&model.HouseholdMember{}).Select("user_id")).Find(&users).Error; err != nil {
+		return err
+	}
+	for _, user := range users {
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			household := model.Household{Name: model.PersonalHouseholdName(user.Username)}
+			if err := tx.Create(&household).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&model.HouseholdMember{
+				HouseholdID: household.ID,
+				UserID:      user.ID,
+				Role:        model.HouseholdRoleOwner,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.User{}).Where("id = ?", user.ID).Update("active_household_id", household.ID).Error; err != nil {
+				return err
+			}
+			return tx.Model(&model.Food{}).Where("user_id = ? AND household_id = 0", user.ID).Update("household_id", household.ID).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// Keys that waste statistics are grouped by.
+const (
+	WasteGroupTag             = "tag"
+	WasteGroupMonth           = "month"
+	WasteGroupStorageLocation = "storage_location"
+)
+
+// WastedDispositions are the dispositions of the archived foods that count as
+// wasted.
+var WastedDispositions = []string{DispositionDiscarded, DispositionExpired}
+
+// WasteQuery represents the period of the waste analytics. The JSON names
+// are the query parameters, which validation errors refer to.
+type WasteQuery struct {
+	From  string `json:"from"`  // First day, YYYY-MM-DD in the user's time zone
+	To    string `json:"to"`    // Last day, YYYY-MM-DD in the user's time zone
+	Limit int    `json:"limit"` // Maximum number of items of the top wasted items
+}
+
+// WasteSummary represents the waste of the foods archived in a period.
+type WasteSummary struct {
+	From                     time.Time `json:"from" gorm:"-" example:"2024-01-01T00:00:00+09:00"` // Start of the period
+	To                       time.Time `json:"to" gorm:"-" example:"2025-01-01T00:00:00+09:00"`   // End of the period, exclusive
+	Total                    int       `json:"total" example:"120"`                               // Foods archived in the period
+	Wasted                   int       `json:"wasted" example:"18"`                               // Foods discarded or expired
+	WasteRate                float64   `json:"waste_rate" example:"0.15"`                         // Wasted divided by total; 0 without foods
+	PricedWasted             int       `json:"priced_wasted" example:"12"`                        // Wasted foods with a price, which money_wasted is estimated from
+	MoneyWasted              float64   `json:"money_wasted" example:"2480"`                       // Price of the quantity left of wasted foods
+	AverageDaysToConsumption *float64  `json:"average_days_to_consumption" example:"4.5"`         // Days from adding to eating up foods; null if none was eaten
+}
+
+// WasteGroup represents the waste of the archived foods that share a key.
+type WasteGroup struct {
+	Key         string  `json:"key" example:"野菜"` // Tag, month (YYYY-MM) or storage location
+	Total       int     `json:"total" example:"30"`
+	Wasted      int     `json:"wasted" example:"6"`
+	WasteRate   float64 `json:"waste_rate" example:"0.2"`
+	MoneyWasted float64 `json:"money_wasted" example:"820"`
+}
+
+// WasteGroupResponse represents the waste statistics grouped by a key.
+type WasteGroupResponse struct {
+	From     time.Time    `json:"from" example:"2024-01-01T00:00:00+09:00"`
+	To       time.Time    `json:"to" example:"2025-01-01T00:00:00+09:00"`
+	TimeZone string       `json:"time_zone" example:"Asia/Tokyo"` // Time zone the months are counted in
+	Groups   []WasteGroup `json:"groups"`                         // Groups ordered by key
+}
+
+// WastedItem represents the foods with the same name that were wasted.
+type WastedItem struct {
+	Name        string  `json:"name" example:"もやし"`
+	Wasted      int     `json:"wasted" example:"5"`
+	MoneyWasted float64 `json:"money_wasted" example:"150"`
+}
+
+// WastedItemResponse represents the most wasted foods.
+type WastedItemResponse struct {
+	From  time.Time    `json:"from" example:"2024-01-01T00:00:00+09:00"`
+	To    time.Time    `json:"to" example:"2025-01-01T00:00:00+09:00"`
+	Items []WastedItem `json:"items"` // Most often wasted first
+}
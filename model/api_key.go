@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// APIKeyPrefixLength is the length of the visible prefix of an API key,
+// e.g. "rwk_1a2b3c4d".
+const APIKeyPrefixLength = 12
+
+// APIKeyPermissions lists the permissions that can be granted to an API key.
+var APIKeyPermissions = []Permission{PermissionFoodRead, PermissionFoodWrite, PermissionFoodDelete}
+
+// APIKey represents a key used by a device, such as the fridge sensing unit,
+// to call the API on behalf of a household. Only the SHA-256 hash of the key
+// is stored; Prefix is kept so that users can tell keys apart.
+type APIKey struct {
+	ID          int        `gorm:"primary_key"`
+	HouseholdID int        `gorm:"not null;index"`
+	Name        string     `gorm:"type:varchar(64);not null"`
+	Prefix      string     `gorm:"type:varchar(16);not null"`
+	KeyHash     string     `gorm:"type:char(64);uniqueIndex;not null"`
+	Permissions string     `gorm:"type:varchar(255);not null"` // Comma-separated permissions
+	CreatedBy   int        `gorm:"not null"`                   // User who created the key
+	LastUsedAt  *time.Time // Updated at most once per minute
+	RevokedAt   *time.Time // Set when the key is revoked
+	CreatedAt   time.Time
+	Household   Household `gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+}
+
+// PermissionList returns the permissions granted to the key.
+func (k APIKey) PermissionList() []Permission {
+	return HouseholdRole{Permissions: k.Permissions}.PermissionList()
+}
+
+// APIKeyScope restricts an AuthUser authenticated by an API key to a
+// household and a set of permissions.
+type APIKeyScope struct {
+	ID          int
+	HouseholdID int
+	Permissions []Permission
+}
+
+// APIKeyRequest represents the request structure for creating an API key.
+type APIKeyRequest struct {
+	Name        string       `json:"name" example:"冷蔵庫センサー"`                                                // Name of the device
+	Permissions []Permission `json:"permissions" swaggertype:"array,string" example:"food:read,food:write"` // Permissions granted to the key
+}
+
+// APIKeyResponse represents an API key without its secret.
+type APIKeyResponse struct {
+	ID          int          `json:"id" example:"1"`                                                        // ID of the key
+	Name        string       `json:"name" example:"冷蔵庫センサー"`                                                // Name of the device
+	Prefix      string       `json:"prefix" example:"rwk_1a2b3c4d"`                                         // Visible prefix of the key
+	Permissions []Permission `json:"permissions" swaggertype:"array,string" example:"food:read,food:write"` // Permissions granted to the key
+	CreatedAt   time.Time    `json:"created_at" example:"2024-09-25T11:46:43Z"`                             // Creation timestamp
+	LastUsedAt  *time.Time   `json:"last_used_at" example:"2024-09-25T11:46:43Z"`                           // Last time the key was used
+	RevokedAt   *time.Time   `json:"revoked_at" example:"2024-09-25T11:46:43Z"`                             // Time the key was revoked
+}
+
+// APIKeyCreatedResponse represents a newly created API key. The key itself is
+// returned only once.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"rwk_1a2b3c4d_q8V2c1mX0kq3Jb9..."` // Send as "Authorization: ApiKey <key>"
+}
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	AuditEntityFood  = "food"
+	AuditEntityUser  = "user"
+	AuditEntityImage = "image"
+)
+
+const (
+	AuditActionFoodCreate           = "food.create"
+	AuditActionFoodUpdate           = "food.update"
+	AuditActionFoodDelete           = "food.delete"
+	AuditActionFoodConsume          = "food.consume"
+	AuditActionFoodDispose          = "food.dispose"
+	AuditActionFoodRestore          = "food.restore"
+	AuditActionUserCreate           = "user.create"
+	AuditActionUserUpdate           = "user.update"
+	AuditActionUserDelete           = "user.delete"
+	AuditActionUserPasswordReset    = "user.password_reset"
+	AuditActionUserEmailVerify      = "user.email_verify"
+	AuditActionUserUnlock           = "user.unlock"
+	AuditActionUserTwoFactorDisable = "user.two_factor_disable"
+	AuditActionImageUpload          = "image.upload"
+)
+
+// RequestMeta represents where a request came from. It is recorded with
+// every audit log entry.
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+// AuditLog represents an entry of the append-only audit log. Entries are
+// never updated or deleted, and outlive the users and foods they refer to.
+type AuditLog struct {
+	ID          int       `gorm:"primary_key"`
+	HouseholdID *int      `gorm:"index:idx_audit_logs_household_id"` // Household of the entity; nil for account changes
+	ActorID     int       `gorm:"not null;index"`                    // User who made the change
+	APIKeyID    *int      // Set when the change was made with an API key
+	Action      string    `gorm:"type:varchar(64);not null"`
+	EntityType  string    `gorm:"type:varchar(32);not null;index:idx_audit_logs_entity"`
+	EntityID    string    `gorm:"type:varchar(255);not null;index:idx_audit_logs_entity"`
+	Changes     string    `gorm:"type:text"` // JSON object of the changed fields with their values before and after
+	IP          string    `gorm:"type:varchar(64)"`
+	UserAgent   string    `gorm:"type:varchar(255)"`
+	RequestID   string    `gorm:"type:varchar(64)"`
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+// AuditEntry represents a change to record in the audit log. Before is nil
+// for created entities and After is nil for deleted ones. Fields listed in
+// Redacted are recorded as changed without their values.
+type AuditEntry struct {
+	Actor       AuthUser
+	Action      string
+	EntityType  string
+	EntityID    string
+	HouseholdID *int
+	Before      interface{}
+	After       interface{}
+	Redacted    []string
+}
+
+// AuditChange represents the values of a field before and after a change.
+type AuditChange struct {
+	Before interface{} `json:"before"` // Value before the change; null if the field did not exist
+	After  interface{} `json:"after"`  // Value after the change; null if the field no longer exists
+}
+
+// AuditLogQuery represents the filters of GET /audit.
+type AuditLogQuery struct {
+	EntityType string     // Only entries of the entity type
+	EntityID   string     // Only entries of the entity; requires EntityType
+	ActorID    *int       // Only entries made by the user
+	Since      *time.Time // Only entries created at or after the time
+	Cursor     string     // next_cursor of the previous page
+	Limit      int        // Maximum number of entries
+}
+
+// AuditLogResponse represents an entry of the audit log.
+type AuditLogResponse struct {
+	ID          int                    `json:"id" example:"1"`                                        // ID of the entry
+	HouseholdID *int                   `json:"household_id" example:"1"`                              // Household of the entity; null for account changes
+	ActorID     int                    `json:"actor_id" example:"1"`                                  // User who made the change
+	APIKeyID    *int                   `json:"api_key_id,omitempty" example:"1"`                      // API key the change was made with
+	Action      string                 `json:"action" example:"food.delete"`                          // What was done
+	EntityType  string                 `json:"entity_type" example:"food"`                            // Type of the changed entity
+	EntityID    string                 `json:"entity_id" example:"12"`                                // ID of the changed entity
+	Changes     map[string]AuditChange `json:"changes"`                                               // Changed fields
+	IP          string                 `json:"ip" example:"192.0.2.1"`                                // IP address of the request
+	UserAgent   string                 `json:"user_agent" example:"Mozilla/5.0"`                      // User agent of the request
+	RequestID   string                 `json:"request_id" example:"vVmEGhNLgMNjTK5lNuSFVOqbm0yANVmR"` // X-Request-Id of the request
+	CreatedAt   time.Time              `json:"created_at" example:"2024-09-25T11:46:43Z"`             // Time of the change
+}
+
+// AuditLogPage represents a page of the audit log, newest first.
+type AuditLogPage struct {
+	Items      []AuditLogResponse `json:"items"`                                // Entries of the page
+	NextCursor string             `json:"next_cursor,omitempty" example:"MTIz"` // Cursor of the next page; omitted on the last page
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// AuthUser represents the authenticated caller of a request. When the caller
+// authenticated with an API key, ID is the user who created the key and
+// APIKey restricts what the caller can do.
+type AuthUser struct {
+	ID     int          `json:"id" example:"1"`                   // ID of the authenticated user
+	Email  string       `json:"email" example:"sample@gmail.com"` // Email of the authenticated user
+	APIKey *APIKeyScope `json:"-"`                                // Set when authenticated with an API key
+	Meta   RequestMeta  `json:"-"`                                // Where the request came from
+}
+
+// TokenResponse represents a pair of access and refresh tokens.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Signed access token
+	TokenType    string `json:"token_type" example:"Bearer"`                                    // Type of the access token
+	ExpiresIn    int    `json:"expires_in" example:"900"`                                       // Lifetime of the access token in seconds
+	RefreshToken string `json:"refresh_token" example:"q8V2c1mX0kq3Jb9..."`                     // Single-use refresh token
+}
+
+// LoginResponse represents the response returned after a successful login.
+// When the user has two-factor authentication enabled, no tokens are issued;
+// MFARequired is set and MFAToken must be sent to POST /users/login/2fa.
+type LoginResponse struct {
+	*TokenResponse
+	MFARequired bool         `json:"mfa_required" example:"false"`                                  // Whether a second factor is required
+	MFAToken    string       `json:"mfa_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6..."` // Short-lived token for the second login step
+	User        UserResponse `json:"user"`                                                          // Logged in user
+}
+
+// RefreshToken represents a long-lived refresh token in the database.
+// Only the SHA-256 hash of the token is stored. Tokens rotated from the same
+// login share a FamilyID so that the whole chain can be revoked at once.
+type RefreshToken struct {
+	ID        int        `gorm:"primary_key"`
+	UserID    int        `gorm:"not null;index"`
+	FamilyID  string     `gorm:"type:varchar(64);not null;index"`
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	RevokedAt *time.Time // Set when the token is rotated or revoked
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// RefreshTokenRequest represents the request structure for refreshing or revoking tokens.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q8V2c1mX0kq3Jb9..."` // Refresh token issued at login
+}
+
+var ErrUnauthorized = errors.New("unauthorized")
+var ErrForbidden = errors.New("forbidden")
+var ErrRefreshTokenRevoked = errors.New("refresh token already revoked")
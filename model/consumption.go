@@ -0,0 +1,31 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// FoodConsumption records that part of a food was used up.
+type FoodConsumption struct {
+	ID                int       `gorm:"primary_key"`
+	FoodID            int       `gorm:"not null;index"`
+	HouseholdID       int       `gorm:"not null;index"`
+	UserID            int       `gorm:"not null"` // User who consumed the food
+	Amount            float64   `gorm:"not null"` // Quantity consumed
+	RemainingQuantity float64   `gorm:"not null"` // Quantity left afterwards
+	ConsumedAt        time.Time `gorm:"not null"`
+	Food              Food      `gorm:"foreignKey:FoodID;constraint:OnDelete:CASCADE"`
+}
+
+var (
+	// ErrInsufficientQuantity is returned when more of a food is consumed than is left.
+	ErrInsufficientQuantity = errors.New("insufficient quantity")
+	// ErrFoodArchived is returned when an archived food is consumed, changed
+	// or disposed of again.
+	ErrFoodArchived = errors.New("food is archived")
+)
+
+// ConsumeFoodRequest represents the request of POST /foods/{id}/consume.
+type ConsumeFoodRequest struct {
+	Amount float64 `json:"amount" example:"0.5"` // Quantity consumed; the food is archived as eaten when none is left
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// DigestDelivery records that the digest of a period was sent to a user. The
+// unique index lets only one server replica claim a digest.
+type DigestDelivery struct {
+	ID     int       `gorm:"primary_key"`
+	UserID int       `gorm:"not null;uniqueIndex:idx_digest_deliveries_user_period"`
+	Period string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_digest_deliveries_user_period"` // Frequency and day of the digest, e.g. "daily:2024-10-02"
+	SentAt time.Time `gorm:"not null;index"`
+}
+
+// DigestPreviewQuery represents the query parameters of GET /users/me/digest/preview.
+// Empty values use the notification preferences of the user.
+type DigestPreviewQuery struct {
+	Frequency string `json:"frequency"`
+	Language  string `json:"language"`
+}
+
+// DigestPreviewResponse represents a digest rendered without sending it.
+type DigestPreviewResponse struct {
+	Subject string `json:"subject" example:"冷蔵庫のまとめ（10月2日(水)）"`
+	Summary string `json:"summary" example:"今日が期限 1件、今週が期限 2件、期限切れ 0件"` // Body of push notifications
+	Text    string `json:"text"`                                        // Plain text body of the email
+	HTML    string `json:"html"`                                        // HTML body of the email
+	Empty   bool   `json:"empty" example:"false"`                       // Digests without any food are not sent
+}
@@ -1,29 +1,144 @@
 package model
 
 import (
+	"encoding/json"
+	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Food represents a food item in the database.
 type Food struct {
 	ID             int       `json:"id" gorm:"primary_key" example:"1"` // ID of the food item
-	Name           string    `json:"name" gorm:"not null" example:"オレンジ"` // Name of the food item
-	UserID         int       `json:"user_id" gorm:"not null" example:"1"` // User ID associated with the food item
+	Name           string    `json:"name" gorm:"not null;type:varchar(255);index:idx_foods_household_name,priority:2" example:"オレンジ"` // Name of the food item
+	UserID         int       `json:"user_id" gorm:"not null" example:"1"` // ID of the user who added the food item
+	HouseholdID    int       `json:"household_id" gorm:"not null;index:idx_foods_household_expiration,priority:1;index:idx_foods_household_created_at,priority:1;index:idx_foods_household_name,priority:1;index:idx_foods_household_tag,priority:1;index:idx_foods_household_archived_at,priority:1" example:"1"` // Household that owns the food item
 	OriginalCode   int       `json:"original_code" example:"12456456"` // Original code of the food item
 	Quantity       float64       `json:"quantity" example:"5.5"` // Quantity of the food item
-	CreatedAt      time.Time `json:"created_at" example:"2024-09-25T11:46:43Z"` // Creation timestamp
-	ExpirationDate *time.Time `json:"expiration_date" example:"2024-12-15T00:00:00Z"` // Expiration date
+	CreatedAt      time.Time `json:"created_at" gorm:"index:idx_foods_household_created_at,priority:2" example:"2024-09-25T11:46:43Z"` // Creation timestamp
+	ExpirationDate *time.Time `json:"expiration_date" gorm:"index:idx_foods_household_expiration,priority:2;index" example:"2024-12-15T00:00:00Z"` // Expiration date
 	ImageURL       string    `json:"image_url" example:"images/orange.jpg"` // URL of the food item image
 	Memo           string    `json:"memo" example:"新鮮なオレンジだったものです"` // Additional notes or memo
-	Tag            string    `json:"tag" gorm:"type:enum('野菜', '肉', '魚', '乳製品','調味料','卵','飲料','果物','加工食品','その他');default:'その他'"` // Tag of the food item
+	Tag            string    `json:"tag" gorm:"index:idx_foods_household_tag,priority:2;type:enum('野菜', '肉', '魚', '乳製品','調味料','卵','飲料','果物','加工食品','その他');default:'その他'"` // Tag of the food item
+	ArchivedAt     *time.Time `json:"-" gorm:"index:idx_foods_household_archived_at,priority:2"` // When the food was disposed of; archived foods are only listed in the history
+	Disposition    string     `json:"-" gorm:"type:varchar(16);not null;default:''"` // One of the Disposition values once archived
+	StorageLocation string    `json:"storage_location" gorm:"type:varchar(16);not null;default:'冷蔵'"` // Where the food is kept
+	Price          *float64   `json:"price"` // Price paid for the food as added, if known
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"` // When the food was moved to the trash; trashed foods are purged after the retention period
+	Version        int        `json:"-" gorm:"not null;default:1"` // Incremented on every change; sent as the ETag of the food
 	User           User      `gorm:"foreignKey:UserID"` // User associated with the food item
 }
 
+var ErrFoodNotFound = errors.New("food not found")
+
+// ErrFoodNotTrashed is returned when a food that is not in the trash is restored.
+var ErrFoodNotTrashed = errors.New("food is not in the trash")
+
+// ErrFoodVersionMismatch is returned when a food changed since the version
+// that If-Match names.
+var ErrFoodVersionMismatch = errors.New("food has been changed")
+
+// ErrPreconditionRequired is returned when a food is changed without If-Match
+// while it is required.
+var ErrPreconditionRequired = errors.New("If-Match is required")
+
+// FoodVersionMatch represents the If-Match header of a change to a food,
+// whose entity tags are the versions of the food.
+type FoodVersionMatch struct {
+	Any      bool  // If-Match: *, which any version matches
+	Versions []int // Versions the food must have one of
+}
+
+// Dispositions of archived foods, which tell whether a food was eaten or wasted.
+const (
+	DispositionEaten     = "eaten"
+	DispositionDiscarded = "discarded"
+	DispositionExpired   = "expired"
+	DispositionGivenAway = "given_away"
+)
+
+// Storage locations of foods.
+const (
+	StorageRefrigerator = "冷蔵"
+	StorageFreezer      = "冷凍"
+	StorageVegetable    = "野菜室"
+	StoragePantry       = "常温"
+)
+
+// DisposeFoodRequest represents the request of POST /foods/{id}/dispose.
+type DisposeFoodRequest struct {
+	Disposition string `json:"disposition" example:"eaten"` // eaten, discarded, expired or given_away
+}
+
+// Sort keys of GET /foods. Ties are broken by the ID of the food.
+const (
+	FoodSortExpirationDate = "expiration_date"
+	FoodSortCreatedAt      = "created_at"
+	FoodSortName           = "name"
+	// FoodSortArchivedAt is only a sort key of GET /foods/history.
+	FoodSortArchivedAt = "archived_at"
+)
+
+// Sort orders of GET /foods.
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// FoodQuery represents the filters, order and page of GET /foods. The JSON
+// names are the query parameters, which validation errors refer to.
+type FoodQuery struct {
+	Tag           string     `json:"tag"`            // Only foods with the tag
+	Name          string     `json:"name"`           // Only foods whose name contains the string
+	ExpiresBefore *time.Time `json:"expires_before"` // Only foods expiring before the time
+	ExpiresAfter  *time.Time `json:"expires_after"`  // Only foods expiring at or after the time
+	Sort          string     `json:"sort"`           // One of the FoodSort keys
+	Order         string     `json:"order"`          // SortOrderAsc or SortOrderDesc
+	Cursor        string     `json:"cursor"`         // next_cursor of the previous page
+	Limit         int        `json:"limit"`          // Maximum number of foods
+	// Disposition selects the archived foods with the disposition in
+	// GET /foods/history.
+	Disposition string `json:"disposition"`
+	// Archived selects the archived foods instead of the active ones. It is
+	// not a parameter.
+	Archived bool `json:"-"`
+	// NoExpirationDate selects only the foods without an expiration date. It
+	// is not a parameter of GET /foods.
+	NoExpirationDate bool `json:"-"`
+}
+// FoodExpirationGroup represents the foods that expire on the same day.
+type FoodExpirationGroup struct {
+	Date          *string        `json:"date" example:"2024-12-15"`  // Day in the user's time zone; null for foods without an expiration date
+	DaysRemaining *int           `json:"days_remaining" example:"2"` // Days from today to the day; negative once expired, null without an expiration date
+	Items         []FoodResponse `json:"items"`                      // Foods that expire on the day
+}
+
+// FoodExpirationResponse represents foods grouped by the day they expire.
+type FoodExpirationResponse struct {
+	TimeZone string                `json:"time_zone" example:"Asia/Tokyo"` // Time zone the days are counted in
+	Today    string                `json:"today" example:"2024-12-13"`     // Today in the time zone
+	Groups   []FoodExpirationGroup `json:"groups"`                         // Groups ordered by day
+}
+
+// FoodPage represents a page of foods.
+type FoodPage struct {
+	Items      []FoodResponse `json:"items"`                                        // Foods of the page
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJpZCI6MTJ9"` // Cursor of the next page; omitted on the last page
+}
+
+// FoodTrashResponse represents the foods in the trash.
+type FoodTrashResponse struct {
+	RetentionDays int            `json:"retention_days" example:"30"` // Days after which trashed foods are deleted permanently
+	Items         []FoodResponse `json:"items"`                       // Most recently deleted first
+}
+
 // FoodResponse represents the response structure for a food item.
 type FoodResponse struct {
 	ID             int       `json:"id" example:"1"` // ID of the food item
 	Name           string    `json:"name" example:"オレンジ"` // Name of the food item
-	UserID         int       `json:"user_id" example:"1"` // User ID associated with the food item
+	UserID         int       `json:"user_id" example:"1"` // ID of the user who added the food item
+	HouseholdID    int       `json:"household_id" example:"1"` // Household that owns the food item
 	OriginalCode   int       `json:"original_code" example:"12456456"` // Original code of the food item
 	Quantity       float64       `json:"quantity" example:"5.5"` // Quantity of the food item
 	CreatedAt      time.Time `json:"created_at" example:"2024-09-25T11:46:43Z"` // Creation timestamp
@@ -31,16 +146,45 @@ type FoodResponse struct {
 	ImageURL       string    `json:"image_url" example:"images/orange.jpg"` // URL of the food item image
 	Tag 		  string    `json:"tag" example:"果物"` // Tag of the food item
 	Memo           string    `json:"memo" example:"新鮮なオレンジだったものです"` // Additional notes or memo
+	StorageLocation string    `json:"storage_location" example:"冷蔵"` // Where the food is kept
+	Price          *float64   `json:"price" example:"298"` // Price paid for the food as added, if known
+	ArchivedAt     *time.Time `json:"archived_at,omitempty" example:"2024-12-14T08:30:00Z"` // When the food was disposed of
+	Disposition    string     `json:"disposition,omitempty" example:"eaten"` // How the food was disposed of: eaten, discarded, expired or given_away
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" example:"2024-12-14T08:30:00Z"` // When the food was moved to the trash
+	Version        int        `json:"version" example:"3"` // Version of the food, also sent as its ETag; If-Match of changes names it
 }
 
+// FoodPatch represents the body of PATCH /foods/{id}, a JSON Merge Patch
+// (RFC 7396) of FoodRequest. Absent members are left unchanged and null resets
+// a member: no expiration date or price, an empty memo or image URL, 0 for the
+// original code and quantity, the tag 'その他' and the storage location '冷蔵'.
+type FoodPatch map[string]json.RawMessage
+
 // FoodRequest represents the request structure for creating a new food item.
 type FoodRequest struct {
 	Name           string    `json:"name" example:"オレンジ"` // Name of the food item
-	UserID         int       `json:"user_id" example:"1"` // User ID associated with the food item
 	OriginalCode   int       `json:"original_code" example:"12456456"` // Original code of the food item
 	Quantity       float64       `json:"quantity" example:"5.5"` // Quantity of the food item
 	ExpirationDate *time.Time `json:"expiration_date" example:"2024-12-15T00:00:00Z"` // Expiration date
 	ImageURL       string    `json:"image_url" example:"images/orange.jpg"` // URL of the food item image
 	Tag 		  string    `json:"tag" example:"果物"` // Tag of the food item'野菜', '肉', '魚', '乳製品','調味料','卵','飲料','果物','加工食品','その他'
 	Memo           string    `json:"memo" example:"新鮮なオレンジだったものです"` // Additional notes or memo
+	StorageLocation string    `json:"storage_location" example:"冷蔵"` // Where the food is kept: '冷蔵', '冷凍', '野菜室', '常温' (default '冷蔵')
+	Price          *float64   `json:"price" example:"298"` // Price paid for the food as added, if known
+}
+
+// Food returns a food with the fields of the request. The other fields, such
+// as the ID, owner, household and version, are left for the server to set.
+func (r FoodRequest) Food() Food {
+	return Food{
+		Name:            r.Name,
+		OriginalCode:    r.OriginalCode,
+		Quantity:        r.Quantity,
+		ExpirationDate:  r.ExpirationDate,
+		ImageURL:        r.ImageURL,
+		Tag:             r.Tag,
+		Memo:            r.Memo,
+		StorageLocation: r.StorageLocation,
+		Price:           r.Price,
+	}
 }
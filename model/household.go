@@ -0,0 +1,116 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Built-in roles of a household member. Households can also define custom
+// roles, see HouseholdRole.
+const (
+	HouseholdRoleOwner  = "owner"  // Has every permission
+	HouseholdRoleMember = "member" // Reads, writes and deletes foods
+	HouseholdRoleViewer = "viewer" // Reads foods
+)
+
+// Household represents a group of users sharing one fridge.
+type Household struct {
+	ID        int               `json:"id" gorm:"primary_key" example:"1"`                        // ID of the household
+	Name      string            `json:"name" gorm:"type:varchar(255);not null" example:"山田家の冷蔵庫"` // Name of the household
+	CreatedAt time.Time         `json:"created_at" example:"2024-09-25T11:46:43Z"`                // Creation timestamp
+	Members   []HouseholdMember `json:"-" gorm:"foreignKey:HouseholdID"`                          // Members of the household
+}
+
+// HouseholdMember represents the membership of a user in a household.
+type HouseholdMember struct {
+	ID          int    `gorm:"primary_key"`
+	HouseholdID int    `gorm:"not null;uniqueIndex:idx_household_members_household_user"`
+	UserID      int    `gorm:"not null;uniqueIndex:idx_household_members_household_user;index"`
+	Role        string `gorm:"type:varchar(32);not null"`
+	CreatedAt   time.Time
+	Household   Household    `gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+	User        User         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Permissions []Permission `gorm:"-"` // Resolved from Role, see Can
+}
+
+// Can reports whether the member has the permission.
+func (m HouseholdMember) Can(permission Permission) bool {
+	for _, p := range m.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HouseholdInvitation represents an invitation sent by email. Only the
+// SHA-256 hash of the invitation token is stored.
+type HouseholdInvitation struct {
+	ID          int        `gorm:"primary_key"`
+	HouseholdID int        `gorm:"not null;index"`
+	Email       string     `gorm:"type:varchar(255);not null;index"`
+	Role        string     `gorm:"type:varchar(32);not null"`
+	TokenHash   string     `gorm:"type:char(64);uniqueIndex;not null"`
+	InvitedBy   int        `gorm:"not null"`
+	ExpiresAt   time.Time  `gorm:"not null"`
+	AcceptedAt  *time.Time // Set when the invitation is accepted
+	DeclinedAt  *time.Time // Set when the invitation is declined
+	CreatedAt   time.Time
+	Household   Household `gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+}
+
+// HouseholdRequest represents the request structure for creating or renaming a household.
+type HouseholdRequest struct {
+	Name string `json:"name" example:"山田家の冷蔵庫"` // Name of the household
+}
+
+// HouseholdResponse represents a household the caller belongs to.
+type HouseholdResponse struct {
+	ID          int          `json:"id" example:"1"`                                                        // ID of the household
+	Name        string       `json:"name" example:"山田家の冷蔵庫"`                                                // Name of the household
+	Role        string       `json:"role" example:"owner"`                                                  // Role of the caller
+	Active      bool         `json:"active" example:"true"`                                                 // Whether this is the caller's active household
+	Permissions []Permission `json:"permissions" swaggertype:"array,string" example:"food:read,food:write"` // Permissions of the caller
+	CreatedAt   time.Time    `json:"created_at" example:"2024-09-25T11:46:43Z"`                             // Creation timestamp
+}
+
+// HouseholdMemberResponse represents a member of a household.
+type HouseholdMemberResponse struct {
+	UserID   int       `json:"user_id" example:"1"`                      // ID of the user
+	Username string    `json:"username" example:"山田太郎"`                  // Username of the user
+	Email    string    `json:"email" example:"sample@gmail.com"`         // Email of the user
+	Role     string    `json:"role" example:"member"`                    // Role in the household
+	JoinedAt time.Time `json:"joined_at" example:"2024-09-25T11:46:43Z"` // Time the user joined
+}
+
+// HouseholdDetailResponse represents a household with its members.
+type HouseholdDetailResponse struct {
+	HouseholdResponse
+	Members []HouseholdMemberResponse `json:"members"` // Members of the household
+}
+
+// HouseholdInvitationRequest represents the request structure for inviting a user by email.
+type HouseholdInvitationRequest struct {
+	Email string `json:"email" example:"sample@gmail.com"` // Email of the invited user
+	Role  string `json:"role" example:"member"`            // Role given on acceptance, any role except owner
+}
+
+// HouseholdInvitationTokenRequest represents the request structure for accepting or declining an invitation.
+type HouseholdInvitationTokenRequest struct {
+	Token string `json:"token" example:"q8V2c1mX0kq3Jb9..."` // Token received by email
+}
+
+// HouseholdMemberRoleRequest represents the request structure for changing the role of a member.
+type HouseholdMemberRoleRequest struct {
+	Role string `json:"role" example:"viewer"` // New role, built-in or custom
+}
+
+// PersonalHouseholdName returns the name of the household created for a new user.
+func PersonalHouseholdName(username string) string {
+	return username + "の冷蔵庫"
+}
+
+var ErrHouseholdNotFound = errors.New("household not found")
+var ErrHouseholdMemberNotFound = errors.New("household member not found")
+var ErrAlreadyHouseholdMember = errors.New("user is already a member of the household")
+var ErrLastHouseholdOwner = errors.New("household must have at least one owner")
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	LoginAttemptScopeAccount = "account" // Identifier is the email address
+	LoginAttemptScopeIP      = "ip"      // Identifier is the client IP address
+)
+
+// LoginAttempt counts consecutive failed logins per account or client IP.
+// It is persisted so that the counters survive restarts and are shared
+// between server instances.
+type LoginAttempt struct {
+	ID           int        `gorm:"primary_key"`
+	Scope        string     `gorm:"type:varchar(16);not null;uniqueIndex:idx_login_attempts_scope_identifier"`
+	Identifier   string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_login_attempts_scope_identifier"`
+	Failures     int        `gorm:"not null;default:0"`
+	LastFailedAt time.Time  `gorm:"not null;index"`
+	LockedUntil  *time.Time // Login is rejected until this time
+}
+
+// LoginLockedError is returned while an account or IP address is locked.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
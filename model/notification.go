@@ -0,0 +1,186 @@
+package model
+
+import "time"
+
+// DefaultNotificationLeadDays are the lead times of users who have not set
+// any: the day before a food expires.
+var DefaultNotificationLeadDays = []int{1}
+
+// Limits of the lead times of a user.
+const (
+	MaxNotificationLeadDays  = 30 // Largest number of days before the expiration date
+	MaxNotificationLeadTimes = 5  // Largest number of lead times
+)
+
+// Channels through which users are notified.
+const (
+	NotificationChannelEmail   = "email"
+	NotificationChannelPush    = "push"    // Browsers registered with Web Push
+	NotificationChannelWebhook = "webhook" // The webhook URL of the user
+)
+
+// NotificationChannels are the channels users can choose from.
+var NotificationChannels = []string{NotificationChannelEmail, NotificationChannelPush, NotificationChannelWebhook}
+
+// DefaultNotificationChannels are the channels of users who have not set any.
+var DefaultNotificationChannels = []string{NotificationChannelEmail, NotificationChannelPush}
+
+// When notifications are delivered.
+const (
+	NotificationDeliveryImmediate = "immediate" // As soon as a food reaches a lead time, outside the quiet hours
+	NotificationDeliveryDigest    = "digest"    // A digest at the digest hour instead of alerts about each food
+)
+
+// How often digests are sent.
+const (
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly" // On the digest weekday
+)
+
+// Languages of notifications.
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+)
+
+// Defaults of the digests of users who have not set them. The hour and the
+// weekday are in the time zone of the user.
+const (
+	DefaultDigestHour    = 8
+	DefaultDigestWeekday = time.Monday
+)
+
+// NotificationPreference represents how a user wants to be notified. Users
+// without a row use the defaults.
+type NotificationPreference struct {
+	UserID          int      `gorm:"primary_key;autoIncrement:false"`
+	LeadDays        []int    `gorm:"serializer:json;type:varchar(255);not null"`                                // Days before the expiration date to notify on
+	Channels        []string `gorm:"serializer:json;type:varchar(255);not null;default:'[\"email\",\"push\"]'"` // Channels to notify through
+	Delivery        string   `gorm:"type:varchar(16);not null;default:'immediate'"`
+	DigestFrequency string   `gorm:"type:varchar(16);not null;default:'daily'"`
+	DigestHour      int      `gorm:"not null"`
+	DigestWeekday   int      `gorm:"not null"` // 0 is Sunday
+	Language        string   `gorm:"type:varchar(8);not null;default:'ja'"`
+	QuietHoursStart string   `gorm:"type:varchar(5);not null;default:''"` // "HH:MM" in the time zone of the user; empty when there are no quiet hours
+	QuietHoursEnd   string   `gorm:"type:varchar(5);not null;default:''"`
+	MutedTags       []string `gorm:"serializer:json;type:varchar(255);not null;default:'[]'"` // Tags of foods not to notify about
+	WebhookURL      string   `gorm:"type:varchar(2048);not null;default:''"`
+	WebhookSecret   string   `gorm:"type:varchar(64);not null;default:''"` // Signs the requests to WebhookURL
+	UpdatedAt       time.Time
+	User            User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// DefaultNotificationPreference returns the preferences of a user who has not saved any.
+func DefaultNotificationPreference(userID int) NotificationPreference {
+	return NotificationPreference{
+		UserID:          userID,
+		LeadDays:        DefaultNotificationLeadDays,
+		Channels:        DefaultNotificationChannels,
+		Delivery:        NotificationDeliveryImmediate,
+		DigestFrequency: DigestFrequencyDaily,
+		DigestHour:      DefaultDigestHour,
+		DigestWeekday:   int(DefaultDigestWeekday),
+		Language:        LanguageJapanese,
+		MutedTags:       []string{},
+	}
+}
+
+// HasQuietHours reports whether the user has set quiet hours.
+func (p NotificationPreference) HasQuietHours() bool {
+	return p.QuietHoursStart != "" && p.QuietHoursEnd != ""
+}
+
+// InQuietHours reports whether t, in the time zone of the user, is within the
+// quiet hours. Quiet hours whose end is before the start span midnight.
+func (p NotificationPreference) InQuietHours(t time.Time) bool {
+	if !p.HasQuietHours() {
+		return false
+	}
+	start, err1 := ParseClock(p.QuietHoursStart)
+	end, err2 := ParseClock(p.QuietHoursEnd)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if start <= end {
+		return start <= minute && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Mutes reports whether the user opted out of notifications about foods with the tag.
+func (p NotificationPreference) Mutes(tag string) bool {
+	for _, muted := range p.MutedTags {
+		if muted == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock returns the minutes since midnight of a "HH:MM" time of day.
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// QuietHours is a range of the day, in the time zone of the user, during which
+// immediate notifications are held back until the end.
+type QuietHours struct {
+	Start string `json:"start" example:"22:00"` // "HH:MM"
+	End   string `json:"end" example:"07:00"`   // "HH:MM"; before the start when the range spans midnight
+}
+
+// NotificationPreferenceRequest represents the request structure for updating notification preferences.
+// Omitted fields are set to the defaults.
+type NotificationPreferenceRequest struct {
+	LeadDays        []int       `json:"lead_days" example:"3,1,0"`                              // Days before the expiration date to notify on; 0 is the day itself
+	Channels        []string    `json:"channels" example:"email,push"`                          // email, push or webhook; an empty list turns the notifications off
+	Delivery        string      `json:"delivery" example:"immediate"`                           // immediate or digest
+	DigestFrequency string      `json:"digest_frequency" example:"daily"`                       // daily or weekly
+	DigestHour      *int        `json:"digest_hour" example:"8"`                                // Hour of the day the digest is sent at, 0 to 23
+	DigestWeekday   *int        `json:"digest_weekday" example:"1"`                             // Day of the week weekly digests are sent on, 0 (Sunday) to 6
+	Language        string      `json:"language" example:"ja"`                                  // Language of notifications, ja or en
+	QuietHours      *QuietHours `json:"quiet_hours"`                                            // No quiet hours when omitted
+	MutedTags       []string    `json:"muted_tags" example:"調味料"`                               // Tags of foods not to notify about
+	WebhookURL      string      `json:"webhook_url" example:"https://example.com/hooks/fridge"` // Required with the webhook channel
+}
+
+// NotificationPreferenceResponse represents the notification preferences of a user.
+type NotificationPreferenceResponse struct {
+	LeadDays        []int       `json:"lead_days" example:"3,1,0"` // Days before the expiration date to notify on, largest first
+	Channels        []string    `json:"channels" example:"email,push"`
+	Delivery        string      `json:"delivery" example:"immediate"`
+	DigestFrequency string      `json:"digest_frequency" example:"daily"`
+	DigestHour      int         `json:"digest_hour" example:"8"`
+	DigestWeekday   int         `json:"digest_weekday" example:"1"`
+	Language        string      `json:"language" example:"ja"`
+	QuietHours      *QuietHours `json:"quiet_hours"`
+	MutedTags       []string    `json:"muted_tags" example:"調味料"`
+	WebhookURL      string      `json:"webhook_url,omitempty" example:"https://example.com/hooks/fridge"`
+	WebhookSecret   string      `json:"webhook_secret,omitempty" example:"whsec_5Jx0..."` // Verifies the X-Watchdog-Signature header of the requests to the webhook URL
+}
+
+// ExpiryAlert records that a user was notified about a food reaching one of
+// the user's lead times. The unique index lets only one server replica claim
+// an alert, and including the expiration date re-arms the alerts when the
+// date of the food is changed.
+type ExpiryAlert struct {
+	ID             int       `gorm:"primary_key"`
+	FoodID         int       `gorm:"not null;uniqueIndex:idx_expiry_alerts_food_user_lead"`
+	UserID         int       `gorm:"not null;uniqueIndex:idx_expiry_alerts_food_user_lead;index"`
+	LeadDays       int       `gorm:"not null;uniqueIndex:idx_expiry_alerts_food_user_lead"`
+	ExpirationDate time.Time `gorm:"not null;uniqueIndex:idx_expiry_alerts_food_user_lead;index"`
+	SentAt         time.Time `gorm:"not null"`
+}
+
+// Lease represents a named lock held by one server replica until ExpiresAt.
+// Background jobs that must run on only one replica take a lease first.
+type Lease struct {
+	Name      string    `gorm:"type:varchar(64);primary_key"`
+	Owner     string    `gorm:"type:varchar(64);not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Permission is an action a household member may be allowed to perform.
+type Permission string
+
+const (
+	PermissionFoodRead        Permission = "food:read"        // List foods
+	PermissionFoodWrite       Permission = "food:write"       // Create and update foods
+	PermissionFoodDelete      Permission = "food:delete"      // Delete foods
+	PermissionMemberInvite    Permission = "member:invite"    // Invite users by email
+	PermissionMemberManage    Permission = "member:manage"    // Change roles of and remove other members
+	PermissionHouseholdManage Permission = "household:manage" // Rename the household and configure custom roles
+	PermissionAuditRead       Permission = "audit:read"       // Read the audit log of the household
+)
+
+// AllPermissions lists every permission in a stable order.
+var AllPermissions = []Permission{
+	PermissionFoodRead,
+	PermissionFoodWrite,
+	PermissionFoodDelete,
+	PermissionMemberInvite,
+	PermissionMemberManage,
+	PermissionHouseholdManage,
+	PermissionAuditRead,
+}
+
+var builtinRolePermissions = map[string][]Permission{
+	HouseholdRoleOwner:  AllPermissions,
+	HouseholdRoleMember: {PermissionFoodRead, PermissionFoodWrite, PermissionFoodDelete},
+	HouseholdRoleViewer: {PermissionFoodRead},
+}
+
+// BuiltinRoles lists the roles every household has.
+var BuiltinRoles = []string{HouseholdRoleOwner, HouseholdRoleMember, HouseholdRoleViewer}
+
+// BuiltinRolePermissions returns the permissions of a built-in role and false
+// if the role is not built in.
+func BuiltinRolePermissions(role string) ([]Permission, bool) {
+	permissions, ok := builtinRolePermissions[role]
+	return permissions, ok
+}
+
+// HouseholdRole represents a custom role configured for a household.
+type HouseholdRole struct {
+	ID          int    `gorm:"primary_key"`
+	HouseholdID int    `gorm:"not null;uniqueIndex:idx_household_roles_household_name"`
+	Name        string `gorm:"type:varchar(32);not null;uniqueIndex:idx_household_roles_household_name"`
+	Permissions string `gorm:"type:varchar(255);not null"` // Comma-separated permissions
+	CreatedAt   time.Time
+	Household   Household `gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+}
+
+// PermissionList returns the permissions of the role.
+func (r HouseholdRole) PermissionList() []Permission {
+	permissions := []Permission{}
+	for _, p := range strings.Split(r.Permissions, ",") {
+		if p != "" {
+			permissions = append(permissions, Permission(p))
+		}
+	}
+	return permissions
+}
+
+// JoinPermissions returns the column value of HouseholdRole.Permissions.
+func JoinPermissions(permissions []Permission) string {
+	s := make([]string, len(permissions))
+	for i, p := range permissions {
+		s[i] = string(p)
+	}
+	return strings.Join(s, ",")
+}
+
+// HouseholdRoleRequest represents the request structure for creating or updating a custom role.
+type HouseholdRoleRequest struct {
+	Name        string       `json:"name" example:"shopper"`                                                // Name of the role
+	Permissions []Permission `json:"permissions" swaggertype:"array,string" example:"food:read,food:write"` // Permissions of the role
+}
+
+// HouseholdRoleResponse represents a role available in a household.
+type HouseholdRoleResponse struct {
+	ID          int          `json:"id" example:"1"`                                                        // ID of the custom role, 0 for built-in roles
+	Name        string       `json:"name" example:"shopper"`                                                // Name of the role
+	Permissions []Permission `json:"permissions" swaggertype:"array,string" example:"food:read,food:write"` // Permissions of the role
+	Builtin     bool         `json:"builtin" example:"false"`                                               // Whether the role is built in
+}
+
+var ErrHouseholdRoleNotFound = errors.New("household role not found")
+var ErrHouseholdRoleExists = errors.New("household role already exists")
+var ErrHouseholdRoleInUse = errors.New("household role is in use")
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// PushSubscription represents a browser subscribed to the Web Push
+// notifications of a user. The endpoint is unique across users: a browser
+// that subscribes again, even for another user, replaces its subscription.
+type PushSubscription struct {
+	ID           int    `gorm:"primary_key"`
+	UserID       int    `gorm:"not null;index"`
+	Endpoint     string `gorm:"type:varchar(2048);not null"`
+	EndpointHash string `gorm:"type:char(64);uniqueIndex;not null"` // SHA-256 of Endpoint, as the endpoint is too long to index
+	P256dh       string `gorm:"type:varchar(128);not null"`         // Public key of the browser
+	Auth         string `gorm:"type:varchar(64);not null"`          // Authentication secret of the browser
+	UserAgent    string `gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	User         User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// VAPIDKey is the key pair that identifies the server to push services. It
+// is generated once and shared by every server replica, unless it is set
+// with the VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY environment variables.
+type VAPIDKey struct {
+	ID         int    `gorm:"primary_key;autoIncrement:false"`
+	PublicKey  string `gorm:"type:varchar(128);not null"`
+	PrivateKey string `gorm:"type:varchar(64);not null"`
+	CreatedAt  time.Time
+}
+
+// PushSubscriptionRequest is the JSON of a PushSubscription of the Push API,
+// as returned by PushSubscription.toJSON() in the browser.
+type PushSubscriptionRequest struct {
+	Endpoint string               `json:"endpoint" example:"https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."` // Push service URL
+	Keys     PushSubscriptionKeys `json:"keys"`
+}
+
+// PushSubscriptionKeys are the keys of a push subscription, base64url encoded.
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh" example:"BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"`
+	Auth   string `json:"auth" example:"BTBZMqHH6r4Tts7J_aSIgg"`
+}
+
+// PushUnsubscribeRequest represents the request structure for removing a push subscription.
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" example:"https://fcm.googleapis.com/fcm/send/c1KrmpTuRm..."` // Endpoint of the subscription to remove
+}
+
+// VAPIDPublicKeyResponse represents the key passed to PushManager.subscribe().
+type VAPIDPublicKeyResponse struct {
+	PublicKey string `json:"public_key" example:"BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"` // applicationServerKey, base64url encoded
+}
+
+var ErrPushSubscriptionNotFound = errors.New("push subscription not found")
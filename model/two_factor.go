@@ -0,0 +1,50 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// RecoveryCode represents a one-time code that can be used in place of a TOTP
+// code. Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        int        `gorm:"primary_key"`
+	UserID    int        `gorm:"not null;index"`
+	CodeHash  string     `gorm:"type:char(64);not null;index"`
+	UsedAt    *time.Time // Set when the code is used
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// TOTPEnrollResponse represents the secret to register in an authenticator app.
+type TOTPEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`                                                                  // Base32 encoded secret
+	OtpauthURL string `json:"otpauth_url" example:"otpauth://totp/RefrigeratorWatchdog:sample@gmail.com?secret=JBSWY3DPEHPK3PXP"` // URI to render as a QR code
+}
+
+// TOTPCodeRequest represents a request carrying a code from an authenticator app.
+type TOTPCodeRequest struct {
+	Code string `json:"code" example:"123456"` // 6-digit TOTP code
+}
+
+// RecoveryCodesResponse represents newly generated recovery codes. They are
+// shown only once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7m2-q9xd,p3nf-8w2c"` // One-time recovery codes
+}
+
+// TwoFactorLoginRequest represents the second step of a login.
+type TwoFactorLoginRequest struct {
+	MFAToken string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6..."` // Token returned by POST /users/login
+	Code     string `json:"code" example:"123456"`                               // TOTP code or recovery code
+}
+
+// TwoFactorDisableRequest represents the request structure for disabling two-factor authentication.
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" example:"password"` // Current password of the user
+}
+
+var ErrInvalidTOTPCode = errors.New("invalid two-factor code")
+var ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+var ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+var ErrTwoFactorNotEnrolled = errors.New("two-factor authentication enrollment has not been started")
@@ -7,20 +7,29 @@ import (
 
 // User represents a user in the system.
 type User struct {
-	ID        int       `json:"id" gorm:"primary_key" example:"1"`                                              // ID of the user
-	Username  string    `json:"username" gorm:"type:varchar(255);not null" example:"山田太郎"`                      // Username of the user
-	Email     string    `json:"email" gorm:"type:varchar(255);uniqueIndex;not null" example:"sample@gmail.com"` // Email of the user
-	Password  string    `json:"password" gorm:"type:varchar(255)" example:"password"`                           // Password of the user
-	CreatedAt time.Time `json:"created_at" example:"2024-09-25T11:46:43Z"`                                      // Creation timestamp
-	Foods     []Food    `gorm:"foreignKey:UserID"`                                                              // Foods associated with the user
+	ID                int        `json:"id" gorm:"primary_key" example:"1"`                                              // ID of the user
+	Username          string     `json:"username" gorm:"type:varchar(255);not null" example:"山田太郎"`                      // Username of the user
+	Email             string     `json:"email" gorm:"type:varchar(255);uniqueIndex;not null" example:"sample@gmail.com"` // Email of the user
+	Password          string     `json:"password" gorm:"type:varchar(255)" example:"password"`                           // Password of the user
+	CreatedAt         time.Time  `json:"created_at" example:"2024-09-25T11:46:43Z"`                                      // Creation timestamp
+	VerifiedAt        *time.Time `json:"-"`                                                                              // Time the email address was verified
+	TOTPSecret        string     `json:"-" gorm:"type:varchar(64)"`                                                      // Base32 TOTP secret, pending until TOTPEnabledAt is set
+	TOTPEnabledAt     *time.Time `json:"-"`                                                                              // Time two-factor authentication was enabled
+	TOTPLastCounter   int64      `json:"-" gorm:"not null;default:0"`                                                    // Time step of the last accepted TOTP code
+	ActiveHouseholdID *int       `json:"-"`                                                                              // Household the food endpoints operate on
+	TimeZone          string     `json:"time_zone" gorm:"type:varchar(64)" example:"Asia/Tokyo"`                         // IANA time zone the days until foods expire are counted in
+	Foods             []Food     `gorm:"foreignKey:UserID"`                                                              // Foods associated with the user
 }
 
 // UserResponse represents a response containing user information.
 type UserResponse struct {
-	ID        int       `json:"id" example:"1"`                            // ID of the user
-	Username  string    `json:"username" example:"山田太郎"`                   // Username of the user
-	Email     string    `json:"email" example:"sample@gmail.com"`          // Email of the user
-	CreatedAt time.Time `json:"created_at" example:"2024-09-25T11:46:43Z"` // Creation timestamp
+	ID               int        `json:"id" example:"1"`                             // ID of the user
+	Username         string     `json:"username" example:"山田太郎"`                    // Username of the user
+	Email            string     `json:"email" example:"sample@gmail.com"`           // Email of the user
+	CreatedAt        time.Time  `json:"created_at" example:"2024-09-25T11:46:43Z"`  // Creation timestamp
+	VerifiedAt       *time.Time `json:"verified_at" example:"2024-09-25T12:00:00Z"` // Time the email address was verified
+	TwoFactorEnabled bool       `json:"two_factor_enabled" example:"false"`         // Whether two-factor authentication is enabled
+	TimeZone         string     `json:"time_zone" example:"Asia/Tokyo"`             // IANA time zone; empty for the server default
 }
 
 // UserRequest represents the request structure for creating or updating a user.
@@ -28,6 +37,10 @@ type UserRequest struct {
 	Username string `json:"username" example:"山田太郎"`          // Username of the user
 	Email    string `json:"email" example:"sample@gmail.com"` // Email of the user
 	Password string `json:"password" example:"password"`      // Password of the user
+	TimeZone string `json:"time_zone" example:"Asia/Tokyo"`   // IANA time zone; optional
 }
 
 var ErrInvalidPassword = errors.New("invalid password")
+var ErrEmailNotVerified = errors.New("email address is not verified")
+var ErrEmailAlreadyVerified = errors.New("email address is already verified")
+var ErrTooManyRequests = errors.New("too many requests")
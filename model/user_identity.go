@@ -0,0 +1,66 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// UserIdentity links a user to an account at an OpenID Connect provider.
+// A user can have one identity per provider.
+type UserIdentity struct {
+	ID        int    `gorm:"primary_key"`
+	UserID    int    `gorm:"not null;uniqueIndex:idx_user_identities_user_provider"`
+	Provider  string `gorm:"type:varchar(32);not null;uniqueIndex:idx_user_identities_provider_subject;uniqueIndex:idx_user_identities_user_provider"`
+	Subject   string `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string `gorm:"type:varchar(255)"` // Email address reported by the provider at the last login
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// OIDCLoginState represents a login started with an OpenID Connect provider
+// and not completed yet. Only the SHA-256 hashes of the state and of the
+// binding cookie are stored; the nonce and PKCE code verifier never leave the
+// server.
+type OIDCLoginState struct {
+	ID           int       `gorm:"primary_key"`
+	Provider     string    `gorm:"type:varchar(32);not null"`
+	StateHash    string    `gorm:"type:char(64);uniqueIndex;not null"`
+	BindingHash  string    `gorm:"type:char(64);not null;default:''"` // Ties the login to the browser that started it
+	Nonce        string    `gorm:"type:varchar(64);not null"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time
+}
+
+// OIDCProviderResponse represents an OpenID Connect provider users can sign in with.
+type OIDCProviderResponse struct {
+	Name string `json:"name" example:"google"` // Name of the provider used in URLs
+}
+
+// OIDCAuthorizationResponse represents the response to start a login with an
+// OpenID Connect provider. The frontend keeps State and checks it against the
+// state returned to the redirect URI before posting the code.
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.google.com/o/oauth2/v2/auth?..."` // URL to send the user to
+	State            string `json:"state" example:"q8V2c1mX0kq3Jb9..."`                                           // State echoed back to the redirect URI
+	Binding          string `json:"-"`                                                                            // Sent as an HttpOnly cookie, never in the body
+}
+
+// OIDCCallbackRequest represents the request structure for completing a
+// login with the authorization code returned to the redirect URI.
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" example:"4/0AY0e-g7..."`       // Authorization code
+	State string `json:"state" example:"q8V2c1mX0kq3Jb9..."` // State returned with the code
+}
+
+// UserIdentityResponse represents a provider account linked to the user.
+type UserIdentityResponse struct {
+	Provider  string    `json:"provider" example:"google"`                 // Name of the provider
+	Email     string    `json:"email" example:"sample@gmail.com"`          // Email address at the provider
+	CreatedAt time.Time `json:"created_at" example:"2024-09-25T11:46:43Z"` // Time the identity was linked
+}
+
+var ErrOIDCProviderNotFound = errors.New("oidc provider not found")
+var ErrOIDCEmailNotVerified = errors.New("email address is not verified by the provider")
+var ErrUserIdentityNotFound = errors.New("identity not found")
+var ErrLastLoginMethod = errors.New("cannot remove the last way to log in")
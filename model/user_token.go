@@ -0,0 +1,38 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"     // Token emailed to reset a forgotten password
+	UserTokenPurposeEmailVerification = "email_verification" // Token emailed to verify the address on signup
+	UserTokenPurposeAccountUnlock     = "account_unlock"     // Token emailed to unlock an account after repeated login failures
+)
+
+// UserToken represents a single-use, time-limited token emailed to a user.
+// Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ID        int        `gorm:"primary_key"`
+	UserID    int        `gorm:"not null;index"`
+	Purpose   string     `gorm:"type:varchar(32);not null;index"`
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time // Set when the token is consumed or superseded
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// PasswordForgotRequest represents the request structure for requesting a password reset.
+type PasswordForgotRequest struct {
+	Email string `json:"email" example:"sample@gmail.com"` // Email of the user
+}
+
+// PasswordResetRequest represents the request structure for resetting a password.
+type PasswordResetRequest struct {
+	Token    string `json:"token" example:"q8V2c1mX0kq3Jb9..."` // Reset token received by email
+	Password string `json:"password" example:"newpassword"`     // New password
+}
+
+var ErrInvalidToken = errors.New("invalid or expired token")
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// WebhookEventType is the kind of event delivered to webhooks.
+type WebhookEventType string
+
+// Events delivered to webhooks.
+const (
+	WebhookEventFoodCreated  WebhookEventType = "food.created"  // A food was added
+	WebhookEventFoodUpdated  WebhookEventType = "food.updated"  // A food was changed, other than consumed
+	WebhookEventFoodConsumed WebhookEventType = "food.consumed" // The quantity of a food decreased
+	WebhookEventFoodDisposed WebhookEventType = "food.disposed" // A food was eaten, discarded or given away
+	WebhookEventFoodDeleted  WebhookEventType = "food.deleted"  // A food was moved to the trash
+	WebhookEventFoodRestored WebhookEventType = "food.restored" // A food was restored from the trash
+	WebhookEventFoodExpiring WebhookEventType = "food.expiring" // A food expires within a day
+	WebhookEventPing         WebhookEventType = "ping"          // Sent by the test endpoint only
+)
+
+// WebhookEventTypes lists the events that webhooks can subscribe to.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventFoodCreated,
+	WebhookEventFoodUpdated,
+	WebhookEventFoodConsumed,
+	WebhookEventFoodDisposed,
+	WebhookEventFoodDeleted,
+	WebhookEventFoodRestored,
+	WebhookEventFoodExpiring,
+}
+
+// Statuses of a webhook delivery.
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for the first attempt or a retry
+	WebhookDeliverySucceeded = "succeeded" // The endpoint responded with 2xx
+	WebhookDeliveryFailed    = "failed"    // Every attempt failed
+)
+
+// Webhook represents a subscription of a household to inventory events. The
+// secret is kept in plain text because it is needed to sign deliveries.
+type Webhook struct {
+	ID          int    `gorm:"primary_key"`
+	HouseholdID int    `gorm:"not null;index"`
+	URL         string `gorm:"type:varchar(2048);not null"`
+	Events      string `gorm:"type:varchar(255);not null"` // Comma-separated event types
+	Secret      string `gorm:"type:varchar(64);not null"`
+	CreatedBy   int    `gorm:"not null"` // User who created the webhook
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Household   Household `gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+}
+
+// EventList returns the events the webhook subscribes to.
+func (w Webhook) EventList() []WebhookEventType {
+	events := []WebhookEventType{}
+	for _, e := range strings.Split(w.Events, ",") {
+		if e != "" {
+			events = append(events, WebhookEventType(e))
+		}
+	}
+	return events
+}
+
+// Subscribes reports whether the webhook subscribes to the event.
+func (w Webhook) Subscribes(event WebhookEventType) bool {
+	for _, e := range w.EventList() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinWebhookEvents returns the column value of Webhook.Events.
+func JoinWebhookEvents(events []WebhookEventType) string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = string(e)
+	}
+	return strings.Join(s, ",")
+}
+
+// WebhookDelivery records an event queued for a webhook and the result of the
+// last attempt to deliver it. The unique index keeps an event from being
+// queued twice for the same webhook.
+type WebhookDelivery struct {
+	ID            int              `gorm:"primary_key"`
+	WebhookID     int              `gorm:"not null;uniqueIndex:idx_webhook_deliveries_webhook_event"`
+	EventID       string           `gorm:"type:varchar(64);not null;uniqueIndex:idx_webhook_deliveries_webhook_event"`
+	EventType     WebhookEventType `gorm:"type:varchar(32);not null"`
+	Payload       string           `gorm:"type:text;not null"` // JSON body, kept so that retries send the same bytes
+	Status        string           `gorm:"type:varchar(16);not null;index:idx_webhook_deliveries_due"`
+	Attempts      int              `gorm:"not null"`
+	NextAttemptAt time.Time        `gorm:"not null;index:idx_webhook_deliveries_due"`
+	ResponseCode  *int             // Status code of the last response, if any
+	Error         string           `gorm:"type:varchar(255);not null"` // Error of the last attempt
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"index"`
+	Webhook       Webhook   `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookEvent is the JSON body posted to webhooks.
+type WebhookEvent struct {
+	ID          string           `json:"id" example:"evt_q8V2c1mX0kq3Jb9"` // Unique ID of the event; the same event may be delivered more than once
+	Type        WebhookEventType `json:"type" swaggertype:"string" example:"food.created"`
+	HouseholdID int              `json:"household_id" example:"1"`
+	OccurredAt  time.Time        `json:"occurred_at" example:"2024-09-25T11:46:43Z"`
+	Data        interface{}      `json:"data"` // WebhookFoodData for food events
+}
+
+// WebhookFoodData is the data of food events.
+type WebhookFoodData struct {
+	Food             FoodResponse `json:"food"`
+	ActorID          int          `json:"actor_id,omitempty" example:"1"`          // User who made the change; unset for food.expiring
+	PreviousQuantity *float64     `json:"previous_quantity,omitempty" example:"6"` // Quantity before food.consumed
+	DaysRemaining    *int         `json:"days_remaining,omitempty" example:"1"`    // Days until the expiration date for food.expiring
+}
+
+// WebhookRequest represents the request structure for creating or updating a webhook.
+type WebhookRequest struct {
+	URL    string             `json:"url" example:"https://homeassistant.local/api/webhook/fridge"`           // Endpoint that receives the events
+	Events []WebhookEventType `json:"events" swaggertype:"array,string" example:"food.created,food.expiring"` // Events to deliver
+}
+
+// WebhookResponse represents a webhook without its secret.
+type WebhookResponse struct {
+	ID        int                `json:"id" example:"1"`
+	URL       string             `json:"url" example:"https://homeassistant.local/api/webhook/fridge"`
+	Events    []WebhookEventType `json:"events" swaggertype:"array,string" example:"food.created,food.expiring"`
+	CreatedBy int                `json:"created_by" example:"1"`
+	CreatedAt time.Time          `json:"created_at" example:"2024-09-25T11:46:43Z"`
+	UpdatedAt time.Time          `json:"updated_at" example:"2024-09-25T11:46:43Z"`
+}
+
+// WebhookCreatedResponse represents a newly created webhook. The secret is
+// returned only once.
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret" example:"whsec_q8V2c1mX0kq3Jb9..."` // Key of the X-Watchdog-Signature HMAC-SHA256 signatures
+}
+
+// WebhookDeliveryResponse represents a delivery in the delivery log.
+type WebhookDeliveryResponse struct {
+	ID            int              `json:"id" example:"1"`
+	EventID       string           `json:"event_id" example:"evt_q8V2c1mX0kq3Jb9"`
+	EventType     WebhookEventType `json:"event_type" swaggertype:"string" example:"food.created"`
+	Status        string           `json:"status" example:"succeeded"` // pending, succeeded or failed
+	Attempts      int              `json:"attempts" example:"1"`
+	ResponseCode  *int             `json:"response_code" example:"200"`                                 // Status code of the last response
+	Error         string           `json:"error,omitempty" example:"webhook responded with status 500"` // Error of the last attempt
+	CreatedAt     time.Time        `json:"created_at" example:"2024-09-25T11:46:43Z"`
+	DeliveredAt   *time.Time       `json:"delivered_at" example:"2024-09-25T11:46:44Z"`
+	NextAttemptAt *time.Time       `json:"next_attempt_at" example:"2024-09-25T11:47:14Z"` // Set while the delivery is pending
+}
+
+var ErrWebhookNotFound = errors.New("webhook not found")
@@ -0,0 +1,61 @@
+// Package netguard keeps requests to URLs chosen by users, such as webhooks
+// and push endpoints, from reaching the network the server runs in.
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a connection to a loopback, private,
+// link-local or unspecified address is refused.
+var ErrPrivateAddress = errors.New("connections to private addresses are not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not
+// reachable from the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Public reports whether the address is reachable from the internet, that is
+// not loopback, private, link-local, multicast or unspecified.
+func Public(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// control refuses to connect to addresses that are not public. It runs after
+// the host name has been resolved, so a name that resolves, or is rebound, to
+// an internal address is refused too.
+func control(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !Public(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// NewClient creates a client with the timeout that only connects to public
+// addresses, unless allowPrivate is true. Proxies from the environment are
+// not used since they would connect on the client's behalf.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = control
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
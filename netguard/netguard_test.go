@@ -0,0 +1,79 @@
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublic(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.0.0.1"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.10"},
+		{ip: "fd00::1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "224.0.0.1"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := Public(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("Public(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	// 名前解決した結果が内部のアドレスでも拒否する
+	byName := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "異常系：ループバックには接続しない", url: srv.URL, wantErr: true},
+		{name: "異常系：内部のアドレスに解決される名前には接続しない", url: byName, wantErr: true},
+		{name: "正常系：許可すればローカルネットワークに接続できる", url: srv.URL, allowPrivate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := NewClient(time.Second, tt.allowPrivate).Get(tt.url)
+			if tt.wantErr {
+				if !errors.Is(err, ErrPrivateAddress) {
+					t.Errorf("Client.Get() error = %v, want %v", err, ErrPrivateAddress)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Client.Get() error = %v", err)
+			}
+			res.Body.Close()
+			if res.StatusCode != http.StatusNoContent {
+				t.Errorf("Client.Get() status = %d", res.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package notifier
+
+type channelNotifier struct {
+	channels map[string]INotifier
+}
+
+// NewChannelNotifier creates a notifier that delivers every notification
+// through the notifiers of the channels listed in Notification.Channels, by
+// channel name. Like NewMultiNotifier, it fails only when all of them fail.
+// Channels without a notifier are ignored.
+func NewChannelNotifier(channels map[string]INotifier) INotifier {
+	return &channelNotifier{channels}
+}
+
+func (cn *channelNotifier) Notify(notification Notification) error {
+	notifiers := []INotifier{}
+	for _, channel := range notification.Channels {
+		if n, ok := cn.channels[channel]; ok {
+			notifiers = append(notifiers, n)
+		}
+	}
+	return NewMultiNotifier(notifiers...).Notify(notification)
+}
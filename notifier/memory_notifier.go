@@ -0,0 +1,33 @@
+package notifier
+
+import "sync"
+
+// MemoryNotifier keeps notifications in memory. It is intended for tests.
+type MemoryNotifier struct {
+	mu   sync.Mutex
+	sent []Notification
+	// Err is returned by Notify, without keeping the notification, when set.
+	Err error
+}
+
+// NewMemoryNotifier creates a new instance of the MemoryNotifier struct.
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (mn *MemoryNotifier) Notify(notification Notification) error {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	if mn.Err != nil {
+		return mn.Err
+	}
+	mn.sent = append(mn.sent, notification)
+	return nil
+}
+
+// Sent returns the notifications delivered so far.
+func (mn *MemoryNotifier) Sent() []Notification {
+	mn.mu.Lock()
+	defer mn.mu.Unlock()
+	return append([]Notification(nil), mn.sent...)
+}
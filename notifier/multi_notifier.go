@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"errors"
+	"log"
+)
+
+type multiNotifier struct {
+	notifiers []INotifier
+}
+
+// NewMultiNotifier creates a notifier that delivers every notification
+// through all of the notifiers. It fails only when all of them fail, so that
+// a notification delivered by one channel is not sent again through it
+// because another channel was down.
+func NewMultiNotifier(notifiers ...INotifier) INotifier {
+	return &multiNotifier{notifiers}
+}
+
+func (mn *multiNotifier) Notify(notification Notification) error {
+	errs := []error{}
+	for _, n := range mn.notifiers {
+		if err := n.Notify(notification); err != nil {
+			log.Println("failed to deliver notification:", err)
+			errs = append(errs, err)
+		}
+	}
+	if len(mn.notifiers) > 0 && len(errs) == len(mn.notifiers) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"RefrigeratorWatchdog-server/mailer"
+	"time"
+)
+
+// Kinds of notifications.
+const (
+	KindFoodExpiring = "food.expiring" // Foods reached one of the user's lead times
+	KindDigest       = "digest"        // Daily or weekly summary of the foods of the user
+)
+
+// ExpiringFood is a food in a KindFoodExpiring notification.
+type ExpiringFood struct {
+	FoodID         int
+	HouseholdID    int
+	Name           string
+	ExpirationDate time.Time
+	DaysRemaining  int // Days until the expiration date in the user's time zone
+}
+
+// Notification represents an alert for a user. Subject and Body are the
+// message for people; the other fields are for channels that deliver
+// structured data.
+type Notification struct {
+	UserID        int
+	Email         string
+	Kind          string
+	Subject       string
+	Body          string
+	HTML          string // HTML version of Body, for email
+	Summary       string // One line for channels with little space; Body otherwise
+	Foods         []ExpiringFood
+	Channels      []string // Channels to deliver through; see NewChannelNotifier
+	WebhookURL    string   // Webhook of the user, for the webhook channel
+	WebhookSecret string
+}
+
+// INotifier is an interface for delivering notifications to users.
+type INotifier interface {
+	Notify(notification Notification) error
+}
+
+type mailNotifier struct {
+	m mailer.IMailer
+}
+
+// NewMailNotifier creates a notifier that emails notifications to the users.
+func NewMailNotifier(m mailer.IMailer) INotifier {
+	return &mailNotifier{m}
+}
+
+func (mn *mailNotifier) Notify(notification Notification) error {
+	return mn.m.Send(mailer.Mail{
+		To:      notification.Email,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+		HTML:    notification.HTML,
+	})
+}
@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"RefrigeratorWatchdog-server/mailer"
+	"RefrigeratorWatchdog-server/netguard"
+	"RefrigeratorWatchdog-server/webhook"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_mailNotifier_Notify(t *testing.T) {
+	mm := mailer.NewMemoryMailer()
+	mn := NewMailNotifier(mm)
+
+	err := mn.Notify(Notification{UserID: 1, Email: "sample@test.com", Kind: KindFoodExpiring, Subject: "期限が近い食材があります", Body: "本文"})
+	if err != nil {
+		t.Fatalf("mailNotifier.Notify() error = %v", err)
+	}
+	sent := mm.Sent()
+	if len(sent) != 1 || sent[0].To != "sample@test.com" || sent[0].Subject != "期限が近い食材があります" || sent[0].Body != "本文" {
+		t.Errorf("mailNotifier.Notify() sent %v", sent)
+	}
+}
+
+func Test_MemoryNotifier_Notify(t *testing.T) {
+	mn := NewMemoryNotifier()
+	if err := mn.Notify(Notification{UserID: 1}); err != nil {
+		t.Fatalf("MemoryNotifier.Notify() error = %v", err)
+	}
+	mn.Err = errors.New("unavailable")
+	if err := mn.Notify(Notification{UserID: 2}); err == nil {
+		t.Errorf("MemoryNotifier.Notify() error = nil, want error")
+	}
+	if sent := mn.Sent(); len(sent) != 1 || sent[0].UserID != 1 {
+		t.Errorf("MemoryNotifier.Sent() = %v", sent)
+	}
+}
+
+func Test_multiNotifier_Notify(t *testing.T) {
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr bool
+	}{
+		{name: "正常系：すべての通知先に送る", errs: []error{nil, nil}},
+		{name: "正常系：一部の失敗はエラーにしない", errs: []error{errors.New("smtp unavailable"), nil}},
+		{name: "異常系：すべて失敗したらエラー", errs: []error{errors.New("smtp unavailable"), errors.New("push unavailable")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifiers := []INotifier{}
+			memories := []*MemoryNotifier{}
+			for _, err := range tt.errs {
+				mn := NewMemoryNotifier()
+				mn.Err = err
+				notifiers = append(notifiers, mn)
+				memories = append(memories, mn)
+			}
+
+			err := NewMultiNotifier(notifiers...).Notify(Notification{UserID: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("multiNotifier.Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, mn := range memories {
+				if sent := len(mn.Sent()); (tt.errs[i] == nil) != (sent == 1) {
+					t.Errorf("notifier %d sent %d", i, sent)
+				}
+			}
+		})
+	}
+}
+
+func Test_channelNotifier_Notify(t *testing.T) {
+	email, push := NewMemoryNotifier(), NewMemoryNotifier()
+	cn := NewChannelNotifier(map[string]INotifier{"email": email, "push": push})
+
+	if err := cn.Notify(Notification{UserID: 1, Channels: []string{"push", "webhook"}}); err != nil {
+		t.Fatalf("channelNotifier.Notify() error = %v", err)
+	}
+	if len(email.Sent()) != 0 || len(push.Sent()) != 1 {
+		t.Errorf("channelNotifier.Notify() sent email %v, push %v", email.Sent(), push.Sent())
+	}
+
+	push.Err = errors.New("push unavailable")
+	if err := cn.Notify(Notification{UserID: 1, Channels: []string{"push"}}); err == nil {
+		t.Errorf("channelNotifier.Notify() error = nil, want error")
+	}
+}
+
+func Test_webhookNotifier_Notify(t *testing.T) {
+	ms := webhook.NewMemorySender()
+	wn := NewWebhookNotifier(ms)
+
+	expires := time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)
+	err := wn.Notify(Notification{
+		UserID:        1,
+		Kind:          KindFoodExpiring,
+		Subject:       "期限が近い食材が1件あります",
+		Foods:         []ExpiringFood{{FoodID: 10, HouseholdID: 1, Name: "牛乳", ExpirationDate: expires, DaysRemaining: 1}},
+		WebhookURL:    "https://example.com/hooks/fridge",
+		WebhookSecret: "whsec_secret",
+	})
+	if err != nil {
+		t.Fatalf("webhookNotifier.Notify() error = %v", err)
+	}
+	sent := ms.Sent()
+	if len(sent) != 1 || sent[0].URL != "https://example.com/hooks/fridge" || sent[0].Secret != "whsec_secret" || sent[0].EventType != KindFoodExpiring {
+		t.Fatalf("webhookNotifier.Notify() sent %v", sent)
+	}
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(sent[0].Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := payload["data"].(map[string]interface{})
+	foods, _ := data["foods"].([]interface{})
+	if payload["id"] != sent[0].EventID || payload["user_id"] != 1.0 || len(foods) != 1 {
+		t.Errorf("payload = %s", sent[0].Payload)
+	}
+
+	if err := wn.Notify(Notification{UserID: 1, Kind: KindFoodExpiring}); err == nil {
+		t.Errorf("webhookNotifier.Notify() without URL error = nil, want error")
+	}
+}
+
+func Test_webhookNotifier_Notify_PrivateNetwork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("notification was posted to %s", r.URL)
+	}))
+	defer srv.Close()
+	wn := NewWebhookNotifier(webhook.NewHTTPSender(nil))
+
+	err := wn.Notify(Notification{UserID: 1, Kind: KindFoodExpiring, WebhookURL: srv.URL + "/hooks/fridge", WebhookSecret: "whsec_secret"})
+	if !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("webhookNotifier.Notify() error = %v, want %v", err, netguard.ErrPrivateAddress)
+	}
+}
@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"RefrigeratorWatchdog-server/webhook"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type webhookNotifier struct {
+	s webhook.ISender
+}
+
+// NewWebhookNotifier creates a notifier that posts notifications to the
+// webhook URL of the users, signed with their secrets like the webhooks of
+// households. Failed requests are not retried.
+// Use webhook.NewHTTPSender(nil) so that, like the webhooks of households,
+// notifications are only posted to public addresses.
+func NewWebhookNotifier(s webhook.ISender) INotifier {
+	return &webhookNotifier{s}
+}
+
+// webhookPayload is the JSON posted to the webhook of a user.
+type webhookPayload struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	UserID     int                 `json:"user_id"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	Data       webhookNotification `json:"data"`
+}
+
+type webhookNotification struct {
+	Subject string                `json:"subject"`
+	Body    string                `json:"body"`
+	Foods   []webhookExpiringFood `json:"foods,omitempty"`
+}
+
+type webhookExpiringFood struct {
+	FoodID         int       `json:"food_id"`
+	HouseholdID    int       `json:"household_id"`
+	Name           string    `json:"name"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	DaysRemaining  int       `json:"days_remaining"`
+}
+
+func (wn *webhookNotifier) Notify(notification Notification) error {
+	if notification.WebhookURL == "" {
+		return errors.New("the user has no webhook URL")
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	now := time.Now()
+	payload := webhookPayload{
+		ID:         "evt_" + base64.RawURLEncoding.EncodeToString(b),
+		Type:       notification.Kind,
+		UserID:     notification.UserID,
+		OccurredAt: now,
+		Data:       webhookNotification{Subject: notification.Subject, Body: notification.Body},
+	}
+	for _, food := range notification.Foods {
+		payload.Data.Foods = append(payload.Data.Foods, webhookExpiringFood(food))
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = wn.s.Send(webhook.Request{
+		URL:       notification.WebhookURL,
+		Secret:    notification.WebhookSecret,
+		EventID:   payload.ID,
+		EventType: payload.Type,
+		Payload:   body,
+		Timestamp: now,
+	})
+	return err
+}
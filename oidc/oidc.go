@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+const defaultScopes = "openid email profile"
+
+// Config represents the registration of this server as a client of an
+// OpenID Connect provider.
+type Config struct {
+	Name         string   // Name of the provider used in URLs, e.g. "google"
+	Issuer       string   // Issuer URL; the discovery document is read from Issuer + "/.well-known/openid-configuration"
+	ClientID     string   // Client ID issued by the provider
+	ClientSecret string   // Client secret issued by the provider
+	RedirectURL  string   // Redirect URI registered at the provider
+	Scopes       []string // Requested scopes; must include "openid"
+}
+
+// Claims represents the verified claims of an ID token.
+type Claims struct {
+	Subject       string // Identifier of the user at the provider
+	Email         string // Email address of the user
+	EmailVerified bool   // Whether the provider verified the email address
+	Name          string // Display name of the user
+}
+
+// IProvider is an interface for the authorization code flow with PKCE
+// against an OpenID Connect provider.
+type IProvider interface {
+	Name() string
+	// AuthCodeURL returns the URL of the provider the user is sent to.
+	AuthCodeURL(state string, nonce string, codeChallenge string) (string, error)
+	// Exchange redeems the authorization code and returns the claims of
+	// the verified ID token, which must carry nonce.
+	Exchange(code string, codeVerifier string, nonce string) (Claims, error)
+}
+
+var ErrInvalidIDToken = errors.New("invalid id token")
+var ErrTokenExchange = errors.New("failed to exchange authorization code")
+
+// CodeChallenge returns the S256 PKCE code challenge of the verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// LoadProviders creates the providers listed in OIDC_PROVIDERS, separated by
+// commas. A provider NAME is configured by OIDC_<NAME>_ISSUER,
+// OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and optionally
+// OIDC_<NAME>_REDIRECT_URL and OIDC_<NAME>_SCOPES. Providers without an
+// issuer or client ID are skipped.
+func LoadProviders() map[string]IProvider {
+	providers := map[string]IProvider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		cfg := Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			continue
+		}
+		if cfg.RedirectURL == "" {
+			cfg.RedirectURL = defaultRedirectURL(name)
+		}
+		providers[name] = NewProvider(cfg, nil)
+	}
+	return providers
+}
+
+// defaultRedirectURL returns the frontend page that receives the
+// authorization code and posts it to POST /users/oidc/{provider}/callback.
+func defaultRedirectURL(name string) string {
+	base := os.Getenv("FRONTEND_URL")
+	if base == "" {
+		base = "http://localhost:3000"
+	}
+	return base + "/oauth/callback/" + name
+}
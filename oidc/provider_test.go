@@ -0,0 +1,224 @@
+package oidc_test
+
+import (
+	"RefrigeratorWatchdog-server/oidc"
+	"RefrigeratorWatchdog-server/oidc/oidctest"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const redirectURL = "http://localhost:3000/oauth/callback/test"
+
+func Test_provider_AuthCodeURL(t *testing.T) {
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+	p := issuer.Provider("test", redirectURL)
+
+	got, err := p.AuthCodeURL("state", "nonce", "challenge")
+	if err != nil {
+		t.Fatalf("provider.AuthCodeURL() error = %v", err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "client",
+		"redirect_uri":          redirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "state",
+		"nonce":                 "nonce",
+		"code_challenge":        "challenge",
+		"code_challenge_method": "S256",
+	}
+	for k, v := range want {
+		if u.Query().Get(k) != v {
+			t.Errorf("provider.AuthCodeURL() %s = %q, want %q", k, u.Query().Get(k), v)
+		}
+	}
+	if u.Scheme+"://"+u.Host+u.Path != issuer.URL+"/authorize" {
+		t.Errorf("provider.AuthCodeURL() = %v", got)
+	}
+}
+
+func Test_provider_Exchange(t *testing.T) {
+	user := oidctest.User{Subject: "1234", Email: "sample@test.com", EmailVerified: true, Name: "山田太郎"}
+	verifier := "verifier-verifier-verifier-verifier-verifier"
+
+	tests := []struct {
+		name         string
+		clientSecret string
+		codeVerifier string
+		nonce        string
+		modifyClaims func(claims jwt.MapClaims)
+		wantErr      error
+	}{
+		{
+			name:         "正常系：IDトークンを検証してクレームを返す",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+		},
+		{
+			name:         "正常系：文字列の email_verified も受け付ける",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+			modifyClaims: func(claims jwt.MapClaims) { claims["email_verified"] = "true" },
+		},
+		{
+			name:         "異常系：code_verifier が一致しない",
+			clientSecret: "secret",
+			codeVerifier: "another-verifier",
+			nonce:        "nonce",
+			wantErr:      oidc.ErrTokenExchange,
+		},
+		{
+			name:         "異常系：クライアントシークレットが一致しない",
+			clientSecret: "wrong",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+			wantErr:      oidc.ErrTokenExchange,
+		},
+		{
+			name:         "異常系：nonce が一致しない",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "another-nonce",
+			wantErr:      oidc.ErrInvalidIDToken,
+		},
+		{
+			name:         "異常系：aud が別のクライアント",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+			modifyClaims: func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+			wantErr:      oidc.ErrInvalidIDToken,
+		},
+		{
+			name:         "異常系：iss が別の発行者",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+			modifyClaims: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+			wantErr:      oidc.ErrInvalidIDToken,
+		},
+		{
+			name:         "異常系：有効期限切れ",
+			clientSecret: "secret",
+			codeVerifier: verifier,
+			nonce:        "nonce",
+			modifyClaims: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr:      oidc.ErrInvalidIDToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := oidctest.NewIssuer("client", "secret")
+			defer issuer.Close()
+			issuer.ModifyClaims = tt.modifyClaims
+			p := oidc.NewProvider(oidc.Config{
+				Name:         "test",
+				Issuer:       issuer.URL,
+				ClientID:     "client",
+				ClientSecret: tt.clientSecret,
+				RedirectURL:  redirectURL,
+			}, nil)
+
+			authURL, err := p.AuthCodeURL("state", "nonce", oidc.CodeChallenge(verifier))
+			if err != nil {
+				t.Fatalf("provider.AuthCodeURL() error = %v", err)
+			}
+			code, _, err := issuer.Authorize(authURL, user)
+			if err != nil {
+				t.Fatalf("Issuer.Authorize() error = %v", err)
+			}
+
+			got, err := p.Exchange(code, tt.codeVerifier, tt.nonce)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("provider.Exchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want := oidc.Claims{Subject: "1234", Email: "sample@test.com", EmailVerified: true, Name: "山田太郎"}
+			if got != want {
+				t.Errorf("provider.Exchange() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_provider_Exchange_CodeIsSingleUse(t *testing.T) {
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+	p := issuer.Provider("test", redirectURL)
+	verifier := "verifier-verifier-verifier-verifier-verifier"
+
+	authURL, err := p.AuthCodeURL("state", "nonce", oidc.CodeChallenge(verifier))
+	if err != nil {
+		t.Fatalf("provider.AuthCodeURL() error = %v", err)
+	}
+	code, _, err := issuer.Authorize(authURL, oidctest.User{Subject: "1234"})
+	if err != nil {
+		t.Fatalf("Issuer.Authorize() error = %v", err)
+	}
+	if _, err := p.Exchange(code, verifier, "nonce"); err != nil {
+		t.Fatalf("provider.Exchange() error = %v", err)
+	}
+	if _, err := p.Exchange(code, verifier, "nonce"); !errors.Is(err, oidc.ErrTokenExchange) {
+		t.Errorf("provider.Exchange() reused code error = %v, want %v", err, oidc.ErrTokenExchange)
+	}
+}
+
+func Test_provider_Exchange_UnknownKeyID(t *testing.T) {
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+	p := issuer.Provider("test", redirectURL)
+	verifier := "verifier-verifier-verifier-verifier-verifier"
+
+	exchange := func() error {
+		authURL, err := p.AuthCodeURL("state", "nonce", oidc.CodeChallenge(verifier))
+		if err != nil {
+			t.Fatalf("provider.AuthCodeURL() error = %v", err)
+		}
+		code, _, err := issuer.Authorize(authURL, oidctest.User{Subject: "1234"})
+		if err != nil {
+			t.Fatalf("Issuer.Authorize() error = %v", err)
+		}
+		_, err = p.Exchange(code, verifier, "nonce")
+		return err
+	}
+
+	issuer.KeyID = "unknown-key"
+	for n := 0; n < 3; n++ {
+		if err := exchange(); !errors.Is(err, oidc.ErrInvalidIDToken) {
+			t.Fatalf("provider.Exchange() error = %v, want %v", err, oidc.ErrInvalidIDToken)
+		}
+	}
+	// 知らない kid が続いても JWKS は一定時間に一度しか取りに行かない
+	if got := issuer.JWKSRequests(); got != 1 {
+		t.Errorf("Issuer.JWKSRequests() = %d, want 1", got)
+	}
+
+	issuer.KeyID = ""
+	if err := exchange(); err != nil {
+		t.Errorf("provider.Exchange() error = %v", err)
+	}
+	if got := issuer.JWKSRequests(); got != 1 {
+		t.Errorf("Issuer.JWKSRequests() = %d, want 1", got)
+	}
+}
+
+func Test_CodeChallenge(t *testing.T) {
+	// RFC 7636 Appendix B
+	got := oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IAPIKeyRepository is an interface for managing device API keys.
+type IAPIKeyRepository interface {
+	CreateAPIKey(key *model.APIKey) error
+	GetAPIKeysByHouseholdID(keys *[]model.APIKey, householdID int) error
+	GetAPIKeyByHash(key *model.APIKey, keyHash string) error
+	RevokeAPIKey(householdID int, id int) error
+	TouchAPIKey(id int, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new instance of the apiKeyRepository struct.
+func NewAPIKeyRepository(db *gorm.DB) IAPIKeyRepository {
+	return &apiKeyRepository{db}
+}
+
+func (kr *apiKeyRepository) CreateAPIKey(key *model.APIKey) error {
+	if err := kr.db.Create(key).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (kr *apiKeyRepository) GetAPIKeysByHouseholdID(keys *[]model.APIKey, householdID int) error {
+	if err := kr.db.Where("household_id = ?", householdID).Order("created_at DESC, id DESC").Find(keys).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (kr *apiKeyRepository) GetAPIKeyByHash(key *model.APIKey, keyHash string) error {
+	if err := kr.db.Where("key_hash = ?", keyHash).First(key).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RevokeAPIKey returns model.ErrAPIKeyNotFound if the key does not exist in
+// the household or has already been revoked.
+func (kr *apiKeyRepository) RevokeAPIKey(householdID int, id int) error {
+	result := kr.db.Model(&model.APIKey{}).Where("household_id = ? AND id = ? AND revoked_at IS NULL", householdID, id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records the use of the key. To avoid a write on every request
+// the timestamp is only updated when it is older than a minute.
+func (kr *apiKeyRepository) TouchAPIKey(id int, usedAt time.Time) error {
+	return kr.db.Model(&model.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-time.Minute)).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	"gorm.io/gorm"
+)
+
+// IAuditLogRepository is an interface for the append-only audit log. It
+// deliberately has no methods to update or delete entries.
+type IAuditLogRepository interface {
+	CreateAuditLog(log *model.AuditLog) error
+	GetAuditLogs(logs *[]model.AuditLog, householdID *int, userID int, query model.AuditLogQuery, beforeID int) error
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new instance of the auditLogRepository struct.
+func NewAuditLogRepository(db *gorm.DB) IAuditLogRepository {
+	return &auditLogRepository{db}
+}
+
+func (ar *auditLogRepository) CreateAuditLog(log *model.AuditLog) error {
+	if err := ar.db.Create(log).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAuditLogs returns entries of the household and the account changes made
+// by the user, newest first. Only entries with an ID below beforeID are
+// returned when it is positive, and at most query.Limit entries.
+func (ar *auditLogRepository) GetAuditLogs(logs *[]model.AuditLog, householdID *int, userID int, query model.AuditLogQuery, beforeID int) error {
+	db := ar.db
+	if householdID != nil {
+		db = db.Where("(household_id = ? OR (household_id IS NULL AND actor_id = ?))", *householdID, userID)
+	} else {
+		db = db.Where("household_id IS NULL AND actor_id = ?", userID)
+	}
+	if query.EntityType != "" {
+		db = db.Where("entity_type = ?", query.EntityType)
+		if query.EntityID != "" {
+			db = db.Where("entity_id = ?", query.EntityID)
+		}
+	}
+	if query.ActorID != nil {
+		db = db.Where("actor_id = ?", *query.ActorID)
+	}
+	if query.Since != nil {
+		db = db.Where("created_at >= ?", *query.Since)
+	}
+	if beforeID > 0 {
+		db = db.Where("id < ?", beforeID)
+	}
+	if err := db.Order("id DESC").Limit(query.Limit).Find(logs).Error; err != nil {
+		return err
+	}
+	return nil
+}
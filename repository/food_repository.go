@@ -2,7 +2,10 @@ package repository
 
 import (
 	"RefrigeratorWatchdog-server/model"
-	"fmt"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -10,10 +13,24 @@ import (
 
 // IFoodRepository is an interface for managing food data.
 type IFoodRepository interface {
-	GetFoodsByUserID(foods *[]model.Food, userID uint) error
+	GetFoodsByHouseholdID(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) error
+	GetFoodByID(food *model.Food, id uint, householdID int) error
+	GetFoodsExpiringBetween(foods *[]model.Food, from time.Time, to time.Time) error
+	GetFoodsForDigest(foods *[]model.Food, householdIDs []int, from time.Time, to time.Time) error
 	CreateFood(food *model.Food) error
-	UpdateFood(food *model.Food, id uint) error
-	DeleteFood(id uint) error
+	UpdateFood(food *model.Food, id uint, householdID int, versions []int) error
+	PatchFood(food *model.Food, id uint, householdID int, fields []string, versions []int) error
+	ConsumeFood(food *model.Food, consumption *model.FoodConsumption) error
+	ArchiveFood(food *model.Food, id uint, householdID int, disposition string, archivedAt time.Time) error
+	GetWasteSummary(summary *model.WasteSummary, householdID int, from time.Time, to time.Time) error
+	GetWasteGroups(groups *[]model.WasteGroup, householdID int, groupBy string, from time.Time, to time.Time) error
+	GetTopWastedItems(items *[]model.WastedItem, householdID int, from time.Time, to time.Time, limit int) error
+	DeleteFood(id uint, householdID int, versions []int) error
+	GetTrashedFoods(foods *[]model.Food, householdID int) error
+	RestoreFood(food *model.Food, id uint, householdID int) error
+	GetFoodsTrashedBefore(foods *[]model.Food, before time.Time) error
+	PurgeFood(id int, trashedBefore time.Time) (bool, error)
+	IsImageInUse(imageURL string) (bool, error)
 }
 
 type foodRepository struct {
@@ -25,34 +42,420 @@ func NewFoodRepository(db *gorm.DB) IFoodRepository {
 	return &foodRepository{db}
 }
 
-func (fr *foodRepository) GetFoodsByUserID(foods *[]model.Food, userID uint) error {
-	if err := fr.db.Where("user_id = ?", userID).Find(&foods).Error; err != nil {
+// likeEscaper escapes the wildcards of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetFoodsByHouseholdID returns the foods of the household that match the
+// query, only the archived ones if query.Archived is set and only the active
+// ones otherwise, ordered by query.Sort and query.Order with the ID breaking ties.
+// Foods without an expiration date come last when sorted by it. When after is
+// not nil only the foods that follow it in that order are returned, and at
+// most query.Limit foods when it is positive.
+func (fr *foodRepository) GetFoodsByHouseholdID(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) error {
+	db := fr.db.Where("household_id = ?", householdID)
+	if query.Archived {
+		db = db.Where("archived_at IS NOT NULL")
+	} else {
+		db = db.Where("archived_at IS NULL")
+	}
+	if query.Disposition != "" {
+		db = db.Where("disposition = ?", query.Disposition)
+	}
+	if query.Tag != "" {
+		db = db.Where("tag = ?", query.Tag)
+	}
+	if query.Name != "" {
+		db = db.Where("name LIKE ?", "%"+likeEscaper.Replace(query.Name)+"%")
+	}
+	if query.ExpiresBefore != nil {
+		db = db.Where("expiration_date < ?", *query.ExpiresBefore)
+	}
+	if query.ExpiresAfter != nil {
+		db = db.Where("expiration_date >= ?", *query.ExpiresAfter)
+	}
+	if query.NoExpirationDate {
+		db = db.Where("expiration_date IS NULL")
+	}
+
+	op, dir := ">", "ASC"
+	if query.Order == model.SortOrderDesc {
+		op, dir = "<", "DESC"
+	}
+	// 世帯IDと並び替えの列の複合インデックスを使う。InnoDB のセカンダリインデックスは主キーを含むため、IDでの並び替えにも使える
+	switch query.Sort {
+	case model.FoodSortExpirationDate:
+		if after != nil {
+			if after.ExpirationDate == nil {
+				db = db.Where("expiration_date IS NULL AND id "+op+" ?", after.ID)
+			} else {
+				db = db.Where("(expiration_date IS NULL OR expiration_date "+op+" ? OR (expiration_date = ? AND id "+op+" ?))", *after.ExpirationDate, *after.ExpirationDate, after.ID)
+			}
+		}
+		db = db.Order("expiration_date IS NULL").Order("expiration_date " + dir)
+	case model.FoodSortCreatedAt:
+		if after != nil {
+			db = db.Where("(created_at "+op+" ? OR (created_at = ? AND id "+op+" ?))", after.CreatedAt, after.CreatedAt, after.ID)
+		}
+		db = db.Order("created_at " + dir)
+	case model.FoodSortName:
+		if after != nil {
+			db = db.Where("(name "+op+" ? OR (name = ? AND id "+op+" ?))", after.Name, after.Name, after.ID)
+		}
+		db = db.Order("name " + dir)
+	case model.FoodSortArchivedAt:
+		if after != nil && after.ArchivedAt != nil {
+			db = db.Where("(archived_at "+op+" ? OR (archived_at = ? AND id "+op+" ?))", *after.ArchivedAt, *after.ArchivedAt, after.ID)
+		}
+		db = db.Order("archived_at " + dir)
+	default:
+		if after != nil {
+			db = db.Where("id "+op+" ?", after.ID)
+		}
+	}
+	db = db.Order("id " + dir)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	if err := db.Find(foods).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fr *foodRepository) CreateFood(food *model.Food) error {
-	if err := fr.db.Create(food).Error; err != nil {
+// GetFoodByID returns model.ErrFoodNotFound if the food does not exist and
+// model.ErrForbidden if it belongs to another household.
+func (fr *foodRepository) GetFoodByID(food *model.Food, id uint, householdID int) error {
+	if err := fr.db.Where("id = ? AND household_id = ?", id, householdID).First(food).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fr.checkFoodHousehold(fr.db, id, householdID)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetFoodsExpiringBetween returns the foods of every household that expire
+// at or after from and before to, ordered by household and expiration date.
+// Archived foods are left out.
+func (fr *foodRepository) GetFoodsExpiringBetween(foods *[]model.Food, from time.Time, to time.Time) error {
+	if err := fr.db.Where("expiration_date >= ? AND expiration_date < ? AND archived_at IS NULL", from, to).Order("household_id, expiration_date, id").Find(foods).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFoodsForDigest returns the foods of the households that expire or were
+// added at or after from and before to, ordered by expiration date. Archived
+// foods are left out.
+func (fr *foodRepository) GetFoodsForDigest(foods *[]model.Food, householdIDs []int, from time.Time, to time.Time) error {
+	if len(householdIDs) == 0 {
+		return nil
+	}
+	if err := fr.db.Where("household_id IN ? AND archived_at IS NULL", householdIDs).
+		Where(fr.db.Where("expiration_date >= ? AND expiration_date < ?", from, to).Or("created_at >= ? AND created_at < ?", from, to)).
+		Order("expiration_date IS NULL, expiration_date, id").Find(foods).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fr *foodRepository) UpdateFood(food *model.Food, id uint) error {
-	if err := fr.db.Model(food).Clauses(clause.Returning{}).Where("id = ?", id).Updates(food).Error; err != nil {
+func (fr *foodRepository) CreateFood(food *model.Food) error {
+	if err := fr.db.Create(food).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (fr *foodRepository) DeleteFood(id uint) error {
-	result := fr.db.Where("id = ?", id).Delete(&model.Food{})
+// UpdateFood writes the non-zero fields of food to the active food. Unless
+// versions is nil, the food must have one of them, or
+// model.ErrFoodVersionMismatch is returned.
+func (fr *foodRepository) UpdateFood(food *model.Food, id uint, householdID int, versions []int) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := fr.incrementVersion(tx, id, householdID, versions); err != nil {
+			return err
+		}
+		if err := fr.checkActiveFood(tx, id, householdID); err != nil {
+			return err
+		}
+		return tx.Model(food).Clauses(clause.Returning{}).Where("id = ? AND household_id = ?", id, householdID).Updates(food).Error
+	})
+}
+
+// PatchFood writes the given columns of food to the active food, zero values
+// and NULL included, unlike UpdateFood which skips them. Unless versions is
+// nil, the food must have one of them, or model.ErrFoodVersionMismatch is
+// returned.
+func (fr *foodRepository) PatchFood(food *model.Food, id uint, householdID int, fields []string, versions []int) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := fr.incrementVersion(tx, id, householdID, versions); err != nil {
+			return err
+		}
+		if err := fr.checkActiveFood(tx, id, householdID); err != nil {
+			return err
+		}
+		return tx.Model(&model.Food{}).Where("id = ? AND household_id = ?", id, householdID).Select(fields).Updates(food).Error
+	})
+}
+
+// incrementVersion increments the version of the food by a conditional
+// UPDATE, which also locks the row until the transaction ends. Unless versions
+// is nil, only a food with one of them is updated, and
+// model.ErrFoodVersionMismatch is returned if the food has another version.
+func (fr *foodRepository) incrementVersion(tx *gorm.DB, id uint, householdID int, versions []int) error {
+	query := tx.Model(&model.Food{}).Where("id = ? AND household_id = ?", id, householdID)
+	if versions != nil {
+		query = query.Where("version IN ?", versions)
+	}
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected < 1 {
-		return fmt.Errorf("record not found")
+		if err := fr.checkFoodHousehold(tx, id, householdID); err != nil {
+			return err
+		}
+		return model.ErrFoodVersionMismatch
 	}
 	return nil
 }
+
+// ConsumeFood subtracts consumption.Amount from the quantity of the food
+// consumption.FoodID of consumption.HouseholdID and records the consumption,
+// archiving the food as eaten at consumption.ConsumedAt when none is left. The
+// quantity is decremented by a conditional UPDATE so that concurrent
+// consumptions are not lost. food is set to the food after the consumption.
+// It returns model.ErrInsufficientQuantity if less than the amount is left
+// and model.ErrFoodArchived if the food is archived.
+func (fr *foodRepository) ConsumeFood(food *model.Food, consumption *model.FoodConsumption) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Food{}).
+			Where("id = ? AND household_id = ? AND archived_at IS NULL AND quantity >= ?", consumption.FoodID, consumption.HouseholdID, consumption.Amount).
+			UpdateColumns(map[string]interface{}{"quantity": gorm.Expr("quantity - ?", consumption.Amount), "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			if err := fr.checkActiveFood(tx, uint(consumption.FoodID), consumption.HouseholdID); err != nil {
+				return err
+			}
+			return model.ErrInsufficientQuantity
+		}
+		// 行は更新によってロックされているので、残りが0なら他の消費と競合せずにアーカイブできる
+		if err := tx.Model(&model.Food{}).Where("id = ? AND quantity <= 0", consumption.FoodID).
+			Updates(map[string]interface{}{"archived_at": consumption.ConsumedAt, "disposition": model.DispositionEaten}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", consumption.FoodID).First(food).Error; err != nil {
+			return err
+		}
+		consumption.RemainingQuantity = food.Quantity
+		return tx.Create(consumption).Error
+	})
+}
+
+// ArchiveFood archives the active food with the disposition, keeping its
+// remaining quantity, and sets food to the archived food. It returns
+// model.ErrFoodArchived if the food is already archived.
+func (fr *foodRepository) ArchiveFood(food *model.Food, id uint, householdID int, disposition string, archivedAt time.Time) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Food{}).Where("id = ? AND household_id = ? AND archived_at IS NULL", id, householdID).
+			Updates(map[string]interface{}{"archived_at": archivedAt, "disposition": disposition, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			if err := fr.checkActiveFood(tx, id, householdID); err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", id).First(food).Error
+	})
+}
+
+// wastedCondition selects the archived foods that were wasted.
+var wastedCondition = "disposition IN ('" + strings.Join(model.WastedDispositions, "', '") + "')"
+
+// wastedPriceColumn is the price of the quantity left of a wasted food, and 0
+// for other foods. The price is for the quantity added, which is the
+// quantity left plus the consumed quantities; the whole price is wasted when
+// that is 0.
+var wastedPriceColumn = "CASE WHEN " + wastedCondition + " AND price IS NOT NULL THEN price * COALESCE(quantity / NULLIF(quantity + " +
+	"(SELECT COALESCE(SUM(amount), 0) FROM food_consumptions WHERE food_consumptions.food_id = foods.id), 0), 1) ELSE 0 END"
+
+// wasteColumns are the statistics of WasteGroup and WasteSummary.
+var wasteColumns = "COUNT(*) AS total, " +
+	"COALESCE(SUM(" + wastedCondition + "), 0) AS wasted, " +
+	"COALESCE(SUM(" + wastedCondition + ") / COUNT(*), 0) AS waste_rate, " +
+	"COALESCE(SUM(" + wastedPriceColumn + "), 0) AS money_wasted"
+
+// archivedBetween selects the foods of the household archived at or after
+// from and before to.
+func (fr *foodRepository) archivedBetween(householdID int, from time.Time, to time.Time) *gorm.DB {
+	return fr.db.Model(&model.Food{}).Where("household_id = ? AND archived_at >= ? AND archived_at < ?", householdID, from, to)
+}
+
+// GetWasteSummary aggregates the foods of the household archived at or after
+// from and before to.
+func (fr *foodRepository) GetWasteSummary(summary *model.WasteSummary, householdID int, from time.Time, to time.Time) error {
+	columns := wasteColumns +
+		", COALESCE(SUM(" + wastedCondition + " AND price IS NOT NULL), 0) AS priced_wasted" +
+		", AVG(CASE WHEN disposition = ? THEN TIMESTAMPDIFF(SECOND, created_at, archived_at) END) / 86400 AS average_days_to_consumption"
+	if err := fr.archivedBetween(householdID, from, to).Select(columns, model.DispositionEaten).Scan(summary).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetWasteGroups aggregates the foods of the household archived at or after
+// from and before to by model.WasteGroupTag, model.WasteGroupStorageLocation
+// or model.WasteGroupMonth, ordered by the key. Months are counted in the
+// time zone of from.
+func (fr *foodRepository) GetWasteGroups(groups *[]model.WasteGroup, householdID int, groupBy string, from time.Time, to time.Time) error {
+	db := fr.archivedBetween(householdID, from, to)
+	switch groupBy {
+	case model.WasteGroupTag:
+		db = db.Select("tag AS `key`, " + wasteColumns)
+	case model.WasteGroupStorageLocation:
+		db = db.Select("storage_location AS `key`, " + wasteColumns)
+	case model.WasteGroupMonth:
+		// タイムゾーンのテーブルがなくても動くように、期間の始めのUTCからの時差で月を数える
+		_, offset := from.Zone()
+		db = db.Select("DATE_FORMAT(DATE_ADD(archived_at, INTERVAL ? SECOND), '%Y-%m') AS `key`, "+wasteColumns, offset)
+	default:
+		return errors.New("unknown waste group " + groupBy)
+	}
+	if err := db.Group("`key`").Order("`key`").Scan(groups).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTopWastedItems returns the names of the foods of the household wasted
+// most often among those archived at or after from and before to, with the
+// most money wasted breaking ties.
+func (fr *foodRepository) GetTopWastedItems(items *[]model.WastedItem, householdID int, from time.Time, to time.Time, limit int) error {
+	if err := fr.archivedBetween(householdID, from, to).Where(wastedCondition).
+		Select("name, COUNT(*) AS wasted, COALESCE(SUM(" + wastedPriceColumn + "), 0) AS money_wasted").
+		Group("name").Order("wasted DESC, money_wasted DESC, name").Limit(limit).
+		Scan(items).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkActiveFood returns model.ErrFoodNotFound if the food does not exist,
+// model.ErrForbidden if it belongs to another household and
+// model.ErrFoodArchived if it is archived.
+func (fr *foodRepository) checkActiveFood(tx *gorm.DB, id uint, householdID int) error {
+	food := model.Food{}
+	if err := tx.Select("id", "household_id", "archived_at").Where("id = ?", id).First(&food).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrFoodNotFound
+		}
+		return err
+	}
+	switch {
+	case food.HouseholdID != householdID:
+		return model.ErrForbidden
+	case food.ArchivedAt != nil:
+		return model.ErrFoodArchived
+	}
+	return nil
+}
+
+// DeleteFood moves the food to the trash. Unless versions is nil, the food
+// must have one of them, or model.ErrFoodVersionMismatch is returned.
+func (fr *foodRepository) DeleteFood(id uint, householdID int, versions []int) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		if err := fr.incrementVersion(tx, id, householdID, versions); err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND household_id = ?", id, householdID).Delete(&model.Food{}).Error
+	})
+}
+
+// checkFoodHousehold returns model.ErrFoodNotFound if the food does not exist
+// and model.ErrForbidden if it belongs to another household.
+func (fr *foodRepository) checkFoodHousehold(tx *gorm.DB, id uint, householdID int) error {
+	food := model.Food{}
+	if err := tx.Select("id", "household_id").Where("id = ?", id).First(&food).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrFoodNotFound
+		}
+		return err
+	}
+	if food.HouseholdID != householdID {
+		return model.ErrForbidden
+	}
+	return nil
+}
+
+// GetTrashedFoods returns the foods of the household in the trash, most
+// recently deleted first.
+func (fr *foodRepository) GetTrashedFoods(foods *[]model.Food, householdID int) error {
+	if err := fr.db.Unscoped().Where("household_id = ? AND deleted_at IS NOT NULL", householdID).Order("deleted_at DESC, id DESC").Find(foods).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreFood moves the food out of the trash and sets food to it. It
+// returns model.ErrFoodNotTrashed if the food is not in the trash.
+func (fr *foodRepository) RestoreFood(food *model.Food, id uint, householdID int) error {
+	return fr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&model.Food{}).Where("id = ? AND household_id = ? AND deleted_at IS NOT NULL", id, householdID).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			trashed := model.Food{}
+			if err := tx.Unscoped().Select("id", "household_id", "deleted_at").Where("id = ?", id).First(&trashed).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return model.ErrFoodNotFound
+				}
+				return err
+			}
+			if trashed.HouseholdID != householdID {
+				return model.ErrForbidden
+			}
+			return model.ErrFoodNotTrashed
+		}
+		return tx.Where("id = ?", id).First(food).Error
+	})
+}
+
+// GetFoodsTrashedBefore returns the foods of every household moved to the
+// trash before the time.
+func (fr *foodRepository) GetFoodsTrashedBefore(foods *[]model.Food, before time.Time) error {
+	if err := fr.db.Unscoped().Where("deleted_at < ?", before).Order("id").Find(foods).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// PurgeFood permanently deletes the food if it is still in the trash since
+// before trashedBefore, and reports whether it did. Its consumptions are
+// deleted with it.
+func (fr *foodRepository) PurgeFood(id int, trashedBefore time.Time) (bool, error) {
+	result := fr.db.Unscoped().Where("id = ? AND deleted_at < ?", id, trashedBefore).Delete(&model.Food{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// IsImageInUse reports whether any food, including those in the trash, has
+// the image, whatever directory its image URL names.
+func (fr *foodRepository) IsImageInUse(imageURL string) (bool, error) {
+	var count int64
+	// 同じファイルを指す表記の違い（images/ の有無など）も使用中とみなす
+	filename := filepath.Base(imageURL)
+	if err := fr.db.Unscoped().Model(&model.Food{}).
+		Where("image_url = ? OR image_url LIKE ?", filename, "%/"+likeEscaper.Replace(filename)).
+		Limit(1).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
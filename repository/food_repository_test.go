@@ -10,25 +10,29 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func Test_foodRepository_GetFoodsByUserID(t *testing.T) {
+
+var expirationDate = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_foodRepository_GetFoodsByHouseholdID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	// モックリポジトリの作成
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
 
-	UserID := uint(1)
+	HouseholdID := 1
 
 	type args struct {
-		foods  *[]model.Food
-		userID uint
+		foods       *[]model.Food
+		householdID int
+		query       model.FoodQuery
 	}
 	tests := []struct {
 		name    string
 		args    args
 		wantErr bool
 	}{
-		{name: "正常系：ユーザーIDに紐づく食材を取得できる",
+		{name: "正常系：世帯IDに紐づく食材を取得できる",
 			args: args{
 				foods: &[]model.Food{
 					{
@@ -38,7 +42,7 @@ func Test_foodRepository_GetFoodsByUserID(t *testing.T) {
 						OriginalCode:   123,
 						Quantity:       1,
 						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpirationDate: &expirationDate,
 						ImageURL:       "https://example.com",
 						Memo:           "memo",
 					},
@@ -49,26 +53,27 @@ func Test_foodRepository_GetFoodsByUserID(t *testing.T) {
 						OriginalCode:   123,
 						Quantity:       1,
 						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						ExpirationDate: &expirationDate,
 						ImageURL:       "https://example.com",
 						Memo:           "memo",
 					},
 				},
-				userID: UserID,
+				householdID: HouseholdID,
+				query:       model.FoodQuery{Sort: model.FoodSortExpirationDate, Order: model.SortOrderAsc, Limit: 51},
 			},
 			wantErr: false,
 		},
-		{name: "異常系：ユーザーIDに紐づく食材が取得できない",
+		{name: "異常系：世帯IDに紐づく食材が取得できない",
 			args: args{
-				foods:  &[]model.Food{},
-				userID: 0,
+				foods:       &[]model.Food{},
+				householdID: 0,
 			},
 			wantErr: true,
 		},
 		{name: "異常系：引数のfoodsがnilの場合",
 			args: args{
-				foods:  nil,
-				userID: UserID,
+				foods:       nil,
+				householdID: HouseholdID,
 			},
 			wantErr: true,
 		},
@@ -78,14 +83,14 @@ func Test_foodRepository_GetFoodsByUserID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			if tt.wantErr {
-				mockRepo.EXPECT().GetFoodsByUserID(tt.args.foods, tt.args.userID).Return(errors.New("error"))
+				mockRepo.EXPECT().GetFoodsByHouseholdID(tt.args.foods, tt.args.householdID, tt.args.query, nil).Return(errors.New("error"))
 
 			} else {
-				mockRepo.EXPECT().GetFoodsByUserID(tt.args.foods, tt.args.userID).Return(nil)
+				mockRepo.EXPECT().GetFoodsByHouseholdID(tt.args.foods, tt.args.householdID, tt.args.query, nil).Return(nil)
 			}
 
-			if err := mockRepo.GetFoodsByUserID(tt.args.foods, tt.args.userID); (err != nil) != tt.wantErr {
-				t.Errorf("foodRepository.GetFoodsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+			if err := mockRepo.GetFoodsByHouseholdID(tt.args.foods, tt.args.householdID, tt.args.query, nil); (err != nil) != tt.wantErr {
+				t.Errorf("foodRepository.GetFoodsByHouseholdID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -115,7 +120,7 @@ func Test_foodRepository_CreateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
@@ -155,8 +160,9 @@ func Test_foodRepository_UpdateFood(t *testing.T) {
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
 
 	type args struct {
-		food *model.Food
-		id   uint
+		food        *model.Food
+		id          uint
+		householdID int
 	}
 	tests := []struct {
 		name    string
@@ -172,18 +178,31 @@ func Test_foodRepository_UpdateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
-				id: 1,
+				id:          1,
+				householdID: 1,
 			},
 			wantErr: false,
 		},
 		{name: "異常系：引数のfoodがnilの場合",
 			args: args{
-				food: nil,
-				id:   1,
+				food:        nil,
+				id:          1,
+				householdID: 1,
+			},
+			wantErr: true,
+		},
+		{name: "異常系：他の世帯の食材は更新できない",
+			args: args{
+				food: &model.Food{
+					Name:   "food1",
+					UserID: 2,
+				},
+				id:          1,
+				householdID: 2,
 			},
 			wantErr: true,
 		},
@@ -193,13 +212,13 @@ func Test_foodRepository_UpdateFood(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			if tt.wantErr {
-				mockRepo.EXPECT().UpdateFood(tt.args.food, tt.args.id).Return(errors.New("error"))
+				mockRepo.EXPECT().UpdateFood(tt.args.food, tt.args.id, tt.args.householdID, nil).Return(model.ErrForbidden)
 
 			} else {
-				mockRepo.EXPECT().UpdateFood(tt.args.food, tt.args.id).Return(nil)
+				mockRepo.EXPECT().UpdateFood(tt.args.food, tt.args.id, tt.args.householdID, nil).Return(nil)
 			}
 
-			if err := mockRepo.UpdateFood(tt.args.food, tt.args.id); (err != nil) != tt.wantErr {
+			if err := mockRepo.UpdateFood(tt.args.food, tt.args.id, tt.args.householdID, nil); (err != nil) != tt.wantErr {
 				t.Errorf("foodRepository.UpdateFood() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -214,7 +233,8 @@ func Test_foodRepository_DeleteFood(t *testing.T) {
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
 
 	type args struct {
-		id uint
+		id          uint
+		householdID int
 	}
 	tests := []struct {
 		name    string
@@ -223,13 +243,22 @@ func Test_foodRepository_DeleteFood(t *testing.T) {
 	}{
 		{name: "正常系：食材を削除できる",
 			args: args{
-				id: 1,
+				id:          1,
+				householdID: 1,
 			},
 			wantErr: false,
 		},
 		{name: "異常系：idが存在しない場合",
 			args: args{
-				id: 0,
+				id:          0,
+				householdID: 1,
+			},
+			wantErr: true,
+		},
+		{name: "異常系：他の世帯の食材は削除できない",
+			args: args{
+				id:          1,
+				householdID: 2,
 			},
 			wantErr: true,
 		},
@@ -238,13 +267,13 @@ func Test_foodRepository_DeleteFood(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			if tt.wantErr {
-				mockRepo.EXPECT().DeleteFood(tt.args.id).Return(errors.New("error"))
+				mockRepo.EXPECT().DeleteFood(tt.args.id, tt.args.householdID, nil).Return(errors.New("error"))
 
 			} else {
-				mockRepo.EXPECT().DeleteFood(tt.args.id).Return(nil)
+				mockRepo.EXPECT().DeleteFood(tt.args.id, tt.args.householdID, nil).Return(nil)
 			}
 
-			if err := mockRepo.DeleteFood(tt.args.id); (err != nil) != tt.wantErr {
+			if err := mockRepo.DeleteFood(tt.args.id, tt.args.householdID, nil); (err != nil) != tt.wantErr {
 				t.Errorf("foodRepository.DeleteFood() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IHouseholdInvitationRepository is an interface for managing household invitations.
+type IHouseholdInvitationRepository interface {
+	CreateInvitation(invitation *model.HouseholdInvitation) error
+	GetInvitationByHash(invitation *model.HouseholdInvitation, tokenHash string) error
+	AcceptInvitation(id int, member *model.HouseholdMember) error
+	DeclineInvitation(id int) error
+}
+
+type householdInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewHouseholdInvitationRepository creates a new instance of the householdInvitationRepository struct.
+func NewHouseholdInvitationRepository(db *gorm.DB) IHouseholdInvitationRepository {
+	return &householdInvitationRepository{db}
+}
+
+func (ir *householdInvitationRepository) CreateInvitation(invitation *model.HouseholdInvitation) error {
+	if err := ir.db.Create(invitation).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ir *householdInvitationRepository) GetInvitationByHash(invitation *model.HouseholdInvitation, tokenHash string) error {
+	if err := ir.db.Preload("Household").Where("token_hash = ?", tokenHash).First(invitation).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation marks a pending invitation as accepted, adds the member
+// and makes the household the member's active household in one transaction.
+func (ir *householdInvitationRepository) AcceptInvitation(id int, member *model.HouseholdMember) error {
+	return ir.db.Transaction(func(tx *gorm.DB) error {
+		if err := respondInvitation(tx, id, "accepted_at"); err != nil {
+			return err
+		}
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", member.UserID).Update("active_household_id", member.HouseholdID).Error
+	})
+}
+
+func (ir *householdInvitationRepository) DeclineInvitation(id int) error {
+	return respondInvitation(ir.db, id, "declined_at")
+}
+
+// respondInvitation sets the column only if the invitation is still pending,
+// so that an invitation cannot be used twice.
+func respondInvitation(db *gorm.DB, id int, column string) error {
+	result := db.Model(&model.HouseholdInvitation{}).Where("id = ? AND accepted_at IS NULL AND declined_at IS NULL", id).Update(column, time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	"gorm.io/gorm"
+)
+
+// IHouseholdRepository is an interface for managing households and their members.
+type IHouseholdRepository interface {
+	CreateHousehold(household *model.Household, ownerID int) error
+	UpdateHousehold(household *model.Household) error
+	GetMembership(member *model.HouseholdMember, householdID int, userID int) error
+	GetMembershipsByUserID(members *[]model.HouseholdMember, userID int) error
+	GetMembersByHouseholdID(members *[]model.HouseholdMember, householdID int) error
+	AddMember(member *model.HouseholdMember) error
+	UpdateMemberRole(householdID int, userID int, role string) error
+	RemoveMember(householdID int, userID int) error
+	CountOwners(householdID int) (int64, error)
+	SetActiveHousehold(userID int, householdID *int) error
+	ShareHousehold(userID int, otherUserID int) (bool, error)
+}
+
+type householdRepository struct {
+	db *gorm.DB
+}
+
+// NewHouseholdRepository creates a new instance of the householdRepository struct.
+func NewHouseholdRepository(db *gorm.DB) IHouseholdRepository {
+	return &householdRepository{db}
+}
+
+// CreateHousehold creates the household, adds ownerID as its owner and makes
+// it the owner's active household.
+func (hr *householdRepository) CreateHousehold(household *model.Household, ownerID int) error {
+	return hr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(household).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.HouseholdMember{
+			HouseholdID: household.ID,
+			UserID:      ownerID,
+			Role:        model.HouseholdRoleOwner,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", ownerID).Update("active_household_id", household.ID).Error
+	})
+}
+
+func (hr *householdRepository) UpdateHousehold(household *model.Household) error {
+	result := hr.db.Model(&model.Household{}).Where("id = ?", household.ID).Update("name", household.Name)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrHouseholdNotFound
+	}
+	return nil
+}
+
+func (hr *householdRepository) GetMembership(member *model.HouseholdMember, householdID int, userID int) error {
+	if err := hr.db.Preload("Household").Where("household_id = ? AND user_id = ?", householdID, userID).First(member).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (hr *householdRepository) GetMembershipsByUserID(members *[]model.HouseholdMember, userID int) error {
+	if err := hr.db.Preload("Household").Where("user_id = ?", userID).Order("created_at, id").Find(members).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (hr *householdRepository) GetMembersByHouseholdID(members *[]model.HouseholdMember, householdID int) error {
+	if err := hr.db.Preload("User").Where("household_id = ?", householdID).Order("created_at, id").Find(members).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (hr *householdRepository) AddMember(member *model.HouseholdMember) error {
+	if err := hr.db.Create(member).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (hr *householdRepository) UpdateMemberRole(householdID int, userID int, role string) error {
+	result := hr.db.Model(&model.HouseholdMember{}).Where("household_id = ? AND user_id = ?", householdID, userID).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		// 同じロールへの変更も0件になるため、メンバーの存在を確認する
+		return hr.db.Where("household_id = ? AND user_id = ?", householdID, userID).First(&model.HouseholdMember{}).Error
+	}
+	return nil
+}
+
+// RemoveMember deletes the membership and clears the active household of the
+// user if it was this household.
+func (hr *householdRepository) RemoveMember(householdID int, userID int) error {
+	return hr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("household_id = ? AND user_id = ?", householdID, userID).Delete(&model.HouseholdMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&model.User{}).Where("id = ? AND active_household_id = ?", userID, householdID).Update("active_household_id", nil).Error
+	})
+}
+
+func (hr *householdRepository) CountOwners(householdID int) (int64, error) {
+	var count int64
+	err := hr.db.Model(&model.HouseholdMember{}).Where("household_id = ? AND role = ?", householdID, model.HouseholdRoleOwner).Count(&count).Error
+	return count, err
+}
+
+func (hr *householdRepository) SetActiveHousehold(userID int, householdID *int) error {
+	return hr.db.Model(&model.User{}).Where("id = ?", userID).Update("active_household_id", householdID).Error
+}
+
+// ShareHousehold reports whether the two users are members of a common household.
+func (hr *householdRepository) ShareHousehold(userID int, otherUserID int) (bool, error) {
+	var count int64
+	err := hr.db.Table("household_members AS a").
+		Joins("JOIN household_members AS b ON a.household_id = b.household_id").
+		Where("a.user_id = ? AND b.user_id = ?", userID, otherUserID).
+		Count(&count).Error
+	return count > 0, err
+}
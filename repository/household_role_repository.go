@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IHouseholdRoleRepository is an interface for managing custom household roles.
+type IHouseholdRoleRepository interface {
+	GetRolesByHouseholdID(roles *[]model.HouseholdRole, householdID int) error
+	GetRoleByName(role *model.HouseholdRole, householdID int, name string) error
+	GetRoleByID(role *model.HouseholdRole, householdID int, id int) error
+	CreateRole(role *model.HouseholdRole) error
+	UpdateRolePermissions(householdID int, id int, permissions string) error
+	DeleteRole(householdID int, id int) error
+}
+
+type householdRoleRepository struct {
+	db *gorm.DB
+}
+
+// NewHouseholdRoleRepository creates a new instance of the householdRoleRepository struct.
+func NewHouseholdRoleRepository(db *gorm.DB) IHouseholdRoleRepository {
+	return &householdRoleRepository{db}
+}
+
+func (rr *householdRoleRepository) GetRolesByHouseholdID(roles *[]model.HouseholdRole, householdID int) error {
+	if err := rr.db.Where("household_id = ?", householdID).Order("name").Find(roles).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *householdRoleRepository) GetRoleByName(role *model.HouseholdRole, householdID int, name string) error {
+	if err := rr.db.Where("household_id = ? AND name = ?", householdID, name).First(role).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *householdRoleRepository) GetRoleByID(role *model.HouseholdRole, householdID int, id int) error {
+	if err := rr.db.Where("household_id = ? AND id = ?", householdID, id).First(role).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *householdRoleRepository) CreateRole(role *model.HouseholdRole) error {
+	if err := rr.db.Create(role).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *householdRoleRepository) UpdateRolePermissions(householdID int, id int, permissions string) error {
+	result := rr.db.Model(&model.HouseholdRole{}).Where("household_id = ? AND id = ?", householdID, id).Update("permissions", permissions)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		// 同じ権限への変更も0件になるため、ロールの存在を確認する
+		return rr.GetRoleByID(&model.HouseholdRole{}, householdID, id)
+	}
+	return nil
+}
+
+// DeleteRole deletes the role unless a member has it or a pending invitation
+// grants it, in which case model.ErrHouseholdRoleInUse is returned.
+func (rr *householdRoleRepository) DeleteRole(householdID int, id int) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		role := model.HouseholdRole{}
+		if err := tx.Where("household_id = ? AND id = ?", householdID, id).First(&role).Error; err != nil {
+			return err
+		}
+
+		var members int64
+		if err := tx.Model(&model.HouseholdMember{}).Where("household_id = ? AND role = ?", householdID, role.Name).Count(&members).Error; err != nil {
+			return err
+		}
+		var invitations int64
+		if err := tx.Model(&model.HouseholdInvitation{}).
+			Where("household_id = ? AND role = ? AND accepted_at IS NULL AND declined_at IS NULL AND expires_at > ?", householdID, role.Name, time.Now()).
+			Count(&invitations).Error; err != nil {
+			return err
+		}
+		if members > 0 || invitations > 0 {
+			return model.ErrHouseholdRoleInUse
+		}
+
+		return tx.Delete(&role).Error
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 )
 
 type IImageRepository interface {
 	UploadImage(image *model.Image) (*model.Image, error)
 	FetchImage(image *model.Image) (*model.Image, error)
+	DeleteImage(filename string) error
 }
 
 type imageRepository struct {
@@ -53,3 +55,12 @@ func (ir *imageRepository) FetchImage(file *model.Image) (*model.Image, error) {
 
 	return file, nil
 }
+
+// DeleteImage deletes the image file. An image that does not exist is not an
+// error.
+func (ir *imageRepository) DeleteImage(filename string) error {
+	if err := os.Remove("images/" + filepath.Base(filename)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
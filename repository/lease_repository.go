@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILeaseRepository is an interface for the leases that elect the server
+// replica running a background job.
+type ILeaseRepository interface {
+	AcquireLease(name string, owner string, now time.Time, ttl time.Duration) (bool, error)
+}
+
+type leaseRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaseRepository creates a new instance of the leaseRepository struct.
+func NewLeaseRepository(db *gorm.DB) ILeaseRepository {
+	return &leaseRepository{db}
+}
+
+// AcquireLease takes or renews the lease for owner until now+ttl if it is
+// free, expired or already held by owner, and reports whether owner holds it.
+// The conditional UPDATE lets only one replica take an expired lease.
+func (lr *leaseRepository) AcquireLease(name string, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	// 初回はすでに期限切れのリースを作っておき、以降は UPDATE だけで取り合う
+	if err := lr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Lease{Name: name, Owner: owner, ExpiresAt: now}).Error; err != nil {
+		return false, err
+	}
+	result := lr.db.Model(&model.Lease{}).
+		Where("name = ? AND (owner = ? OR expires_at <= ?)", name, owner, now).
+		Updates(map[string]interface{}{"owner": owner, "expires_at": now.Add(ttl)})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILoginAttemptRepository is an interface for persisting failed login counters.
+type ILoginAttemptRepository interface {
+	GetLoginAttempt(attempt *model.LoginAttempt, scope string, identifier string) error
+	IncrementLoginFailures(attempt *model.LoginAttempt, scope string, identifier string, now time.Time, window time.Duration) error
+	LockLoginAttempt(id int, lockedUntil time.Time) error
+	ResetLoginAttempt(scope string, identifier string) error
+	DeleteStaleLoginAttempts(before time.Time) (int64, error)
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a new instance of the loginAttemptRepository struct.
+func NewLoginAttemptRepository(db *gorm.DB) ILoginAttemptRepository {
+	return &loginAttemptRepository{db}
+}
+
+func (lr *loginAttemptRepository) GetLoginAttempt(attempt *model.LoginAttempt, scope string, identifier string) error {
+	if err := lr.db.Where("scope = ? AND identifier = ?", scope, identifier).First(attempt).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// IncrementLoginFailures atomically increments the failure counter and loads
+// the updated row into attempt. Failures older than window are not counted.
+func (lr *loginAttemptRepository) IncrementLoginFailures(attempt *model.LoginAttempt, scope string, identifier string, now time.Time, window time.Duration) error {
+	return lr.db.Transaction(func(tx *gorm.DB) error {
+		// failures は last_failed_at を更新する前の値で判定するため先に代入する
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "identifier"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "failures"}, Value: gorm.Expr("CASE WHEN last_failed_at < ? THEN 1 ELSE failures + 1 END", now.Add(-window))},
+				{Column: clause.Column{Name: "last_failed_at"}, Value: now},
+			},
+		}).Create(&model.LoginAttempt{
+			Scope:        scope,
+			Identifier:   identifier,
+			Failures:     1,
+			LastFailedAt: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("scope = ? AND identifier = ?", scope, identifier).First(attempt).Error
+	})
+}
+
+func (lr *loginAttemptRepository) LockLoginAttempt(id int, lockedUntil time.Time) error {
+	return lr.db.Model(&model.LoginAttempt{}).Where("id = ?", id).Update("locked_until", lockedUntil).Error
+}
+
+func (lr *loginAttemptRepository) ResetLoginAttempt(scope string, identifier string) error {
+	return lr.db.Where("scope = ? AND identifier = ?", scope, identifier).Delete(&model.LoginAttempt{}).Error
+}
+
+// DeleteStaleLoginAttempts deletes counters whose last failure is older than
+// before and which are no longer locked.
+func (lr *loginAttemptRepository) DeleteStaleLoginAttempts(before time.Time) (int64, error) {
+	result := lr.db.Where("last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).Delete(&model.LoginAttempt{})
+	return result.RowsAffected, result.Error
+}
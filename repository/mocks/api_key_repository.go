@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/api_key_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/api_key_repository.go -destination=repository/mocks/api_key_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAPIKeyRepository is a mock of IAPIKeyRepository interface.
+type MockIAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyRepositoryMockRecorder
+}
+
+// MockIAPIKeyRepositoryMockRecorder is the mock recorder for MockIAPIKeyRepository.
+type MockIAPIKeyRepositoryMockRecorder struct {
+	mock *MockIAPIKeyRepository
+}
+
+// NewMockIAPIKeyRepository creates a new mock instance.
+func NewMockIAPIKeyRepository(ctrl *gomock.Controller) *MockIAPIKeyRepository {
+	mock := &MockIAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyRepository) EXPECT() *MockIAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) CreateAPIKey(key *model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) CreateAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).CreateAPIKey), key)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockIAPIKeyRepository) GetAPIKeyByHash(key *model.APIKey, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", key, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockIAPIKeyRepositoryMockRecorder) GetAPIKeyByHash(key, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockIAPIKeyRepository)(nil).GetAPIKeyByHash), key, keyHash)
+}
+
+// GetAPIKeysByHouseholdID mocks base method.
+func (m *MockIAPIKeyRepository) GetAPIKeysByHouseholdID(keys *[]model.APIKey, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeysByHouseholdID", keys, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAPIKeysByHouseholdID indicates an expected call of GetAPIKeysByHouseholdID.
+func (mr *MockIAPIKeyRepositoryMockRecorder) GetAPIKeysByHouseholdID(keys, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeysByHouseholdID", reflect.TypeOf((*MockIAPIKeyRepository)(nil).GetAPIKeysByHouseholdID), keys, householdID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) RevokeAPIKey(householdID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", householdID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) RevokeAPIKey(householdID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).RevokeAPIKey), householdID, id)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) TouchAPIKey(id int, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) TouchAPIKey(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).TouchAPIKey), id, usedAt)
+}
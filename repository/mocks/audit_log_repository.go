@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/audit_log_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/audit_log_repository.go -destination=repository/mocks/audit_log_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditLogRepository is a mock of IAuditLogRepository interface.
+type MockIAuditLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditLogRepositoryMockRecorder
+}
+
+// MockIAuditLogRepositoryMockRecorder is the mock recorder for MockIAuditLogRepository.
+type MockIAuditLogRepositoryMockRecorder struct {
+	mock *MockIAuditLogRepository
+}
+
+// NewMockIAuditLogRepository creates a new mock instance.
+func NewMockIAuditLogRepository(ctrl *gomock.Controller) *MockIAuditLogRepository {
+	mock := &MockIAuditLogRepository{ctrl: ctrl}
+	mock.recorder = &MockIAuditLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditLogRepository) EXPECT() *MockIAuditLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockIAuditLogRepository) CreateAuditLog(log *model.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockIAuditLogRepositoryMockRecorder) CreateAuditLog(log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockIAuditLogRepository)(nil).CreateAuditLog), log)
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockIAuditLogRepository) GetAuditLogs(logs *[]model.AuditLog, householdID *int, userID int, query model.AuditLogQuery, beforeID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", logs, householdID, userID, query, beforeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockIAuditLogRepositoryMockRecorder) GetAuditLogs(logs, householdID, userID, query, beforeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIAuditLogRepository)(nil).GetAuditLogs), logs, householdID, userID, query, beforeID)
+}
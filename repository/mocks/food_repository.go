@@ -12,6 +12,7 @@ package mocks
 import (
 	model "RefrigeratorWatchdog-server/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,6 +40,34 @@ func (m *MockIFoodRepository) EXPECT() *MockIFoodRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ArchiveFood mocks base method.
+func (m *MockIFoodRepository) ArchiveFood(food *model.Food, id uint, householdID int, disposition string, archivedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveFood", food, id, householdID, disposition, archivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveFood indicates an expected call of ArchiveFood.
+func (mr *MockIFoodRepositoryMockRecorder) ArchiveFood(food, id, householdID, disposition, archivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveFood", reflect.TypeOf((*MockIFoodRepository)(nil).ArchiveFood), food, id, householdID, disposition, archivedAt)
+}
+
+// ConsumeFood mocks base method.
+func (m *MockIFoodRepository) ConsumeFood(food *model.Food, consumption *model.FoodConsumption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeFood", food, consumption)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeFood indicates an expected call of ConsumeFood.
+func (mr *MockIFoodRepositoryMockRecorder) ConsumeFood(food, consumption any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeFood", reflect.TypeOf((*MockIFoodRepository)(nil).ConsumeFood), food, consumption)
+}
+
 // CreateFood mocks base method.
 func (m *MockIFoodRepository) CreateFood(food *model.Food) error {
 	m.ctrl.T.Helper()
@@ -54,43 +83,213 @@ func (mr *MockIFoodRepositoryMockRecorder) CreateFood(food any) *gomock.Call {
 }
 
 // DeleteFood mocks base method.
-func (m *MockIFoodRepository) DeleteFood(id uint) error {
+func (m *MockIFoodRepository) DeleteFood(id uint, householdID int, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFood", id)
+	ret := m.ctrl.Call(m, "DeleteFood", id, householdID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFood indicates an expected call of DeleteFood.
-func (mr *MockIFoodRepositoryMockRecorder) DeleteFood(id any) *gomock.Call {
+func (mr *MockIFoodRepositoryMockRecorder) DeleteFood(id, householdID, versions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFood", reflect.TypeOf((*MockIFoodRepository)(nil).DeleteFood), id, householdID, versions)
+}
+
+// GetFoodByID mocks base method.
+func (m *MockIFoodRepository) GetFoodByID(food *model.Food, id uint, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodByID", food, id, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodByID indicates an expected call of GetFoodByID.
+func (mr *MockIFoodRepositoryMockRecorder) GetFoodByID(food, id, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodByID", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodByID), food, id, householdID)
+}
+
+// GetFoodsByHouseholdID mocks base method.
+func (m *MockIFoodRepository) GetFoodsByHouseholdID(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodsByHouseholdID", foods, householdID, query, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodsByHouseholdID indicates an expected call of GetFoodsByHouseholdID.
+func (mr *MockIFoodRepositoryMockRecorder) GetFoodsByHouseholdID(foods, householdID, query, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsByHouseholdID", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodsByHouseholdID), foods, householdID, query, after)
+}
+
+// GetFoodsExpiringBetween mocks base method.
+func (m *MockIFoodRepository) GetFoodsExpiringBetween(foods *[]model.Food, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodsExpiringBetween", foods, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodsExpiringBetween indicates an expected call of GetFoodsExpiringBetween.
+func (mr *MockIFoodRepositoryMockRecorder) GetFoodsExpiringBetween(foods, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsExpiringBetween", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodsExpiringBetween), foods, from, to)
+}
+
+// GetFoodsForDigest mocks base method.
+func (m *MockIFoodRepository) GetFoodsForDigest(foods *[]model.Food, householdIDs []int, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodsForDigest", foods, householdIDs, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodsForDigest indicates an expected call of GetFoodsForDigest.
+func (mr *MockIFoodRepositoryMockRecorder) GetFoodsForDigest(foods, householdIDs, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsForDigest", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodsForDigest), foods, householdIDs, from, to)
+}
+
+// GetFoodsTrashedBefore mocks base method.
+func (m *MockIFoodRepository) GetFoodsTrashedBefore(foods *[]model.Food, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodsTrashedBefore", foods, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFoodsTrashedBefore indicates an expected call of GetFoodsTrashedBefore.
+func (mr *MockIFoodRepositoryMockRecorder) GetFoodsTrashedBefore(foods, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsTrashedBefore", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodsTrashedBefore), foods, before)
+}
+
+// GetTopWastedItems mocks base method.
+func (m *MockIFoodRepository) GetTopWastedItems(items *[]model.WastedItem, householdID int, from, to time.Time, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopWastedItems", items, householdID, from, to, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTopWastedItems indicates an expected call of GetTopWastedItems.
+func (mr *MockIFoodRepositoryMockRecorder) GetTopWastedItems(items, householdID, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopWastedItems", reflect.TypeOf((*MockIFoodRepository)(nil).GetTopWastedItems), items, householdID, from, to, limit)
+}
+
+// GetTrashedFoods mocks base method.
+func (m *MockIFoodRepository) GetTrashedFoods(foods *[]model.Food, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedFoods", foods, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetTrashedFoods indicates an expected call of GetTrashedFoods.
+func (mr *MockIFoodRepositoryMockRecorder) GetTrashedFoods(foods, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedFoods", reflect.TypeOf((*MockIFoodRepository)(nil).GetTrashedFoods), foods, householdID)
+}
+
+// GetWasteGroups mocks base method.
+func (m *MockIFoodRepository) GetWasteGroups(groups *[]model.WasteGroup, householdID int, groupBy string, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteGroups", groups, householdID, groupBy, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteGroups indicates an expected call of GetWasteGroups.
+func (mr *MockIFoodRepositoryMockRecorder) GetWasteGroups(groups, householdID, groupBy, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteGroups", reflect.TypeOf((*MockIFoodRepository)(nil).GetWasteGroups), groups, householdID, groupBy, from, to)
+}
+
+// GetWasteSummary mocks base method.
+func (m *MockIFoodRepository) GetWasteSummary(summary *model.WasteSummary, householdID int, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteSummary", summary, householdID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWasteSummary indicates an expected call of GetWasteSummary.
+func (mr *MockIFoodRepositoryMockRecorder) GetWasteSummary(summary, householdID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteSummary", reflect.TypeOf((*MockIFoodRepository)(nil).GetWasteSummary), summary, householdID, from, to)
+}
+
+// IsImageInUse mocks base method.
+func (m *MockIFoodRepository) IsImageInUse(imageURL string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsImageInUse", imageURL)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsImageInUse indicates an expected call of IsImageInUse.
+func (mr *MockIFoodRepositoryMockRecorder) IsImageInUse(imageURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsImageInUse", reflect.TypeOf((*MockIFoodRepository)(nil).IsImageInUse), imageURL)
+}
+
+// PatchFood mocks base method.
+func (m *MockIFoodRepository) PatchFood(food *model.Food, id uint, householdID int, fields []string, versions []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchFood", food, id, householdID, fields, versions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchFood indicates an expected call of PatchFood.
+func (mr *MockIFoodRepositoryMockRecorder) PatchFood(food, id, householdID, fields, versions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFood", reflect.TypeOf((*MockIFoodRepository)(nil).PatchFood), food, id, householdID, fields, versions)
+}
+
+// PurgeFood mocks base method.
+func (m *MockIFoodRepository) PurgeFood(id int, trashedBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFood", id, trashedBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFood indicates an expected call of PurgeFood.
+func (mr *MockIFoodRepositoryMockRecorder) PurgeFood(id, trashedBefore any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFood", reflect.TypeOf((*MockIFoodRepository)(nil).DeleteFood), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFood", reflect.TypeOf((*MockIFoodRepository)(nil).PurgeFood), id, trashedBefore)
 }
 
-// GetFoodsByUserID mocks base method.
-func (m *MockIFoodRepository) GetFoodsByUserID(foods *[]model.Food, userID uint) error {
+// RestoreFood mocks base method.
+func (m *MockIFoodRepository) RestoreFood(food *model.Food, id uint, householdID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFoodsByUserID", foods, userID)
+	ret := m.ctrl.Call(m, "RestoreFood", food, id, householdID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetFoodsByUserID indicates an expected call of GetFoodsByUserID.
-func (mr *MockIFoodRepositoryMockRecorder) GetFoodsByUserID(foods, userID any) *gomock.Call {
+// RestoreFood indicates an expected call of RestoreFood.
+func (mr *MockIFoodRepositoryMockRecorder) RestoreFood(food, id, householdID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsByUserID", reflect.TypeOf((*MockIFoodRepository)(nil).GetFoodsByUserID), foods, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFood", reflect.TypeOf((*MockIFoodRepository)(nil).RestoreFood), food, id, householdID)
 }
 
 // UpdateFood mocks base method.
-func (m *MockIFoodRepository) UpdateFood(food *model.Food, id uint) error {
+func (m *MockIFoodRepository) UpdateFood(food *model.Food, id uint, householdID int, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFood", food, id)
+	ret := m.ctrl.Call(m, "UpdateFood", food, id, householdID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFood indicates an expected call of UpdateFood.
-func (mr *MockIFoodRepositoryMockRecorder) UpdateFood(food, id any) *gomock.Call {
+func (mr *MockIFoodRepositoryMockRecorder) UpdateFood(food, id, householdID, versions any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFood", reflect.TypeOf((*MockIFoodRepository)(nil).UpdateFood), food, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFood", reflect.TypeOf((*MockIFoodRepository)(nil).UpdateFood), food, id, householdID, versions)
 }
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/household_invitation_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/household_invitation_repository.go -destination=repository/mocks/household_invitation_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHouseholdInvitationRepository is a mock of IHouseholdInvitationRepository interface.
+type MockIHouseholdInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdInvitationRepositoryMockRecorder
+}
+
+// MockIHouseholdInvitationRepositoryMockRecorder is the mock recorder for MockIHouseholdInvitationRepository.
+type MockIHouseholdInvitationRepositoryMockRecorder struct {
+	mock *MockIHouseholdInvitationRepository
+}
+
+// NewMockIHouseholdInvitationRepository creates a new mock instance.
+func NewMockIHouseholdInvitationRepository(ctrl *gomock.Controller) *MockIHouseholdInvitationRepository {
+	mock := &MockIHouseholdInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdInvitationRepository) EXPECT() *MockIHouseholdInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockIHouseholdInvitationRepository) AcceptInvitation(id int, member *model.HouseholdMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", id, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockIHouseholdInvitationRepositoryMockRecorder) AcceptInvitation(id, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockIHouseholdInvitationRepository)(nil).AcceptInvitation), id, member)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockIHouseholdInvitationRepository) CreateInvitation(invitation *model.HouseholdInvitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockIHouseholdInvitationRepositoryMockRecorder) CreateInvitation(invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockIHouseholdInvitationRepository)(nil).CreateInvitation), invitation)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockIHouseholdInvitationRepository) DeclineInvitation(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockIHouseholdInvitationRepositoryMockRecorder) DeclineInvitation(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockIHouseholdInvitationRepository)(nil).DeclineInvitation), id)
+}
+
+// GetInvitationByHash mocks base method.
+func (m *MockIHouseholdInvitationRepository) GetInvitationByHash(invitation *model.HouseholdInvitation, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationByHash", invitation, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetInvitationByHash indicates an expected call of GetInvitationByHash.
+func (mr *MockIHouseholdInvitationRepositoryMockRecorder) GetInvitationByHash(invitation, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationByHash", reflect.TypeOf((*MockIHouseholdInvitationRepository)(nil).GetInvitationByHash), invitation, tokenHash)
+}
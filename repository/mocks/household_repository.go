@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/household_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/household_repository.go -destination=repository/mocks/household_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHouseholdRepository is a mock of IHouseholdRepository interface.
+type MockIHouseholdRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdRepositoryMockRecorder
+}
+
+// MockIHouseholdRepositoryMockRecorder is the mock recorder for MockIHouseholdRepository.
+type MockIHouseholdRepositoryMockRecorder struct {
+	mock *MockIHouseholdRepository
+}
+
+// NewMockIHouseholdRepository creates a new mock instance.
+func NewMockIHouseholdRepository(ctrl *gomock.Controller) *MockIHouseholdRepository {
+	mock := &MockIHouseholdRepository{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdRepository) EXPECT() *MockIHouseholdRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockIHouseholdRepository) AddMember(member *model.HouseholdMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockIHouseholdRepositoryMockRecorder) AddMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockIHouseholdRepository)(nil).AddMember), member)
+}
+
+// CountOwners mocks base method.
+func (m *MockIHouseholdRepository) CountOwners(householdID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", householdID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockIHouseholdRepositoryMockRecorder) CountOwners(householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockIHouseholdRepository)(nil).CountOwners), householdID)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockIHouseholdRepository) CreateHousehold(household *model.Household, ownerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", household, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockIHouseholdRepositoryMockRecorder) CreateHousehold(household, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockIHouseholdRepository)(nil).CreateHousehold), household, ownerID)
+}
+
+// GetMembersByHouseholdID mocks base method.
+func (m *MockIHouseholdRepository) GetMembersByHouseholdID(members *[]model.HouseholdMember, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembersByHouseholdID", members, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMembersByHouseholdID indicates an expected call of GetMembersByHouseholdID.
+func (mr *MockIHouseholdRepositoryMockRecorder) GetMembersByHouseholdID(members, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersByHouseholdID", reflect.TypeOf((*MockIHouseholdRepository)(nil).GetMembersByHouseholdID), members, householdID)
+}
+
+// GetMembership mocks base method.
+func (m *MockIHouseholdRepository) GetMembership(member *model.HouseholdMember, householdID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", member, householdID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockIHouseholdRepositoryMockRecorder) GetMembership(member, householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockIHouseholdRepository)(nil).GetMembership), member, householdID, userID)
+}
+
+// GetMembershipsByUserID mocks base method.
+func (m *MockIHouseholdRepository) GetMembershipsByUserID(members *[]model.HouseholdMember, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipsByUserID", members, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetMembershipsByUserID indicates an expected call of GetMembershipsByUserID.
+func (mr *MockIHouseholdRepositoryMockRecorder) GetMembershipsByUserID(members, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipsByUserID", reflect.TypeOf((*MockIHouseholdRepository)(nil).GetMembershipsByUserID), members, userID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockIHouseholdRepository) RemoveMember(householdID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", householdID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockIHouseholdRepositoryMockRecorder) RemoveMember(householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockIHouseholdRepository)(nil).RemoveMember), householdID, userID)
+}
+
+// SetActiveHousehold mocks base method.
+func (m *MockIHouseholdRepository) SetActiveHousehold(userID int, householdID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActiveHousehold", userID, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActiveHousehold indicates an expected call of SetActiveHousehold.
+func (mr *MockIHouseholdRepositoryMockRecorder) SetActiveHousehold(userID, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActiveHousehold", reflect.TypeOf((*MockIHouseholdRepository)(nil).SetActiveHousehold), userID, householdID)
+}
+
+// ShareHousehold mocks base method.
+func (m *MockIHouseholdRepository) ShareHousehold(userID, otherUserID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareHousehold", userID, otherUserID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareHousehold indicates an expected call of ShareHousehold.
+func (mr *MockIHouseholdRepositoryMockRecorder) ShareHousehold(userID, otherUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareHousehold", reflect.TypeOf((*MockIHouseholdRepository)(nil).ShareHousehold), userID, otherUserID)
+}
+
+// UpdateHousehold mocks base method.
+func (m *MockIHouseholdRepository) UpdateHousehold(household *model.Household) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHousehold", household)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHousehold indicates an expected call of UpdateHousehold.
+func (mr *MockIHouseholdRepositoryMockRecorder) UpdateHousehold(household any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHousehold", reflect.TypeOf((*MockIHouseholdRepository)(nil).UpdateHousehold), household)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockIHouseholdRepository) UpdateMemberRole(householdID, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", householdID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockIHouseholdRepositoryMockRecorder) UpdateMemberRole(householdID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockIHouseholdRepository)(nil).UpdateMemberRole), householdID, userID, role)
+}
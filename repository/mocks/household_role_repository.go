@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/household_role_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/household_role_repository.go -destination=repository/mocks/household_role_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHouseholdRoleRepository is a mock of IHouseholdRoleRepository interface.
+type MockIHouseholdRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdRoleRepositoryMockRecorder
+}
+
+// MockIHouseholdRoleRepositoryMockRecorder is the mock recorder for MockIHouseholdRoleRepository.
+type MockIHouseholdRoleRepositoryMockRecorder struct {
+	mock *MockIHouseholdRoleRepository
+}
+
+// NewMockIHouseholdRoleRepository creates a new mock instance.
+func NewMockIHouseholdRoleRepository(ctrl *gomock.Controller) *MockIHouseholdRoleRepository {
+	mock := &MockIHouseholdRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdRoleRepository) EXPECT() *MockIHouseholdRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRole mocks base method.
+func (m *MockIHouseholdRoleRepository) CreateRole(role *model.HouseholdRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) CreateRole(role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).CreateRole), role)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIHouseholdRoleRepository) DeleteRole(householdID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", householdID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) DeleteRole(householdID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).DeleteRole), householdID, id)
+}
+
+// GetRoleByID mocks base method.
+func (m *MockIHouseholdRoleRepository) GetRoleByID(role *model.HouseholdRole, householdID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByID", role, householdID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetRoleByID indicates an expected call of GetRoleByID.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) GetRoleByID(role, householdID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).GetRoleByID), role, householdID, id)
+}
+
+// GetRoleByName mocks base method.
+func (m *MockIHouseholdRoleRepository) GetRoleByName(role *model.HouseholdRole, householdID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleByName", role, householdID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetRoleByName indicates an expected call of GetRoleByName.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) GetRoleByName(role, householdID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).GetRoleByName), role, householdID, name)
+}
+
+// GetRolesByHouseholdID mocks base method.
+func (m *MockIHouseholdRoleRepository) GetRolesByHouseholdID(roles *[]model.HouseholdRole, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesByHouseholdID", roles, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetRolesByHouseholdID indicates an expected call of GetRolesByHouseholdID.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) GetRolesByHouseholdID(roles, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByHouseholdID", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).GetRolesByHouseholdID), roles, householdID)
+}
+
+// UpdateRolePermissions mocks base method.
+func (m *MockIHouseholdRoleRepository) UpdateRolePermissions(householdID, id int, permissions string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRolePermissions", householdID, id, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRolePermissions indicates an expected call of UpdateRolePermissions.
+func (mr *MockIHouseholdRoleRepositoryMockRecorder) UpdateRolePermissions(householdID, id, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRolePermissions", reflect.TypeOf((*MockIHouseholdRoleRepository)(nil).UpdateRolePermissions), householdID, id, permissions)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/image_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/image_repository.go -destination=repository/mocks/image_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIImageRepository is a mock of IImageRepository interface.
+type MockIImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImageRepositoryMockRecorder
+}
+
+// MockIImageRepositoryMockRecorder is the mock recorder for MockIImageRepository.
+type MockIImageRepositoryMockRecorder struct {
+	mock *MockIImageRepository
+}
+
+// NewMockIImageRepository creates a new mock instance.
+func NewMockIImageRepository(ctrl *gomock.Controller) *MockIImageRepository {
+	mock := &MockIImageRepository{ctrl: ctrl}
+	mock.recorder = &MockIImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImageRepository) EXPECT() *MockIImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteImage mocks base method.
+func (m *MockIImageRepository) DeleteImage(filename string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", filename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockIImageRepositoryMockRecorder) DeleteImage(filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockIImageRepository)(nil).DeleteImage), filename)
+}
+
+// FetchImage mocks base method.
+func (m *MockIImageRepository) FetchImage(image *model.Image) (*model.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchImage", image)
+	ret0, _ := ret[0].(*model.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchImage indicates an expected call of FetchImage.
+func (mr *MockIImageRepositoryMockRecorder) FetchImage(image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchImage", reflect.TypeOf((*MockIImageRepository)(nil).FetchImage), image)
+}
+
+// UploadImage mocks base method.
+func (m *MockIImageRepository) UploadImage(image *model.Image) (*model.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", image)
+	ret0, _ := ret[0].(*model.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockIImageRepositoryMockRecorder) UploadImage(image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockIImageRepository)(nil).UploadImage), image)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/lease_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/lease_repository.go -destination=repository/mocks/lease_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILeaseRepository is a mock of ILeaseRepository interface.
+type MockILeaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILeaseRepositoryMockRecorder
+}
+
+// MockILeaseRepositoryMockRecorder is the mock recorder for MockILeaseRepository.
+type MockILeaseRepositoryMockRecorder struct {
+	mock *MockILeaseRepository
+}
+
+// NewMockILeaseRepository creates a new mock instance.
+func NewMockILeaseRepository(ctrl *gomock.Controller) *MockILeaseRepository {
+	mock := &MockILeaseRepository{ctrl: ctrl}
+	mock.recorder = &MockILeaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILeaseRepository) EXPECT() *MockILeaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireLease mocks base method.
+func (m *MockILeaseRepository) AcquireLease(name, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", name, owner, now, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease.
+func (mr *MockILeaseRepositoryMockRecorder) AcquireLease(name, owner, now, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockILeaseRepository)(nil).AcquireLease), name, owner, now, ttl)
+}
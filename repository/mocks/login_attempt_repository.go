@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/login_attempt_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/login_attempt_repository.go -destination=repository/mocks/login_attempt_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILoginAttemptRepository is a mock of ILoginAttemptRepository interface.
+type MockILoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginAttemptRepositoryMockRecorder
+}
+
+// MockILoginAttemptRepositoryMockRecorder is the mock recorder for MockILoginAttemptRepository.
+type MockILoginAttemptRepositoryMockRecorder struct {
+	mock *MockILoginAttemptRepository
+}
+
+// NewMockILoginAttemptRepository creates a new mock instance.
+func NewMockILoginAttemptRepository(ctrl *gomock.Controller) *MockILoginAttemptRepository {
+	mock := &MockILoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockILoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginAttemptRepository) EXPECT() *MockILoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteStaleLoginAttempts mocks base method.
+func (m *MockILoginAttemptRepository) DeleteStaleLoginAttempts(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleLoginAttempts", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleLoginAttempts indicates an expected call of DeleteStaleLoginAttempts.
+func (mr *MockILoginAttemptRepositoryMockRecorder) DeleteStaleLoginAttempts(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleLoginAttempts", reflect.TypeOf((*MockILoginAttemptRepository)(nil).DeleteStaleLoginAttempts), before)
+}
+
+// GetLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepository) GetLoginAttempt(attempt *model.LoginAttempt, scope, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempt", attempt, scope, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockILoginAttemptRepositoryMockRecorder) GetLoginAttempt(attempt, scope, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepository)(nil).GetLoginAttempt), attempt, scope, identifier)
+}
+
+// IncrementLoginFailures mocks base method.
+func (m *MockILoginAttemptRepository) IncrementLoginFailures(attempt *model.LoginAttempt, scope, identifier string, now time.Time, window time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementLoginFailures", attempt, scope, identifier, now, window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementLoginFailures indicates an expected call of IncrementLoginFailures.
+func (mr *MockILoginAttemptRepositoryMockRecorder) IncrementLoginFailures(attempt, scope, identifier, now, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementLoginFailures", reflect.TypeOf((*MockILoginAttemptRepository)(nil).IncrementLoginFailures), attempt, scope, identifier, now, window)
+}
+
+// LockLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepository) LockLoginAttempt(id int, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLoginAttempt", id, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLoginAttempt indicates an expected call of LockLoginAttempt.
+func (mr *MockILoginAttemptRepositoryMockRecorder) LockLoginAttempt(id, lockedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepository)(nil).LockLoginAttempt), id, lockedUntil)
+}
+
+// ResetLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepository) ResetLoginAttempt(scope, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginAttempt", scope, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginAttempt indicates an expected call of ResetLoginAttempt.
+func (mr *MockILoginAttemptRepositoryMockRecorder) ResetLoginAttempt(scope, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepository)(nil).ResetLoginAttempt), scope, identifier)
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/notification_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/notification_repository.go -destination=repository/mocks/notification_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockINotificationRepository is a mock of INotificationRepository interface.
+type MockINotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationRepositoryMockRecorder
+}
+
+// MockINotificationRepositoryMockRecorder is the mock recorder for MockINotificationRepository.
+type MockINotificationRepositoryMockRecorder struct {
+	mock *MockINotificationRepository
+}
+
+// NewMockINotificationRepository creates a new mock instance.
+func NewMockINotificationRepository(ctrl *gomock.Controller) *MockINotificationRepository {
+	mock := &MockINotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockINotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationRepository) EXPECT() *MockINotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDigestDelivery mocks base method.
+func (m *MockINotificationRepository) ClaimDigestDelivery(delivery *model.DigestDelivery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDigestDelivery", delivery)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDigestDelivery indicates an expected call of ClaimDigestDelivery.
+func (mr *MockINotificationRepositoryMockRecorder) ClaimDigestDelivery(delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDigestDelivery", reflect.TypeOf((*MockINotificationRepository)(nil).ClaimDigestDelivery), delivery)
+}
+
+// ClaimExpiryAlert mocks base method.
+func (m *MockINotificationRepository) ClaimExpiryAlert(alert *model.ExpiryAlert) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimExpiryAlert", alert)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimExpiryAlert indicates an expected call of ClaimExpiryAlert.
+func (mr *MockINotificationRepositoryMockRecorder) ClaimExpiryAlert(alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimExpiryAlert", reflect.TypeOf((*MockINotificationRepository)(nil).ClaimExpiryAlert), alert)
+}
+
+// DeleteDigestDeliveriesBefore mocks base method.
+func (m *MockINotificationRepository) DeleteDigestDeliveriesBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDigestDeliveriesBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDigestDeliveriesBefore indicates an expected call of DeleteDigestDeliveriesBefore.
+func (mr *MockINotificationRepositoryMockRecorder) DeleteDigestDeliveriesBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDigestDeliveriesBefore", reflect.TypeOf((*MockINotificationRepository)(nil).DeleteDigestDeliveriesBefore), before)
+}
+
+// DeleteDigestDelivery mocks base method.
+func (m *MockINotificationRepository) DeleteDigestDelivery(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDigestDelivery", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDigestDelivery indicates an expected call of DeleteDigestDelivery.
+func (mr *MockINotificationRepositoryMockRecorder) DeleteDigestDelivery(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDigestDelivery", reflect.TypeOf((*MockINotificationRepository)(nil).DeleteDigestDelivery), id)
+}
+
+// DeleteExpiryAlert mocks base method.
+func (m *MockINotificationRepository) DeleteExpiryAlert(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiryAlert", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiryAlert indicates an expected call of DeleteExpiryAlert.
+func (mr *MockINotificationRepositoryMockRecorder) DeleteExpiryAlert(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiryAlert", reflect.TypeOf((*MockINotificationRepository)(nil).DeleteExpiryAlert), id)
+}
+
+// DeleteExpiryAlertsBefore mocks base method.
+func (m *MockINotificationRepository) DeleteExpiryAlertsBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiryAlertsBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiryAlertsBefore indicates an expected call of DeleteExpiryAlertsBefore.
+func (mr *MockINotificationRepositoryMockRecorder) DeleteExpiryAlertsBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiryAlertsBefore", reflect.TypeOf((*MockINotificationRepository)(nil).DeleteExpiryAlertsBefore), before)
+}
+
+// DeletePreference mocks base method.
+func (m *MockINotificationRepository) DeletePreference(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreference", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePreference indicates an expected call of DeletePreference.
+func (mr *MockINotificationRepositoryMockRecorder) DeletePreference(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreference", reflect.TypeOf((*MockINotificationRepository)(nil).DeletePreference), userID)
+}
+
+// GetDigestPreferences mocks base method.
+func (m *MockINotificationRepository) GetDigestPreferences(prefs *[]model.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestPreferences", prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDigestPreferences indicates an expected call of GetDigestPreferences.
+func (mr *MockINotificationRepositoryMockRecorder) GetDigestPreferences(prefs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestPreferences", reflect.TypeOf((*MockINotificationRepository)(nil).GetDigestPreferences), prefs)
+}
+
+// GetPreference mocks base method.
+func (m *MockINotificationRepository) GetPreference(pref *model.NotificationPreference, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", pref, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockINotificationRepositoryMockRecorder) GetPreference(pref, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockINotificationRepository)(nil).GetPreference), pref, userID)
+}
+
+// GetPreferencesByUserIDs mocks base method.
+func (m *MockINotificationRepository) GetPreferencesByUserIDs(prefs *[]model.NotificationPreference, userIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferencesByUserIDs", prefs, userIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetPreferencesByUserIDs indicates an expected call of GetPreferencesByUserIDs.
+func (mr *MockINotificationRepositoryMockRecorder) GetPreferencesByUserIDs(prefs, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferencesByUserIDs", reflect.TypeOf((*MockINotificationRepository)(nil).GetPreferencesByUserIDs), prefs, userIDs)
+}
+
+// SavePreference mocks base method.
+func (m *MockINotificationRepository) SavePreference(pref *model.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreference", pref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreference indicates an expected call of SavePreference.
+func (mr *MockINotificationRepositoryMockRecorder) SavePreference(pref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreference", reflect.TypeOf((*MockINotificationRepository)(nil).SavePreference), pref)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/push_subscription_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/push_subscription_repository.go -destination=repository/mocks/push_subscription_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPushSubscriptionRepository is a mock of IPushSubscriptionRepository interface.
+type MockIPushSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPushSubscriptionRepositoryMockRecorder
+}
+
+// MockIPushSubscriptionRepositoryMockRecorder is the mock recorder for MockIPushSubscriptionRepository.
+type MockIPushSubscriptionRepositoryMockRecorder struct {
+	mock *MockIPushSubscriptionRepository
+}
+
+// NewMockIPushSubscriptionRepository creates a new mock instance.
+func NewMockIPushSubscriptionRepository(ctrl *gomock.Controller) *MockIPushSubscriptionRepository {
+	mock := &MockIPushSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockIPushSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPushSubscriptionRepository) EXPECT() *MockIPushSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockIPushSubscriptionRepository) DeleteSubscription(userID int, endpointHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", userID, endpointHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockIPushSubscriptionRepositoryMockRecorder) DeleteSubscription(userID, endpointHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockIPushSubscriptionRepository)(nil).DeleteSubscription), userID, endpointHash)
+}
+
+// DeleteSubscriptionByID mocks base method.
+func (m *MockIPushSubscriptionRepository) DeleteSubscriptionByID(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionByID indicates an expected call of DeleteSubscriptionByID.
+func (mr *MockIPushSubscriptionRepositoryMockRecorder) DeleteSubscriptionByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionByID", reflect.TypeOf((*MockIPushSubscriptionRepository)(nil).DeleteSubscriptionByID), id)
+}
+
+// GetOrCreateVAPIDKey mocks base method.
+func (m *MockIPushSubscriptionRepository) GetOrCreateVAPIDKey(key *model.VAPIDKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateVAPIDKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetOrCreateVAPIDKey indicates an expected call of GetOrCreateVAPIDKey.
+func (mr *MockIPushSubscriptionRepositoryMockRecorder) GetOrCreateVAPIDKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateVAPIDKey", reflect.TypeOf((*MockIPushSubscriptionRepository)(nil).GetOrCreateVAPIDKey), key)
+}
+
+// GetSubscriptionsByUserID mocks base method.
+func (m *MockIPushSubscriptionRepository) GetSubscriptionsByUserID(subs *[]model.PushSubscription, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsByUserID", subs, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetSubscriptionsByUserID indicates an expected call of GetSubscriptionsByUserID.
+func (mr *MockIPushSubscriptionRepositoryMockRecorder) GetSubscriptionsByUserID(subs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsByUserID", reflect.TypeOf((*MockIPushSubscriptionRepository)(nil).GetSubscriptionsByUserID), subs, userID)
+}
+
+// SaveSubscription mocks base method.
+func (m *MockIPushSubscriptionRepository) SaveSubscription(sub *model.PushSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSubscription", sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSubscription indicates an expected call of SaveSubscription.
+func (mr *MockIPushSubscriptionRepositoryMockRecorder) SaveSubscription(sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSubscription", reflect.TypeOf((*MockIPushSubscriptionRepository)(nil).SaveSubscription), sub)
+}
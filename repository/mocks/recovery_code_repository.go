@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/recovery_code_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/recovery_code_repository.go -destination=repository/mocks/recovery_code_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRecoveryCodeRepository is a mock of IRecoveryCodeRepository interface.
+type MockIRecoveryCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecoveryCodeRepositoryMockRecorder
+}
+
+// MockIRecoveryCodeRepositoryMockRecorder is the mock recorder for MockIRecoveryCodeRepository.
+type MockIRecoveryCodeRepositoryMockRecorder struct {
+	mock *MockIRecoveryCodeRepository
+}
+
+// NewMockIRecoveryCodeRepository creates a new mock instance.
+func NewMockIRecoveryCodeRepository(ctrl *gomock.Controller) *MockIRecoveryCodeRepository {
+	mock := &MockIRecoveryCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockIRecoveryCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecoveryCodeRepository) EXPECT() *MockIRecoveryCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRecoveryCodes mocks base method.
+func (m *MockIRecoveryCodeRepository) DeleteRecoveryCodes(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockIRecoveryCodeRepositoryMockRecorder) DeleteRecoveryCodes(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockIRecoveryCodeRepository)(nil).DeleteRecoveryCodes), userID)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockIRecoveryCodeRepository) ReplaceRecoveryCodes(userID int, codes []model.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", userID, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockIRecoveryCodeRepositoryMockRecorder) ReplaceRecoveryCodes(userID, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockIRecoveryCodeRepository)(nil).ReplaceRecoveryCodes), userID, codes)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockIRecoveryCodeRepository) UseRecoveryCode(userID int, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockIRecoveryCodeRepositoryMockRecorder) UseRecoveryCode(userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockIRecoveryCodeRepository)(nil).UseRecoveryCode), userID, codeHash)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/user_identity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/user_identity_repository.go -destination=repository/mocks/user_identity_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserIdentityRepository is a mock of IUserIdentityRepository interface.
+type MockIUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserIdentityRepositoryMockRecorder
+}
+
+// MockIUserIdentityRepositoryMockRecorder is the mock recorder for MockIUserIdentityRepository.
+type MockIUserIdentityRepositoryMockRecorder struct {
+	mock *MockIUserIdentityRepository
+}
+
+// NewMockIUserIdentityRepository creates a new mock instance.
+func NewMockIUserIdentityRepository(ctrl *gomock.Controller) *MockIUserIdentityRepository {
+	mock := &MockIUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserIdentityRepository) EXPECT() *MockIUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeLoginState mocks base method.
+func (m *MockIUserIdentityRepository) ConsumeLoginState(state *model.OIDCLoginState, stateHash, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLoginState", state, stateHash, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeLoginState indicates an expected call of ConsumeLoginState.
+func (mr *MockIUserIdentityRepositoryMockRecorder) ConsumeLoginState(state, stateHash, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLoginState", reflect.TypeOf((*MockIUserIdentityRepository)(nil).ConsumeLoginState), state, stateHash, provider)
+}
+
+// CreateIdentity mocks base method.
+func (m *MockIUserIdentityRepository) CreateIdentity(identity *model.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockIUserIdentityRepositoryMockRecorder) CreateIdentity(identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockIUserIdentityRepository)(nil).CreateIdentity), identity)
+}
+
+// CreateLoginState mocks base method.
+func (m *MockIUserIdentityRepository) CreateLoginState(state *model.OIDCLoginState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginState", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoginState indicates an expected call of CreateLoginState.
+func (mr *MockIUserIdentityRepositoryMockRecorder) CreateLoginState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginState", reflect.TypeOf((*MockIUserIdentityRepository)(nil).CreateLoginState), state)
+}
+
+// DeleteExpiredLoginStates mocks base method.
+func (m *MockIUserIdentityRepository) DeleteExpiredLoginStates(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLoginStates", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredLoginStates indicates an expected call of DeleteExpiredLoginStates.
+func (mr *MockIUserIdentityRepositoryMockRecorder) DeleteExpiredLoginStates(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoginStates", reflect.TypeOf((*MockIUserIdentityRepository)(nil).DeleteExpiredLoginStates), now)
+}
+
+// DeleteIdentity mocks base method.
+func (m *MockIUserIdentityRepository) DeleteIdentity(userID int, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdentity", userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdentity indicates an expected call of DeleteIdentity.
+func (mr *MockIUserIdentityRepositoryMockRecorder) DeleteIdentity(userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentity", reflect.TypeOf((*MockIUserIdentityRepository)(nil).DeleteIdentity), userID, provider)
+}
+
+// GetIdentitiesByUserID mocks base method.
+func (m *MockIUserIdentityRepository) GetIdentitiesByUserID(identities *[]model.UserIdentity, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentitiesByUserID", identities, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetIdentitiesByUserID indicates an expected call of GetIdentitiesByUserID.
+func (mr *MockIUserIdentityRepositoryMockRecorder) GetIdentitiesByUserID(identities, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentitiesByUserID", reflect.TypeOf((*MockIUserIdentityRepository)(nil).GetIdentitiesByUserID), identities, userID)
+}
+
+// GetIdentity mocks base method.
+func (m *MockIUserIdentityRepository) GetIdentity(identity *model.UserIdentity, provider, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", identity, provider, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockIUserIdentityRepositoryMockRecorder) GetIdentity(identity, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockIUserIdentityRepository)(nil).GetIdentity), identity, provider, subject)
+}
+
+// UpdateIdentityEmail mocks base method.
+func (m *MockIUserIdentityRepository) UpdateIdentityEmail(id int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIdentityEmail", id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIdentityEmail indicates an expected call of UpdateIdentityEmail.
+func (mr *MockIUserIdentityRepositoryMockRecorder) UpdateIdentityEmail(id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdentityEmail", reflect.TypeOf((*MockIUserIdentityRepository)(nil).UpdateIdentityEmail), id, email)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	model "RefrigeratorWatchdog-server/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -39,6 +40,20 @@ func (m *MockIUserRepository) EXPECT() *MockIUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClearPassword mocks base method.
+func (m *MockIUserRepository) ClearPassword(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearPassword", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearPassword indicates an expected call of ClearPassword.
+func (mr *MockIUserRepositoryMockRecorder) ClearPassword(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearPassword", reflect.TypeOf((*MockIUserRepository)(nil).ClearPassword), id)
+}
+
 // CreateUser mocks base method.
 func (m *MockIUserRepository) CreateUser(user *model.User) error {
 	m.ctrl.T.Helper()
@@ -67,6 +82,34 @@ func (mr *MockIUserRepositoryMockRecorder) DeleteUser(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserRepository)(nil).DeleteUser), user)
 }
 
+// DisableTOTP mocks base method.
+func (m *MockIUserRepository) DisableTOTP(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockIUserRepositoryMockRecorder) DisableTOTP(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockIUserRepository)(nil).DisableTOTP), id)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockIUserRepository) EnableTOTP(id int, counter int64, enabledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", id, counter, enabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockIUserRepositoryMockRecorder) EnableTOTP(id, counter, enabledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockIUserRepository)(nil).EnableTOTP), id, counter, enabledAt)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockIUserRepository) GetUserByEmail(user *model.User, email string) error {
 	m.ctrl.T.Helper()
@@ -81,6 +124,62 @@ func (mr *MockIUserRepositoryMockRecorder) GetUserByEmail(user, email any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByEmail), user, email)
 }
 
+// GetUserByID mocks base method.
+func (m *MockIUserRepository) GetUserByID(user *model.User, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIUserRepositoryMockRecorder) GetUserByID(user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIUserRepository)(nil).GetUserByID), user, id)
+}
+
+// MarkUserVerified mocks base method.
+func (m *MockIUserRepository) MarkUserVerified(id int, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserVerified", id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUserVerified indicates an expected call of MarkUserVerified.
+func (mr *MockIUserRepositoryMockRecorder) MarkUserVerified(id, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserVerified", reflect.TypeOf((*MockIUserRepository)(nil).MarkUserVerified), id, verifiedAt)
+}
+
+// SetTOTPSecret mocks base method.
+func (m *MockIUserRepository) SetTOTPSecret(id int, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTOTPSecret", id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTOTPSecret indicates an expected call of SetTOTPSecret.
+func (mr *MockIUserRepositoryMockRecorder) SetTOTPSecret(id, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTOTPSecret", reflect.TypeOf((*MockIUserRepository)(nil).SetTOTPSecret), id, secret)
+}
+
+// UnmarkUserVerified mocks base method.
+func (m *MockIUserRepository) UnmarkUserVerified(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkUserVerified", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkUserVerified indicates an expected call of UnmarkUserVerified.
+func (mr *MockIUserRepositoryMockRecorder) UnmarkUserVerified(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkUserVerified", reflect.TypeOf((*MockIUserRepository)(nil).UnmarkUserVerified), id)
+}
+
 // UpdateUser mocks base method.
 func (m *MockIUserRepository) UpdateUser(user *model.User, email string) error {
 	m.ctrl.T.Helper()
@@ -94,3 +193,17 @@ func (mr *MockIUserRepositoryMockRecorder) UpdateUser(user, email any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserRepository)(nil).UpdateUser), user, email)
 }
+
+// UseTOTPCounter mocks base method.
+func (m *MockIUserRepository) UseTOTPCounter(id int, counter int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPCounter", id, counter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPCounter indicates an expected call of UseTOTPCounter.
+func (mr *MockIUserRepositoryMockRecorder) UseTOTPCounter(id, counter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPCounter", reflect.TypeOf((*MockIUserRepository)(nil).UseTOTPCounter), id, counter)
+}
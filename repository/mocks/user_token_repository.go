@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/user_token_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/user_token_repository.go -destination=repository/mocks/user_token_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIUserTokenRepository is a mock of IUserTokenRepository interface.
+type MockIUserTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserTokenRepositoryMockRecorder
+}
+
+// MockIUserTokenRepositoryMockRecorder is the mock recorder for MockIUserTokenRepository.
+type MockIUserTokenRepositoryMockRecorder struct {
+	mock *MockIUserTokenRepository
+}
+
+// NewMockIUserTokenRepository creates a new mock instance.
+func NewMockIUserTokenRepository(ctrl *gomock.Controller) *MockIUserTokenRepository {
+	mock := &MockIUserTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockIUserTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserTokenRepository) EXPECT() *MockIUserTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateUserToken mocks base method.
+func (m *MockIUserTokenRepository) CreateUserToken(token *model.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockIUserTokenRepositoryMockRecorder) CreateUserToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockIUserTokenRepository)(nil).CreateUserToken), token)
+}
+
+// GetLatestUserToken mocks base method.
+func (m *MockIUserTokenRepository) GetLatestUserToken(token *model.UserToken, userID int, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestUserToken", token, userID, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLatestUserToken indicates an expected call of GetLatestUserToken.
+func (mr *MockIUserTokenRepositoryMockRecorder) GetLatestUserToken(token, userID, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestUserToken", reflect.TypeOf((*MockIUserTokenRepository)(nil).GetLatestUserToken), token, userID, purpose)
+}
+
+// GetUserTokenByHash mocks base method.
+func (m *MockIUserTokenRepository) GetUserTokenByHash(token *model.UserToken, tokenHash, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTokenByHash", token, tokenHash, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserTokenByHash indicates an expected call of GetUserTokenByHash.
+func (mr *MockIUserTokenRepositoryMockRecorder) GetUserTokenByHash(token, tokenHash, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokenByHash", reflect.TypeOf((*MockIUserTokenRepository)(nil).GetUserTokenByHash), token, tokenHash, purpose)
+}
+
+// InvalidateUserTokens mocks base method.
+func (m *MockIUserTokenRepository) InvalidateUserTokens(userID int, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateUserTokens", userID, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateUserTokens indicates an expected call of InvalidateUserTokens.
+func (mr *MockIUserTokenRepositoryMockRecorder) InvalidateUserTokens(userID, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUserTokens", reflect.TypeOf((*MockIUserTokenRepository)(nil).InvalidateUserTokens), userID, purpose)
+}
+
+// UseUserToken mocks base method.
+func (m *MockIUserTokenRepository) UseUserToken(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserToken", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseUserToken indicates an expected call of UseUserToken.
+func (mr *MockIUserTokenRepositoryMockRecorder) UseUserToken(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserToken", reflect.TypeOf((*MockIUserTokenRepository)(nil).UseUserToken), id)
+}
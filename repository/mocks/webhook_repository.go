@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository/webhook_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repository/webhook_repository.go -destination=repository/mocks/webhook_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookRepository is a mock of IWebhookRepository interface.
+type MockIWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookRepositoryMockRecorder
+}
+
+// MockIWebhookRepositoryMockRecorder is the mock recorder for MockIWebhookRepository.
+type MockIWebhookRepositoryMockRecorder struct {
+	mock *MockIWebhookRepository
+}
+
+// NewMockIWebhookRepository creates a new mock instance.
+func NewMockIWebhookRepository(ctrl *gomock.Controller) *MockIWebhookRepository {
+	mock := &MockIWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockIWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookRepository) EXPECT() *MockIWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *MockIWebhookRepository) ClaimDelivery(id int, now, lockedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", id, now, lockedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockIWebhookRepositoryMockRecorder) ClaimDelivery(id, now, lockedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*MockIWebhookRepository)(nil).ClaimDelivery), id, now, lockedUntil)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockIWebhookRepository) CreateDeliveries(deliveries []model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockIWebhookRepositoryMockRecorder) CreateDeliveries(deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockIWebhookRepository)(nil).CreateDeliveries), deliveries)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockIWebhookRepository) CreateWebhook(webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockIWebhookRepositoryMockRecorder) CreateWebhook(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockIWebhookRepository)(nil).CreateWebhook), webhook)
+}
+
+// DeleteDeliveriesBefore mocks base method.
+func (m *MockIWebhookRepository) DeleteDeliveriesBefore(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeliveriesBefore", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDeliveriesBefore indicates an expected call of DeleteDeliveriesBefore.
+func (mr *MockIWebhookRepositoryMockRecorder) DeleteDeliveriesBefore(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeliveriesBefore", reflect.TypeOf((*MockIWebhookRepository)(nil).DeleteDeliveriesBefore), before)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockIWebhookRepository) DeleteWebhook(householdID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", householdID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockIWebhookRepositoryMockRecorder) DeleteWebhook(householdID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockIWebhookRepository)(nil).DeleteWebhook), householdID, id)
+}
+
+// GetDeliveriesByWebhookID mocks base method.
+func (m *MockIWebhookRepository) GetDeliveriesByWebhookID(deliveries *[]model.WebhookDelivery, webhookID, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveriesByWebhookID", deliveries, webhookID, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDeliveriesByWebhookID indicates an expected call of GetDeliveriesByWebhookID.
+func (mr *MockIWebhookRepositoryMockRecorder) GetDeliveriesByWebhookID(deliveries, webhookID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveriesByWebhookID", reflect.TypeOf((*MockIWebhookRepository)(nil).GetDeliveriesByWebhookID), deliveries, webhookID, limit)
+}
+
+// GetDueDeliveries mocks base method.
+func (m *MockIWebhookRepository) GetDueDeliveries(deliveries *[]model.WebhookDelivery, now time.Time, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeliveries", deliveries, now, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetDueDeliveries indicates an expected call of GetDueDeliveries.
+func (mr *MockIWebhookRepositoryMockRecorder) GetDueDeliveries(deliveries, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeliveries", reflect.TypeOf((*MockIWebhookRepository)(nil).GetDueDeliveries), deliveries, now, limit)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockIWebhookRepository) GetWebhookByID(webhook *model.Webhook, householdID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", webhook, householdID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockIWebhookRepositoryMockRecorder) GetWebhookByID(webhook, householdID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockIWebhookRepository)(nil).GetWebhookByID), webhook, householdID, id)
+}
+
+// GetWebhooksByHouseholdID mocks base method.
+func (m *MockIWebhookRepository) GetWebhooksByHouseholdID(webhooks *[]model.Webhook, householdID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByHouseholdID", webhooks, householdID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWebhooksByHouseholdID indicates an expected call of GetWebhooksByHouseholdID.
+func (mr *MockIWebhookRepositoryMockRecorder) GetWebhooksByHouseholdID(webhooks, householdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByHouseholdID", reflect.TypeOf((*MockIWebhookRepository)(nil).GetWebhooksByHouseholdID), webhooks, householdID)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockIWebhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockIWebhookRepositoryMockRecorder) UpdateDelivery(delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockIWebhookRepository)(nil).UpdateDelivery), delivery)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockIWebhookRepository) UpdateWebhook(webhook *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockIWebhookRepositoryMockRecorder) UpdateWebhook(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockIWebhookRepository)(nil).UpdateWebhook), webhook)
+}
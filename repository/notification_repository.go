@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// INotificationRepository is an interface for notification preferences and
+// the record of sent expiry alerts.
+type INotificationRepository interface {
+	GetPreference(pref *model.NotificationPreference, userID int) error
+	GetPreferencesByUserIDs(prefs *[]model.NotificationPreference, userIDs []int) error
+	SavePreference(pref *model.NotificationPreference) error
+	DeletePreference(userID int) error
+	ClaimExpiryAlert(alert *model.ExpiryAlert) (bool, error)
+	DeleteExpiryAlert(id int) error
+	DeleteExpiryAlertsBefore(before time.Time) (int64, error)
+	GetDigestPreferences(prefs *[]model.NotificationPreference) error
+	ClaimDigestDelivery(delivery *model.DigestDelivery) (bool, error)
+	DeleteDigestDelivery(id int) error
+	DeleteDigestDeliveriesBefore(before time.Time) (int64, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new instance of the notificationRepository struct.
+func NewNotificationRepository(db *gorm.DB) INotificationRepository {
+	return &notificationRepository{db}
+}
+
+func (nr *notificationRepository) GetPreference(pref *model.NotificationPreference, userID int) error {
+	if err := nr.db.Where("user_id = ?", userID).First(pref).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (nr *notificationRepository) GetPreferencesByUserIDs(prefs *[]model.NotificationPreference, userIDs []int) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if err := nr.db.Where("user_id IN ?", userIDs).Find(prefs).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SavePreference creates or replaces the preferences of pref.UserID.
+func (nr *notificationRepository) SavePreference(pref *model.NotificationPreference) error {
+	if err := nr.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(pref).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeletePreference deletes the preferences of the user, who then gets the defaults.
+func (nr *notificationRepository) DeletePreference(userID int) error {
+	return nr.db.Where("user_id = ?", userID).Delete(&model.NotificationPreference{}).Error
+}
+
+// ClaimExpiryAlert records the alert unless the same alert was already
+// recorded, by this or another server replica, and reports whether it did.
+// Only the caller that claimed an alert sends it.
+func (nr *notificationRepository) ClaimExpiryAlert(alert *model.ExpiryAlert) (bool, error) {
+	result := nr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpiryAlert releases a claimed alert that could not be sent so that
+// it is claimed again by the next run.
+func (nr *notificationRepository) DeleteExpiryAlert(id int) error {
+	return nr.db.Where("id = ?", id).Delete(&model.ExpiryAlert{}).Error
+}
+
+// DeleteExpiryAlertsBefore deletes the alerts of foods that expired before
+// before, which can no longer be sent again.
+func (nr *notificationRepository) DeleteExpiryAlertsBefore(before time.Time) (int64, error) {
+	result := nr.db.Where("expiration_date < ?", before).Delete(&model.ExpiryAlert{})
+	return result.RowsAffected, result.Error
+}
+
+// GetDigestPreferences returns the preferences of the users who get digests,
+// with the users.
+func (nr *notificationRepository) GetDigestPreferences(prefs *[]model.NotificationPreference) error {
+	if err := nr.db.Preload("User").Where("delivery = ?", model.NotificationDeliveryDigest).Order("user_id").Find(prefs).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimDigestDelivery records the digest unless it was already recorded and
+// reports whether it did, like ClaimExpiryAlert.
+func (nr *notificationRepository) ClaimDigestDelivery(delivery *model.DigestDelivery) (bool, error) {
+	result := nr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteDigestDelivery releases a claimed digest that could not be sent.
+func (nr *notificationRepository) DeleteDigestDelivery(id int) error {
+	return nr.db.Where("id = ?", id).Delete(&model.DigestDelivery{}).Error
+}
+
+// DeleteDigestDeliveriesBefore deletes the records of digests sent before
+// before, whose periods are over.
+func (nr *notificationRepository) DeleteDigestDeliveriesBefore(before time.Time) (int64, error) {
+	result := nr.db.Where("sent_at < ?", before).Delete(&model.DigestDelivery{})
+	return result.RowsAffected, result.Error
+}
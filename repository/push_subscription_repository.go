@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IPushSubscriptionRepository is an interface for the Web Push subscriptions
+// of users and the VAPID key of the server.
+type IPushSubscriptionRepository interface {
+	SaveSubscription(sub *model.PushSubscription) error
+	GetSubscriptionsByUserID(subs *[]model.PushSubscription, userID int) error
+	DeleteSubscription(userID int, endpointHash string) error
+	DeleteSubscriptionByID(id int) error
+	GetOrCreateVAPIDKey(key *model.VAPIDKey) error
+}
+
+type pushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewPushSubscriptionRepository creates a new instance of the pushSubscriptionRepository struct.
+func NewPushSubscriptionRepository(db *gorm.DB) IPushSubscriptionRepository {
+	return &pushSubscriptionRepository{db}
+}
+
+// SaveSubscription creates the subscription, or replaces the subscription
+// with the same endpoint.
+func (pr *pushSubscriptionRepository) SaveSubscription(sub *model.PushSubscription) error {
+	if err := pr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "endpoint_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "endpoint", "p256dh", "auth", "user_agent", "updated_at"}),
+	}).Create(sub).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *pushSubscriptionRepository) GetSubscriptionsByUserID(subs *[]model.PushSubscription, userID int) error {
+	if err := pr.db.Where("user_id = ?", userID).Order("id").Find(subs).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteSubscription returns model.ErrPushSubscriptionNotFound if the user
+// has no subscription with the endpoint.
+func (pr *pushSubscriptionRepository) DeleteSubscription(userID int, endpointHash string) error {
+	result := pr.db.Where("user_id = ? AND endpoint_hash = ?", userID, endpointHash).Delete(&model.PushSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrPushSubscriptionNotFound
+	}
+	return nil
+}
+
+func (pr *pushSubscriptionRepository) DeleteSubscriptionByID(id int) error {
+	return pr.db.Where("id = ?", id).Delete(&model.PushSubscription{}).Error
+}
+
+// GetOrCreateVAPIDKey stores key unless a key was already stored, by this or
+// another server replica, and loads the stored key into key.
+func (pr *pushSubscriptionRepository) GetOrCreateVAPIDKey(key *model.VAPIDKey) error {
+	key.ID = 1
+	if err := pr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(key).Error; err != nil {
+		return err
+	}
+	if err := pr.db.Where("id = ?", 1).First(key).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IRecoveryCodeRepository is an interface for managing two-factor recovery codes.
+type IRecoveryCodeRepository interface {
+	ReplaceRecoveryCodes(userID int, codes []model.RecoveryCode) error
+	UseRecoveryCode(userID int, codeHash string) error
+	DeleteRecoveryCodes(userID int) error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository creates a new instance of the recoveryCodeRepository struct.
+func NewRecoveryCodeRepository(db *gorm.DB) IRecoveryCodeRepository {
+	return &recoveryCodeRepository{db}
+}
+
+// ReplaceRecoveryCodes deletes the existing codes of the user and stores the new ones.
+func (rr *recoveryCodeRepository) ReplaceRecoveryCodes(userID int, codes []model.RecoveryCode) error {
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marks an unused code of the user as used. It returns
+// model.ErrInvalidTOTPCode if no such code exists.
+func (rr *recoveryCodeRepository) UseRecoveryCode(userID int, codeHash string) error {
+	result := rr.db.Model(&model.RecoveryCode{}).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (rr *recoveryCodeRepository) DeleteRecoveryCodes(userID int) error {
+	return rr.db.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+}
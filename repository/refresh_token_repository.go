@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IRefreshTokenRepository is an interface for managing refresh tokens.
+type IRefreshTokenRepository interface {
+	CreateRefreshToken(token *model.RefreshToken) error
+	GetRefreshTokenByHash(token *model.RefreshToken, tokenHash string) error
+	RevokeRefreshToken(id int) error
+	RevokeRefreshTokenFamily(familyID string) error
+	RevokeRefreshTokensByUserID(userID int) error
+	DeleteExpiredRefreshTokens(now time.Time) (int64, error)
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new instance of the refreshTokenRepository struct.
+func NewRefreshTokenRepository(db *gorm.DB) IRefreshTokenRepository {
+	return &refreshTokenRepository{db}
+}
+
+func (rr *refreshTokenRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	if err := rr.db.Create(token).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) GetRefreshTokenByHash(token *model.RefreshToken, tokenHash string) error {
+	if err := rr.db.Preload("User").Where("token_hash = ?", tokenHash).First(token).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RevokeRefreshToken revokes the token only if it has not been revoked yet,
+// so that two concurrent refreshes with the same token cannot both succeed.
+func (rr *refreshTokenRepository) RevokeRefreshToken(id int) error {
+	result := rr.db.Model(&model.RefreshToken{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrRefreshTokenRevoked
+	}
+	return nil
+}
+
+func (rr *refreshTokenRepository) RevokeRefreshTokenFamily(familyID string) error {
+	return rr.db.Model(&model.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", time.Now()).Error
+}
+
+func (rr *refreshTokenRepository) RevokeRefreshTokensByUserID(userID int) error {
+	return rr.db.Model(&model.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).Update("revoked_at", time.Now()).Error
+}
+
+func (rr *refreshTokenRepository) DeleteExpiredRefreshTokens(now time.Time) (int64, error) {
+	result := rr.db.Where("expires_at < ?", now).Delete(&model.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func Test_refreshTokenRepository_RevokeRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// モックリポジトリの作成
+	mockRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+
+	type args struct {
+		id int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{name: "正常系：リフレッシュトークンを失効できる",
+			args: args{
+				id: 1,
+			},
+			wantErr: false,
+		},
+		{name: "異常系：失効済みのリフレッシュトークン",
+			args: args{
+				id: 2,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				mockRepo.EXPECT().RevokeRefreshToken(tt.args.id).Return(model.ErrRefreshTokenRevoked)
+			} else {
+				mockRepo.EXPECT().RevokeRefreshToken(tt.args.id).Return(nil)
+			}
+
+			if err := mockRepo.RevokeRefreshToken(tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("refreshTokenRepository.RevokeRefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
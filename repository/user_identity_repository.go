@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IUserIdentityRepository is an interface for OpenID Connect identities and
+// the state of logins in progress.
+type IUserIdentityRepository interface {
+	GetIdentity(identity *model.UserIdentity, provider string, subject string) error
+	GetIdentitiesByUserID(identities *[]model.UserIdentity, userID int) error
+	CreateIdentity(identity *model.UserIdentity) error
+	UpdateIdentityEmail(id int, email string) error
+	DeleteIdentity(userID int, provider string) error
+	CreateLoginState(state *model.OIDCLoginState) error
+	ConsumeLoginState(state *model.OIDCLoginState, stateHash string, provider string) error
+	DeleteExpiredLoginStates(now time.Time) (int64, error)
+}
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository creates a new instance of the userIdentityRepository struct.
+func NewUserIdentityRepository(db *gorm.DB) IUserIdentityRepository {
+	return &userIdentityRepository{db}
+}
+
+func (ir *userIdentityRepository) GetIdentity(identity *model.UserIdentity, provider string, subject string) error {
+	if err := ir.db.Preload("User").Where("provider = ? AND subject = ?", provider, subject).First(identity).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ir *userIdentityRepository) GetIdentitiesByUserID(identities *[]model.UserIdentity, userID int) error {
+	if err := ir.db.Where("user_id = ?", userID).Order("created_at").Find(identities).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ir *userIdentityRepository) CreateIdentity(identity *model.UserIdentity) error {
+	if err := ir.db.Create(identity).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (ir *userIdentityRepository) UpdateIdentityEmail(id int, email string) error {
+	return ir.db.Model(&model.UserIdentity{}).Where("id = ?", id).Update("email", email).Error
+}
+
+func (ir *userIdentityRepository) DeleteIdentity(userID int, provider string) error {
+	result := ir.db.Where("user_id = ? AND provider = ?", userID, provider).Delete(&model.UserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrUserIdentityNotFound
+	}
+	return nil
+}
+
+func (ir *userIdentityRepository) CreateLoginState(state *model.OIDCLoginState) error {
+	if err := ir.db.Create(state).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsumeLoginState loads and deletes the state in one step, so that a state
+// cannot be used by two callbacks. Expired states are not returned.
+func (ir *userIdentityRepository) ConsumeLoginState(state *model.OIDCLoginState, stateHash string, provider string) error {
+	return ir.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ? AND provider = ? AND expires_at > ?", stateHash, provider, time.Now()).First(state).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrInvalidToken
+			}
+			return err
+		}
+		result := tx.Delete(&model.OIDCLoginState{}, state.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return model.ErrInvalidToken
+		}
+		return nil
+	})
+}
+
+func (ir *userIdentityRepository) DeleteExpiredLoginStates(now time.Time) (int64, error) {
+	result := ir.db.Where("expires_at <= ?", now).Delete(&model.OIDCLoginState{})
+	return result.RowsAffected, result.Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"RefrigeratorWatchdog-server/model"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -11,9 +12,17 @@ import (
 // IUserRepository is an interface for user repository.
 type IUserRepository interface {
 	GetUserByEmail(user *model.User, email string) error
+	GetUserByID(user *model.User, id int) error
 	CreateUser(user *model.User) error
 	UpdateUser(user *model.User,email string) error
 	DeleteUser(user *model.User) error
+	MarkUserVerified(id int, verifiedAt time.Time) error
+	UnmarkUserVerified(id int) error
+	SetTOTPSecret(id int, secret string) error
+	EnableTOTP(id int, counter int64, enabledAt time.Time) error
+	UseTOTPCounter(id int, counter int64) error
+	DisableTOTP(id int) error
+	ClearPassword(id int) error
 }
 
 type userRepository struct {
@@ -32,6 +41,13 @@ func (ur *userRepository) GetUserByEmail(user *model.User, email string) error {
 	return nil
 }
 
+func (ur *userRepository) GetUserByID(user *model.User, id int) error {
+	if err := ur.db.Where("id = ?", id).First(user).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (ur *userRepository) CreateUser(user *model.User) error {
 	if err := ur.db.Create(user).Error; err != nil {
 		return err
@@ -63,3 +79,84 @@ func (ur *userRepository) DeleteUser(user *model.User) error {
 	}
 	return nil
 }
+
+func (ur *userRepository) MarkUserVerified(id int, verifiedAt time.Time) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND verified_at IS NULL", id).Update("verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrEmailAlreadyVerified
+	}
+	return nil
+}
+
+// UnmarkUserVerified clears the verification of the email address, which has
+// to be verified again after it changes.
+func (ur *userRepository) UnmarkUserVerified(id int) error {
+	return ur.db.Model(&model.User{}).Where("id = ?", id).Update("verified_at", nil).Error
+}
+
+// SetTOTPSecret stores a pending TOTP secret unless two-factor authentication
+// is already enabled.
+func (ur *userRepository) SetTOTPSecret(id int, secret string) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND totp_enabled_at IS NULL", id).Update("totp_secret", secret)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+// EnableTOTP enables the pending TOTP secret and records the time step of the
+// code used to confirm it.
+func (ur *userRepository) EnableTOTP(id int, counter int64, enabledAt time.Time) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND totp_enabled_at IS NULL AND totp_secret <> ''", id).Updates(map[string]interface{}{
+		"totp_enabled_at":   enabledAt,
+		"totp_last_counter": counter,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrTwoFactorAlreadyEnabled
+	}
+	return nil
+}
+
+// UseTOTPCounter records the time step of an accepted TOTP code. It fails if
+// a code of the same or a later time step has already been used, so that a
+// code cannot be replayed.
+func (ur *userRepository) UseTOTPCounter(id int, counter int64) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND totp_last_counter < ?", id, counter).Update("totp_last_counter", counter)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (ur *userRepository) DisableTOTP(id int) error {
+	result := ur.db.Model(&model.User{}).Where("id = ? AND totp_enabled_at IS NOT NULL", id).Updates(map[string]interface{}{
+		"totp_secret":       "",
+		"totp_enabled_at":   nil,
+		"totp_last_counter": 0,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrTwoFactorNotEnabled
+	}
+	return nil
+}
+
+// ClearPassword removes the password so that the user can only log in with a
+// linked identity until a new password is set through a password reset.
+func (ur *userRepository) ClearPassword(id int) error {
+	return ur.db.Model(&model.User{}).Where("id = ?", id).Update("password", "").Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IUserTokenRepository is an interface for managing single-use user tokens.
+type IUserTokenRepository interface {
+	CreateUserToken(token *model.UserToken) error
+	GetUserTokenByHash(token *model.UserToken, tokenHash string, purpose string) error
+	GetLatestUserToken(token *model.UserToken, userID int, purpose string) error
+	UseUserToken(id int) error
+	InvalidateUserTokens(userID int, purpose string) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates a new instance of the userTokenRepository struct.
+func NewUserTokenRepository(db *gorm.DB) IUserTokenRepository {
+	return &userTokenRepository{db}
+}
+
+func (tr *userTokenRepository) CreateUserToken(token *model.UserToken) error {
+	if err := tr.db.Create(token).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tr *userTokenRepository) GetUserTokenByHash(token *model.UserToken, tokenHash string, purpose string) error {
+	if err := tr.db.Preload("User").Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(token).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tr *userTokenRepository) GetLatestUserToken(token *model.UserToken, userID int, purpose string) error {
+	if err := tr.db.Where("user_id = ? AND purpose = ?", userID, purpose).Order("created_at DESC").First(token).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UseUserToken marks the token as used only if it has not been used yet,
+// so that a token cannot be consumed twice by concurrent requests.
+func (tr *userTokenRepository) UseUserToken(id int) error {
+	result := tr.db.Model(&model.UserToken{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrInvalidToken
+	}
+	return nil
+}
+
+func (tr *userTokenRepository) InvalidateUserTokens(userID int, purpose string) error {
+	return tr.db.Model(&model.UserToken{}).Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).Update("used_at", time.Now()).Error
+}
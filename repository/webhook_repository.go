@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWebhookRepository is an interface for webhooks and their deliveries.
+type IWebhookRepository interface {
+	CreateWebhook(webhook *model.Webhook) error
+	GetWebhooksByHouseholdID(webhooks *[]model.Webhook, householdID int) error
+	GetWebhookByID(webhook *model.Webhook, householdID int, id int) error
+	UpdateWebhook(webhook *model.Webhook) error
+	DeleteWebhook(householdID int, id int) error
+	CreateDeliveries(deliveries []model.WebhookDelivery) error
+	GetDeliveriesByWebhookID(deliveries *[]model.WebhookDelivery, webhookID int, limit int) error
+	GetDueDeliveries(deliveries *[]model.WebhookDelivery, now time.Time, limit int) error
+	ClaimDelivery(id int, now time.Time, lockedUntil time.Time) (bool, error)
+	UpdateDelivery(delivery *model.WebhookDelivery) error
+	DeleteDeliveriesBefore(before time.Time) (int64, error)
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new instance of the webhookRepository struct.
+func NewWebhookRepository(db *gorm.DB) IWebhookRepository {
+	return &webhookRepository{db}
+}
+
+func (wr *webhookRepository) CreateWebhook(webhook *model.Webhook) error {
+	if err := wr.db.Create(webhook).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (wr *webhookRepository) GetWebhooksByHouseholdID(webhooks *[]model.Webhook, householdID int) error {
+	if err := wr.db.Where("household_id = ?", householdID).Order("id").Find(webhooks).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (wr *webhookRepository) GetWebhookByID(webhook *model.Webhook, householdID int, id int) error {
+	if err := wr.db.Where("household_id = ? AND id = ?", householdID, id).First(webhook).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateWebhook changes the URL and the events of the webhook.
+func (wr *webhookRepository) UpdateWebhook(webhook *model.Webhook) error {
+	result := wr.db.Model(webhook).
+		Where("household_id = ?", webhook.HouseholdID).
+		Select("url", "events").
+		Updates(model.Webhook{URL: webhook.URL, Events: webhook.Events})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DeleteWebhook returns model.ErrWebhookNotFound if the webhook does not
+// exist in the household. Its deliveries are deleted with it.
+func (wr *webhookRepository) DeleteWebhook(householdID int, id int) error {
+	result := wr.db.Where("household_id = ? AND id = ?", householdID, id).Delete(&model.Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return model.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// CreateDeliveries queues the deliveries, skipping events already queued
+// for the same webhook.
+func (wr *webhookRepository) CreateDeliveries(deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := wr.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDeliveriesByWebhookID returns the latest deliveries of the webhook, newest first.
+func (wr *webhookRepository) GetDeliveriesByWebhookID(deliveries *[]model.WebhookDelivery, webhookID int, limit int) error {
+	if err := wr.db.Where("webhook_id = ?", webhookID).Order("created_at DESC, id DESC").Limit(limit).Find(deliveries).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDueDeliveries returns pending deliveries whose next attempt is due,
+// oldest first, with their webhooks.
+func (wr *webhookRepository) GetDueDeliveries(deliveries *[]model.WebhookDelivery, now time.Time, limit int) error {
+	if err := wr.db.Preload("Webhook").
+		Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(deliveries).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimDelivery postpones the next attempt of a due delivery to lockedUntil
+// and reports whether it did. Only the server replica that claimed a
+// delivery attempts it; if the replica stops, the delivery is due again
+// after lockedUntil.
+func (wr *webhookRepository) ClaimDelivery(id int, now time.Time, lockedUntil time.Time) (bool, error) {
+	result := wr.db.Model(&model.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, model.WebhookDeliveryPending, now).
+		Update("next_attempt_at", lockedUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateDelivery saves the result of an attempt.
+func (wr *webhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	return wr.db.Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "response_code", "error", "delivered_at").
+		Updates(delivery).Error
+}
+
+// DeleteDeliveriesBefore deletes deliveries queued before before that are
+// no longer pending.
+func (wr *webhookRepository) DeleteDeliveriesBefore(before time.Time) (int64, error) {
+	result := wr.db.Where("created_at < ? AND status <> ?", before, model.WebhookDeliveryPending).Delete(&model.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
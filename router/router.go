@@ -3,41 +3,98 @@ package router
 import (
 	"RefrigeratorWatchdog-server/controller"
 	_ "RefrigeratorWatchdog-server/docs"
+	"RefrigeratorWatchdog-server/model"
+	"log"
+	"net"
+	"os"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// ipExtractor returns how the IP address of clients is determined. By default
+// it is the address of the connection, and X-Forwarded-For is only used when
+// TRUSTED_PROXIES lists the comma-separated IP addresses or CIDR ranges of the
+// reverse proxies in front of the server.
+func ipExtractor() echo.IPExtractor {
+	// 既定で信頼されるループバックやプライベートネットワークも、指定がなければ信頼しない
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	trusted := 0
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.Fatalln("invalid TRUSTED_PROXIES:", err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+		trusted++
+	}
+	if trusted == 0 {
+		return echo.ExtractIPDirect()
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 // @title Echo Swagger Example API
 // @version 1.0
 // @description This is a sample server for Swagger using Echo.
 
 // @host localhost:1323
 // @BasePath /api/v1
-func NewRouter(fc controller.IFoodController, uc controller.IUserController, ic controller.IImageController) *echo.Echo {
+func NewRouter(fc controller.IFoodController, uc controller.IUserController, ic controller.IImageController, hc controller.IHouseholdController, kc controller.IAPIKeyController, oc controller.IOIDCController, ac controller.IAuditController, nc controller.INotificationController, wc controller.IWebhookController, pc controller.IPushController, dc controller.IDigestController, lc controller.IAnalyticsController, auth echo.MiddlewareFunc, deviceAuth echo.MiddlewareFunc, pm controller.IPermissionMiddleware) *echo.Echo {
 	e := echo.New()
+	// ログインの制限や監査ログに使うクライアントのIPアドレスを、偽装できるヘッダーから取らない
+	e.IPExtractor = ipExtractor()
+	// 監査ログに記録するリクエストIDを発行する
+	e.Use(middleware.RequestID())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:3000"},
 		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept,
-			echo.HeaderAccessControlAllowHeaders},
-		AllowMethods:     []string{"GET", "PUT", "POST", "DELETE"},
+			echo.HeaderAccessControlAllowHeaders, echo.HeaderAuthorization, "If-Match"},
+		AllowMethods:     []string{"GET", "PUT", "PATCH", "POST", "DELETE"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true,
 	}))
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	f := e.Group("/foods")
-	f.GET("/:id", fc.GetFoodsByUserID)
-	f.POST("", fc.CreateFood)
-	f.PUT("/:id", fc.UpdateFood)
-	f.DELETE("/:id", fc.DeleteFood)
+	// 認証が必要なルート（デバイスのAPIキーも受け付ける）
+	f := e.Group("/foods", deviceAuth)
+	f.GET("", fc.GetFoodsByUserID, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/expiring", fc.GetExpiringFoods, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/expired", fc.GetExpiredFoods, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/history", fc.GetFoodHistory, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/trash", fc.GetTrashedFoods, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/analytics/waste", lc.GetWasteSummary, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/analytics/waste/tags", lc.GetWasteByTag, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/analytics/waste/months", lc.GetWasteByMonth, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/analytics/waste/storage-locations", lc.GetWasteByStorageLocation, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/analytics/waste/top-items", lc.GetTopWastedItems, pm.RequireActive(model.PermissionFoodRead))
+	f.GET("/:id", fc.GetFood, pm.RequireActive(model.PermissionFoodRead))
+	f.POST("", fc.CreateFood, pm.RequireActive(model.PermissionFoodWrite))
+	f.PUT("/:id", fc.UpdateFood, pm.RequireActive(model.PermissionFoodWrite))
+	f.PATCH("/:id", fc.PatchFood, pm.RequireActive(model.PermissionFoodWrite))
+	f.DELETE("/:id", fc.DeleteFood, pm.RequireActive(model.PermissionFoodDelete))
+	f.POST("/:id/consume", fc.ConsumeFood, pm.RequireActive(model.PermissionFoodWrite))
+	f.POST("/:id/dispose", fc.DisposeFood, pm.RequireActive(model.PermissionFoodDelete))
+	f.POST("/:id/restore", fc.RestoreFood, pm.RequireActive(model.PermissionFoodDelete))
 
 	//POST例
 	/*
 	   	{
 	     "name": "オレンジ",
-	     "user_id": 1,
 	     "original_code": 12456456,
 	     "quantity": 5,
 	     "expiration_date": "2024-12-15T00:00:00Z",
@@ -47,12 +104,36 @@ func NewRouter(fc controller.IFoodController, uc controller.IUserController, ic
 	*/
 
 	u := e.Group("/users")
-	u.GET("/:email", uc.GetUser)
+	// 認証なしでアクセスできるルート
 	u.POST("", uc.CreateUser)
-	u.PUT("/:email", uc.UpdateUser)
-	// DELETEする際にuser情報をすべて送信する必要がある
-	u.DELETE("", uc.DeleteUser)
 	u.POST("/login", uc.LoginUser)
+	u.POST("/token/refresh", uc.RefreshToken)
+	u.POST("/logout", uc.Logout)
+	u.POST("/password/forgot", uc.ForgotPassword)
+	u.POST("/password/reset", uc.ResetPassword)
+	u.GET("/verify", uc.VerifyEmail)
+	u.POST("/verify/resend", uc.ResendVerificationEmail, auth)
+	u.GET("/unlock", uc.UnlockAccount)
+	u.POST("/login/2fa", uc.LoginTwoFactor)
+	u.GET("/oidc/providers", oc.GetProviders)
+	u.GET("/oidc/:provider/authorize", oc.StartLogin)
+	u.POST("/oidc/:provider/callback", oc.CompleteLogin)
+	u.GET("/identities", oc.GetIdentities, auth)
+	u.DELETE("/identities/:provider", oc.UnlinkIdentity, auth)
+	u.POST("/2fa/totp", uc.EnrollTOTP, auth)
+	u.POST("/2fa/totp/confirm", uc.ConfirmTOTP, auth)
+	u.DELETE("/2fa", uc.DisableTwoFactor, auth)
+	u.POST("/logout-all", uc.LogoutAll, auth)
+	u.GET("/me/notification-preferences", nc.GetPreference, auth)
+	u.PUT("/me/notification-preferences", nc.UpdatePreference, auth)
+	u.DELETE("/me/notification-preferences", nc.DeletePreference, auth)
+	u.GET("/me/digest/preview", dc.Preview, auth)
+	u.POST("/me/push-subscriptions", pc.Subscribe, auth)
+	u.DELETE("/me/push-subscriptions", pc.Unsubscribe, auth)
+	u.GET("/:email", uc.GetUser, auth)
+	u.PUT("/:email", uc.UpdateUser, auth)
+	// DELETEする際にuser情報をすべて送信する必要がある
+	u.DELETE("", uc.DeleteUser, auth)
 
 	//POST例
 	/*
@@ -63,9 +144,38 @@ func NewRouter(fc controller.IFoodController, uc controller.IUserController, ic
 	     "password": "password"
 	   }
 	*/
-	i := e.Group("/images")
-	i.GET("/:imageURL", ic.FetchImage)
-	i.POST("", ic.UploadImage)
+	h := e.Group("/households", auth)
+	h.GET("", hc.GetHouseholds)
+	h.POST("", hc.CreateHousehold)
+	h.POST("/invitations/accept", hc.AcceptInvitation)
+	h.POST("/invitations/decline", hc.DeclineInvitation)
+	h.GET("/:id", hc.GetHousehold)
+	h.PUT("/:id", hc.UpdateHousehold, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.POST("/:id/activate", hc.SwitchHousehold)
+	h.POST("/:id/invitations", hc.InviteMember, pm.RequireHousehold("id", model.PermissionMemberInvite))
+	h.PUT("/:id/members/:userID", hc.UpdateMemberRole, pm.RequireHousehold("id", model.PermissionMemberManage))
+	// メンバーは権限がなくても自分で世帯から抜けられる
+	h.DELETE("/:id/members/:userID", hc.RemoveMember)
+	h.GET("/:id/roles", hc.GetRoles)
+	h.POST("/:id/roles", hc.CreateRole, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.PUT("/:id/roles/:roleID", hc.UpdateRole, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.DELETE("/:id/roles/:roleID", hc.DeleteRole, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.GET("/:id/api-keys", kc.GetAPIKeys, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.POST("/:id/api-keys", kc.CreateAPIKey, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.DELETE("/:id/api-keys/:keyID", kc.RevokeAPIKey, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.GET("/:id/webhooks", wc.GetWebhooks, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.POST("/:id/webhooks", wc.CreateWebhook, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.PUT("/:id/webhooks/:webhookID", wc.UpdateWebhook, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.DELETE("/:id/webhooks/:webhookID", wc.DeleteWebhook, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.GET("/:id/webhooks/:webhookID/deliveries", wc.GetDeliveries, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+	h.POST("/:id/webhooks/:webhookID/test", wc.SendTestEvent, pm.RequireHousehold("id", model.PermissionHouseholdManage))
+
+	e.GET("/audit", ac.GetAuditLogs, auth)
+	e.GET("/push/vapid-public-key", pc.GetVAPIDPublicKey)
+
+	i := e.Group("/images", deviceAuth)
+	i.GET("/:imageURL", ic.FetchImage, pm.RequireActive(model.PermissionFoodRead))
+	i.POST("", ic.UploadImage, pm.RequireActive(model.PermissionFoodWrite))
 
 	return e
 
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"time"
+)
+
+const (
+	// defaultWasteMonths is how many months the waste analytics cover by
+	// default, including the current month.
+	defaultWasteMonths = 12
+	// defaultWastedItemLimit is the number of top wasted items by default.
+	defaultWastedItemLimit = 10
+)
+
+// IAnalyticsUsecase aggregates the archived foods of the active household to
+// show how much is wasted. Periods are counted in the time zone of the user.
+type IAnalyticsUsecase interface {
+	GetWasteSummary(user model.AuthUser, query model.WasteQuery) (model.WasteSummary, error)
+	// GetWasteGroups returns the waste grouped by model.WasteGroupTag,
+	// model.WasteGroupMonth or model.WasteGroupStorageLocation.
+	GetWasteGroups(user model.AuthUser, groupBy string, query model.WasteQuery) (model.WasteGroupResponse, error)
+	GetTopWastedItems(user model.AuthUser, query model.WasteQuery) (model.WastedItemResponse, error)
+}
+
+type analyticsUsecase struct {
+	fr    repository.IFoodRepository
+	fv    validator.IFoodValidator
+	ur    repository.IUserRepository
+	hu    IHouseholdUsecase
+	clock Clock
+	// location is the time zone of users who have not set one
+	location *time.Location
+}
+
+// NewAnalyticsUsecase creates a new instance of the analyticsUsecase struct.
+func NewAnalyticsUsecase(fr repository.IFoodRepository, fv validator.IFoodValidator, ur repository.IUserRepository, hu IHouseholdUsecase, clock Clock) IAnalyticsUsecase {
+	return &analyticsUsecase{
+		fr:       fr,
+		fv:       fv,
+		ur:       ur,
+		hu:       hu,
+		clock:    clock,
+		location: defaultLocation(),
+	}
+}
+
+// wastePeriod returns the household whose foods the user can see and the
+// period of the query, from the first day up to the end of the last day. By
+// default the period ends today and starts on the first day of the month 11
+// months before the last day.
+func (au *analyticsUsecase) wastePeriod(user model.AuthUser, query model.WasteQuery) (int, time.Time, time.Time, error) {
+	if err := au.fv.ValidateWasteQuery(query); err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+	member, err := au.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+	u := model.User{}
+	if err := au.ur.GetUserByID(&u, user.ID); err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
+	loc := userTimeZone(u, au.location)
+
+	to := startOfDay(au.clock.Now(), loc).AddDate(0, 0, 1)
+	if query.To != "" {
+		day, _ := time.ParseInLocation(time.DateOnly, query.To, loc)
+		to = day.AddDate(0, 0, 1)
+	}
+	// 最終日の月を含めて12か月分を、月の初日から数える
+	last := to.AddDate(0, 0, -1)
+	from := time.Date(last.Year(), last.Month()-defaultWasteMonths+1, 1, 0, 0, 0, 0, loc)
+	if query.From != "" {
+		from, _ = time.ParseInLocation(time.DateOnly, query.From, loc)
+	}
+	return member.HouseholdID, from, to, nil
+}
+
+func (au *analyticsUsecase) GetWasteSummary(user model.AuthUser, query model.WasteQuery) (model.WasteSummary, error) {
+	householdID, from, to, err := au.wastePeriod(user, query)
+	if err != nil {
+		return model.WasteSummary{}, err
+	}
+	summary := model.WasteSummary{}
+	if err := au.fr.GetWasteSummary(&summary, householdID, from, to); err != nil {
+		return model.WasteSummary{}, err
+	}
+	summary.From, summary.To = from, to
+	return summary, nil
+}
+
+func (au *analyticsUsecase) GetWasteGroups(user model.AuthUser, groupBy string, query model.WasteQuery) (model.WasteGroupResponse, error) {
+	householdID, from, to, err := au.wastePeriod(user, query)
+	if err != nil {
+		return model.WasteGroupResponse{}, err
+	}
+	groups := []model.WasteGroup{}
+	if err := au.fr.GetWasteGroups(&groups, householdID, groupBy, from, to); err != nil {
+		return model.WasteGroupResponse{}, err
+	}
+	return model.WasteGroupResponse{From: from, To: to, TimeZone: from.Location().String(), Groups: groups}, nil
+}
+
+func (au *analyticsUsecase) GetTopWastedItems(user model.AuthUser, query model.WasteQuery) (model.WastedItemResponse, error) {
+	householdID, from, to, err := au.wastePeriod(user, query)
+	if err != nil {
+		return model.WastedItemResponse{}, err
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultWastedItemLimit
+	}
+	items := []model.WastedItem{}
+	if err := au.fr.GetTopWastedItems(&items, householdID, from, to, query.Limit); err != nil {
+		return model.WastedItemResponse{}, err
+	}
+	return model.WastedItemResponse{From: from, To: to, Items: items}, nil
+}
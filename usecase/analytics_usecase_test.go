@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_analyticsUsecase_GetWasteSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockFood := mocks.NewMockIFoodRepository(ctrl)
+	mockUser := mocks.NewMockIUserRepository(ctrl)
+	// 2024-10-01 20:00 UTC は東京では 10月2日 05:00
+	au := &analyticsUsecase{
+		fr:       mockFood,
+		fv:       validator.NewFoodValidator(),
+		ur:       mockUser,
+		hu:       newOwnerHouseholdUsecase(ctrl),
+		clock:    fakeClock{time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)},
+		location: time.UTC,
+	}
+	mockUser.EXPECT().GetUserByID(gomock.Any(), 1).SetArg(0, model.User{ID: 1, TimeZone: "Asia/Tokyo"}).Return(nil).AnyTimes()
+
+	tests := []struct {
+		name     string
+		query    model.WasteQuery
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：ユーザーのタイムゾーンで今日までの12か月を集計する",
+			wantFrom: time.Date(2023, 11, 1, 0, 0, 0, 0, tokyo),
+			wantTo:   time.Date(2024, 10, 3, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "正常系：指定した最終日を含める",
+			query:    model.WasteQuery{From: "2024-04-01", To: "2024-06-30"},
+			wantFrom: time.Date(2024, 4, 1, 0, 0, 0, 0, tokyo),
+			wantTo:   time.Date(2024, 7, 1, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "正常系：月末までなら、その月を含めて12か月を集計する",
+			query:    model.WasteQuery{To: "2024-06-30"},
+			wantFrom: time.Date(2023, 7, 1, 0, 0, 0, 0, tokyo),
+			wantTo:   time.Date(2024, 7, 1, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:    "異常系：日付の形式が不正",
+			query:   model.WasteQuery{From: "2024/04/01"},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：最終日が最初の日より前",
+			query:   model.WasteQuery{From: "2024-06-01", To: "2024-05-31"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantErr {
+				mockFood.EXPECT().GetWasteSummary(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(func(summary *model.WasteSummary, householdID int, from time.Time, to time.Time) error {
+					if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+						t.Errorf("GetWasteSummary() period = %v - %v, want %v - %v", from, to, tt.wantFrom, tt.wantTo)
+					}
+					*summary = model.WasteSummary{Total: 20, Wasted: 5, WasteRate: 0.25, MoneyWasted: 600}
+					return nil
+				})
+			}
+
+			got, err := au.GetWasteSummary(model.AuthUser{ID: 1}, tt.query)
+			if tt.wantErr {
+				var verrs validation.Errors
+				if !errors.As(err, &verrs) {
+					t.Errorf("analyticsUsecase.GetWasteSummary() error = %v, want validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("analyticsUsecase.GetWasteSummary() error = %v", err)
+			}
+			if got.Wasted != 5 || got.WasteRate != 0.25 || !got.From.Equal(tt.wantFrom) || !got.To.Equal(tt.wantTo) {
+				t.Errorf("analyticsUsecase.GetWasteSummary() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_analyticsUsecase_GetWasteGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFood := mocks.NewMockIFoodRepository(ctrl)
+	mockUser := mocks.NewMockIUserRepository(ctrl)
+	au := &analyticsUsecase{
+		fr:       mockFood,
+		fv:       validator.NewFoodValidator(),
+		ur:       mockUser,
+		hu:       newOwnerHouseholdUsecase(ctrl),
+		clock:    fakeClock{time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)},
+		location: time.UTC,
+	}
+	mockUser.EXPECT().GetUserByID(gomock.Any(), 1).SetArg(0, model.User{ID: 1, TimeZone: "Asia/Tokyo"}).Return(nil).AnyTimes()
+
+	for _, groupBy := range []string{model.WasteGroupTag, model.WasteGroupMonth, model.WasteGroupStorageLocation} {
+		t.Run("正常系："+groupBy+"ごとに集計する", func(t *testing.T) {
+			groups := []model.WasteGroup{{Key: "a", Total: 2, Wasted: 1, WasteRate: 0.5}}
+			mockFood.EXPECT().GetWasteGroups(gomock.Any(), 1, groupBy, gomock.Any(), gomock.Any()).SetArg(0, groups).Return(nil)
+
+			got, err := au.GetWasteGroups(model.AuthUser{ID: 1}, groupBy, model.WasteQuery{})
+			if err != nil {
+				t.Fatalf("analyticsUsecase.GetWasteGroups() error = %v", err)
+			}
+			if got.TimeZone != "Asia/Tokyo" || len(got.Groups) != 1 || got.Groups[0].Key != "a" {
+				t.Errorf("analyticsUsecase.GetWasteGroups() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_analyticsUsecase_GetTopWastedItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFood := mocks.NewMockIFoodRepository(ctrl)
+	mockUser := mocks.NewMockIUserRepository(ctrl)
+	au := &analyticsUsecase{
+		fr:       mockFood,
+		fv:       validator.NewFoodValidator(),
+		ur:       mockUser,
+		hu:       newOwnerHouseholdUsecase(ctrl),
+		clock:    fakeClock{time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)},
+		location: time.UTC,
+	}
+	mockUser.EXPECT().GetUserByID(gomock.Any(), 1).SetArg(0, model.User{ID: 1}).Return(nil).AnyTimes()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "正常系：既定では10件返す", wantLimit: defaultWastedItemLimit},
+		{name: "正常系：件数を指定できる", limit: 3, wantLimit: 3},
+		{name: "異常系：100件より多くは返さない", limit: 101, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantErr {
+				mockFood.EXPECT().GetTopWastedItems(gomock.Any(), 1, gomock.Any(), gomock.Any(), tt.wantLimit).SetArg(0, []model.WastedItem{{Name: "もやし", Wasted: 3}}).Return(nil)
+			}
+
+			got, err := au.GetTopWastedItems(model.AuthUser{ID: 1}, model.WasteQuery{Limit: tt.limit})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("analyticsUsecase.GetTopWastedItems() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (len(got.Items) != 1 || got.Items[0].Name != "もやし") {
+				t.Errorf("analyticsUsecase.GetTopWastedItems() = %+v", got)
+			}
+		})
+	}
+}
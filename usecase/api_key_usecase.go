@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyPrefix marks API keys so that leaked keys can be found by secret scanners.
+const apiKeyPrefix = "rwk_"
+
+type IAPIKeyUsecase interface {
+	CreateAPIKey(householdID int, userID int, key model.APIKeyRequest) (model.APIKeyCreatedResponse, error)
+	GetAPIKeys(householdID int, userID int) ([]model.APIKeyResponse, error)
+	RevokeAPIKey(householdID int, userID int, id int) error
+	// Authenticate returns the caller identified by the key, scoped to the
+	// household and permissions of the key. Keys stop working once their
+	// creator is no longer a member of the household.
+	Authenticate(key string) (model.AuthUser, error)
+}
+
+type apiKeyUsecase struct {
+	kr repository.IAPIKeyRepository
+	kv validator.IAPIKeyValidator
+	hu IHouseholdUsecase
+}
+
+func NewAPIKeyUsecase(kr repository.IAPIKeyRepository, kv validator.IAPIKeyValidator, hu IHouseholdUsecase) IAPIKeyUsecase {
+	return &apiKeyUsecase{kr, kv, hu}
+}
+
+// generateAPIKey returns a key of the form "rwk_<8 hex>_<secret>" and its
+// visible prefix.
+func generateAPIKey() (string, string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := apiKeyPrefix + hex.EncodeToString(b)
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix + "_" + secret, prefix, nil
+}
+
+func apiKeyResponse(key model.APIKey) model.APIKeyResponse {
+	return model.APIKeyResponse{
+		ID:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: key.PermissionList(),
+		CreatedAt:   key.CreatedAt,
+		LastUsedAt:  key.LastUsedAt,
+		RevokedAt:   key.RevokedAt,
+	}
+}
+
+func (ku *apiKeyUsecase) CreateAPIKey(householdID int, userID int, key model.APIKeyRequest) (model.APIKeyCreatedResponse, error) {
+	if _, err := ku.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return model.APIKeyCreatedResponse{}, err
+	}
+	if err := ku.kv.ValidateAPIKey(key); err != nil {
+		return model.APIKeyCreatedResponse{}, err
+	}
+
+	plain, prefix, err := generateAPIKey()
+	if err != nil {
+		return model.APIKeyCreatedResponse{}, err
+	}
+	newKey := model.APIKey{
+		HouseholdID: householdID,
+		Name:        key.Name,
+		Prefix:      prefix,
+		KeyHash:     hashToken(plain),
+		Permissions: model.JoinPermissions(key.Permissions),
+		CreatedBy:   userID,
+	}
+	if err := ku.kr.CreateAPIKey(&newKey); err != nil {
+		return model.APIKeyCreatedResponse{}, err
+	}
+
+	return model.APIKeyCreatedResponse{
+		APIKeyResponse: apiKeyResponse(newKey),
+		Key:            plain,
+	}, nil
+}
+
+func (ku *apiKeyUsecase) GetAPIKeys(householdID int, userID int) ([]model.APIKeyResponse, error) {
+	if _, err := ku.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return nil, err
+	}
+
+	keys := []model.APIKey{}
+	if err := ku.kr.GetAPIKeysByHouseholdID(&keys, householdID); err != nil {
+		return nil, err
+	}
+	resKeys := []model.APIKeyResponse{}
+	for _, key := range keys {
+		resKeys = append(resKeys, apiKeyResponse(key))
+	}
+	return resKeys, nil
+}
+
+func (ku *apiKeyUsecase) RevokeAPIKey(householdID int, userID int, id int) error {
+	if _, err := ku.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return err
+	}
+	return ku.kr.RevokeAPIKey(householdID, id)
+}
+
+func (ku *apiKeyUsecase) Authenticate(plain string) (model.AuthUser, error) {
+	if !strings.HasPrefix(plain, apiKeyPrefix) || len(plain) <= model.APIKeyPrefixLength {
+		return model.AuthUser{}, model.ErrUnauthorized
+	}
+
+	key := model.APIKey{}
+	if err := ku.kr.GetAPIKeyByHash(&key, hashToken(plain)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.AuthUser{}, model.ErrUnauthorized
+		}
+		return model.AuthUser{}, err
+	}
+	if key.RevokedAt != nil {
+		return model.AuthUser{}, model.ErrUnauthorized
+	}
+	// 世帯から外れたメンバーが作ったキーでは操作させない
+	memberships, err := ku.hu.Memberships(key.CreatedBy)
+	if err != nil {
+		return model.AuthUser{}, err
+	}
+	isMember := false
+	for _, membership := range memberships {
+		if membership.HouseholdID == key.HouseholdID {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return model.AuthUser{}, model.ErrUnauthorized
+	}
+
+	// 最終使用日時の記録に失敗しても認証は成功とする
+	if err := ku.kr.TouchAPIKey(key.ID, time.Now()); err != nil {
+		log.Println("failed to record api key usage:", err)
+	}
+
+	return model.AuthUser{
+		ID: key.CreatedBy,
+		APIKey: &model.APIKeyScope{
+			ID:          key.ID,
+			HouseholdID: key.HouseholdID,
+			Permissions: key.PermissionList(),
+		},
+	}, nil
+}
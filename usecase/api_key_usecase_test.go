@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_apiKeyUsecase_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIAPIKeyRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name    string
+		authErr error
+		key     model.APIKeyRequest
+		wantErr bool
+	}{
+		{
+			name: "正常系：APIキーを作成できる",
+			key:  model.APIKeyRequest{Name: "冷蔵庫センサー", Permissions: []model.Permission{model.PermissionFoodRead, model.PermissionFoodWrite}},
+		},
+		{
+			name:    "異常系：household:manage がなければ作成できない",
+			authErr: model.ErrForbidden,
+			key:     model.APIKeyRequest{Name: "冷蔵庫センサー", Permissions: []model.Permission{model.PermissionFoodRead}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：食材以外の権限は付与できない",
+			key:     model.APIKeyRequest{Name: "冷蔵庫センサー", Permissions: []model.Permission{model.PermissionMemberInvite}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ku := &apiKeyUsecase{kr: mockRepo, kv: validator.NewAPIKeyValidator(), hu: mockHousehold}
+
+			mockHousehold.EXPECT().Authorize(1, 1, model.PermissionHouseholdManage).Return(model.HouseholdMember{}, tt.authErr).Times(1)
+			var stored model.APIKey
+			if !tt.wantErr {
+				mockRepo.EXPECT().CreateAPIKey(gomock.Any()).Do(func(key *model.APIKey) {
+					key.ID = 1
+					stored = *key
+				}).Return(nil).Times(1)
+			}
+
+			got, err := ku.CreateAPIKey(1, 1, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apiKeyUsecase.CreateAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.HasPrefix(got.Key, got.Prefix+"_") || len(got.Prefix) != model.APIKeyPrefixLength {
+				t.Errorf("apiKeyUsecase.CreateAPIKey() key = %v, prefix = %v", got.Key, got.Prefix)
+			}
+			if stored.KeyHash != hashToken(got.Key) || strings.Contains(stored.KeyHash, got.Key) {
+				t.Errorf("stored key hash = %v", stored.KeyHash)
+			}
+			if stored.Permissions != "food:read,food:write" || stored.HouseholdID != 1 || stored.CreatedBy != 1 {
+				t.Errorf("stored key = %+v", stored)
+			}
+		})
+	}
+}
+
+func Test_apiKeyUsecase_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIAPIKeyRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+
+	revokedAt := time.Now()
+	active := model.APIKey{ID: 1, HouseholdID: 2, Permissions: "food:read,food:write", CreatedBy: 3}
+	revoked := active
+	revoked.RevokedAt = &revokedAt
+
+	tests := []struct {
+		name        string
+		key         string
+		stored      *model.APIKey
+		memberships []model.HouseholdMember
+		wantErr     error
+	}{
+		{name: "正常系：有効なAPIキーで認証できる", key: "rwk_1a2b3c4d_secret", stored: &active, memberships: []model.HouseholdMember{{HouseholdID: 1}, {HouseholdID: 2}}},
+		{name: "異常系：作成者が世帯から外れたAPIキーでは認証できない", key: "rwk_1a2b3c4d_secret", stored: &active, memberships: []model.HouseholdMember{{HouseholdID: 1}}, wantErr: model.ErrUnauthorized},
+		{name: "異常系：失効したAPIキーでは認証できない", key: "rwk_1a2b3c4d_secret", stored: &revoked, wantErr: model.ErrUnauthorized},
+		{name: "異常系：存在しないAPIキーでは認証できない", key: "rwk_1a2b3c4d_unknown", wantErr: model.ErrUnauthorized},
+		{name: "異常系：形式が不正なAPIキーでは認証できない", key: "secret", wantErr: model.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ku := &apiKeyUsecase{kr: mockRepo, hu: mockHousehold}
+
+			if strings.HasPrefix(tt.key, apiKeyPrefix) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashToken(tt.key)).DoAndReturn(func(key *model.APIKey, keyHash string) error {
+					if tt.stored == nil {
+						return gorm.ErrRecordNotFound
+					}
+					*key = *tt.stored
+					return nil
+				}).Times(1)
+			}
+			if tt.memberships != nil {
+				mockHousehold.EXPECT().Memberships(tt.stored.CreatedBy).Return(tt.memberships, nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().TouchAPIKey(tt.stored.ID, gomock.Any()).Return(nil).Times(1)
+			}
+
+			got, err := ku.Authenticate(tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("apiKeyUsecase.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.ID != 3 || got.APIKey == nil || got.APIKey.HouseholdID != 2 || len(got.APIKey.Permissions) != 2 {
+				t.Errorf("apiKeyUsecase.Authenticate() = %+v", got)
+			}
+		})
+	}
+}
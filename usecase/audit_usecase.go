@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"reflect"
+	"strconv"
+)
+
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+	// redactedValue replaces the values of redacted fields in recorded changes.
+	redactedValue = "[redacted]"
+	// maxUserAgentLength is the size of the user_agent column.
+	maxUserAgentLength = 255
+)
+
+// IAuditUsecase records changes to foods, images and accounts and lets
+// household members with the audit:read permission read them.
+type IAuditUsecase interface {
+	// Record appends the entry to the audit log. Failures are logged and do
+	// not fail the change being recorded, which has already been made.
+	Record(entry model.AuditEntry)
+	GetAuditLogs(user model.AuthUser, query model.AuditLogQuery) (model.AuditLogPage, error)
+}
+
+type auditUsecase struct {
+	ar repository.IAuditLogRepository
+	hu IHouseholdUsecase
+}
+
+// NewAuditUsecase creates a new instance of the auditUsecase struct.
+func NewAuditUsecase(ar repository.IAuditLogRepository, hu IHouseholdUsecase) IAuditUsecase {
+	return &auditUsecase{ar, hu}
+}
+
+func (au *auditUsecase) Record(entry model.AuditEntry) {
+	changes, err := auditChanges(entry.Before, entry.After, entry.Redacted)
+	if err != nil {
+		log.Println("failed to record audit log:", err)
+		return
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		log.Println("failed to record audit log:", err)
+		return
+	}
+
+	userAgent := entry.Actor.Meta.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	auditLog := model.AuditLog{
+		HouseholdID: entry.HouseholdID,
+		ActorID:     entry.Actor.ID,
+		Action:      entry.Action,
+		EntityType:  entry.EntityType,
+		EntityID:    entry.EntityID,
+		Changes:     string(encoded),
+		IP:          entry.Actor.Meta.IP,
+		UserAgent:   userAgent,
+		RequestID:   entry.Actor.Meta.RequestID,
+	}
+	if entry.Actor.APIKey != nil {
+		auditLog.APIKeyID = &entry.Actor.APIKey.ID
+	}
+	if err := au.ar.CreateAuditLog(&auditLog); err != nil {
+		log.Println("failed to record audit log:", err)
+	}
+}
+
+// auditChanges returns the fields whose JSON values differ between before
+// and after. Redacted fields are always included, without their values.
+func auditChanges(before interface{}, after interface{}, redacted []string) (map[string]model.AuditChange, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]model.AuditChange{}
+	for name, value := range beforeFields {
+		if afterValue, ok := afterFields[name]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[name] = model.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = model.AuditChange{After: value}
+		}
+	}
+	for _, name := range redacted {
+		changes[name] = model.AuditChange{Before: redactedValue, After: redactedValue}
+	}
+	return changes, nil
+}
+
+// jsonFields returns the top-level fields of v encoded as JSON.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if v == nil {
+		return fields, nil
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// GetAuditLogs returns the entries of the active household, if the user has
+// the audit:read permission there, together with the user's own account
+// changes.
+func (au *auditUsecase) GetAuditLogs(user model.AuthUser, query model.AuditLogQuery) (model.AuditLogPage, error) {
+	var householdID *int
+	member, err := au.hu.AuthorizeActive(user, model.PermissionAuditRead)
+	switch {
+	case err == nil:
+		householdID = &member.HouseholdID
+	case !errors.Is(err, model.ErrForbidden):
+		return model.AuditLogPage{}, err
+	}
+
+	beforeID, err := decodeAuditCursor(query.Cursor)
+	if err != nil {
+		return model.AuditLogPage{}, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditLogLimit
+	}
+	if query.Limit > maxAuditLogLimit {
+		query.Limit = maxAuditLogLimit
+	}
+	limit := query.Limit
+	// 次のページがあるかを知るために1件多く取得する
+	query.Limit++
+
+	logs := []model.AuditLog{}
+	if err := au.ar.GetAuditLogs(&logs, householdID, user.ID, query, beforeID); err != nil {
+		return model.AuditLogPage{}, err
+	}
+
+	page := model.AuditLogPage{Items: []model.AuditLogResponse{}}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		page.NextCursor = encodeAuditCursor(logs[limit-1].ID)
+	}
+	for _, l := range logs {
+		changes := map[string]model.AuditChange{}
+		if l.Changes != "" {
+			if err := json.Unmarshal([]byte(l.Changes), &changes); err != nil {
+				return model.AuditLogPage{}, err
+			}
+		}
+		page.Items = append(page.Items, model.AuditLogResponse{
+			ID:          l.ID,
+			HouseholdID: l.HouseholdID,
+			ActorID:     l.ActorID,
+			APIKeyID:    l.APIKeyID,
+			Action:      l.Action,
+			EntityType:  l.EntityType,
+			EntityID:    l.EntityID,
+			Changes:     changes,
+			IP:          l.IP,
+			UserAgent:   l.UserAgent,
+			RequestID:   l.RequestID,
+			CreatedAt:   l.CreatedAt,
+		})
+	}
+	return page, nil
+}
+
+// encodeAuditCursor returns an opaque cursor pointing after the entry.
+func encodeAuditCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+func decodeAuditCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, model.ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(string(decoded))
+	if err != nil || id <= 0 {
+		return 0, model.ErrInvalidCursor
+	}
+	return id, nil
+}
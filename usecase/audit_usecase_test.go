@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// newNopAuditUsecase returns an audit usecase that accepts any entry.
+func newNopAuditUsecase(ctrl *gomock.Controller) *usecasemocks.MockIAuditUsecase {
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	mockAudit.EXPECT().Record(gomock.Any()).AnyTimes()
+	return mockAudit
+}
+
+func Test_auditUsecase_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIAuditLogRepository(ctrl)
+	householdID := 2
+	actor := model.AuthUser{ID: 1, Meta: model.RequestMeta{IP: "192.0.2.1", UserAgent: "test-agent", RequestID: "req-1"}}
+	deviceActor := model.AuthUser{ID: 1, APIKey: &model.APIKeyScope{ID: 5, HouseholdID: 2}}
+
+	tests := []struct {
+		name        string
+		entry       model.AuditEntry
+		wantChanges map[string]model.AuditChange
+		wantAPIKey  *int
+	}{
+		{
+			name: "正常系：変更された項目だけを記録する",
+			entry: model.AuditEntry{
+				Actor: actor, Action: model.AuditActionFoodUpdate, EntityType: model.AuditEntityFood, EntityID: "10", HouseholdID: &householdID,
+				Before: model.FoodResponse{ID: 10, Name: "オレンジ", Quantity: 5},
+				After:  model.FoodResponse{ID: 10, Name: "オレンジ", Quantity: 3},
+			},
+			wantChanges: map[string]model.AuditChange{"quantity": {Before: float64(5), After: float64(3)}},
+		},
+		{
+			name: "正常系：削除はすべての項目の変更前の値を記録する",
+			entry: model.AuditEntry{
+				Actor: actor, Action: model.AuditActionImageUpload, EntityType: model.AuditEntityImage, EntityID: "1_a.jpg", HouseholdID: &householdID,
+				Before: map[string]string{"filename": "1_a.jpg"},
+			},
+			wantChanges: map[string]model.AuditChange{"filename": {Before: "1_a.jpg"}},
+		},
+		{
+			name: "正常系：パスワードは値を記録しない",
+			entry: model.AuditEntry{
+				Actor: actor, Action: model.AuditActionUserPasswordReset, EntityType: model.AuditEntityUser, EntityID: "1",
+				Redacted: []string{"password"},
+			},
+			wantChanges: map[string]model.AuditChange{"password": {Before: redactedValue, After: redactedValue}},
+		},
+		{
+			name: "正常系：APIキーでの変更はキーのIDを記録する",
+			entry: model.AuditEntry{
+				Actor: deviceActor, Action: model.AuditActionFoodCreate, EntityType: model.AuditEntityFood, EntityID: "10", HouseholdID: &householdID,
+				After: map[string]int{"id": 10},
+			},
+			wantChanges: map[string]model.AuditChange{"id": {After: float64(10)}},
+			wantAPIKey:  &deviceActor.APIKey.ID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			au := &auditUsecase{ar: mockRepo}
+
+			mockRepo.EXPECT().CreateAuditLog(gomock.Any()).Do(func(log *model.AuditLog) {
+				if log.ActorID != tt.entry.Actor.ID || log.Action != tt.entry.Action || log.EntityType != tt.entry.EntityType || log.EntityID != tt.entry.EntityID {
+					t.Errorf("CreateAuditLog() log = %+v", log)
+				}
+				if log.IP != tt.entry.Actor.Meta.IP || log.UserAgent != tt.entry.Actor.Meta.UserAgent || log.RequestID != tt.entry.Actor.Meta.RequestID {
+					t.Errorf("CreateAuditLog() request meta = %+v", log)
+				}
+				if (log.APIKeyID == nil) != (tt.wantAPIKey == nil) || (log.APIKeyID != nil && *log.APIKeyID != *tt.wantAPIKey) {
+					t.Errorf("CreateAuditLog() api key = %v, want %v", log.APIKeyID, tt.wantAPIKey)
+				}
+				changes := map[string]model.AuditChange{}
+				if err := json.Unmarshal([]byte(log.Changes), &changes); err != nil {
+					t.Fatalf("json.Unmarshal() error = %v", err)
+				}
+				if len(changes) != len(tt.wantChanges) {
+					t.Fatalf("CreateAuditLog() changes = %v, want %v", changes, tt.wantChanges)
+				}
+				for name, want := range tt.wantChanges {
+					if got := changes[name]; got != want {
+						t.Errorf("CreateAuditLog() changes[%s] = %v, want %v", name, got, want)
+					}
+				}
+			}).Return(nil).Times(1)
+
+			au.Record(tt.entry)
+		})
+	}
+}
+
+func Test_auditUsecase_GetAuditLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIAuditLogRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+	user := model.AuthUser{ID: 1}
+
+	logs := func(ids ...int) []model.AuditLog {
+		res := []model.AuditLog{}
+		for _, id := range ids {
+			res = append(res, model.AuditLog{ID: id, ActorID: 1, Action: model.AuditActionFoodDelete, Changes: `{"name":{"before":"オレンジ","after":null}}`})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name           string
+		authErr        error
+		query          model.AuditLogQuery
+		wantHousehold  bool
+		wantBeforeID   int
+		stored         []model.AuditLog
+		wantItems      int
+		wantNextCursor string
+		wantErr        error
+	}{
+		{
+			name:          "正常系：audit:read があれば世帯の記録を取得できる",
+			query:         model.AuditLogQuery{Limit: 2},
+			wantHousehold: true,
+			stored:        logs(9, 8),
+			wantItems:     2,
+		},
+		{
+			name:           "正常系：続きがあれば次のカーソルを返す",
+			query:          model.AuditLogQuery{Limit: 2},
+			wantHousehold:  true,
+			stored:         logs(9, 8, 7),
+			wantItems:      2,
+			wantNextCursor: encodeAuditCursor(8),
+		},
+		{
+			name:          "正常系：カーソル以降を取得する",
+			query:         model.AuditLogQuery{Limit: 2, Cursor: encodeAuditCursor(8)},
+			wantHousehold: true,
+			wantBeforeID:  8,
+			stored:        logs(7),
+			wantItems:     1,
+		},
+		{
+			name:      "正常系：audit:read がなければ自分のアカウントの記録だけを取得する",
+			authErr:   model.ErrForbidden,
+			query:     model.AuditLogQuery{Limit: 2},
+			stored:    logs(3),
+			wantItems: 1,
+		},
+		{
+			name:    "異常系：不正なカーソル",
+			query:   model.AuditLogQuery{Cursor: "!!"},
+			wantErr: model.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			au := &auditUsecase{ar: mockRepo, hu: mockHousehold}
+
+			mockHousehold.EXPECT().AuthorizeActive(user, model.PermissionAuditRead).Return(model.HouseholdMember{HouseholdID: 2, UserID: 1}, tt.authErr).Times(1)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().GetAuditLogs(gomock.Any(), gomock.Any(), 1, gomock.Any(), tt.wantBeforeID).DoAndReturn(func(l *[]model.AuditLog, householdID *int, userID int, query model.AuditLogQuery, beforeID int) error {
+					if (householdID != nil) != tt.wantHousehold || (householdID != nil && *householdID != 2) {
+						t.Errorf("GetAuditLogs() householdID = %v", householdID)
+					}
+					// 次のページの有無を知るために1件多く要求する
+					if query.Limit != tt.query.Limit+1 {
+						t.Errorf("GetAuditLogs() limit = %v, want %v", query.Limit, tt.query.Limit+1)
+					}
+					*l = tt.stored
+					return nil
+				}).Times(1)
+			}
+
+			got, err := au.GetAuditLogs(user, tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("auditUsecase.GetAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got.Items) != tt.wantItems || got.NextCursor != tt.wantNextCursor {
+				t.Errorf("auditUsecase.GetAuditLogs() = %d items, next_cursor %q; want %d, %q", len(got.Items), got.NextCursor, tt.wantItems, tt.wantNextCursor)
+			}
+			if got.Items[0].Changes["name"].Before != "オレンジ" {
+				t.Errorf("auditUsecase.GetAuditLogs() changes = %v", got.Items[0].Changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+	defaultMFATokenTTL     = 5 * time.Minute
+	// minSecretLength is the minimum length in bytes of SECRET, the HS256 key.
+	minSecretLength = 32
+)
+
+// mfaTokenAudience is the audience of tokens issued between the password and
+// the second factor of a login. They are not accepted as access tokens.
+const mfaTokenAudience = "mfa"
+
+type IAuthUsecase interface {
+	IssueTokens(user model.User) (model.TokenResponse, error)
+	RefreshTokens(refreshToken string) (model.TokenResponse, error)
+	Logout(refreshToken string) error
+	LogoutAll(userID int) error
+	Authenticate(accessToken string) (model.AuthUser, error)
+	SweepExpiredRefreshTokens() (int64, error)
+	IssueMFAToken(user model.User) (string, error)
+	VerifyMFAToken(mfaToken string) (int, error)
+}
+
+type authUsecase struct {
+	rr              repository.IRefreshTokenRepository
+	secret          []byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	mfaTokenTTL     time.Duration
+}
+
+type accessTokenClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// NewAuthUsecase creates a new instance of the authUsecase struct.
+// The signing key is read from SECRET, which must be at least 32 bytes, and
+// the token lifetimes from ACCESS_TOKEN_TTL, REFRESH_TOKEN_TTL and MFA_TOKEN_TTL.
+func NewAuthUsecase(rr repository.IRefreshTokenRepository) IAuthUsecase {
+	secret, err := secretFromEnv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return &authUsecase{
+		rr:              rr,
+		secret:          secret,
+		accessTokenTTL:  durationFromEnv("ACCESS_TOKEN_TTL", defaultAccessTokenTTL),
+		refreshTokenTTL: durationFromEnv("REFRESH_TOKEN_TTL", defaultRefreshTokenTTL),
+		mfaTokenTTL:     durationFromEnv("MFA_TOKEN_TTL", defaultMFATokenTTL),
+	}
+}
+
+// secretFromEnv returns the signing key of tokens. An empty or short key
+// would let anyone forge access tokens, so it is an error.
+func secretFromEnv() ([]byte, error) {
+	secret := []byte(os.Getenv("SECRET"))
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("SECRET must be at least %d bytes", minSecretLength)
+	}
+	return secret, nil
+}
+
+// durationFromEnv parses the environment variable as a time.Duration and
+// falls back to def when it is unset or invalid.
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+// intFromEnv parses the environment variable as a positive int and falls back
+// to def when it is unset or invalid.
+func intFromEnv(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+// randomToken returns a URL-safe random string of n bytes of entropy.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex encoded SHA-256 hash used to store opaque tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (au *authUsecase) IssueTokens(user model.User) (model.TokenResponse, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	return au.issueTokens(user, familyID)
+}
+
+func (au *authUsecase) issueTokens(user model.User, familyID string) (model.TokenResponse, error) {
+	accessToken, err := au.issueAccessToken(user)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return model.TokenResponse{}, err
+	}
+	if err := au.rr.CreateRefreshToken(&model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(au.refreshTokenTTL),
+	}); err != nil {
+		return model.TokenResponse{}, err
+	}
+
+	return model.TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(au.accessTokenTTL.Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (au *authUsecase) issueAccessToken(user model.User) (string, error) {
+	now := time.Now()
+	claims := accessTokenClaims{
+		Email: user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(au.accessTokenTTL)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(au.secret)
+}
+
+// RefreshTokens rotates the refresh token. Presenting a token that has already
+// been rotated is treated as token theft and revokes the whole token family.
+func (au *authUsecase) RefreshTokens(refreshToken string) (model.TokenResponse, error) {
+	stored := model.RefreshToken{}
+	if err := au.rr.GetRefreshTokenByHash(&stored, hashToken(refreshToken)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.TokenResponse{}, model.ErrUnauthorized
+		}
+		return model.TokenResponse{}, err
+	}
+
+	if stored.RevokedAt != nil {
+		if err := au.rr.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+			return model.TokenResponse{}, err
+		}
+		return model.TokenResponse{}, model.ErrUnauthorized
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return model.TokenResponse{}, model.ErrUnauthorized
+	}
+
+	if err := au.rr.RevokeRefreshToken(stored.ID); err != nil {
+		if errors.Is(err, model.ErrRefreshTokenRevoked) {
+			// 同じトークンで同時にリフレッシュされた場合も再利用とみなす
+			if err := au.rr.RevokeRefreshTokenFamily(stored.FamilyID); err != nil {
+				return model.TokenResponse{}, err
+			}
+			return model.TokenResponse{}, model.ErrUnauthorized
+		}
+		return model.TokenResponse{}, err
+	}
+
+	return au.issueTokens(stored.User, stored.FamilyID)
+}
+
+func (au *authUsecase) Logout(refreshToken string) error {
+	stored := model.RefreshToken{}
+	if err := au.rr.GetRefreshTokenByHash(&stored, hashToken(refreshToken)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrUnauthorized
+		}
+		return err
+	}
+	return au.rr.RevokeRefreshTokenFamily(stored.FamilyID)
+}
+
+func (au *authUsecase) LogoutAll(userID int) error {
+	return au.rr.RevokeRefreshTokensByUserID(userID)
+}
+
+func (au *authUsecase) Authenticate(accessToken string) (model.AuthUser, error) {
+	claims := accessTokenClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		return au.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || len(claims.Audience) > 0 {
+		return model.AuthUser{}, model.ErrUnauthorized
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return model.AuthUser{}, model.ErrUnauthorized
+	}
+	return model.AuthUser{
+		ID:    userID,
+		Email: claims.Email,
+	}, nil
+}
+
+func (au *authUsecase) SweepExpiredRefreshTokens() (int64, error) {
+	return au.rr.DeleteExpiredRefreshTokens(time.Now())
+}
+
+// IssueMFAToken returns a short-lived token that proves the password step of
+// a login has succeeded.
+func (au *authUsecase) IssueMFAToken(user model.User) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   strconv.Itoa(user.ID),
+		Audience:  jwt.ClaimStrings{mfaTokenAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(au.mfaTokenTTL)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(au.secret)
+}
+
+// VerifyMFAToken returns the ID of the user the MFA token was issued to.
+func (au *authUsecase) VerifyMFAToken(mfaToken string) (int, error) {
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(mfaToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		return au.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(), jwt.WithAudience(mfaTokenAudience))
+	if err != nil {
+		return 0, model.ErrUnauthorized
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, model.ErrUnauthorized
+	}
+	return userID, nil
+}
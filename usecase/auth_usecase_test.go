@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_authUsecase_Authenticate(t *testing.T) {
+	au := &authUsecase{secret: []byte("secret"), accessTokenTTL: 15 * time.Minute, mfaTokenTTL: 5 * time.Minute}
+	user := model.User{ID: 1, Email: "sample@test.com"}
+
+	validToken, err := au.issueAccessToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueAccessToken() error = %v", err)
+	}
+	expiredToken, err := (&authUsecase{secret: []byte("secret"), accessTokenTTL: -time.Minute}).issueAccessToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueAccessToken() error = %v", err)
+	}
+	otherSecretToken, err := (&authUsecase{secret: []byte("other"), accessTokenTTL: time.Minute}).issueAccessToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueAccessToken() error = %v", err)
+	}
+	mfaToken, err := au.IssueMFAToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueMFAToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    model.AuthUser
+		wantErr bool
+	}{
+		{
+			name:  "正常系：有効なトークンからユーザーを取得できる",
+			token: validToken,
+			want:  model.AuthUser{ID: 1, Email: "sample@test.com"},
+		},
+		{
+			name:    "異常系：期限切れのトークン",
+			token:   expiredToken,
+			wantErr: true,
+		},
+		{
+			name:    "異常系：異なる鍵で署名されたトークン",
+			token:   otherSecretToken,
+			wantErr: true,
+		},
+		{
+			name:    "異常系：不正な形式のトークン",
+			token:   "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "異常系：MFA トークンはアクセストークンとして使えない",
+			token:   mfaToken,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := au.Authenticate(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authUsecase.Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authUsecase.Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_authUsecase_VerifyMFAToken(t *testing.T) {
+	au := &authUsecase{secret: []byte("secret"), accessTokenTTL: 15 * time.Minute, mfaTokenTTL: 5 * time.Minute}
+	user := model.User{ID: 1, Email: "sample@test.com"}
+
+	mfaToken, err := au.IssueMFAToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueMFAToken() error = %v", err)
+	}
+	expiredToken, err := (&authUsecase{secret: []byte("secret"), mfaTokenTTL: -time.Minute}).IssueMFAToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueMFAToken() error = %v", err)
+	}
+	accessToken, err := au.issueAccessToken(user)
+	if err != nil {
+		t.Fatalf("authUsecase.IssueAccessToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "正常系：MFA トークンからユーザー ID を取得できる",
+			token: mfaToken,
+			want:  1,
+		},
+		{
+			name:    "異常系：期限切れの MFA トークン",
+			token:   expiredToken,
+			wantErr: true,
+		},
+		{
+			name:    "異常系：アクセストークンは MFA トークンとして使えない",
+			token:   accessToken,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := au.VerifyMFAToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authUsecase.VerifyMFAToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("authUsecase.VerifyMFAToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_authUsecase_RefreshTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+	au := &authUsecase{rr: mockRepo, secret: []byte("secret"), accessTokenTTL: 15 * time.Minute, refreshTokenTTL: time.Hour}
+
+	user := model.User{ID: 1, Email: "sample@test.com"}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		stored  model.RefreshToken
+		findErr error
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "正常系：リフレッシュトークンをローテーションできる",
+			stored: model.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), User: user},
+			setup: func() {
+				mockRepo.EXPECT().RevokeRefreshToken(1).Return(nil).Times(1)
+				mockRepo.EXPECT().CreateRefreshToken(gomock.Any()).Do(func(token *model.RefreshToken) {
+					if token.FamilyID != "family" || token.UserID != 1 {
+						t.Errorf("CreateRefreshToken() token = %v, want same family", token)
+					}
+				}).Return(nil).Times(1)
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "異常系：使用済みのトークンが再利用された場合はファミリーごと失効する",
+			stored: model.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt, User: user},
+			setup: func() {
+				mockRepo.EXPECT().RevokeRefreshTokenFamily("family").Return(nil).Times(1)
+			},
+			wantErr: model.ErrUnauthorized,
+		},
+		{
+			name:   "異常系：同時にリフレッシュされた場合はファミリーごと失効する",
+			stored: model.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), User: user},
+			setup: func() {
+				mockRepo.EXPECT().RevokeRefreshToken(1).Return(model.ErrRefreshTokenRevoked).Times(1)
+				mockRepo.EXPECT().RevokeRefreshTokenFamily("family").Return(nil).Times(1)
+			},
+			wantErr: model.ErrUnauthorized,
+		},
+		{
+			name:    "異常系：期限切れのトークン",
+			stored:  model.RefreshToken{ID: 1, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Hour), User: user},
+			setup:   func() {},
+			wantErr: model.ErrUnauthorized,
+		},
+		{
+			name:    "異常系：存在しないトークン",
+			findErr: gorm.ErrRecordNotFound,
+			setup:   func() {},
+			wantErr: model.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), hashToken("refresh")).Do(func(token *model.RefreshToken, tokenHash string) {
+				*token = tt.stored
+			}).Return(tt.findErr).Times(1)
+			tt.setup()
+
+			got, err := au.RefreshTokens("refresh")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("authUsecase.RefreshTokens() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (got.AccessToken == "" || got.RefreshToken == "" || got.RefreshToken == "refresh") {
+				t.Errorf("authUsecase.RefreshTokens() = %v", got)
+			}
+		})
+	}
+}
+
+func Test_secretFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "正常系：32バイト以上の鍵", secret: strings.Repeat("k", 32)},
+		{name: "異常系：未設定", secret: "", wantErr: true},
+		{name: "異常系：短すぎる鍵", secret: strings.Repeat("k", 31), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SECRET", tt.secret)
+
+			got, err := secretFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("secretFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(got) != tt.secret {
+				t.Errorf("secretFromEnv() = %q, want %q", got, tt.secret)
+			}
+		})
+	}
+}
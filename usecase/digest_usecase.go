@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/digest"
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// digestLease is the lease that elects the replica sending digests.
+	digestLease = "digests"
+	// defaultDigestLeaseTTL must be longer than sending the digests takes.
+	defaultDigestLeaseTTL = 10 * time.Minute
+	// digestDeliveryRetention is how long the records of sent digests are
+	// kept; longer than the period of weekly digests.
+	digestDeliveryRetention = 8 * 24 * time.Hour
+	// digestWeekDays is how many days "this week" covers, including today.
+	digestWeekDays = 7
+)
+
+// IDigestUsecase renders and sends the digests that summarize the foods of
+// the users who chose them over alerts about each food.
+type IDigestUsecase interface {
+	// Preview renders the digest of the user as it would be sent now,
+	// without sending it.
+	Preview(userID int, query model.DigestPreviewQuery) (model.DigestPreviewResponse, error)
+	// SendDigests sends the digests that are due, at the digest hour of each
+	// user, and returns the number sent. It is called periodically by every
+	// replica, and the lease lets only one of them send. Digests without any
+	// food are not sent.
+	SendDigests() (int, error)
+	SweepDigestDeliveries() (int64, error)
+}
+
+type digestUsecase struct {
+	nr    repository.INotificationRepository
+	lr    repository.ILeaseRepository
+	fr    repository.IFoodRepository
+	ur    repository.IUserRepository
+	hu    IHouseholdUsecase
+	nv    validator.INotificationValidator
+	n     notifier.INotifier
+	clock Clock
+	// owner identifies this replica in leases
+	owner    string
+	leaseTTL time.Duration
+	location *time.Location
+}
+
+// NewDigestUsecase creates a new instance of the digestUsecase struct.
+// DIGEST_LEASE_TTL sets how long a replica keeps sending digests to itself.
+func NewDigestUsecase(nr repository.INotificationRepository, lr repository.ILeaseRepository, fr repository.IFoodRepository, ur repository.IUserRepository, hu IHouseholdUsecase, nv validator.INotificationValidator, n notifier.INotifier, clock Clock) IDigestUsecase {
+	owner, err := randomToken(16)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return &digestUsecase{
+		nr:       nr,
+		lr:       lr,
+		fr:       fr,
+		ur:       ur,
+		hu:       hu,
+		nv:       nv,
+		n:        n,
+		clock:    clock,
+		owner:    owner,
+		leaseTTL: durationFromEnv("DIGEST_LEASE_TTL", defaultDigestLeaseTTL),
+		location: defaultLocation(),
+	}
+}
+
+func (du *digestUsecase) Preview(userID int, query model.DigestPreviewQuery) (model.DigestPreviewResponse, error) {
+	if err := du.nv.ValidateDigestPreviewQuery(query); err != nil {
+		return model.DigestPreviewResponse{}, err
+	}
+	user := model.User{}
+	if err := du.ur.GetUserByID(&user, userID); err != nil {
+		return model.DigestPreviewResponse{}, err
+	}
+	pref, err := notificationPreference(du.nr, userID)
+	if err != nil {
+		return model.DigestPreviewResponse{}, err
+	}
+	if query.Frequency != "" {
+		pref.DigestFrequency = query.Frequency
+	}
+	if query.Language != "" {
+		pref.Language = query.Language
+	}
+
+	d, err := du.buildDigest(user, pref, du.clock.Now())
+	if err != nil {
+		return model.DigestPreviewResponse{}, err
+	}
+	rendered, err := digest.Render(d)
+	if err != nil {
+		return model.DigestPreviewResponse{}, err
+	}
+	return model.DigestPreviewResponse{
+		Subject: rendered.Subject,
+		Summary: rendered.Summary,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+		Empty:   d.Empty(),
+	}, nil
+}
+
+// buildDigest collects the foods of the households in which the user can see
+// foods, counting days in the time zone of the user. Foods with muted tags
+// are left out.
+func (du *digestUsecase) buildDigest(user model.User, pref model.NotificationPreference, now time.Time) (digest.Digest, error) {
+	loc := userTimeZone(user, du.location)
+	today := startOfDay(now, loc)
+	periodDays := 1
+	if pref.DigestFrequency == model.DigestFrequencyWeekly {
+		periodDays = 7
+	}
+	d := digest.Digest{
+		UserName:  user.Username,
+		Frequency: pref.DigestFrequency,
+		Language:  pref.Language,
+		Date:      today,
+		URL:       frontendURL(),
+	}
+
+	members, err := du.hu.Memberships(user.ID)
+	if err != nil {
+		return digest.Digest{}, err
+	}
+	households := map[int]string{}
+	householdIDs := []int{}
+	for _, member := range members {
+		if member.Can(model.PermissionFoodRead) {
+			households[member.HouseholdID] = member.Household.Name
+			householdIDs = append(householdIDs, member.HouseholdID)
+		}
+	}
+
+	// 期限は日付で保存されているので、どのタイムゾーンでも収まるように1日ずつ広げる
+	since := now.AddDate(0, 0, -periodDays)
+	foods := []model.Food{}
+	if err := du.fr.GetFoodsForDigest(&foods, householdIDs, today.AddDate(0, 0, -periodDays-1), today.AddDate(0, 0, digestWeekDays+1)); err != nil {
+		return digest.Digest{}, err
+	}
+	for _, food := range foods {
+		if pref.Mutes(food.Tag) {
+			continue
+		}
+		item := digest.Item{Name: food.Name, Tag: food.Tag, HouseholdName: households[food.HouseholdID]}
+		if food.ExpirationDate != nil {
+			expires := food.ExpirationDate.In(loc)
+			item.ExpirationDate = &expires
+			item.DaysRemaining = daysBetween(today, expires)
+		}
+		if !food.CreatedAt.Before(since) && food.CreatedAt.Before(now) {
+			d.Added = append(d.Added, item)
+		}
+		if item.ExpirationDate == nil {
+			continue
+		}
+		switch {
+		case item.DaysRemaining == 0:
+			d.ExpiringToday = append(d.ExpiringToday, item)
+		case item.DaysRemaining > 0 && item.DaysRemaining < digestWeekDays:
+			d.ExpiringThisWeek = append(d.ExpiringThisWeek, item)
+		case item.DaysRemaining < 0 && item.DaysRemaining >= -periodDays:
+			d.Wasted = append(d.Wasted, item)
+		}
+	}
+	return d, nil
+}
+
+// digestDue reports whether the digest of the user is due at local, in the
+// time zone of the user.
+func digestDue(pref model.NotificationPreference, local time.Time) bool {
+	if len(pref.Channels) == 0 || local.Hour() != pref.DigestHour {
+		return false
+	}
+	return pref.DigestFrequency != model.DigestFrequencyWeekly || int(local.Weekday()) == pref.DigestWeekday
+}
+
+func (du *digestUsecase) SendDigests() (int, error) {
+	now := du.clock.Now()
+	acquired, err := du.lr.AcquireLease(digestLease, du.owner, now, du.leaseTTL)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	prefs := []model.NotificationPreference{}
+	if err := du.nr.GetDigestPreferences(&prefs); err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, pref := range prefs {
+		local := now.In(userTimeZone(pref.User, du.location))
+		if !digestDue(pref, local) {
+			continue
+		}
+		// 同じ日の同じ種類のまとめは一度だけ送る
+		delivery := model.DigestDelivery{UserID: pref.UserID, Period: fmt.Sprintf("%s:%s", pref.DigestFrequency, local.Format("2006-01-02")), SentAt: now}
+		claimed, err := du.nr.ClaimDigestDelivery(&delivery)
+		if err != nil {
+			log.Println("failed to claim digest:", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		ok, err := du.send(pref, now)
+		if err != nil {
+			log.Println("failed to send digest:", err)
+			// 次回の実行で再送できるように記録を消す
+			if err := du.nr.DeleteDigestDelivery(delivery.ID); err != nil {
+				log.Println("failed to release digest:", err)
+			}
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send renders the digest of the user and sends it through the channels of
+// the user, and reports whether it did. Digests without any food are not sent.
+func (du *digestUsecase) send(pref model.NotificationPreference, now time.Time) (bool, error) {
+	d, err := du.buildDigest(pref.User, pref, now)
+	if err != nil {
+		return false, err
+	}
+	if d.Empty() {
+		return false, nil
+	}
+	rendered, err := digest.Render(d)
+	if err != nil {
+		return false, err
+	}
+	err = du.n.Notify(notifier.Notification{
+		UserID:        pref.UserID,
+		Email:         pref.User.Email,
+		Kind:          notifier.KindDigest,
+		Subject:       rendered.Subject,
+		Body:          rendered.Text,
+		HTML:          rendered.HTML,
+		Summary:       rendered.Summary,
+		Channels:      pref.Channels,
+		WebhookURL:    pref.WebhookURL,
+		WebhookSecret: pref.WebhookSecret,
+	})
+	return err == nil, err
+}
+
+func (du *digestUsecase) SweepDigestDeliveries() (int64, error) {
+	return du.nr.DeleteDigestDeliveriesBefore(du.clock.Now().Add(-digestDeliveryRetention))
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// digestFoods returns foods relative to 2024-10-02 in Tokyo, where the
+// digests of the tests are built.
+func digestFoods() []model.Food {
+	date := func(month time.Month, day int) *time.Time {
+		d := time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	added := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	old := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	// リポジトリと同じく期限の順に並べる
+	return []model.Food{
+		{ID: 7, HouseholdID: 1, Name: "ハム", Tag: "肉", ExpirationDate: date(9, 26), CreatedAt: old},
+		{ID: 5, HouseholdID: 1, Name: "ヨーグルト", Tag: "乳製品", ExpirationDate: date(9, 28), CreatedAt: old},
+		{ID: 3, HouseholdID: 1, Name: "豆腐", Tag: "加工食品", ExpirationDate: date(10, 1), CreatedAt: old},
+		{ID: 1, HouseholdID: 1, Name: "牛乳", Tag: "乳製品", ExpirationDate: date(10, 2), CreatedAt: old},
+		{ID: 4, HouseholdID: 1, Name: "醤油", Tag: "調味料", ExpirationDate: date(10, 3), CreatedAt: old},
+		{ID: 2, HouseholdID: 1, Name: "卵", Tag: "卵", ExpirationDate: date(10, 5), CreatedAt: added},
+		{ID: 6, HouseholdID: 1, Name: "米", Tag: "その他", CreatedAt: added},
+	}
+}
+
+func Test_digestUsecase_Preview(t *testing.T) {
+	// 2024-10-01 20:00 UTC は東京では 10月2日 05:00
+	now := time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)
+	user := model.User{ID: 1, Username: "山田太郎", Email: "yamada@test.com", TimeZone: "Asia/Tokyo"}
+	household := model.Household{ID: 1, Name: "山田家"}
+	reader := []model.Permission{model.PermissionFoodRead}
+
+	tests := []struct {
+		name        string
+		query       model.DigestPreviewQuery
+		pref        *model.NotificationPreference
+		permissions []model.Permission
+		wantSubject string
+		wantText    []string
+		notWant     []string
+		wantEmpty   bool
+		wantErr     bool
+	}{
+		{
+			name:        "正常系：ユーザーのタイムゾーンで今日と今週の期限、追加、期限切れをまとめる",
+			permissions: reader,
+			wantSubject: "冷蔵庫のまとめ（10月2日(水)）",
+			wantText: []string{
+				"■ 今日が期限の食材 (1)\n・牛乳（10月2日(水)、今日まで）",
+				"■ 今週中に期限が来る食材 (2)\n・醤油（10月3日(木)、明日まで）\n・卵（10月5日(土)、あと3日）",
+				"■ 昨日から追加された食材 (2)\n・卵（10月5日(土)、あと3日）\n・米",
+				"■ 昨日期限が切れた食材 (1)\n・豆腐（10月1日(火)、昨日期限切れ）",
+			},
+		},
+		{
+			name:        "正常系：除外したタグの食材は含めない",
+			pref:        &model.NotificationPreference{UserID: 1, DigestFrequency: model.DigestFrequencyDaily, Language: model.LanguageJapanese, MutedTags: []string{"調味料"}},
+			permissions: reader,
+			wantSubject: "冷蔵庫のまとめ（10月2日(水)）",
+			notWant:     []string{"醤油"},
+		},
+		{
+			name:        "正常系：週間まとめを英語で確認できる",
+			query:       model.DigestPreviewQuery{Frequency: model.DigestFrequencyWeekly, Language: model.LanguageEnglish},
+			permissions: reader,
+			wantSubject: "Your weekly fridge digest for Wed, Oct 2",
+			wantText:    []string{"■ Expired in the last 7 days (3)", "・ハム (Thu, Sep 26, expired 6 days ago)"},
+		},
+		{
+			name:      "正常系：食材を見られない世帯は含めない",
+			wantEmpty: true,
+		},
+		{name: "異常系：未知の頻度", query: model.DigestPreviewQuery{Frequency: "monthly"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockINotificationRepository(ctrl)
+			mockFood := mocks.NewMockIFoodRepository(ctrl)
+			mockUser := mocks.NewMockIUserRepository(ctrl)
+			mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+			du := &digestUsecase{nr: mockRepo, fr: mockFood, ur: mockUser, hu: mockHousehold, nv: validator.NewNotificationValidator(), clock: fakeClock{now}, location: time.UTC}
+
+			if !tt.wantErr {
+				mockUser.EXPECT().GetUserByID(gomock.Any(), 1).SetArg(0, user).Return(nil).Times(1)
+				mockRepo.EXPECT().GetPreference(gomock.Any(), 1).DoAndReturn(func(pref *model.NotificationPreference, userID int) error {
+					if tt.pref == nil {
+						return gorm.ErrRecordNotFound
+					}
+					*pref = *tt.pref
+					return nil
+				}).Times(1)
+				mockHousehold.EXPECT().Memberships(1).Return([]model.HouseholdMember{{HouseholdID: 1, UserID: 1, Household: household, Permissions: tt.permissions}}, nil).Times(1)
+				mockFood.EXPECT().GetFoodsForDigest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(foods *[]model.Food, householdIDs []int, from time.Time, to time.Time) error {
+					if len(householdIDs) > 0 {
+						*foods = digestFoods()
+					}
+					return nil
+				}).Times(1)
+			}
+
+			got, err := du.Preview(1, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("digestUsecase.Preview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Empty != tt.wantEmpty {
+				t.Errorf("digestUsecase.Preview() empty = %v, want %v", got.Empty, tt.wantEmpty)
+			}
+			if tt.wantSubject != "" && got.Subject != tt.wantSubject {
+				t.Errorf("digestUsecase.Preview() subject = %v, want %v", got.Subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantText {
+				if !strings.Contains(got.Text, want) {
+					t.Errorf("digestUsecase.Preview() text does not contain %q:\n%s", want, got.Text)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got.Text, notWant) {
+					t.Errorf("digestUsecase.Preview() text contains %q:\n%s", notWant, got.Text)
+				}
+			}
+		})
+	}
+}
+
+func Test_digestDue(t *testing.T) {
+	// 2024-10-02 は水曜日
+	at := func(hour int) time.Time { return time.Date(2024, 10, 2, hour, 30, 0, 0, time.UTC) }
+	daily := model.DefaultNotificationPreference(1)
+	weekly := model.DefaultNotificationPreference(1)
+	weekly.DigestFrequency = model.DigestFrequencyWeekly
+	weekly.DigestWeekday = int(time.Wednesday)
+	otherDay := weekly
+	otherDay.DigestWeekday = int(time.Monday)
+	off := daily
+	off.Channels = []string{}
+
+	tests := []struct {
+		name  string
+		pref  model.NotificationPreference
+		local time.Time
+		want  bool
+	}{
+		{name: "正常系：毎日のまとめは指定した時刻に送る", pref: daily, local: at(8), want: true},
+		{name: "正常系：指定した時刻以外は送らない", pref: daily, local: at(9)},
+		{name: "正常系：週間まとめは指定した曜日に送る", pref: weekly, local: at(8), want: true},
+		{name: "正常系：週間まとめは他の曜日には送らない", pref: otherDay, local: at(8)},
+		{name: "正常系：通知先がなければ送らない", pref: off, local: at(8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := digestDue(tt.pref, tt.local); got != tt.want {
+				t.Errorf("digestDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_digestUsecase_SendDigests(t *testing.T) {
+	// 2024-10-01 23:00 UTC は東京では 10月2日 08:00
+	now := time.Date(2024, 10, 1, 23, 0, 0, 0, time.UTC)
+	pref := model.DefaultNotificationPreference(1)
+	pref.Delivery = model.NotificationDeliveryDigest
+	pref.User = model.User{ID: 1, Username: "山田太郎", Email: "yamada@test.com", TimeZone: "Asia/Tokyo"}
+	notDue := pref
+	notDue.UserID, notDue.User.ID, notDue.User.TimeZone = 2, 2, ""
+
+	tests := []struct {
+		name        string
+		acquired    bool
+		claimed     bool
+		foods       []model.Food
+		notifyErr   error
+		wantSent    int
+		wantRelease bool
+	}{
+		{name: "正常系：リースを取れなければ何もしない"},
+		{name: "正常系：指定した時刻のユーザーにまとめを送る", acquired: true, claimed: true, foods: digestFoods(), wantSent: 1},
+		{name: "正常系：他のレプリカが送ったまとめは送らない", acquired: true, foods: digestFoods()},
+		{name: "正常系：食材がなければ送らない", acquired: true, claimed: true},
+		{name: "異常系：送信に失敗したら記録を戻す", acquired: true, claimed: true, foods: digestFoods(), notifyErr: errors.New("smtp unavailable"), wantRelease: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockINotificationRepository(ctrl)
+			mockLease := mocks.NewMockILeaseRepository(ctrl)
+			mockFood := mocks.NewMockIFoodRepository(ctrl)
+			mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+			mn := notifier.NewMemoryNotifier()
+			mn.Err = tt.notifyErr
+			du := &digestUsecase{nr: mockRepo, lr: mockLease, fr: mockFood, hu: mockHousehold, n: mn, clock: fakeClock{now}, owner: "replica-1", leaseTTL: time.Minute, location: time.UTC}
+
+			mockLease.EXPECT().AcquireLease(digestLease, "replica-1", now, time.Minute).Return(tt.acquired, nil).Times(1)
+			if tt.acquired {
+				mockRepo.EXPECT().GetDigestPreferences(gomock.Any()).SetArg(0, []model.NotificationPreference{pref, notDue}).Return(nil).Times(1)
+				mockRepo.EXPECT().ClaimDigestDelivery(gomock.Any()).DoAndReturn(func(delivery *model.DigestDelivery) (bool, error) {
+					if delivery.UserID != 1 || delivery.Period != "daily:2024-10-02" {
+						t.Errorf("ClaimDigestDelivery() = %+v", delivery)
+					}
+					delivery.ID = 10
+					return tt.claimed, nil
+				}).Times(1)
+			}
+			if tt.claimed {
+				mockHousehold.EXPECT().Memberships(1).Return([]model.HouseholdMember{{HouseholdID: 1, UserID: 1, Permissions: []model.Permission{model.PermissionFoodRead}}}, nil).Times(1)
+				mockFood.EXPECT().GetFoodsForDigest(gomock.Any(), []int{1}, gomock.Any(), gomock.Any()).SetArg(0, tt.foods).Return(nil).Times(1)
+			}
+			if tt.wantRelease {
+				mockRepo.EXPECT().DeleteDigestDelivery(10).Return(nil).Times(1)
+			}
+
+			sent, err := du.SendDigests()
+			if err != nil {
+				t.Fatalf("digestUsecase.SendDigests() error = %v", err)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("digestUsecase.SendDigests() = %v, want %v", sent, tt.wantSent)
+			}
+			if tt.wantSent > 0 {
+				n := mn.Sent()[0]
+				if n.Kind != notifier.KindDigest || n.Email != "yamada@test.com" || n.HTML == "" || n.Summary == "" || len(n.Channels) == 0 {
+					t.Errorf("notification = %+v", n)
+				}
+			}
+		})
+	}
+}
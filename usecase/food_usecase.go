@@ -2,63 +2,142 @@ package usecase
 
 import (
 	"RefrigeratorWatchdog-server/model"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
 
 	"RefrigeratorWatchdog-server/repository"
 	"RefrigeratorWatchdog-server/validator"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	defaultFoodLimit = 50
+	maxFoodLimit     = 200
+	// defaultTrashRetentionDays is how long deleted foods stay in the trash.
+	defaultTrashRetentionDays = 30
+)
+
+// Policies for foods without an expiration date in the expiring and expired
+// listings, set by NO_EXPIRATION_DATE_POLICY.
+const (
+	// noExpirationIgnore leaves them out of both listings.
+	noExpirationIgnore = "ignore"
+	// noExpirationExpiring lists them as expiring so that they get checked.
+	noExpirationExpiring = "expiring"
+	// noExpirationExpired lists them as expired.
+	noExpirationExpired = "expired"
 )
 
+// IFoodUsecase operates on the foods of the user's active household.
 type IFoodUsecase interface {
-	GetFoodsByUserID(userID uint) ([]model.FoodResponse, error)
-	CreateFood(food model.Food) (model.FoodResponse, error)
-	UpdateFood(food model.Food, id uint) (model.FoodResponse, error)
-	DeleteFood(id uint) error
+	GetFoodsByUserID(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error)
+	CreateFood(food model.Food, user model.AuthUser) (model.FoodResponse, error)
+	GetFood(id uint, user model.AuthUser) (model.FoodResponse, error)
+	// UpdateFood, PatchFood and DeleteFood change the food only if it has
+	// one of the versions that match names. A nil match is rejected with
+	// model.ErrPreconditionRequired unless If-Match is optional.
+	UpdateFood(food model.Food, id uint, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error)
+	// PatchFood applies a JSON Merge Patch to the food, validating only the
+	// members it contains, and returns the food as saved.
+	PatchFood(id uint, patch model.FoodPatch, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error)
+	// DeleteFood moves the food to the trash, from which it can be restored
+	// until it is purged.
+	DeleteFood(id uint, match *model.FoodVersionMatch, user model.AuthUser) error
+	GetTrashedFoods(user model.AuthUser) (model.FoodTrashResponse, error)
+	RestoreFood(id uint, user model.AuthUser) (model.FoodResponse, error)
+	// PurgeTrashedFoods permanently deletes the foods that have been in the
+	// trash for longer than the retention period, with their images unless
+	// other foods use them, and returns the number deleted.
+	PurgeTrashedFoods() (int, error)
+	// ConsumeFood uses up part of the food and archives it as eaten when none
+	// is left.
+	ConsumeFood(id uint, req model.ConsumeFoodRequest, user model.AuthUser) (model.FoodResponse, error)
+	// DisposeFood archives the food with the disposition, keeping the
+	// quantity that was left.
+	DisposeFood(id uint, req model.DisposeFoodRequest, user model.AuthUser) (model.FoodResponse, error)
+	// GetFoodHistory returns a page of the archived foods of the active
+	// household, most recently archived first unless the query says otherwise.
+	GetFoodHistory(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error)
+	// GetExpiringFoods returns the foods that expire from today up to the
+	// given number of days later, in the time zone of the user.
+	GetExpiringFoods(user model.AuthUser, days int) (model.FoodExpirationResponse, error)
+	// GetExpiredFoods returns the foods that expired before today, in the
+	// time zone of the user.
+	GetExpiredFoods(user model.AuthUser) (model.FoodExpirationResponse, error)
 }
 
 type foodUsecase struct {
 	fr repository.IFoodRepository
 	fv validator.IFoodValidator
+	ur repository.IUserRepository
+	ir repository.IImageRepository
+	hu IHouseholdUsecase
+	al IAuditUsecase
+	wh IWebhookUsecase
+	// requireVerifiedEmail blocks food creation by users who have not verified their email
+	requireVerifiedEmail bool
+	// noExpirationPolicy is where foods without an expiration date are listed
+	noExpirationPolicy string
+	// location is the time zone of users who have not set one
+	location *time.Location
+	// trashRetentionDays is how many days deleted foods stay in the trash
+	trashRetentionDays int
+	// requireIfMatch rejects changes to foods that do not name the version they change
+	requireIfMatch bool
 }
 
-func NewFoodUsecase(fr repository.IFoodRepository, fv validator.IFoodValidator) IFoodUsecase {
-	return &foodUsecase{fr, fv}
-}
-
-func (fu *foodUsecase) GetFoodsByUserID(userID uint) ([]model.FoodResponse, error) {
-	foods := []model.Food{}
-	if err := fu.fr.GetFoodsByUserID(&foods, userID); err != nil {
-		return nil, err
-	}
-	resFoods := []model.FoodResponse{}
-	for _, food := range foods {
-		resFoods = append(resFoods, model.FoodResponse{
-			ID:             food.ID,
-			Name:           food.Name,
-			UserID:         food.UserID,
-			OriginalCode:   food.OriginalCode,
-			Quantity:       food.Quantity,
-			CreatedAt:      food.CreatedAt,
-			ExpirationDate: food.ExpirationDate,
-			ImageURL:       food.ImageURL,
-			Tag:            food.Tag,
-			Memo:           food.Memo,
-		})
-	}
-	return resFoods, nil
-}
-
-func (fu *foodUsecase) CreateFood(food model.Food) (model.FoodResponse, error) {
-	if err := fu.fv.ValidateFood(food); err != nil {
-		return model.FoodResponse{}, err
+// NewFoodUsecase creates a new instance of the foodUsecase struct.
+// Setting REQUIRE_EMAIL_VERIFICATION to "true" blocks unverified users from creating foods.
+// NO_EXPIRATION_DATE_POLICY is "ignore" (default), "expiring" or "expired", and
+// DEFAULT_TIME_ZONE is the time zone of users who have not set one (default UTC).
+// FOOD_TRASH_RETENTION_DAYS is how many days deleted foods can be restored (default 30).
+// Setting FOOD_REQUIRE_IF_MATCH to "false" allows changing foods without If-Match.
+func NewFoodUsecase(fr repository.IFoodRepository, fv validator.IFoodValidator, ur repository.IUserRepository, ir repository.IImageRepository, hu IHouseholdUsecase, al IAuditUsecase, wh IWebhookUsecase) IFoodUsecase {
+	noExpirationPolicy := os.Getenv("NO_EXPIRATION_DATE_POLICY")
+	switch noExpirationPolicy {
+	case noExpirationIgnore, noExpirationExpiring, noExpirationExpired:
+	case "":
+		noExpirationPolicy = noExpirationIgnore
+	default:
+		log.Printf("unknown NO_EXPIRATION_DATE_POLICY %q, using %q", noExpirationPolicy, noExpirationIgnore)
+		noExpirationPolicy = noExpirationIgnore
 	}
 
-	if err := fu.fr.CreateFood(&food); err != nil {
-		return model.FoodResponse{}, err
+	return &foodUsecase{
+		fr:                   fr,
+		fv:                   fv,
+		ur:                   ur,
+		ir:                   ir,
+		hu:                   hu,
+		al:                   al,
+		wh:                   wh,
+		requireVerifiedEmail: os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true",
+		noExpirationPolicy:   noExpirationPolicy,
+		location:             defaultLocation(),
+		trashRetentionDays:   intFromEnv("FOOD_TRASH_RETENTION_DAYS", defaultTrashRetentionDays),
+		requireIfMatch:       os.Getenv("FOOD_REQUIRE_IF_MATCH") != "false",
 	}
+}
 
+func foodResponse(food model.Food) model.FoodResponse {
+	var deletedAt *time.Time
+	if food.DeletedAt.Valid {
+		deletedAt = &food.DeletedAt.Time
+	}
 	return model.FoodResponse{
 		ID:             food.ID,
 		Name:           food.Name,
 		UserID:         food.UserID,
+		HouseholdID:    food.HouseholdID,
 		OriginalCode:   food.OriginalCode,
 		Quantity:       food.Quantity,
 		CreatedAt:      food.CreatedAt,
@@ -66,37 +145,695 @@ func (fu *foodUsecase) CreateFood(food model.Food) (model.FoodResponse, error) {
 		ImageURL:       food.ImageURL,
 		Tag:            food.Tag,
 		Memo:           food.Memo,
-	}, nil
+		StorageLocation: food.StorageLocation,
+		Price:          food.Price,
+		ArchivedAt:     food.ArchivedAt,
+		Disposition:    food.Disposition,
+		DeletedAt:      deletedAt,
+		Version:        food.Version,
+	}
+}
+
+// matchVersions returns the versions that the food must have to be changed,
+// or nil if any version can be.
+func (fu *foodUsecase) matchVersions(match *model.FoodVersionMatch) ([]int, error) {
+	switch {
+	case match == nil && fu.requireIfMatch:
+		return nil, model.ErrPreconditionRequired
+	case match == nil || match.Any:
+		return nil, nil
+	case len(match.Versions) == 0:
+		// どのバージョンも指していなければ一致しない
+		return nil, model.ErrFoodVersionMismatch
+	}
+	return match.Versions, nil
+}
+
+// GetFoodsByUserID returns a page of the active foods of the active
+// household that match the query. Foods are sorted by creation time, oldest
+// first, unless the query says otherwise.
+func (fu *foodUsecase) GetFoodsByUserID(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error) {
+	if err := fu.fv.ValidateFoodQuery(query); err != nil {
+		return model.FoodPage{}, err
+	}
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodPage{}, err
+	}
+
+	if query.Sort == "" {
+		query.Sort = model.FoodSortCreatedAt
+	}
+	if query.Order == "" {
+		query.Order = model.SortOrderAsc
+	}
+	query.Archived = false
+	return fu.foodPage(member.HouseholdID, query)
+}
+
+func (fu *foodUsecase) GetFoodHistory(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error) {
+	if err := fu.fv.ValidateFoodHistoryQuery(query); err != nil {
+		return model.FoodPage{}, err
+	}
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodPage{}, err
+	}
+
+	if query.Sort == "" {
+		query.Sort = model.FoodSortArchivedAt
+	}
+	if query.Order == "" {
+		query.Order = model.SortOrderDesc
+	}
+	query.Archived = true
+	return fu.foodPage(member.HouseholdID, query)
+}
+
+// foodPage returns the page of the foods of the household that the sorted
+// query selects.
+func (fu *foodUsecase) foodPage(householdID int, query model.FoodQuery) (model.FoodPage, error) {
+	after, err := decodeFoodCursor(query)
+	if err != nil {
+		return model.FoodPage{}, err
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultFoodLimit
+	}
+	if query.Limit > maxFoodLimit {
+		query.Limit = maxFoodLimit
+	}
+	limit := query.Limit
+	// 次のページがあるかを知るために1件多く取得する
+	query.Limit++
+
+	foods := []model.Food{}
+	if err := fu.fr.GetFoodsByHouseholdID(&foods, householdID, query, after); err != nil {
+		return model.FoodPage{}, err
+	}
+
+	page := model.FoodPage{Items: []model.FoodResponse{}}
+	if len(foods) > limit {
+		foods = foods[:limit]
+		page.NextCursor = encodeFoodCursor(query, foods[limit-1])
+	}
+	for _, food := range foods {
+		page.Items = append(page.Items, foodResponse(food))
+	}
+	return page, nil
+}
+
+// foodCursor is the position of the last food of a page in the sort order
+// of the page. The sort and order are kept so that a cursor is not used with
+// another order.
+type foodCursor struct {
+	Sort  string     `json:"s"`
+	Order string     `json:"o"`
+	ID    int        `json:"id"`
+	Time  *time.Time `json:"t,omitempty"`
+	Name  string     `json:"n,omitempty"`
+}
+
+// encodeFoodCursor returns an opaque cursor pointing after the food.
+func encodeFoodCursor(query model.FoodQuery, food model.Food) string {
+	cursor := foodCursor{Sort: query.Sort, Order: query.Order, ID: food.ID}
+	switch query.Sort {
+	case model.FoodSortExpirationDate:
+		cursor.Time = food.ExpirationDate
+	case model.FoodSortCreatedAt:
+		cursor.Time = &food.CreatedAt
+	case model.FoodSortName:
+		cursor.Name = food.Name
+	case model.FoodSortArchivedAt:
+		cursor.Time = food.ArchivedAt
+	}
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeFoodCursor returns the food that the cursor of the query points
+// after, with the fields of the sort order set, or nil if there is no cursor.
+func decodeFoodCursor(query model.FoodQuery) (*model.Food, error) {
+	if query.Cursor == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, model.ErrInvalidCursor
+	}
+	cursor := foodCursor{}
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, model.ErrInvalidCursor
+	}
+	if cursor.Sort != query.Sort || cursor.Order != query.Order || cursor.ID <= 0 {
+		return nil, model.ErrInvalidCursor
+	}
+
+	after := &model.Food{ID: cursor.ID, Name: cursor.Name}
+	switch query.Sort {
+	case model.FoodSortExpirationDate:
+		after.ExpirationDate = cursor.Time
+	case model.FoodSortCreatedAt:
+		if cursor.Time == nil {
+			return nil, model.ErrInvalidCursor
+		}
+		after.CreatedAt = *cursor.Time
+	case model.FoodSortArchivedAt:
+		if cursor.Time == nil {
+			return nil, model.ErrInvalidCursor
+		}
+		after.ArchivedAt = cursor.Time
+	}
+	return after, nil
 }
 
-func (fu *foodUsecase) UpdateFood(food model.Food, id uint) (model.FoodResponse, error) {
+func (fu *foodUsecase) CreateFood(food model.Food, user model.AuthUser) (model.FoodResponse, error) {
+	food.UserID = user.ID
 	if err := fu.fv.ValidateFood(food); err != nil {
 		return model.FoodResponse{}, err
 	}
 
-	if err := fu.fr.UpdateFood(&food, id); err != nil {
+	if fu.requireVerifiedEmail {
+		creator := model.User{}
+		if err := fu.ur.GetUserByID(&creator, user.ID); err != nil {
+			return model.FoodResponse{}, err
+		}
+		if creator.VerifiedAt == nil {
+			return model.FoodResponse{}, model.ErrEmailNotVerified
+		}
+	}
+
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodWrite)
+	if err != nil {
 		return model.FoodResponse{}, err
 	}
+	food.HouseholdID = member.HouseholdID
+	food.Version = 1
 
-	return model.FoodResponse{
-		ID:             food.ID,
-		Name:           food.Name,
-		UserID:         food.UserID,
-		OriginalCode:   food.OriginalCode,
-		Quantity:       food.Quantity,
-		CreatedAt:      food.CreatedAt,
-		ExpirationDate: food.ExpirationDate,
-		ImageURL:       food.ImageURL,
-		Tag:            food.Tag,
-		Memo:           food.Memo,
-	}, nil
+	if err := fu.fr.CreateFood(&food); err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	res := foodResponse(food)
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodCreate, food, nil, res))
+	fu.publishFoodEvent(model.WebhookEventFoodCreated, model.WebhookFoodData{Food: res, ActorID: user.ID})
+	return res, nil
+}
+
+// publishFoodEvent queues a webhook event about a change to the food.
+func (fu *foodUsecase) publishFoodEvent(eventType model.WebhookEventType, data model.WebhookFoodData) {
+	id, err := newWebhookEventID()
+	if err != nil {
+		log.Println("failed to publish webhook event:", err)
+		return
+	}
+	fu.wh.Publish(model.WebhookEvent{
+		ID:          id,
+		Type:        eventType,
+		HouseholdID: data.Food.HouseholdID,
+		OccurredAt:  time.Now(),
+		Data:        data,
+	})
+}
+
+// foodAuditEntry returns the audit log entry of a change to the food.
+func foodAuditEntry(user model.AuthUser, action string, food model.Food, before interface{}, after interface{}) model.AuditEntry {
+	return model.AuditEntry{
+		Actor:       user,
+		Action:      action,
+		EntityType:  model.AuditEntityFood,
+		EntityID:    strconv.Itoa(food.ID),
+		HouseholdID: &food.HouseholdID,
+		Before:      before,
+		After:       after,
+	}
+}
+
+func (fu *foodUsecase) GetFood(id uint, user model.AuthUser) (model.FoodResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	food := model.Food{}
+	if err := fu.fr.GetFoodByID(&food, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+	return foodResponse(food), nil
+}
+
+func (fu *foodUsecase) UpdateFood(food model.Food, id uint, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error) {
+	food.UserID = user.ID
+	if err := fu.fv.ValidateFood(food); err != nil {
+		return model.FoodResponse{}, err
+	}
+	// 登録したユーザーは変更しない
+	food.UserID = 0
+
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodWrite)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+	// 他の世帯へは移動できない
+	food.HouseholdID = member.HouseholdID
+	versions, err := fu.matchVersions(match)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	before := model.Food{}
+	if err := fu.fr.GetFoodByID(&before, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+	// 履歴に残った食材は変更しない
+	if before.ArchivedAt != nil {
+		return model.FoodResponse{}, model.ErrFoodArchived
+	}
+	if err := fu.fr.UpdateFood(&food, id, member.HouseholdID, versions); err != nil {
+		return model.FoodResponse{}, err
+	}
+	// 変更されなかった項目も含めて、保存された内容を返す
+	after := model.Food{}
+	if err := fu.fr.GetFoodByID(&after, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	return fu.foodUpdated(user, before, after), nil
+}
+
+func (fu *foodUsecase) PatchFood(id uint, patch model.FoodPatch, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodWrite)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+	versions, err := fu.matchVersions(match)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	before := model.Food{}
+	if err := fu.fr.GetFoodByID(&before, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+	// 履歴に残った食材は変更しない
+	if before.ArchivedAt != nil {
+		return model.FoodResponse{}, model.ErrFoodArchived
+	}
+	food := before
+	fields, err := applyFoodPatch(&food, patch)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+	if err := fu.fv.ValidateFoodPatch(food, fields); err != nil {
+		return model.FoodResponse{}, err
+	}
+	if len(fields) > 0 {
+		if err := fu.fr.PatchFood(&food, id, member.HouseholdID, fields, versions); err != nil {
+			return model.FoodResponse{}, err
+		}
+	} else if versions != nil && !slices.Contains(versions, before.Version) {
+		// 何も変更しなくても、古いバージョンを指していれば失敗させる
+		return model.FoodResponse{}, model.ErrFoodVersionMismatch
+	}
+	after := model.Food{}
+	if err := fu.fr.GetFoodByID(&after, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+	return fu.foodUpdated(user, before, after), nil
 }
 
-func (fu *foodUsecase) DeleteFood(id uint) error {
+// applyFoodPatch sets the fields of the food that the patch contains and
+// returns their names, sorted. Members that are not fields of
+// model.FoodRequest or whose values have the wrong type are returned as
+// validation errors.
+func applyFoodPatch(food *model.Food, patch model.FoodPatch) ([]string, error) {
+	targets := map[string]interface{}{
+		"name":             &food.Name,
+		"original_code":    &food.OriginalCode,
+		"quantity":         &food.Quantity,
+		"expiration_date":  &food.ExpirationDate,
+		"image_url":        &food.ImageURL,
+		"tag":              &food.Tag,
+		"memo":             &food.Memo,
+		"storage_location": &food.StorageLocation,
+		"price":            &food.Price,
+	}
+	fields := []string{}
+	errs := validation.Errors{}
+	for member, value := range patch {
+		target, ok := targets[member]
+		if !ok {
+			errs[member] = validation.NewError("validation_patch_unknown_member", "cannot be changed")
+			continue
+		}
+		// 元の値を共有しないよう既定値に戻してから読み込む。null ならそのまま既定値になる
+		field := reflect.ValueOf(target).Elem()
+		field.Set(reflect.Zero(field.Type()))
+		if err := json.Unmarshal(value, target); err != nil {
+			errs[member] = validation.NewError("validation_patch_invalid_value", "has a value of the wrong type")
+			continue
+		}
+		fields = append(fields, member)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if food.Tag == "" {
+		food.Tag = "その他"
+	}
+	if food.StorageLocation == "" {
+		food.StorageLocation = model.StorageRefrigerator
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// foodUpdated records the change of the food and returns the food as saved.
+func (fu *foodUsecase) foodUpdated(user model.AuthUser, before model.Food, after model.Food) model.FoodResponse {
+	res := foodResponse(after)
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodUpdate, after, foodResponse(before), res))
+	// 数量が減った変更は消費として通知する
+	if after.Quantity < before.Quantity {
+		fu.publishFoodEvent(model.WebhookEventFoodConsumed, model.WebhookFoodData{Food: res, ActorID: user.ID, PreviousQuantity: &before.Quantity})
+	} else {
+		fu.publishFoodEvent(model.WebhookEventFoodUpdated, model.WebhookFoodData{Food: res, ActorID: user.ID})
+	}
+	return res
+}
+
+func (fu *foodUsecase) DeleteFood(id uint, match *model.FoodVersionMatch, user model.AuthUser) error {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodDelete)
+	if err != nil {
+		return err
+	}
+	versions, err := fu.matchVersions(match)
+	if err != nil {
+		return err
+	}
 
-	if err := fu.fr.DeleteFood(id); err != nil {
+	before := model.Food{}
+	if err := fu.fr.GetFoodByID(&before, id, member.HouseholdID); err != nil {
+		return err
+	}
+	if err := fu.fr.DeleteFood(id, member.HouseholdID, versions); err != nil {
 		return err
 	}
 
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodDelete, before, foodResponse(before), nil))
+	fu.publishFoodEvent(model.WebhookEventFoodDeleted, model.WebhookFoodData{Food: foodResponse(before), ActorID: user.ID})
 	return nil
 }
+
+func (fu *foodUsecase) GetTrashedFoods(user model.AuthUser) (model.FoodTrashResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodTrashResponse{}, err
+	}
+
+	foods := []model.Food{}
+	if err := fu.fr.GetTrashedFoods(&foods, member.HouseholdID); err != nil {
+		return model.FoodTrashResponse{}, err
+	}
+	res := model.FoodTrashResponse{RetentionDays: fu.trashRetentionDays, Items: []model.FoodResponse{}}
+	for _, food := range foods {
+		res.Items = append(res.Items, foodResponse(food))
+	}
+	return res, nil
+}
+
+func (fu *foodUsecase) RestoreFood(id uint, user model.AuthUser) (model.FoodResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodDelete)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	food := model.Food{}
+	if err := fu.fr.RestoreFood(&food, id, member.HouseholdID); err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	res := foodResponse(food)
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodRestore, food, nil, res))
+	fu.publishFoodEvent(model.WebhookEventFoodRestored, model.WebhookFoodData{Food: res, ActorID: user.ID})
+	return res, nil
+}
+
+func (fu *foodUsecase) PurgeTrashedFoods() (int, error) {
+	before := time.Now().AddDate(0, 0, -fu.trashRetentionDays)
+	foods := []model.Food{}
+	if err := fu.fr.GetFoodsTrashedBefore(&foods, before); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, food := range foods {
+		deleted, err := fu.fr.PurgeFood(food.ID, before)
+		if err != nil {
+			log.Println("failed to purge food:", err)
+			continue
+		}
+		// 削除までの間に復元されていれば残す
+		if !deleted {
+			continue
+		}
+		purged++
+		if food.ImageURL == "" {
+			continue
+		}
+		filename := filepath.Base(food.ImageURL)
+		// image_url はクライアントが指定できるため、他の世帯の画像は消さない
+		owned, err := fu.isHouseholdImage(filename, food.HouseholdID)
+		if err != nil {
+			log.Println("failed to check owner of image of purged food:", err)
+			continue
+		}
+		if !owned {
+			continue
+		}
+		// 同じ画像を使う食材が残っていれば消さない
+		inUse, err := fu.fr.IsImageInUse(filename)
+		if err != nil {
+			log.Println("failed to check image of purged food:", err)
+			continue
+		}
+		if !inUse {
+			if err := fu.ir.DeleteImage(filename); err != nil {
+				log.Println("failed to delete image of purged food:", err)
+			}
+		}
+	}
+	return purged, nil
+}
+
+// isHouseholdImage reports whether the image was uploaded by a member of the
+// household, by the owner prefix of its filename as canFetchImage does.
+func (fu *foodUsecase) isHouseholdImage(filename string, householdID int) (bool, error) {
+	ownerID, ok := imageOwnerID(filename)
+	if !ok {
+		return false, nil
+	}
+	members, err := fu.hu.Members(householdID)
+	if err != nil {
+		return false, err
+	}
+	for _, member := range members {
+		if member.UserID == ownerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (fu *foodUsecase) ConsumeFood(id uint, req model.ConsumeFoodRequest, user model.AuthUser) (model.FoodResponse, error) {
+	if err := fu.fv.ValidateConsumeFood(req); err != nil {
+		return model.FoodResponse{}, err
+	}
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodWrite)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	food := model.Food{}
+	consumption := model.FoodConsumption{
+		FoodID:      int(id),
+		HouseholdID: member.HouseholdID,
+		UserID:      user.ID,
+		Amount:      req.Amount,
+		ConsumedAt:  time.Now(),
+	}
+	if err := fu.fr.ConsumeFood(&food, &consumption); err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	res := foodResponse(food)
+	previousQuantity := consumption.RemainingQuantity + consumption.Amount
+	before := res
+	before.Quantity = previousQuantity
+	before.ArchivedAt = nil
+	before.Disposition = ""
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodConsume, food, before, res))
+	fu.publishFoodEvent(model.WebhookEventFoodConsumed, model.WebhookFoodData{Food: res, ActorID: user.ID, PreviousQuantity: &previousQuantity})
+	return res, nil
+}
+
+func (fu *foodUsecase) DisposeFood(id uint, req model.DisposeFoodRequest, user model.AuthUser) (model.FoodResponse, error) {
+	if err := fu.fv.ValidateDisposeFood(req); err != nil {
+		return model.FoodResponse{}, err
+	}
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodDelete)
+	if err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	food := model.Food{}
+	if err := fu.fr.ArchiveFood(&food, id, member.HouseholdID, req.Disposition, time.Now()); err != nil {
+		return model.FoodResponse{}, err
+	}
+
+	res := foodResponse(food)
+	before := res
+	before.ArchivedAt = nil
+	before.Disposition = ""
+	fu.al.Record(foodAuditEntry(user, model.AuditActionFoodDispose, food, before, res))
+	fu.publishFoodEvent(model.WebhookEventFoodDisposed, model.WebhookFoodData{Food: res, ActorID: user.ID})
+	return res, nil
+}
+
+func (fu *foodUsecase) GetExpiringFoods(user model.AuthUser, days int) (model.FoodExpirationResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	loc, err := fu.userLocation(user.ID)
+	if err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	today := startOfDay(time.Now(), loc)
+	end := today.AddDate(0, 0, days+1)
+
+	foods := []model.Food{}
+	query := model.FoodQuery{ExpiresAfter: &today, ExpiresBefore: &end, Sort: model.FoodSortExpirationDate, Order: model.SortOrderAsc}
+	if err := fu.fr.GetFoodsByHouseholdID(&foods, member.HouseholdID, query, nil); err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	return fu.foodExpirationResponse(foods, member.HouseholdID, today, noExpirationExpiring)
+}
+
+func (fu *foodUsecase) GetExpiredFoods(user model.AuthUser) (model.FoodExpirationResponse, error) {
+	member, err := fu.hu.AuthorizeActive(user, model.PermissionFoodRead)
+	if err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	loc, err := fu.userLocation(user.ID)
+	if err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	today := startOfDay(time.Now(), loc)
+
+	// 最近期限が切れたものから返す
+	foods := []model.Food{}
+	query := model.FoodQuery{ExpiresBefore: &today, Sort: model.FoodSortExpirationDate, Order: model.SortOrderDesc}
+	if err := fu.fr.GetFoodsByHouseholdID(&foods, member.HouseholdID, query, nil); err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	return fu.foodExpirationResponse(foods, member.HouseholdID, today, noExpirationExpired)
+}
+
+// foodExpirationResponse groups the foods by the day they expire. The foods
+// without an expiration date of the household are added as the last group
+// when the policy lists them in the listing.
+func (fu *foodUsecase) foodExpirationResponse(foods []model.Food, householdID int, today time.Time, listing string) (model.FoodExpirationResponse, error) {
+	res := model.FoodExpirationResponse{
+		TimeZone: today.Location().String(),
+		Today:    today.Format(time.DateOnly),
+		Groups:   groupFoodsByDay(foods, today),
+	}
+	if fu.noExpirationPolicy != listing {
+		return res, nil
+	}
+
+	undated := []model.Food{}
+	if err := fu.fr.GetFoodsByHouseholdID(&undated, householdID, model.FoodQuery{NoExpirationDate: true}, nil); err != nil {
+		return model.FoodExpirationResponse{}, err
+	}
+	if len(undated) > 0 {
+		group := model.FoodExpirationGroup{Items: []model.FoodResponse{}}
+		for _, food := range undated {
+			group.Items = append(group.Items, foodResponse(food))
+		}
+		res.Groups = append(res.Groups, group)
+	}
+	return res, nil
+}
+
+// userLocation returns the time zone of the user.
+func (fu *foodUsecase) userLocation(userID int) (*time.Location, error) {
+	user := model.User{}
+	if err := fu.ur.GetUserByID(&user, userID); err != nil {
+		return nil, err
+	}
+	return userTimeZone(user, fu.location), nil
+}
+
+// defaultLocation returns the time zone set by DEFAULT_TIME_ZONE, or UTC.
+func defaultLocation() *time.Location {
+	name := os.Getenv("DEFAULT_TIME_ZONE")
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("unknown DEFAULT_TIME_ZONE %q, using UTC", name)
+		return time.UTC
+	}
+	return loc
+}
+
+// userTimeZone returns the time zone of the user, or def when the user has
+// not set one.
+func userTimeZone(user model.User, def *time.Location) *time.Location {
+	if user.TimeZone != "" {
+		if loc, err := time.LoadLocation(user.TimeZone); err == nil {
+			return loc
+		}
+	}
+	if def == nil {
+		return time.UTC
+	}
+	return def
+}
+
+// startOfDay returns midnight of the day of t in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// groupFoodsByDay groups the foods, which are sorted by expiration date, by
+// the day they expire in the time zone of today. Foods without an expiration
+// date are left out.
+func groupFoodsByDay(foods []model.Food, today time.Time) []model.FoodExpirationGroup {
+	groups := []model.FoodExpirationGroup{}
+	for _, food := range foods {
+		if food.ExpirationDate == nil {
+			continue
+		}
+		day := startOfDay(*food.ExpirationDate, today.Location())
+		date := day.Format(time.DateOnly)
+		if n := len(groups); n == 0 || *groups[n-1].Date != date {
+			days := daysBetween(today, day)
+			groups = append(groups, model.FoodExpirationGroup{Date: &date, DaysRemaining: &days, Items: []model.FoodResponse{}})
+		}
+		groups[len(groups)-1].Items = append(groups[len(groups)-1].Items, foodResponse(food))
+	}
+	return groups
+}
+
+// daysBetween returns the number of calendar days from the day of from to the
+// day of to. Days are counted on the calendar so that days with a daylight
+// saving time change count as one.
+func daysBetween(from time.Time, to time.Time) int {
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
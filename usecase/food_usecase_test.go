@@ -4,123 +4,169 @@ import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/repository"
 	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
 	"RefrigeratorWatchdog-server/validator"
+	"encoding/json"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
+
+var expirationDate = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newOwnerHouseholdUsecase returns a household usecase mock in which every user
+// owns the household with the same ID as the user.
+func newOwnerHouseholdUsecase(ctrl *gomock.Controller) *usecasemocks.MockIHouseholdUsecase {
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+	mockHousehold.EXPECT().AuthorizeActive(gomock.Any(), gomock.Any()).DoAndReturn(func(user model.AuthUser, permission model.Permission) (model.HouseholdMember, error) {
+		return model.HouseholdMember{HouseholdID: user.ID, UserID: user.ID, Role: model.HouseholdRoleOwner, Permissions: model.AllPermissions}, nil
+	}).AnyTimes()
+	return mockHousehold
+}
+
 func Test_foodUsecase_GetFoodsByUserID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockHousehold := newOwnerHouseholdUsecase(ctrl)
 	Validator := validator.NewFoodValidator()
 
-	type fields struct {
-		fr repository.IFoodRepository
-		fv validator.IFoodValidator
+	foods := []model.Food{
+		{
+			ID:             1,
+			Name:           "food1",
+			UserID:         1,
+			OriginalCode:   123,
+			Quantity:       1,
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpirationDate: &expirationDate,
+			ImageURL:       "https://example.com",
+			Memo:           "memo",
+		},
+		{
+			ID:             2,
+			Name:           "food2",
+			UserID:         1,
+			OriginalCode:   123,
+			Quantity:       1,
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpirationDate: &expirationDate,
+			ImageURL:       "https://example.com",
+			Memo:           "memo",
+		},
 	}
+	nameCursor := encodeFoodCursor(model.FoodQuery{Sort: model.FoodSortName, Order: model.SortOrderDesc}, foods[0])
+
 	type args struct {
 		userID uint
-		foods  []model.Food
+		query  model.FoodQuery
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []model.FoodResponse
-		wantErr bool
+		name      string
+		args      args
+		foods     []model.Food
+		wantQuery *model.FoodQuery
+		wantAfter *model.Food
+		want      model.FoodPage
+		wantErr   error
 	}{
 		{
 			name: "正常系：ユーザーIDに紐づく食材を取得できる",
-			fields: fields{
-				fr: mockRepo,
-				fv: Validator,
+			args: args{
+				userID: 1,
+			},
+			foods:     foods,
+			wantQuery: &model.FoodQuery{Sort: model.FoodSortCreatedAt, Order: model.SortOrderAsc, Limit: defaultFoodLimit + 1},
+			want:      model.FoodPage{Items: []model.FoodResponse{foodResponse(foods[0]), foodResponse(foods[1])}},
+		},
+		{
+			name: "正常系：食材がなければ空のページを返す",
+			args: args{
+				userID: 0,
 			},
+			foods:     []model.Food{},
+			wantQuery: &model.FoodQuery{Sort: model.FoodSortCreatedAt, Order: model.SortOrderAsc, Limit: defaultFoodLimit + 1},
+			want:      model.FoodPage{Items: []model.FoodResponse{}},
+		},
+		{
+			name: "正常系：続きがあれば次のページのカーソルを返す",
 			args: args{
 				userID: 1,
-				foods: []model.Food{
-					{
-						ID:             1,
-						Name:           "food1",
-						UserID:         1,
-						OriginalCode:   123,
-						Quantity:       1,
-						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ImageURL:       "https://example.com",
-						Memo:           "memo",
-					},
-					{
-						ID:             2,
-						Name:           "food2",
-						UserID:         1,
-						OriginalCode:   123,
-						Quantity:       1,
-						CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-						ImageURL:       "https://example.com",
-						Memo:           "memo",
-					},
-				},
+				query:  model.FoodQuery{Tag: "果物", Sort: model.FoodSortName, Order: model.SortOrderDesc, Limit: 1},
 			},
-			want: []model.FoodResponse{
-				{
-					ID:             1,
-					Name:           "food1",
-					UserID:         1,
-					OriginalCode:   123,
-					Quantity:       1,
-					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ImageURL:       "https://example.com",
-					Memo:           "memo",
-				},
-				{
-					ID:             2,
-					Name:           "food2",
-					UserID:         1,
-					OriginalCode:   123,
-					Quantity:       1,
-					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ImageURL:       "https://example.com",
-					Memo:           "memo",
-				},
+			foods:     foods,
+			wantQuery: &model.FoodQuery{Tag: "果物", Sort: model.FoodSortName, Order: model.SortOrderDesc, Limit: 2},
+			want:      model.FoodPage{Items: []model.FoodResponse{foodResponse(foods[0])}, NextCursor: nameCursor},
+		},
+		{
+			name: "正常系：カーソルの次の食材から取得する",
+			args: args{
+				userID: 1,
+				query:  model.FoodQuery{Sort: model.FoodSortName, Order: model.SortOrderDesc, Cursor: nameCursor, Limit: 500},
 			},
-			wantErr: false,
+			foods:     foods[1:],
+			wantQuery: &model.FoodQuery{Sort: model.FoodSortName, Order: model.SortOrderDesc, Cursor: nameCursor, Limit: maxFoodLimit + 1},
+			wantAfter: &model.Food{ID: 1, Name: "food1"},
+			want:      model.FoodPage{Items: []model.FoodResponse{foodResponse(foods[1])}},
 		},
 		{
-			name: "異常系：ユーザーIDに紐づく食材が取得できない",
-			fields: fields{
-				fr: mockRepo,
-				fv: Validator,
+			name: "異常系：並び順の異なるカーソルは使えない",
+			args: args{
+				userID: 1,
+				query:  model.FoodQuery{Sort: model.FoodSortName, Order: model.SortOrderAsc, Cursor: nameCursor},
 			},
+			wantErr: model.ErrInvalidCursor,
+		},
+		{
+			name: "異常系：不正なカーソル",
 			args: args{
-				userID: 0,
-				foods:  []model.Food{},
+				userID: 1,
+				query:  model.FoodQuery{Cursor: "!!"},
 			},
-			want:    []model.FoodResponse{},
-			wantErr: false,
+			wantErr: model.ErrInvalidCursor,
+		},
+		{
+			name: "異常系：不正な並び替えの列",
+			args: args{
+				userID: 1,
+				query:  model.FoodQuery{Sort: "memo"},
+			},
+			wantErr: errors.New("sort: must be a valid value."),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fu := &foodUsecase{
-				fr: tt.fields.fr,
-				fv: tt.fields.fv,
+				fr: mockRepo,
+				fv: Validator,
+				hu: mockHousehold,
+				al: newNopAuditUsecase(ctrl),
+				wh: newNopWebhookUsecase(ctrl),
+			}
+			if tt.wantQuery != nil {
+				mockRepo.EXPECT().GetFoodsByHouseholdID(gomock.Any(), int(tt.args.userID), *tt.wantQuery, tt.wantAfter).Do(func(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) {
+					*foods = tt.foods
+				}).Return(nil).Times(1)
 			}
-			mockRepo.EXPECT().GetFoodsByUserID(gomock.Any(), tt.args.userID).Do(func(foods *[]model.Food, userID uint) {
-				*foods = tt.args.foods
-			}).Return(nil).Times(1)
 
-			got, err := fu.GetFoodsByUserID(tt.args.userID)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("foodUsecase.GetFoodsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := fu.GetFoodsByUserID(model.AuthUser{ID: int(tt.args.userID)}, tt.args.query)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Errorf("foodUsecase.GetFoodsByUserID() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("foodUsecase.GetFoodsByUserID() error = %v", err)
 				return
 			}
 
@@ -131,11 +177,73 @@ func Test_foodUsecase_GetFoodsByUserID(t *testing.T) {
 	}
 }
 
+func Test_foodCursor(t *testing.T) {
+	createdAt := time.Date(2021, 1, 1, 12, 30, 0, 123000000, time.UTC)
+	archivedAt := time.Date(2021, 1, 2, 8, 0, 0, 0, time.UTC)
+	food := model.Food{ID: 3, Name: "food3", CreatedAt: createdAt, ExpirationDate: &expirationDate, ArchivedAt: &archivedAt}
+
+	tests := []struct {
+		name  string
+		query model.FoodQuery
+		food  model.Food
+		want  *model.Food
+	}{
+		{
+			name:  "正常系：期限順のカーソル",
+			query: model.FoodQuery{Sort: model.FoodSortExpirationDate, Order: model.SortOrderAsc},
+			food:  food,
+			want:  &model.Food{ID: 3, ExpirationDate: &expirationDate},
+		},
+		{
+			name:  "正常系：期限のない食材のカーソル",
+			query: model.FoodQuery{Sort: model.FoodSortExpirationDate, Order: model.SortOrderDesc},
+			food:  model.Food{ID: 4, Name: "food4"},
+			want:  &model.Food{ID: 4},
+		},
+		{
+			name:  "正常系：登録日時順のカーソル",
+			query: model.FoodQuery{Sort: model.FoodSortCreatedAt, Order: model.SortOrderDesc},
+			food:  food,
+			want:  &model.Food{ID: 3, CreatedAt: createdAt},
+		},
+		{
+			name:  "正常系：名前順のカーソル",
+			query: model.FoodQuery{Sort: model.FoodSortName, Order: model.SortOrderAsc},
+			food:  food,
+			want:  &model.Food{ID: 3, Name: "food3"},
+		},
+		{
+			name:  "正常系：アーカイブ日時順のカーソル",
+			query: model.FoodQuery{Sort: model.FoodSortArchivedAt, Order: model.SortOrderDesc},
+			food:  food,
+			want:  &model.Food{ID: 3, ArchivedAt: &archivedAt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			query.Cursor = encodeFoodCursor(tt.query, tt.food)
+			got, err := decodeFoodCursor(query)
+			if err != nil {
+				t.Fatalf("decodeFoodCursor() error = %v", err)
+			}
+			if got.ID != tt.want.ID || got.Name != tt.want.Name || !got.CreatedAt.Equal(tt.want.CreatedAt) ||
+				(got.ExpirationDate == nil) != (tt.want.ExpirationDate == nil) ||
+				(got.ExpirationDate != nil && !got.ExpirationDate.Equal(*tt.want.ExpirationDate)) ||
+				(got.ArchivedAt == nil) != (tt.want.ArchivedAt == nil) ||
+				(got.ArchivedAt != nil && !got.ArchivedAt.Equal(*tt.want.ArchivedAt)) {
+				t.Errorf("decodeFoodCursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_foodUsecase_CreateFood(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockHousehold := newOwnerHouseholdUsecase(ctrl)
 	Validator := validator.NewFoodValidator()
 
 	type fields struct {
@@ -143,7 +251,8 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 		fv validator.IFoodValidator
 	}
 	type args struct {
-		food model.Food
+		food   model.Food
+		userID uint
 	}
 	tests := []struct {
 		name    string
@@ -166,10 +275,11 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
+				userID: 1,
 			},
 			want: model.FoodResponse{
 				ID:             1,
@@ -178,7 +288,7 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 				OriginalCode:   123,
 				Quantity:       1,
 				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-				ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpirationDate: &expirationDate,
 				ImageURL:       "https://example.com",
 				Memo:           "memo",
 			},
@@ -198,10 +308,11 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
+				userID: 1,
 			},
 			want:    model.FoodResponse{},
 			wantErr: true,
@@ -212,10 +323,13 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 			fu := &foodUsecase{
 				fr: tt.fields.fr,
 				fv: tt.fields.fv,
+				hu: mockHousehold,
+				al: newNopAuditUsecase(ctrl),
+				wh: newNopWebhookUsecase(ctrl),
 			}
 
 			if tt.wantErr {
-				got, err := fu.CreateFood(tt.args.food)
+				got, err := fu.CreateFood(tt.args.food, model.AuthUser{ID: int(tt.args.userID)})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("foodUsecase.CreateFood() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -231,7 +345,7 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 				*food = tt.args.food 
 			}).Return(nil).Times(1)
 
-			got, err := fu.CreateFood(tt.args.food)
+			got, err := fu.CreateFood(tt.args.food, model.AuthUser{ID: int(tt.args.userID)})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("foodUsecase.CreateFood() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -244,11 +358,64 @@ func Test_foodUsecase_CreateFood(t *testing.T) {
 
 }
 
+func Test_foodUsecase_CreateFood_RequireVerifiedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+
+	verifiedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	food := model.Food{Name: "food1", Quantity: 1}
+
+	tests := []struct {
+		name    string
+		user    model.User
+		wantErr error
+	}{
+		{
+			name:    "正常系：確認済みのユーザーは食材を作成できる",
+			user:    model.User{ID: 1, VerifiedAt: &verifiedAt},
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：未確認のユーザーは食材を作成できない",
+			user:    model.User{ID: 1},
+			wantErr: model.ErrEmailNotVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fu := &foodUsecase{
+				fr:                   mockRepo,
+				fv:                   validator.NewFoodValidator(),
+				ur:                   mockUserRepo,
+				hu:                   newOwnerHouseholdUsecase(ctrl),
+				requireVerifiedEmail: true,
+				al:                   newNopAuditUsecase(ctrl),
+				wh:                   newNopWebhookUsecase(ctrl),
+			}
+
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Do(func(user *model.User, id int) {
+				*user = tt.user
+			}).Return(nil).Times(1)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().CreateFood(gomock.Any()).Return(nil).Times(1)
+			}
+
+			if _, err := fu.CreateFood(food, model.AuthUser{ID: tt.user.ID}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("foodUsecase.CreateFood() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_foodUsecase_UpdateFood(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockHousehold := newOwnerHouseholdUsecase(ctrl)
 	Validator := validator.NewFoodValidator()
 
 	type fields struct {
@@ -256,13 +423,15 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 		fv validator.IFoodValidator
 	}
 	type args struct {
-		food model.Food
-		id   uint
+		food   model.Food
+		id     uint
+		userID uint
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		repoErr error
 		want    model.FoodResponse
 		wantErr bool
 	}{
@@ -280,11 +449,12 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
-				id: 1,
+				id:     1,
+				userID: 1,
 			},
 			want: model.FoodResponse{
 				ID:             1,
@@ -293,7 +463,7 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 				OriginalCode:   123,
 				Quantity:       1,
 				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-				ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpirationDate: &expirationDate,
 				ImageURL:       "https://example.com",
 				Memo:           "memo",
 			},
@@ -313,13 +483,50 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 					OriginalCode:   123,
 					Quantity:       1,
 					CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-					ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					ExpirationDate: &expirationDate,
 
 					ImageURL:       "https://example.com",
 					Memo:           "memo",
 				},
-				id: 1,
+				id:     1,
+				userID: 1,
+			},
+			want:    model.FoodResponse{},
+			wantErr: true,
+		},
+		{
+			name: "異常系：他の世帯の食材は更新できない",
+			fields: fields{
+				fr: mockRepo,
+				fv: Validator,
+			},
+			args: args{
+				food: model.Food{
+					Name:     "food1",
+					Quantity: 1,
+				},
+				id:     1,
+				userID: 2,
+			},
+			repoErr: model.ErrForbidden,
+			want:    model.FoodResponse{},
+			wantErr: true,
+		},
+		{
+			name: "異常系：存在しない食材は更新できない",
+			fields: fields{
+				fr: mockRepo,
+				fv: Validator,
+			},
+			args: args{
+				food: model.Food{
+					Name:     "food1",
+					Quantity: 1,
+				},
+				id:     100,
+				userID: 1,
 			},
+			repoErr: model.ErrFoodNotFound,
 			want:    model.FoodResponse{},
 			wantErr: true,
 		},
@@ -329,10 +536,21 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 			fu := &foodUsecase{
 				fr: tt.fields.fr,
 				fv: tt.fields.fv,
+				hu: mockHousehold,
+				al: newNopAuditUsecase(ctrl),
+				wh: newNopWebhookUsecase(ctrl),
+			}
+
+			if tt.repoErr != nil {
+				mockRepo.EXPECT().GetFoodByID(gomock.Any(), tt.args.id, int(tt.args.userID)).Return(tt.repoErr).Times(1)
+				if _, err := fu.UpdateFood(tt.args.food, tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)}); !errors.Is(err, tt.repoErr) {
+					t.Errorf("foodUsecase.UpdateFood() error = %v, want %v", err, tt.repoErr)
+				}
+				return
 			}
 
 			if tt.wantErr {
-				got, err := fu.UpdateFood(tt.args.food, tt.args.id)
+				got, err := fu.UpdateFood(tt.args.food, tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("foodUsecase.UpdateFood() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -343,11 +561,16 @@ func Test_foodUsecase_UpdateFood(t *testing.T) {
 				return 
 			}
 
-			mockRepo.EXPECT().UpdateFood(gomock.Any(), tt.args.id).Do(func(food *model.Food, id uint) {
+			before := tt.args.food
+			before.Memo = "old memo"
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), tt.args.id, int(tt.args.userID)).SetArg(0, before).Return(nil).Times(1)
+			mockRepo.EXPECT().UpdateFood(gomock.Any(), tt.args.id, int(tt.args.userID), nil).Do(func(food *model.Food, id uint, householdID int, versions []int) {
 				*food = tt.args.food
 			}).Return(nil).Times(1)
+			// 更新後は保存された内容を読み直す
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), tt.args.id, int(tt.args.userID)).SetArg(0, tt.args.food).Return(nil).Times(1)
 
-			got, err := fu.UpdateFood(tt.args.food, tt.args.id)
+			got, err := fu.UpdateFood(tt.args.food, tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("foodUsecase.UpdateFood() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -364,13 +587,15 @@ func Test_foodUsecase_DeleteFood(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockHousehold := newOwnerHouseholdUsecase(ctrl)
 
 	type fields struct {
 		fr repository.IFoodRepository
 		fv validator.IFoodValidator
 	}
 	type args struct {
-		id uint
+		id     uint
+		userID uint
 	}
 	tests := []struct {
 		name    string
@@ -385,7 +610,8 @@ func Test_foodUsecase_DeleteFood(t *testing.T) {
 				fv: validator.NewFoodValidator(),
 			},
 			args: args{
-				id: 1,
+				id:     1,
+				userID: 1,
 			},
 			wantErr: false,
 		},
@@ -396,10 +622,23 @@ func Test_foodUsecase_DeleteFood(t *testing.T) {
 				fv: validator.NewFoodValidator(),
 			},
 			args: args{
-				id: 0,
+				id:     0,
+				userID: 1,
 			},
 			wantErr: false,
 		},
+		{
+			name: "異常系：他の世帯の食材は削除できない",
+			fields: fields{
+				fr: mockRepo,
+				fv: validator.NewFoodValidator(),
+			},
+			args: args{
+				id:     1,
+				userID: 2,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -407,21 +646,1040 @@ func Test_foodUsecase_DeleteFood(t *testing.T) {
 			fu := &foodUsecase{
 				fr: tt.fields.fr,
 				fv: tt.fields.fv,
+				hu: mockHousehold,
+				al: newNopAuditUsecase(ctrl),
+				wh: newNopWebhookUsecase(ctrl),
 			}
 
 			if tt.wantErr {
-				mockRepo.EXPECT().DeleteFood(tt.args.id).Return(nil).Times(1)
-				if err := fu.DeleteFood(tt.args.id); err == nil {
+				mockRepo.EXPECT().GetFoodByID(gomock.Any(), tt.args.id, int(tt.args.userID)).Return(model.ErrForbidden).Times(1)
+				if err := fu.DeleteFood(tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)}); !errors.Is(err, model.ErrForbidden) {
 					t.Errorf("foodUsecase.DeleteFood() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
 			}
 
-			mockRepo.EXPECT().DeleteFood(tt.args.id).Return(nil).Times(1)
-			if err := fu.DeleteFood(tt.args.id); err != nil {
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), tt.args.id, int(tt.args.userID)).SetArg(0, model.Food{ID: int(tt.args.id), HouseholdID: int(tt.args.userID)}).Return(nil).Times(1)
+			mockRepo.EXPECT().DeleteFood(tt.args.id, int(tt.args.userID), nil).Return(nil).Times(1)
+			if err := fu.DeleteFood(tt.args.id, nil, model.AuthUser{ID: int(tt.args.userID)}); err != nil {
 				t.Errorf("foodUsecase.DeleteFood() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
+
+func Test_foodUsecase_Permissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: mockHousehold,
+		al: newNopAuditUsecase(ctrl),
+		wh: newNopWebhookUsecase(ctrl),
+	}
+	food := model.Food{Name: "food1", Quantity: 1}
+
+	tests := []struct {
+		name       string
+		permission model.Permission
+		call       func() error
+	}{
+		{
+			name:       "異常系：food:read がなければ食材を取得できない",
+			permission: model.PermissionFoodRead,
+			call: func() error {
+				_, err := fu.GetFoodsByUserID(model.AuthUser{ID: 1}, model.FoodQuery{})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:write がなければ食材を作成できない",
+			permission: model.PermissionFoodWrite,
+			call: func() error {
+				_, err := fu.CreateFood(food, model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:write がなければ食材を更新できない",
+			permission: model.PermissionFoodWrite,
+			call: func() error {
+				_, err := fu.UpdateFood(food, 1, nil, model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:write がなければ食材を消費できない",
+			permission: model.PermissionFoodWrite,
+			call: func() error {
+				_, err := fu.ConsumeFood(1, model.ConsumeFoodRequest{Amount: 1}, model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:delete がなければ食材を片付けられない",
+			permission: model.PermissionFoodDelete,
+			call: func() error {
+				_, err := fu.DisposeFood(1, model.DisposeFoodRequest{Disposition: model.DispositionEaten}, model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:read がなければ履歴を取得できない",
+			permission: model.PermissionFoodRead,
+			call: func() error {
+				_, err := fu.GetFoodHistory(model.AuthUser{ID: 1}, model.FoodQuery{})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:delete がなければ食材を復元できない",
+			permission: model.PermissionFoodDelete,
+			call: func() error {
+				_, err := fu.RestoreFood(1, model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:read がなければゴミ箱を見られない",
+			permission: model.PermissionFoodRead,
+			call: func() error {
+				_, err := fu.GetTrashedFoods(model.AuthUser{ID: 1})
+				return err
+			},
+		},
+		{
+			name:       "異常系：food:delete がなければ食材を削除できない",
+			permission: model.PermissionFoodDelete,
+			call: func() error {
+				return fu.DeleteFood(1, nil, model.AuthUser{ID: 1})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHousehold.EXPECT().AuthorizeActive(model.AuthUser{ID: 1}, tt.permission).Return(model.HouseholdMember{}, model.ErrForbidden).Times(1)
+
+			if err := tt.call(); !errors.Is(err, model.ErrForbidden) {
+				t.Errorf("error = %v, want %v", err, model.ErrForbidden)
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_AuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+		al: mockAudit,
+		wh: newNopWebhookUsecase(ctrl),
+	}
+	user := model.AuthUser{ID: 1, Meta: model.RequestMeta{IP: "192.0.2.1"}}
+	stored := model.Food{ID: 10, Name: "オレンジ", UserID: 1, HouseholdID: 1, Quantity: 5}
+
+	t.Run("正常系：作成した食材を記録する", func(t *testing.T) {
+		mockRepo.EXPECT().CreateFood(gomock.Any()).Do(func(food *model.Food) {
+			food.ID = 10
+		}).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionFoodCreate || entry.EntityID != "10" || *entry.HouseholdID != 1 || entry.Before != nil || entry.Actor.Meta.IP != "192.0.2.1" {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+		})
+
+		if _, err := fu.CreateFood(model.Food{Name: "オレンジ", Quantity: 5}, user); err != nil {
+			t.Errorf("foodUsecase.CreateFood() error = %v", err)
+		}
+	})
+
+	t.Run("正常系：更新前後の食材を記録する", func(t *testing.T) {
+		updated := stored
+		updated.Quantity = 3
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+		mockRepo.EXPECT().UpdateFood(gomock.Any(), uint(10), 1, nil).Return(nil)
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, updated).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionFoodUpdate || entry.Before != foodResponse(stored) || entry.After != foodResponse(updated) {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+		})
+
+		if _, err := fu.UpdateFood(model.Food{Name: "オレンジ", Quantity: 3}, 10, nil, user); err != nil {
+			t.Errorf("foodUsecase.UpdateFood() error = %v", err)
+		}
+	})
+
+	t.Run("正常系：削除した食材を記録する", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+		mockRepo.EXPECT().DeleteFood(uint(10), 1, nil).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionFoodDelete || entry.Before != foodResponse(stored) || entry.After != nil {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+		})
+
+		if err := fu.DeleteFood(10, nil, user); err != nil {
+			t.Errorf("foodUsecase.DeleteFood() error = %v", err)
+		}
+	})
+
+	t.Run("異常系：削除に失敗したら記録しない", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(11), 1).Return(model.ErrFoodNotFound)
+
+		if err := fu.DeleteFood(11, nil, user); !errors.Is(err, model.ErrFoodNotFound) {
+			t.Errorf("foodUsecase.DeleteFood() error = %v, want %v", err, model.ErrFoodNotFound)
+		}
+	})
+}
+
+func Test_groupFoodsByDay(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(s string) *time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &tm
+	}
+	foods := []model.Food{
+		{ID: 1, ExpirationDate: at("2024-12-14T14:00:00Z")},
+		{ID: 2, ExpirationDate: at("2024-12-14T16:00:00Z")},
+		{ID: 3, ExpirationDate: at("2024-12-15T00:00:00Z")},
+		{ID: 4},
+	}
+
+	type group struct {
+		date string
+		days int
+		ids  []int
+	}
+	tests := []struct {
+		name  string
+		today time.Time
+		want  []group
+	}{
+		{
+			name:  "正常系：日本時間の日付でまとめる",
+			today: startOfDay(*at("2024-12-13T03:00:00Z"), tokyo),
+			want: []group{
+				{date: "2024-12-14", days: 1, ids: []int{1}},
+				{date: "2024-12-15", days: 2, ids: []int{2, 3}},
+			},
+		},
+		{
+			name:  "正常系：ニューヨーク時間の日付でまとめる",
+			today: startOfDay(*at("2024-12-15T03:00:00Z"), newYork),
+			want: []group{
+				{date: "2024-12-14", days: 0, ids: []int{1, 2, 3}},
+			},
+		},
+		{
+			name:  "正常系：期限切れは負の残り日数になる",
+			today: startOfDay(*at("2024-12-20T00:00:00Z"), time.UTC),
+			want: []group{
+				{date: "2024-12-14", days: -6, ids: []int{1, 2}},
+				{date: "2024-12-15", days: -5, ids: []int{3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupFoodsByDay(foods, tt.today)
+			if len(got) != len(tt.want) {
+				t.Fatalf("groupFoodsByDay() = %d groups, want %d", len(got), len(tt.want))
+			}
+			for i, g := range got {
+				ids := []int{}
+				for _, item := range g.Items {
+					ids = append(ids, item.ID)
+				}
+				if *g.Date != tt.want[i].date || *g.DaysRemaining != tt.want[i].days || !reflect.DeepEqual(ids, tt.want[i].ids) {
+					t.Errorf("groupFoodsByDay()[%d] = %s %d %v, want %v", i, *g.Date, *g.DaysRemaining, ids, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_daysBetween(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 夏時間の終わる日をまたいでも1日として数える
+	from := time.Date(2024, 11, 2, 0, 0, 0, 0, newYork)
+	to := time.Date(2024, 11, 4, 0, 0, 0, 0, newYork)
+	if got := daysBetween(from, to); got != 2 {
+		t.Errorf("daysBetween() = %v, want 2", got)
+	}
+	if got := daysBetween(to, from); got != -2 {
+		t.Errorf("daysBetween() = %v, want -2", got)
+	}
+}
+
+func Test_foodUsecase_GetExpiringFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tomorrow := startOfDay(time.Now(), tokyo).AddDate(0, 0, 1).Add(12 * time.Hour)
+	dated := []model.Food{{ID: 1, Name: "food1", ExpirationDate: &tomorrow}}
+	undated := []model.Food{{ID: 2, Name: "food2"}}
+
+	tests := []struct {
+		name       string
+		timeZone   string
+		policy     string
+		wantZone   string
+		wantGroups int
+	}{
+		{name: "正常系：ユーザーのタイムゾーンで数える", timeZone: "Asia/Tokyo", policy: noExpirationIgnore, wantZone: "Asia/Tokyo", wantGroups: 1},
+		{name: "正常系：タイムゾーン未設定なら既定のタイムゾーンを使う", policy: noExpirationIgnore, wantZone: "UTC", wantGroups: 1},
+		{name: "正常系：期限のない食材を期限間近として返す", timeZone: "Asia/Tokyo", policy: noExpirationExpiring, wantZone: "Asia/Tokyo", wantGroups: 2},
+		{name: "正常系：期限のない食材を期限切れとする設定では返さない", timeZone: "Asia/Tokyo", policy: noExpirationExpired, wantZone: "Asia/Tokyo", wantGroups: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIFoodRepository(ctrl)
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			fu := &foodUsecase{
+				fr:                 mockRepo,
+				fv:                 validator.NewFoodValidator(),
+				ur:                 mockUserRepo,
+				hu:                 newOwnerHouseholdUsecase(ctrl),
+				al:                 newNopAuditUsecase(ctrl),
+				wh:                 newNopWebhookUsecase(ctrl),
+				noExpirationPolicy: tt.policy,
+				location:           time.UTC,
+			}
+
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), 1).DoAndReturn(func(user *model.User, id int) error {
+				*user = model.User{ID: id, TimeZone: tt.timeZone}
+				return nil
+			})
+			mockRepo.EXPECT().GetFoodsByHouseholdID(gomock.Any(), 1, gomock.Any(), nil).DoAndReturn(func(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) error {
+				if query.NoExpirationDate {
+					*foods = undated
+					return nil
+				}
+				loc, _ := time.LoadLocation(tt.wantZone)
+				today := startOfDay(time.Now(), loc)
+				if !query.ExpiresAfter.Equal(today) || !query.ExpiresBefore.Equal(today.AddDate(0, 0, 4)) || query.Sort != model.FoodSortExpirationDate {
+					t.Errorf("GetFoodsByHouseholdID() query = %+v, want from %v for 4 days", query, today)
+				}
+				*foods = dated
+				return nil
+			}).MinTimes(1)
+
+			got, err := fu.GetExpiringFoods(model.AuthUser{ID: 1}, 3)
+			if err != nil {
+				t.Fatalf("foodUsecase.GetExpiringFoods() error = %v", err)
+			}
+			if got.TimeZone != tt.wantZone || len(got.Groups) != tt.wantGroups {
+				t.Errorf("foodUsecase.GetExpiringFoods() = %+v, want %s with %d groups", got, tt.wantZone, tt.wantGroups)
+			}
+			if tt.wantGroups == 2 && (got.Groups[1].Date != nil || got.Groups[1].Items[0].ID != 2) {
+				t.Errorf("foodUsecase.GetExpiringFoods() undated group = %+v", got.Groups[1])
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_GetExpiredFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	fu := &foodUsecase{
+		fr:                 mockRepo,
+		fv:                 validator.NewFoodValidator(),
+		ur:                 mockUserRepo,
+		hu:                 newOwnerHouseholdUsecase(ctrl),
+		al:                 newNopAuditUsecase(ctrl),
+		wh:                 newNopWebhookUsecase(ctrl),
+		noExpirationPolicy: noExpirationExpired,
+		location:           time.UTC,
+	}
+	yesterday := startOfDay(time.Now(), time.UTC).AddDate(0, 0, -1)
+
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), 1).Return(nil)
+	mockRepo.EXPECT().GetFoodsByHouseholdID(gomock.Any(), 1, gomock.Any(), nil).DoAndReturn(func(foods *[]model.Food, householdID int, query model.FoodQuery, after *model.Food) error {
+		if query.NoExpirationDate {
+			*foods = []model.Food{{ID: 2}}
+			return nil
+		}
+		if query.ExpiresAfter != nil || !query.ExpiresBefore.Equal(yesterday.AddDate(0, 0, 1)) || query.Order != model.SortOrderDesc {
+			t.Errorf("GetFoodsByHouseholdID() query = %+v", query)
+		}
+		*foods = []model.Food{{ID: 1, ExpirationDate: &yesterday}}
+		return nil
+	}).Times(2)
+
+	got, err := fu.GetExpiredFoods(model.AuthUser{ID: 1})
+	if err != nil {
+		t.Fatalf("foodUsecase.GetExpiredFoods() error = %v", err)
+	}
+	if len(got.Groups) != 2 || *got.Groups[0].DaysRemaining != -1 || got.Groups[1].DaysRemaining != nil {
+		t.Errorf("foodUsecase.GetExpiredFoods() = %+v", got)
+	}
+}
+
+func Test_foodUsecase_WebhookEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+		al: newNopAuditUsecase(ctrl),
+		wh: mockWebhook,
+	}
+	user := model.AuthUser{ID: 1}
+	stored := model.Food{ID: 10, Name: "オレンジ", UserID: 1, HouseholdID: 1, Quantity: 5}
+
+	tests := []struct {
+		name         string
+		quantity     float64
+		wantType     model.WebhookEventType
+		wantPrevious *float64
+	}{
+		{name: "正常系：数量が減ったら消費として通知する", quantity: 3, wantType: model.WebhookEventFoodConsumed, wantPrevious: &stored.Quantity},
+		{name: "正常系：数量が減らなければ更新として通知する", quantity: 6, wantType: model.WebhookEventFoodUpdated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := stored
+			updated.Quantity = tt.quantity
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+			mockRepo.EXPECT().UpdateFood(gomock.Any(), uint(10), 1, nil).Return(nil)
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, updated).Return(nil)
+			mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+				data := event.Data.(model.WebhookFoodData)
+				if event.Type != tt.wantType || event.HouseholdID != 1 || !strings.HasPrefix(event.ID, webhookEventIDPrefix) || data.Food.Quantity != tt.quantity || data.ActorID != 1 {
+					t.Errorf("Publish() event = %+v", event)
+				}
+				if (data.PreviousQuantity == nil) != (tt.wantPrevious == nil) || (tt.wantPrevious != nil && *data.PreviousQuantity != *tt.wantPrevious) {
+					t.Errorf("Publish() previous quantity = %v", data.PreviousQuantity)
+				}
+			})
+
+			if _, err := fu.UpdateFood(model.Food{Name: "オレンジ", Quantity: tt.quantity}, 10, nil, user); err != nil {
+				t.Errorf("foodUsecase.UpdateFood() error = %v", err)
+			}
+		})
+	}
+
+	t.Run("正常系：削除を通知する", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+		mockRepo.EXPECT().DeleteFood(uint(10), 1, nil).Return(nil)
+		mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+			if event.Type != model.WebhookEventFoodDeleted || event.Data.(model.WebhookFoodData).Food.ID != 10 {
+				t.Errorf("Publish() event = %+v", event)
+			}
+		})
+
+		if err := fu.DeleteFood(10, nil, user); err != nil {
+			t.Errorf("foodUsecase.DeleteFood() error = %v", err)
+		}
+	})
+}
+
+func Test_foodUsecase_ConsumeFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+		al: mockAudit,
+		wh: mockWebhook,
+	}
+	user := model.AuthUser{ID: 1}
+	archivedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		amount       float64
+		remaining    float64
+		archivedAt   *time.Time
+		repoErr      error
+		wantErr      error
+		wantValidate bool
+	}{
+		{name: "正常系：数量を減らして消費を記録する", amount: 0.5, remaining: 1.5},
+		{name: "正常系：残りがなくなればアーカイブされる", amount: 2, remaining: 0, archivedAt: &archivedAt},
+		{name: "異常系：0は消費できない", amount: 0, wantValidate: true},
+		{name: "異常系：負の量は消費できない", amount: -1, wantValidate: true},
+		{name: "異常系：残りより多くは消費できない", amount: 3, repoErr: model.ErrInsufficientQuantity, wantErr: model.ErrInsufficientQuantity},
+		{name: "異常系：アーカイブ済みの食材は消費できない", amount: 1, repoErr: model.ErrFoodArchived, wantErr: model.ErrFoodArchived},
+		{name: "異常系：他の世帯の食材は消費できない", amount: 1, repoErr: model.ErrForbidden, wantErr: model.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantValidate {
+				mockRepo.EXPECT().ConsumeFood(gomock.Any(), gomock.Any()).DoAndReturn(func(food *model.Food, consumption *model.FoodConsumption) error {
+					if consumption.FoodID != 10 || consumption.HouseholdID != 1 || consumption.UserID != 1 || consumption.Amount != tt.amount || consumption.ConsumedAt.IsZero() {
+						t.Errorf("ConsumeFood() consumption = %+v", consumption)
+					}
+					if tt.repoErr != nil {
+						return tt.repoErr
+					}
+					*food = model.Food{ID: 10, Name: "牛乳", UserID: 1, HouseholdID: 1, Quantity: tt.remaining, ArchivedAt: tt.archivedAt}
+					consumption.RemainingQuantity = tt.remaining
+					return nil
+				})
+			}
+			if tt.repoErr == nil && !tt.wantValidate {
+				mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+					before := entry.Before.(model.FoodResponse)
+					if entry.Action != model.AuditActionFoodConsume || entry.EntityID != "10" || before.Quantity != 2 || before.ArchivedAt != nil {
+						t.Errorf("Record() entry = %+v", entry)
+					}
+				})
+				mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+					data := event.Data.(model.WebhookFoodData)
+					if event.Type != model.WebhookEventFoodConsumed || data.Food.Quantity != tt.remaining || data.PreviousQuantity == nil || *data.PreviousQuantity != 2 || data.ActorID != 1 {
+						t.Errorf("Publish() event = %+v", event)
+					}
+				})
+			}
+
+			got, err := fu.ConsumeFood(10, model.ConsumeFoodRequest{Amount: tt.amount}, user)
+			if tt.wantValidate {
+				var verrs validation.Errors
+				if !errors.As(err, &verrs) {
+					t.Errorf("foodUsecase.ConsumeFood() error = %v, want validation error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("foodUsecase.ConsumeFood() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Quantity != tt.remaining || (got.ArchivedAt == nil) != (tt.archivedAt == nil) {
+				t.Errorf("foodUsecase.ConsumeFood() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_DisposeFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+		al: mockAudit,
+		wh: mockWebhook,
+	}
+	user := model.AuthUser{ID: 1}
+	archivedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		disposition  string
+		repoErr      error
+		wantErr      error
+		wantValidate bool
+	}{
+		{name: "正常系：食べた食材を履歴に残す", disposition: model.DispositionEaten},
+		{name: "正常系：捨てた食材を履歴に残す", disposition: model.DispositionDiscarded},
+		{name: "正常系：譲った食材を履歴に残す", disposition: model.DispositionGivenAway},
+		{name: "異常系：片付け方は必須", disposition: "", wantValidate: true},
+		{name: "異常系：不明な片付け方", disposition: "lost", wantValidate: true},
+		{name: "異常系：アーカイブ済みの食材は片付けられない", disposition: model.DispositionEaten, repoErr: model.ErrFoodArchived, wantErr: model.ErrFoodArchived},
+		{name: "異常系：他の世帯の食材は片付けられない", disposition: model.DispositionEaten, repoErr: model.ErrForbidden, wantErr: model.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantValidate {
+				mockRepo.EXPECT().ArchiveFood(gomock.Any(), uint(10), 1, tt.disposition, gomock.Any()).DoAndReturn(func(food *model.Food, id uint, householdID int, disposition string, at time.Time) error {
+					if tt.repoErr != nil {
+						return tt.repoErr
+					}
+					*food = model.Food{ID: 10, Name: "牛乳", UserID: 1, HouseholdID: 1, Quantity: 0.5, ArchivedAt: &archivedAt, Disposition: disposition}
+					return nil
+				})
+			}
+			if tt.repoErr == nil && !tt.wantValidate {
+				mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+					before := entry.Before.(model.FoodResponse)
+					if entry.Action != model.AuditActionFoodDispose || before.Disposition != "" || before.ArchivedAt != nil || entry.After.(model.FoodResponse).Disposition != tt.disposition {
+						t.Errorf("Record() entry = %+v", entry)
+					}
+				})
+				mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+					data := event.Data.(model.WebhookFoodData)
+					if event.Type != model.WebhookEventFoodDisposed || data.Food.Disposition != tt.disposition || data.Food.Quantity != 0.5 {
+						t.Errorf("Publish() event = %+v", event)
+					}
+				})
+			}
+
+			got, err := fu.DisposeFood(10, model.DisposeFoodRequest{Disposition: tt.disposition}, user)
+			if tt.wantValidate {
+				var verrs validation.Errors
+				if !errors.As(err, &verrs) {
+					t.Errorf("foodUsecase.DisposeFood() error = %v, want validation error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("foodUsecase.DisposeFood() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Disposition != tt.disposition || got.ArchivedAt == nil || got.Quantity != 0.5) {
+				t.Errorf("foodUsecase.DisposeFood() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_GetFoodHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+	}
+	archivedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     model.FoodQuery
+		wantQuery model.FoodQuery
+		wantErr   bool
+	}{
+		{
+			name:      "正常系：新しく片付けたものから返す",
+			query:     model.FoodQuery{},
+			wantQuery: model.FoodQuery{Sort: model.FoodSortArchivedAt, Order: model.SortOrderDesc, Limit: defaultFoodLimit + 1, Archived: true},
+		},
+		{
+			name:      "正常系：片付け方で絞り込める",
+			query:     model.FoodQuery{Disposition: model.DispositionDiscarded, Sort: model.FoodSortName, Order: model.SortOrderAsc, Limit: 10},
+			wantQuery: model.FoodQuery{Disposition: model.DispositionDiscarded, Sort: model.FoodSortName, Order: model.SortOrderAsc, Limit: 11, Archived: true},
+		},
+		{
+			name:    "異常系：不明な片付け方では絞り込めない",
+			query:   model.FoodQuery{Disposition: "lost"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantErr {
+				mockRepo.EXPECT().GetFoodsByHouseholdID(gomock.Any(), 1, tt.wantQuery, nil).
+					SetArg(0, []model.Food{{ID: 1, Name: "牛乳", HouseholdID: 1, ArchivedAt: &archivedAt, Disposition: model.DispositionEaten}}).Return(nil)
+			}
+
+			got, err := fu.GetFoodHistory(model.AuthUser{ID: 1}, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("foodUsecase.GetFoodHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (len(got.Items) != 1 || got.Items[0].Disposition != model.DispositionEaten || got.NextCursor != "") {
+				t.Errorf("foodUsecase.GetFoodHistory() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_UpdateFood_Archived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+	}
+	archivedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+
+	t.Run("異常系：履歴の食材は変更できない", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(1), 1).SetArg(0, model.Food{ID: 1, HouseholdID: 1, ArchivedAt: &archivedAt}).Return(nil)
+
+		if _, err := fu.UpdateFood(model.Food{Name: "牛乳", Quantity: 1}, 1, nil, model.AuthUser{ID: 1}); !errors.Is(err, model.ErrFoodArchived) {
+			t.Errorf("foodUsecase.UpdateFood() error = %v, want %v", err, model.ErrFoodArchived)
+		}
+	})
+}
+
+func Test_foodUsecase_PatchFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		fv: validator.NewFoodValidator(),
+		hu: newOwnerHouseholdUsecase(ctrl),
+		al: newNopAuditUsecase(ctrl),
+		wh: newNopWebhookUsecase(ctrl),
+	}
+	user := model.AuthUser{ID: 1}
+	price := 198.0
+	archivedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+	stored := func() model.Food {
+		date := expirationDate
+		return model.Food{ID: 10, Name: "牛乳", UserID: 2, HouseholdID: 1, Quantity: 2, ExpirationDate: &date, Memo: "特売", Tag: "乳製品", StorageLocation: model.StorageRefrigerator, Price: &price}
+	}
+
+	tests := []struct {
+		name         string
+		patch        string
+		before       model.Food
+		wantFields   []string
+		want         func(food model.Food) bool
+		wantErr      error
+		wantValidate bool
+	}{
+		{
+			name:       "正常系：数量を0にし、メモと期限を消せる",
+			patch:      `{"quantity": 0, "memo": "", "expiration_date": null}`,
+			before:     stored(),
+			wantFields: []string{"expiration_date", "memo", "quantity"},
+			want: func(food model.Food) bool {
+				return food.Quantity == 0 && food.Memo == "" && food.ExpirationDate == nil && food.Name == "牛乳" && food.UserID == 2 && food.Price != nil
+			},
+		},
+		{
+			name:       "正常系：null は既定値に戻す",
+			patch:      `{"tag": null, "storage_location": null, "price": null, "memo": null}`,
+			before:     stored(),
+			wantFields: []string{"memo", "price", "storage_location", "tag"},
+			want: func(food model.Food) bool {
+				return food.Tag == "その他" && food.StorageLocation == model.StorageRefrigerator && food.Price == nil && food.Memo == ""
+			},
+		},
+		{
+			name:       "正常系：期限を変更しても元の値は変わらない",
+			patch:      `{"expiration_date": "2024-12-15T00:00:00Z"}`,
+			before:     stored(),
+			wantFields: []string{"expiration_date"},
+			want: func(food model.Food) bool {
+				return food.ExpirationDate.Equal(time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC))
+			},
+		},
+		{
+			name:   "正常系：空のパッチは何も変更しない",
+			patch:  `{}`,
+			before: stored(),
+		},
+		{
+			name:         "異常系：名前は消せない",
+			patch:        `{"name": null}`,
+			before:       stored(),
+			wantValidate: true,
+		},
+		{
+			name:         "異常系：数量は負にできない",
+			patch:        `{"quantity": -1}`,
+			before:       stored(),
+			wantValidate: true,
+		},
+		{
+			name:         "異常系：型が違う値",
+			patch:        `{"quantity": "たくさん"}`,
+			before:       stored(),
+			wantValidate: true,
+		},
+		{
+			name:         "異常系：登録したユーザーは変更できない",
+			patch:        `{"user_id": 3}`,
+			before:       stored(),
+			wantValidate: true,
+		},
+		{
+			name:    "異常系：履歴の食材は変更できない",
+			patch:   `{"quantity": 1}`,
+			before:  model.Food{ID: 10, Name: "牛乳", HouseholdID: 1, ArchivedAt: &archivedAt},
+			wantErr: model.ErrFoodArchived,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := model.FoodPatch{}
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+			mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, tt.before).Return(nil)
+			saved := tt.before
+			if tt.wantFields != nil {
+				mockRepo.EXPECT().PatchFood(gomock.Any(), uint(10), 1, tt.wantFields, nil).DoAndReturn(func(food *model.Food, id uint, householdID int, fields []string, versions []int) error {
+					if !tt.want(*food) {
+						t.Errorf("PatchFood() food = %+v", *food)
+					}
+					saved = *food
+					return nil
+				})
+			}
+			if tt.wantErr == nil && !tt.wantValidate {
+				mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).DoAndReturn(func(food *model.Food, id uint, householdID int) error {
+					*food = saved
+					return nil
+				})
+			}
+
+			got, err := fu.PatchFood(10, patch, nil, user)
+			if tt.wantValidate {
+				var verrs validation.Errors
+				if !errors.As(err, &verrs) {
+					t.Errorf("foodUsecase.PatchFood() error = %v, want validation error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("foodUsecase.PatchFood() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, foodResponse(saved)) {
+				t.Errorf("foodUsecase.PatchFood() = %+v, want %+v", got, foodResponse(saved))
+			}
+			if tt.before.ExpirationDate != nil && !tt.before.ExpirationDate.Equal(expirationDate) {
+				t.Errorf("foodUsecase.PatchFood() changed the stored expiration date to %v", tt.before.ExpirationDate)
+			}
+		})
+	}
+}
+
+func Test_foodUsecase_GetFood(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	fu := &foodUsecase{
+		fr: mockRepo,
+		hu: newOwnerHouseholdUsecase(ctrl),
+	}
+
+	t.Run("正常系：バージョンを含めて返す", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, model.Food{ID: 10, Name: "牛乳", HouseholdID: 1, Version: 3}).Return(nil)
+
+		got, err := fu.GetFood(10, model.AuthUser{ID: 1})
+		if err != nil || got.ID != 10 || got.Version != 3 {
+			t.Errorf("foodUsecase.GetFood() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("異常系：他の世帯の食材は見られない", func(t *testing.T) {
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(11), 1).Return(model.ErrForbidden)
+
+		if _, err := fu.GetFood(11, model.AuthUser{ID: 1}); !errors.Is(err, model.ErrForbidden) {
+			t.Errorf("foodUsecase.GetFood() error = %v, want %v", err, model.ErrForbidden)
+		}
+	})
+}
+
+func Test_foodUsecase_IfMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	stored := model.Food{ID: 10, Name: "牛乳", UserID: 1, HouseholdID: 1, Quantity: 1, Version: 3}
+
+	tests := []struct {
+		name           string
+		requireIfMatch bool
+		match          *model.FoodVersionMatch
+		wantVersions   []int
+		repoErr        error
+		wantErr        error
+	}{
+		{name: "正常系：If-Match のバージョンの食材だけを変更する", requireIfMatch: true, match: &model.FoodVersionMatch{Versions: []int{3}}, wantVersions: []int{3}},
+		{name: "正常系：* はどのバージョンでも変更する", requireIfMatch: true, match: &model.FoodVersionMatch{Any: true}},
+		{name: "正常系：必須でなければ If-Match なしで変更できる", requireIfMatch: false},
+		{name: "異常系：必須なら If-Match なしでは変更できない", requireIfMatch: true, wantErr: model.ErrPreconditionRequired},
+		{name: "異常系：バージョンを指さない If-Match は一致しない", requireIfMatch: true, match: &model.FoodVersionMatch{Versions: []int{}}, wantErr: model.ErrFoodVersionMismatch},
+		{name: "異常系：変更された食材は変更できない", requireIfMatch: true, match: &model.FoodVersionMatch{Versions: []int{2}}, wantVersions: []int{2}, repoErr: model.ErrFoodVersionMismatch, wantErr: model.ErrFoodVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fu := &foodUsecase{
+				fr:             mockRepo,
+				hu:             newOwnerHouseholdUsecase(ctrl),
+				al:             newNopAuditUsecase(ctrl),
+				wh:             newNopWebhookUsecase(ctrl),
+				requireIfMatch: tt.requireIfMatch,
+			}
+			if tt.wantErr == nil || tt.repoErr != nil {
+				mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+				mockRepo.EXPECT().DeleteFood(uint(10), 1, gomock.Any()).DoAndReturn(func(id uint, householdID int, versions []int) error {
+					if !reflect.DeepEqual(versions, tt.wantVersions) {
+						t.Errorf("DeleteFood() versions = %v, want %v", versions, tt.wantVersions)
+					}
+					return tt.repoErr
+				})
+			}
+
+			if err := fu.DeleteFood(10, tt.match, model.AuthUser{ID: 1}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("foodUsecase.DeleteFood() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("異常系：空のパッチでも古いバージョンなら失敗する", func(t *testing.T) {
+		fu := &foodUsecase{
+			fr:             mockRepo,
+			fv:             validator.NewFoodValidator(),
+			hu:             newOwnerHouseholdUsecase(ctrl),
+			requireIfMatch: true,
+		}
+		mockRepo.EXPECT().GetFoodByID(gomock.Any(), uint(10), 1).SetArg(0, stored).Return(nil)
+
+		if _, err := fu.PatchFood(10, model.FoodPatch{}, &model.FoodVersionMatch{Versions: []int{2}}, model.AuthUser{ID: 1}); !errors.Is(err, model.ErrFoodVersionMismatch) {
+			t.Errorf("foodUsecase.PatchFood() error = %v, want %v", err, model.ErrFoodVersionMismatch)
+		}
+	})
+}
+
+func Test_foodUsecase_Trash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	fu := &foodUsecase{
+		fr:                 mockRepo,
+		fv:                 validator.NewFoodValidator(),
+		hu:                 newOwnerHouseholdUsecase(ctrl),
+		al:                 mockAudit,
+		wh:                 mockWebhook,
+		trashRetentionDays: 30,
+	}
+	user := model.AuthUser{ID: 1}
+	deletedAt := time.Date(2024, 12, 14, 8, 30, 0, 0, time.UTC)
+
+	t.Run("正常系：ゴミ箱の食材を削除日時つきで返す", func(t *testing.T) {
+		mockRepo.EXPECT().GetTrashedFoods(gomock.Any(), 1).SetArg(0, []model.Food{
+			{ID: 10, Name: "牛乳", HouseholdID: 1, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+		}).Return(nil)
+
+		got, err := fu.GetTrashedFoods(user)
+		if err != nil {
+			t.Fatalf("foodUsecase.GetTrashedFoods() error = %v", err)
+		}
+		if got.RetentionDays != 30 || len(got.Items) != 1 || got.Items[0].DeletedAt == nil || !got.Items[0].DeletedAt.Equal(deletedAt) {
+			t.Errorf("foodUsecase.GetTrashedFoods() = %+v", got)
+		}
+	})
+
+	t.Run("正常系：食材を復元できる", func(t *testing.T) {
+		mockRepo.EXPECT().RestoreFood(gomock.Any(), uint(10), 1).SetArg(0, model.Food{ID: 10, Name: "牛乳", HouseholdID: 1}).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionFoodRestore || entry.EntityID != "10" {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+		})
+		mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+			if event.Type != model.WebhookEventFoodRestored || event.Data.(model.WebhookFoodData).Food.ID != 10 {
+				t.Errorf("Publish() event = %+v", event)
+			}
+		})
+
+		got, err := fu.RestoreFood(10, user)
+		if err != nil || got.ID != 10 || got.DeletedAt != nil {
+			t.Errorf("foodUsecase.RestoreFood() = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("異常系：ゴミ箱にない食材は復元できない", func(t *testing.T) {
+		mockRepo.EXPECT().RestoreFood(gomock.Any(), uint(11), 1).Return(model.ErrFoodNotTrashed)
+
+		if _, err := fu.RestoreFood(11, user); !errors.Is(err, model.ErrFoodNotTrashed) {
+			t.Errorf("foodUsecase.RestoreFood() error = %v, want %v", err, model.ErrFoodNotTrashed)
+		}
+	})
+}
+
+func Test_foodUsecase_PurgeTrashedFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIFoodRepository(ctrl)
+	mockImage := mocks.NewMockIImageRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+	fu := &foodUsecase{
+		fr:                 mockRepo,
+		ir:                 mockImage,
+		hu:                 mockHousehold,
+		trashRetentionDays: 30,
+	}
+
+	trashed := []model.Food{
+		{ID: 1, HouseholdID: 1, ImageURL: "images/1_100_milk.jpg"},
+		{ID: 2, HouseholdID: 1, ImageURL: "images/1_200_shared.jpg"},
+		{ID: 3, HouseholdID: 1},
+		{ID: 4, HouseholdID: 1, ImageURL: "images/1_300_restored.jpg"},
+		// 他の世帯（ユーザー3）がアップロードした画像を指定した食材
+		{ID: 5, HouseholdID: 1, ImageURL: "3_400_other.jpg"},
+		{ID: 6, HouseholdID: 1, ImageURL: "images/other.jpg"},
+	}
+	mockRepo.EXPECT().GetFoodsTrashedBefore(gomock.Any(), gomock.Any()).DoAndReturn(func(foods *[]model.Food, before time.Time) error {
+		if days := time.Since(before).Hours() / 24; days < 29.9 || days > 30.1 {
+			t.Errorf("GetFoodsTrashedBefore() before = %v", before)
+		}
+		*foods = trashed
+		return nil
+	})
+	mockHousehold.EXPECT().Members(1).Return([]model.HouseholdMember{{HouseholdID: 1, UserID: 1}, {HouseholdID: 1, UserID: 2}}, nil).AnyTimes()
+	mockRepo.EXPECT().PurgeFood(1, gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().PurgeFood(2, gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().PurgeFood(3, gomock.Any()).Return(true, nil)
+	// 削除までの間に復元された
+	mockRepo.EXPECT().PurgeFood(4, gomock.Any()).Return(false, nil)
+	mockRepo.EXPECT().PurgeFood(5, gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().PurgeFood(6, gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().IsImageInUse("1_100_milk.jpg").Return(false, nil)
+	// 他の食材が同じ画像を使っている
+	mockRepo.EXPECT().IsImageInUse("1_200_shared.jpg").Return(true, nil)
+	// 世帯のメンバーの画像だけを消す
+	mockImage.EXPECT().DeleteImage("1_100_milk.jpg").Return(nil).Times(1)
+
+	purged, err := fu.PurgeTrashedFoods()
+	if err != nil {
+		t.Fatalf("foodUsecase.PurgeTrashedFoods() error = %v", err)
+	}
+	if purged != 5 {
+		t.Errorf("foodUsecase.PurgeTrashedFoods() = %v, want 5", purged)
+	}
+}
@@ -0,0 +1,670 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/mailer"
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultHouseholdInvitationTTL = 7 * 24 * time.Hour
+
+type IHouseholdUsecase interface {
+	CreateHousehold(household model.Household, userID int) (model.HouseholdResponse, error)
+	GetHouseholds(userID int) ([]model.HouseholdResponse, error)
+	GetHousehold(id int, userID int) (model.HouseholdDetailResponse, error)
+	UpdateHousehold(household model.Household, id int, userID int) (model.HouseholdResponse, error)
+	SwitchHousehold(id int, userID int) error
+	InviteMember(id int, userID int, invitation model.HouseholdInvitationRequest) error
+	AcceptInvitation(token string, userID int) (model.HouseholdResponse, error)
+	DeclineInvitation(token string, userID int) error
+	UpdateMemberRole(id int, userID int, memberID int, role string) error
+	RemoveMember(id int, userID int, memberID int) error
+	GetRoles(id int, userID int) ([]model.HouseholdRoleResponse, error)
+	CreateRole(id int, userID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error)
+	UpdateRole(id int, userID int, roleID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error)
+	DeleteRole(id int, userID int, roleID int) error
+	// ActiveMembership returns the membership of the household the food
+	// endpoints operate on for the user.
+	ActiveMembership(userID int) (model.HouseholdMember, error)
+	// Authorize returns the membership of the user in the household, or
+	// model.ErrForbidden if the user lacks the permission.
+	Authorize(id int, userID int, permission model.Permission) (model.HouseholdMember, error)
+	// AuthorizeActive is Authorize for the active household of the user. For
+	// callers authenticated with an API key the household and permissions of
+	// the key are used instead.
+	AuthorizeActive(user model.AuthUser, permission model.Permission) (model.HouseholdMember, error)
+	// Members returns the members of the household with their users and
+	// permissions, for background jobs. It does not check any caller.
+	Members(id int) ([]model.HouseholdMember, error)
+	// Memberships returns the memberships of the user with their households
+	// and permissions, for background jobs and API key authentication. It
+	// does not check any caller.
+	Memberships(userID int) ([]model.HouseholdMember, error)
+}
+
+type householdUsecase struct {
+	hr  repository.IHouseholdRepository
+	hir repository.IHouseholdInvitationRepository
+	hrr repository.IHouseholdRoleRepository
+	ur  repository.IUserRepository
+	hv  validator.IHouseholdValidator
+	m   mailer.IMailer
+}
+
+func NewHouseholdUsecase(hr repository.IHouseholdRepository, hir repository.IHouseholdInvitationRepository, hrr repository.IHouseholdRoleRepository, ur repository.IUserRepository, hv validator.IHouseholdValidator, m mailer.IMailer) IHouseholdUsecase {
+	return &householdUsecase{hr, hir, hrr, ur, hv, m}
+}
+
+// getMembership returns model.ErrHouseholdNotFound if the user is not a
+// member so that households of others cannot be probed. The permissions of
+// the returned membership are not resolved.
+func (hu *householdUsecase) getMembership(id int, userID int) (model.HouseholdMember, error) {
+	member := model.HouseholdMember{}
+	if err := hu.hr.GetMembership(&member, id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.HouseholdMember{}, model.ErrHouseholdNotFound
+		}
+		return model.HouseholdMember{}, err
+	}
+	return member, nil
+}
+
+// membership is getMembership with the permissions of the member resolved.
+func (hu *householdUsecase) membership(id int, userID int) (model.HouseholdMember, error) {
+	member, err := hu.getMembership(id, userID)
+	if err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if err := hu.resolvePermissions(&member); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	return member, nil
+}
+
+// resolvePermissions sets the permissions of the member's built-in or custom role.
+func (hu *householdUsecase) resolvePermissions(member *model.HouseholdMember) error {
+	if permissions, ok := model.BuiltinRolePermissions(member.Role); ok {
+		member.Permissions = permissions
+		return nil
+	}
+	role := model.HouseholdRole{}
+	if err := hu.hrr.GetRoleByName(&role, member.HouseholdID, member.Role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			member.Permissions = []model.Permission{}
+			return nil
+		}
+		return err
+	}
+	member.Permissions = role.PermissionList()
+	return nil
+}
+
+// rolePermissions returns the permissions of the built-in or custom role, or
+// model.ErrHouseholdRoleNotFound if the household has no such role.
+func (hu *householdUsecase) rolePermissions(id int, role string) ([]model.Permission, error) {
+	if permissions, ok := model.BuiltinRolePermissions(role); ok {
+		return permissions, nil
+	}
+	custom := model.HouseholdRole{}
+	if err := hu.hrr.GetRoleByName(&custom, id, role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.ErrHouseholdRoleNotFound
+		}
+		return nil, err
+	}
+	return custom.PermissionList(), nil
+}
+
+// canGrant reports whether the member can give a role with the permissions to
+// others: owners can give any role, others only roles with permissions they
+// hold themselves.
+func canGrant(member model.HouseholdMember, permissions []model.Permission) bool {
+	if member.Role == model.HouseholdRoleOwner {
+		return true
+	}
+	for _, permission := range permissions {
+		if !member.Can(permission) {
+			return false
+		}
+	}
+	return true
+}
+
+func (hu *householdUsecase) Authorize(id int, userID int, permission model.Permission) (model.HouseholdMember, error) {
+	member, err := hu.membership(id, userID)
+	if err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if !member.Can(permission) {
+		return model.HouseholdMember{}, model.ErrForbidden
+	}
+	return member, nil
+}
+
+func (hu *householdUsecase) AuthorizeActive(user model.AuthUser, permission model.Permission) (model.HouseholdMember, error) {
+	if user.APIKey != nil {
+		member := model.HouseholdMember{
+			HouseholdID: user.APIKey.HouseholdID,
+			UserID:      user.ID,
+			Permissions: user.APIKey.Permissions,
+		}
+		if !member.Can(permission) {
+			return model.HouseholdMember{}, model.ErrForbidden
+		}
+		return member, nil
+	}
+
+	member, err := hu.ActiveMembership(user.ID)
+	if err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if !member.Can(permission) {
+		return model.HouseholdMember{}, model.ErrForbidden
+	}
+	return member, nil
+}
+
+func householdResponse(member model.HouseholdMember, activeID *int) model.HouseholdResponse {
+	return model.HouseholdResponse{
+		ID:          member.Household.ID,
+		Name:        member.Household.Name,
+		Role:        member.Role,
+		Active:      activeID != nil && *activeID == member.HouseholdID,
+		Permissions: member.Permissions,
+		CreatedAt:   member.Household.CreatedAt,
+	}
+}
+
+func (hu *householdUsecase) CreateHousehold(household model.Household, userID int) (model.HouseholdResponse, error) {
+	if err := hu.hv.ValidateHousehold(household); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+
+	household.ID = 0
+	if err := hu.hr.CreateHousehold(&household, userID); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+
+	ownerPermissions, _ := model.BuiltinRolePermissions(model.HouseholdRoleOwner)
+	return model.HouseholdResponse{
+		ID:          household.ID,
+		Name:        household.Name,
+		Role:        model.HouseholdRoleOwner,
+		Active:      true,
+		Permissions: ownerPermissions,
+		CreatedAt:   household.CreatedAt,
+	}, nil
+}
+
+func (hu *householdUsecase) GetHouseholds(userID int) ([]model.HouseholdResponse, error) {
+	active, err := hu.ActiveMembership(userID)
+	if err != nil && !errors.Is(err, model.ErrHouseholdNotFound) {
+		return nil, err
+	}
+
+	members := []model.HouseholdMember{}
+	if err := hu.hr.GetMembershipsByUserID(&members, userID); err != nil {
+		return nil, err
+	}
+	resHouseholds := []model.HouseholdResponse{}
+	for _, member := range members {
+		if err := hu.resolvePermissions(&member); err != nil {
+			return nil, err
+		}
+		resHouseholds = append(resHouseholds, householdResponse(member, &active.HouseholdID))
+	}
+	return resHouseholds, nil
+}
+
+func (hu *householdUsecase) GetHousehold(id int, userID int) (model.HouseholdDetailResponse, error) {
+	member, err := hu.membership(id, userID)
+	if err != nil {
+		return model.HouseholdDetailResponse{}, err
+	}
+	user := model.User{}
+	if err := hu.ur.GetUserByID(&user, userID); err != nil {
+		return model.HouseholdDetailResponse{}, err
+	}
+
+	members := []model.HouseholdMember{}
+	if err := hu.hr.GetMembersByHouseholdID(&members, id); err != nil {
+		return model.HouseholdDetailResponse{}, err
+	}
+	resMembers := []model.HouseholdMemberResponse{}
+	for _, m := range members {
+		resMembers = append(resMembers, model.HouseholdMemberResponse{
+			UserID:   m.UserID,
+			Username: m.User.Username,
+			Email:    m.User.Email,
+			Role:     m.Role,
+			JoinedAt: m.CreatedAt,
+		})
+	}
+
+	return model.HouseholdDetailResponse{
+		HouseholdResponse: householdResponse(member, user.ActiveHouseholdID),
+		Members:           resMembers,
+	}, nil
+}
+
+func (hu *householdUsecase) UpdateHousehold(household model.Household, id int, userID int) (model.HouseholdResponse, error) {
+	member, err := hu.Authorize(id, userID, model.PermissionHouseholdManage)
+	if err != nil {
+		return model.HouseholdResponse{}, err
+	}
+	if err := hu.hv.ValidateHousehold(household); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+
+	household.ID = id
+	if err := hu.hr.UpdateHousehold(&household); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+
+	member.Household.Name = household.Name
+	user := model.User{}
+	if err := hu.ur.GetUserByID(&user, userID); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+	return householdResponse(member, user.ActiveHouseholdID), nil
+}
+
+func (hu *householdUsecase) SwitchHousehold(id int, userID int) error {
+	if _, err := hu.getMembership(id, userID); err != nil {
+		return err
+	}
+	return hu.hr.SetActiveHousehold(userID, &id)
+}
+
+// InviteMember emails an invitation link. Only owners can invite with a role
+// that has permissions they do not hold themselves.
+func (hu *householdUsecase) InviteMember(id int, userID int, invitation model.HouseholdInvitationRequest) error {
+	member, err := hu.Authorize(id, userID, model.PermissionMemberInvite)
+	if err != nil {
+		return err
+	}
+	if err := hu.hv.ValidateInvitation(invitation); err != nil {
+		return err
+	}
+	permissions, err := hu.rolePermissions(id, invitation.Role)
+	if err != nil {
+		return err
+	}
+	if !canGrant(member, permissions) {
+		return model.ErrForbidden
+	}
+
+	// 既にメンバーのユーザーは招待できない
+	invitee := model.User{}
+	if err := hu.ur.GetUserByEmail(&invitee, invitation.Email); err == nil {
+		if _, err := hu.getMembership(id, invitee.ID); err == nil {
+			return model.ErrAlreadyHouseholdMember
+		} else if !errors.Is(err, model.ErrHouseholdNotFound) {
+			return err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	ttl := durationFromEnv("HOUSEHOLD_INVITATION_TTL", defaultHouseholdInvitationTTL)
+	if err := hu.hir.CreateInvitation(&model.HouseholdInvitation{
+		HouseholdID: id,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		TokenHash:   hashToken(token),
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+
+	link := frontendURL() + "/households/invitations?token=" + url.QueryEscape(token)
+	return hu.m.Send(mailer.Mail{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("「%s」への招待", member.Household.Name),
+		Body: fmt.Sprintf("「%s」に招待されました。\n以下のリンクから参加または辞退できます。\n%s\n\nこのリンクの有効期限は%d日です。\n",
+			member.Household.Name, link, int(ttl.Hours()/24)),
+	})
+}
+
+// pendingInvitation returns the invitation if it is still pending and was
+// sent to the email address of the user.
+func (hu *householdUsecase) pendingInvitation(token string, userID int) (model.HouseholdInvitation, error) {
+	invitation := model.HouseholdInvitation{}
+	if err := hu.hir.GetInvitationByHash(&invitation, hashToken(token)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.HouseholdInvitation{}, model.ErrInvalidToken
+		}
+		return model.HouseholdInvitation{}, err
+	}
+	if invitation.AcceptedAt != nil || invitation.DeclinedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return model.HouseholdInvitation{}, model.ErrInvalidToken
+	}
+
+	user := model.User{}
+	if err := hu.ur.GetUserByID(&user, userID); err != nil {
+		return model.HouseholdInvitation{}, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return model.HouseholdInvitation{}, model.ErrForbidden
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation adds the user to the household and makes it active.
+func (hu *householdUsecase) AcceptInvitation(token string, userID int) (model.HouseholdResponse, error) {
+	invitation, err := hu.pendingInvitation(token, userID)
+	if err != nil {
+		return model.HouseholdResponse{}, err
+	}
+	if _, err := hu.getMembership(invitation.HouseholdID, userID); err == nil {
+		return model.HouseholdResponse{}, model.ErrAlreadyHouseholdMember
+	} else if !errors.Is(err, model.ErrHouseholdNotFound) {
+		return model.HouseholdResponse{}, err
+	}
+
+	member := model.HouseholdMember{
+		HouseholdID: invitation.HouseholdID,
+		UserID:      userID,
+		Role:        invitation.Role,
+	}
+	if err := hu.hir.AcceptInvitation(invitation.ID, &member); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+
+	member.Household = invitation.Household
+	if err := hu.resolvePermissions(&member); err != nil {
+		return model.HouseholdResponse{}, err
+	}
+	return householdResponse(member, &member.HouseholdID), nil
+}
+
+func (hu *householdUsecase) DeclineInvitation(token string, userID int) error {
+	invitation, err := hu.pendingInvitation(token, userID)
+	if err != nil {
+		return err
+	}
+	return hu.hir.DeclineInvitation(invitation.ID)
+}
+
+// UpdateMemberRole changes the role of a member. Only owners can appoint or
+// demote owners or give a role with permissions they do not hold themselves,
+// and the last owner cannot be demoted.
+func (hu *householdUsecase) UpdateMemberRole(id int, userID int, memberID int, role string) error {
+	member, err := hu.Authorize(id, userID, model.PermissionMemberManage)
+	if err != nil {
+		return err
+	}
+	if err := hu.hv.ValidateRole(role); err != nil {
+		return err
+	}
+	permissions, err := hu.rolePermissions(id, role)
+	if err != nil {
+		return err
+	}
+	target, err := hu.targetMembership(id, memberID)
+	if err != nil {
+		return err
+	}
+	if (target.Role == model.HouseholdRoleOwner || role == model.HouseholdRoleOwner) && member.Role != model.HouseholdRoleOwner {
+		return model.ErrForbidden
+	}
+	if !canGrant(member, permissions) {
+		return model.ErrForbidden
+	}
+	if target.Role == model.HouseholdRoleOwner && role != model.HouseholdRoleOwner {
+		if err := hu.ensureAnotherOwner(id); err != nil {
+			return err
+		}
+	}
+	return hu.hr.UpdateMemberRole(id, memberID, role)
+}
+
+// RemoveMember removes a member. Every member can leave by removing
+// themselves, except for the last owner. Removing others requires
+// model.PermissionMemberManage, and only owners can remove owners.
+func (hu *householdUsecase) RemoveMember(id int, userID int, memberID int) error {
+	member, err := hu.membership(id, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !member.Can(model.PermissionMemberManage) {
+		return model.ErrForbidden
+	}
+	target, err := hu.targetMembership(id, memberID)
+	if err != nil {
+		return err
+	}
+	if target.Role == model.HouseholdRoleOwner && member.Role != model.HouseholdRoleOwner {
+		return model.ErrForbidden
+	}
+	if target.Role == model.HouseholdRoleOwner {
+		if err := hu.ensureAnotherOwner(id); err != nil {
+			return err
+		}
+	}
+	return hu.hr.RemoveMember(id, memberID)
+}
+
+func (hu *householdUsecase) targetMembership(id int, memberID int) (model.HouseholdMember, error) {
+	target := model.HouseholdMember{}
+	if err := hu.hr.GetMembership(&target, id, memberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.HouseholdMember{}, model.ErrHouseholdMemberNotFound
+		}
+		return model.HouseholdMember{}, err
+	}
+	return target, nil
+}
+
+// ensureAnotherOwner returns model.ErrLastHouseholdOwner unless the household
+// has more than one owner.
+func (hu *householdUsecase) ensureAnotherOwner(id int) error {
+	owners, err := hu.hr.CountOwners(id)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return model.ErrLastHouseholdOwner
+	}
+	return nil
+}
+
+func (hu *householdUsecase) Members(id int) ([]model.HouseholdMember, error) {
+	members := []model.HouseholdMember{}
+	if err := hu.hr.GetMembersByHouseholdID(&members, id); err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if err := hu.resolvePermissions(&members[i]); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+func (hu *householdUsecase) Memberships(userID int) ([]model.HouseholdMember, error) {
+	members := []model.HouseholdMember{}
+	if err := hu.hr.GetMembershipsByUserID(&members, userID); err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if err := hu.resolvePermissions(&members[i]); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// ActiveMembership falls back to the oldest membership when the active
+// household is unset or the user has left it, and creates a personal
+// household for users who belong to none.
+func (hu *householdUsecase) ActiveMembership(userID int) (model.HouseholdMember, error) {
+	user := model.User{}
+	if err := hu.ur.GetUserByID(&user, userID); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if user.ActiveHouseholdID != nil {
+		member, err := hu.membership(*user.ActiveHouseholdID, userID)
+		if err == nil {
+			return member, nil
+		}
+		if !errors.Is(err, model.ErrHouseholdNotFound) {
+			return model.HouseholdMember{}, err
+		}
+	}
+
+	members := []model.HouseholdMember{}
+	if err := hu.hr.GetMembershipsByUserID(&members, userID); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if len(members) == 0 {
+		return hu.createPersonalHousehold(user)
+	}
+	if err := hu.hr.SetActiveHousehold(userID, &members[0].HouseholdID); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	if err := hu.resolvePermissions(&members[0]); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	return members[0], nil
+}
+
+func (hu *householdUsecase) createPersonalHousehold(user model.User) (model.HouseholdMember, error) {
+	household := model.Household{Name: model.PersonalHouseholdName(user.Username)}
+	if err := hu.hr.CreateHousehold(&household, user.ID); err != nil {
+		return model.HouseholdMember{}, err
+	}
+	ownerPermissions, _ := model.BuiltinRolePermissions(model.HouseholdRoleOwner)
+	return model.HouseholdMember{
+		HouseholdID: household.ID,
+		UserID:      user.ID,
+		Role:        model.HouseholdRoleOwner,
+		CreatedAt:   household.CreatedAt,
+		Household:   household,
+		Permissions: ownerPermissions,
+	}, nil
+}
+
+// GetRoles returns the built-in roles followed by the custom roles of the household.
+func (hu *householdUsecase) GetRoles(id int, userID int) ([]model.HouseholdRoleResponse, error) {
+	if _, err := hu.getMembership(id, userID); err != nil {
+		return nil, err
+	}
+
+	resRoles := []model.HouseholdRoleResponse{}
+	for _, name := range model.BuiltinRoles {
+		permissions, _ := model.BuiltinRolePermissions(name)
+		resRoles = append(resRoles, model.HouseholdRoleResponse{
+			Name:        name,
+			Permissions: permissions,
+			Builtin:     true,
+		})
+	}
+
+	roles := []model.HouseholdRole{}
+	if err := hu.hrr.GetRolesByHouseholdID(&roles, id); err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		resRoles = append(resRoles, householdRoleResponse(role))
+	}
+	return resRoles, nil
+}
+
+// CreateRole creates a custom role. Only owners can create a role with
+// permissions they do not hold themselves.
+func (hu *householdUsecase) CreateRole(id int, userID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error) {
+	member, err := hu.Authorize(id, userID, model.PermissionHouseholdManage)
+	if err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	if err := hu.hv.ValidateHouseholdRole(role); err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	if !canGrant(member, role.Permissions) {
+		return model.HouseholdRoleResponse{}, model.ErrForbidden
+	}
+	if err := hu.hrr.GetRoleByName(&model.HouseholdRole{}, id, role.Name); err == nil {
+		return model.HouseholdRoleResponse{}, model.ErrHouseholdRoleExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.HouseholdRoleResponse{}, err
+	}
+
+	newRole := model.HouseholdRole{
+		HouseholdID: id,
+		Name:        role.Name,
+		Permissions: model.JoinPermissions(role.Permissions),
+	}
+	if err := hu.hrr.CreateRole(&newRole); err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	return householdRoleResponse(newRole), nil
+}
+
+// UpdateRole replaces the permissions of a custom role. Roles cannot be
+// renamed because members refer to them by name. Only owners can change their
+// own role or give a role permissions they do not hold themselves.
+func (hu *householdUsecase) UpdateRole(id int, userID int, roleID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error) {
+	member, err := hu.Authorize(id, userID, model.PermissionHouseholdManage)
+	if err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	current := model.HouseholdRole{}
+	if err := hu.hrr.GetRoleByID(&current, id, roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.HouseholdRoleResponse{}, model.ErrHouseholdRoleNotFound
+		}
+		return model.HouseholdRoleResponse{}, err
+	}
+	role.Name = current.Name
+	if err := hu.hv.ValidateHouseholdRole(role); err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	if member.Role != model.HouseholdRoleOwner && member.Role == current.Name {
+		return model.HouseholdRoleResponse{}, model.ErrForbidden
+	}
+	if !canGrant(member, role.Permissions) {
+		return model.HouseholdRoleResponse{}, model.ErrForbidden
+	}
+
+	current.Permissions = model.JoinPermissions(role.Permissions)
+	if err := hu.hrr.UpdateRolePermissions(id, roleID, current.Permissions); err != nil {
+		return model.HouseholdRoleResponse{}, err
+	}
+	return householdRoleResponse(current), nil
+}
+
+// DeleteRole deletes a custom role that no member or pending invitation uses.
+func (hu *householdUsecase) DeleteRole(id int, userID int, roleID int) error {
+	if _, err := hu.Authorize(id, userID, model.PermissionHouseholdManage); err != nil {
+		return err
+	}
+	if err := hu.hrr.DeleteRole(id, roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrHouseholdRoleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func householdRoleResponse(role model.HouseholdRole) model.HouseholdRoleResponse {
+	return model.HouseholdRoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Permissions: role.PermissionList(),
+	}
+}
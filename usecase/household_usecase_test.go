@@ -0,0 +1,813 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/mailer"
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_householdUsecase_ActiveMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+
+	activeID := 2
+
+	tests := []struct {
+		name          string
+		user          model.User
+		active        error
+		memberships   []model.HouseholdMember
+		wantHousehold int
+		wantCreate    bool
+	}{
+		{
+			name:          "正常系：アクティブな世帯を取得できる",
+			user:          model.User{ID: 1, ActiveHouseholdID: &activeID},
+			wantHousehold: 2,
+		},
+		{
+			name:          "正常系：アクティブな世帯から抜けた場合は最初の世帯に切り替わる",
+			user:          model.User{ID: 1, ActiveHouseholdID: &activeID},
+			active:        gorm.ErrRecordNotFound,
+			memberships:   []model.HouseholdMember{{HouseholdID: 3, UserID: 1, Role: model.HouseholdRoleViewer}},
+			wantHousehold: 3,
+		},
+		{
+			name:          "正常系：世帯に属していない場合は個人の世帯を作成する",
+			user:          model.User{ID: 1, Username: "山田太郎"},
+			memberships:   []model.HouseholdMember{},
+			wantHousehold: 4,
+			wantCreate:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, ur: mockUserRepo}
+
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Do(func(user *model.User, id int) {
+				*user = tt.user
+			}).Return(nil).Times(1)
+			if tt.user.ActiveHouseholdID != nil {
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), *tt.user.ActiveHouseholdID, tt.user.ID).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+					*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: model.HouseholdRoleMember}
+				}).Return(tt.active).Times(1)
+			}
+			if tt.memberships != nil {
+				mockHouseholdRepo.EXPECT().GetMembershipsByUserID(gomock.Any(), tt.user.ID).Do(func(members *[]model.HouseholdMember, userID int) {
+					*members = tt.memberships
+				}).Return(nil).Times(1)
+			}
+			if tt.wantCreate {
+				mockHouseholdRepo.EXPECT().CreateHousehold(gomock.Any(), tt.user.ID).Do(func(household *model.Household, ownerID int) {
+					if household.Name != model.PersonalHouseholdName(tt.user.Username) {
+						t.Errorf("household name = %v, want %v", household.Name, model.PersonalHouseholdName(tt.user.Username))
+					}
+					household.ID = tt.wantHousehold
+				}).Return(nil).Times(1)
+			} else if len(tt.memberships) > 0 {
+				mockHouseholdRepo.EXPECT().SetActiveHousehold(tt.user.ID, gomock.Any()).Return(nil).Times(1)
+			}
+
+			got, err := hu.ActiveMembership(tt.user.ID)
+			if err != nil {
+				t.Fatalf("householdUsecase.ActiveMembership() error = %v", err)
+			}
+			if got.HouseholdID != tt.wantHousehold {
+				t.Errorf("householdUsecase.ActiveMembership() household = %v, want %v", got.HouseholdID, tt.wantHousehold)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_InviteMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockIHouseholdInvitationRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+
+	tests := []struct {
+		name       string
+		role       string
+		invitation model.HouseholdInvitationRequest
+		invitee    error
+		wantErr    error
+	}{
+		{
+			name:       "正常系：オーナーはメンバーを招待できる",
+			role:       model.HouseholdRoleOwner,
+			invitation: model.HouseholdInvitationRequest{Email: "invitee@test.com", Role: model.HouseholdRoleMember},
+			invitee:    gorm.ErrRecordNotFound,
+		},
+		{
+			name:       "異常系：オーナー以外は招待できない",
+			role:       model.HouseholdRoleMember,
+			invitation: model.HouseholdInvitationRequest{Email: "invitee@test.com", Role: model.HouseholdRoleMember},
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：既にメンバーのユーザーは招待できない",
+			role:       model.HouseholdRoleOwner,
+			invitation: model.HouseholdInvitationRequest{Email: "invitee@test.com", Role: model.HouseholdRoleViewer},
+			wantErr:    model.ErrAlreadyHouseholdMember,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
+			hu := &householdUsecase{hr: mockHouseholdRepo, hir: mockInvitationRepo, ur: mockUserRepo, hv: validator.NewHouseholdValidator(), m: m}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.role, Household: model.Household{ID: householdID, Name: "山田家の冷蔵庫"}}
+			}).Return(nil).Times(1)
+			if tt.role == model.HouseholdRoleOwner {
+				if tt.invitee == nil {
+					mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.invitation.Email).Do(func(user *model.User, email string) {
+						*user = model.User{ID: 2, Email: email}
+					}).Return(nil).Times(1)
+					mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 2).Return(nil).Times(1)
+				} else {
+					mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.invitation.Email).Return(tt.invitee).Times(1)
+				}
+			}
+			if tt.wantErr == nil {
+				mockInvitationRepo.EXPECT().CreateInvitation(gomock.Any()).Do(func(invitation *model.HouseholdInvitation) {
+					if invitation.Email != tt.invitation.Email || invitation.Role != tt.invitation.Role || len(invitation.TokenHash) != 64 {
+						t.Errorf("unexpected invitation %+v", invitation)
+					}
+				}).Return(nil).Times(1)
+			}
+
+			err := hu.InviteMember(1, 1, tt.invitation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("householdUsecase.InviteMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if sent := m.Sent(); len(sent) != 1 || !strings.Contains(sent[0].Body, "/households/invitations?token=") {
+					t.Errorf("invitation email was not sent: %+v", sent)
+				}
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_InviteMember_Role(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockIHouseholdInvitationRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+
+	inviter := model.HouseholdRole{ID: 1, HouseholdID: 1, Name: "inviter", Permissions: "member:invite,food:read"}
+
+	tests := []struct {
+		name    string
+		role    string
+		wantErr error
+	}{
+		{
+			name: "正常系：自分が持つ権限だけのロールで招待できる",
+			role: model.HouseholdRoleViewer,
+		},
+		{
+			name:    "異常系：自分が持たない権限のロールでは招待できない",
+			role:    model.HouseholdRoleMember,
+			wantErr: model.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hir: mockInvitationRepo, hrr: mockRoleRepo, ur: mockUserRepo, hv: validator.NewHouseholdValidator(), m: mailer.NewMemoryMailer()}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: inviter.Name, Household: model.Household{ID: householdID, Name: "山田家の冷蔵庫"}}
+			}).Return(nil).Times(1)
+			mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, inviter.Name).SetArg(0, inviter).Return(nil).Times(1)
+			if tt.wantErr == nil {
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "invitee@test.com").Return(gorm.ErrRecordNotFound).Times(1)
+				mockInvitationRepo.EXPECT().CreateInvitation(gomock.Any()).Return(nil).Times(1)
+			}
+
+			err := hu.InviteMember(1, 1, model.HouseholdInvitationRequest{Email: "invitee@test.com", Role: tt.role})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase.InviteMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_AcceptInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockInvitationRepo := mocks.NewMockIHouseholdInvitationRepository(ctrl)
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+
+	respondedAt := time.Now()
+	pending := model.HouseholdInvitation{
+		ID:          1,
+		HouseholdID: 1,
+		Email:       "invitee@test.com",
+		Role:        model.HouseholdRoleViewer,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		Household:   model.Household{ID: 1, Name: "山田家の冷蔵庫"},
+	}
+	expired := pending
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	accepted := pending
+	accepted.AcceptedAt = &respondedAt
+
+	tests := []struct {
+		name       string
+		invitation model.HouseholdInvitation
+		email      string
+		member     error
+		wantErr    error
+	}{
+		{
+			name:       "正常系：招待を承認できる",
+			invitation: pending,
+			email:      "Invitee@test.com",
+			member:     gorm.ErrRecordNotFound,
+		},
+		{
+			name:       "異常系：期限切れの招待は承認できない",
+			invitation: expired,
+			wantErr:    model.ErrInvalidToken,
+		},
+		{
+			name:       "異常系：応答済みの招待は承認できない",
+			invitation: accepted,
+			wantErr:    model.ErrInvalidToken,
+		},
+		{
+			name:       "異常系：招待されたメールアドレス以外のユーザーは承認できない",
+			invitation: pending,
+			email:      "other@test.com",
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：既にメンバーの場合は承認できない",
+			invitation: pending,
+			email:      "invitee@test.com",
+			wantErr:    model.ErrAlreadyHouseholdMember,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hir: mockInvitationRepo, ur: mockUserRepo}
+
+			mockInvitationRepo.EXPECT().GetInvitationByHash(gomock.Any(), hashToken("token")).Do(func(invitation *model.HouseholdInvitation, tokenHash string) {
+				*invitation = tt.invitation
+			}).Return(nil).Times(1)
+			if tt.email != "" {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), 2).Do(func(user *model.User, id int) {
+					*user = model.User{ID: id, Email: tt.email}
+				}).Return(nil).Times(1)
+			}
+			if tt.wantErr == nil || errors.Is(tt.wantErr, model.ErrAlreadyHouseholdMember) {
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 2).Return(tt.member).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockInvitationRepo.EXPECT().AcceptInvitation(tt.invitation.ID, gomock.Any()).Do(func(id int, member *model.HouseholdMember) {
+					if member.Role != tt.invitation.Role || member.UserID != 2 {
+						t.Errorf("unexpected member %+v", member)
+					}
+				}).Return(nil).Times(1)
+			}
+
+			got, err := hu.AcceptInvitation("token", 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("householdUsecase.AcceptInvitation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.ID != 1 || got.Role != model.HouseholdRoleViewer || !got.Active) {
+				t.Errorf("householdUsecase.AcceptInvitation() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_UpdateMemberRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+
+	tests := []struct {
+		name       string
+		callerRole string
+		targetRole string
+		role       string
+		owners     int64
+		wantErr    error
+	}{
+		{
+			name:       "正常系：オーナーはメンバーを閲覧者に変更できる",
+			callerRole: model.HouseholdRoleOwner,
+			targetRole: model.HouseholdRoleMember,
+			role:       model.HouseholdRoleViewer,
+		},
+		{
+			name:       "正常系：オーナーが複数いればオーナーを降格できる",
+			callerRole: model.HouseholdRoleOwner,
+			targetRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleMember,
+			owners:     2,
+		},
+		{
+			name:       "異常系：最後のオーナーは降格できない",
+			callerRole: model.HouseholdRoleOwner,
+			targetRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleMember,
+			owners:     1,
+			wantErr:    model.ErrLastHouseholdOwner,
+		},
+		{
+			name:       "異常系：オーナー以外は役割を変更できない",
+			callerRole: model.HouseholdRoleMember,
+			role:       model.HouseholdRoleViewer,
+			wantErr:    model.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hv: validator.NewHouseholdValidator()}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.callerRole}
+			}).Return(nil).Times(1)
+			if tt.targetRole != "" {
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 2).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+					*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.targetRole}
+				}).Return(nil).Times(1)
+			}
+			if tt.owners > 0 {
+				mockHouseholdRepo.EXPECT().CountOwners(1).Return(tt.owners, nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockHouseholdRepo.EXPECT().UpdateMemberRole(1, 2, tt.role).Return(nil).Times(1)
+			}
+
+			if err := hu.UpdateMemberRole(1, 1, 2, tt.role); !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase.UpdateMemberRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_RemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+
+	tests := []struct {
+		name       string
+		memberID   int
+		callerRole string
+		targetRole string
+		owners     int64
+		wantErr    error
+	}{
+		{
+			name:       "正常系：メンバーは自分で世帯から抜けられる",
+			memberID:   1,
+			callerRole: model.HouseholdRoleViewer,
+			targetRole: model.HouseholdRoleViewer,
+		},
+		{
+			name:       "正常系：オーナーはメンバーを削除できる",
+			memberID:   2,
+			callerRole: model.HouseholdRoleOwner,
+			targetRole: model.HouseholdRoleMember,
+		},
+		{
+			name:       "異常系：オーナー以外は他のメンバーを削除できない",
+			memberID:   2,
+			callerRole: model.HouseholdRoleMember,
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：最後のオーナーは抜けられない",
+			memberID:   1,
+			callerRole: model.HouseholdRoleOwner,
+			targetRole: model.HouseholdRoleOwner,
+			owners:     1,
+			wantErr:    model.ErrLastHouseholdOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.callerRole}
+			}).Return(nil).Times(1)
+			if tt.targetRole != "" {
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, tt.memberID).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+					*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.targetRole}
+				}).Return(nil).Times(1)
+			}
+			if tt.owners > 0 {
+				mockHouseholdRepo.EXPECT().CountOwners(1).Return(tt.owners, nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockHouseholdRepo.EXPECT().RemoveMember(1, tt.memberID).Return(nil).Times(1)
+			}
+
+			if err := hu.RemoveMember(1, 1, tt.memberID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase.RemoveMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+
+	shopper := model.HouseholdRole{ID: 1, HouseholdID: 1, Name: "shopper", Permissions: "food:read,food:write"}
+
+	tests := []struct {
+		role  string
+		allow []model.Permission
+	}{
+		{
+			role:  model.HouseholdRoleOwner,
+			allow: model.AllPermissions,
+		},
+		{
+			role:  model.HouseholdRoleMember,
+			allow: []model.Permission{model.PermissionFoodRead, model.PermissionFoodWrite, model.PermissionFoodDelete},
+		},
+		{
+			role:  model.HouseholdRoleViewer,
+			allow: []model.Permission{model.PermissionFoodRead},
+		},
+		{
+			role:  shopper.Name,
+			allow: []model.Permission{model.PermissionFoodRead, model.PermissionFoodWrite},
+		},
+		{
+			// 削除されたカスタムロールには権限がない
+			role:  "deleted",
+			allow: []model.Permission{},
+		},
+	}
+	for _, tt := range tests {
+		for _, permission := range model.AllPermissions {
+			wantAllow := false
+			for _, p := range tt.allow {
+				if p == permission {
+					wantAllow = true
+				}
+			}
+			name := "異常系：" + tt.role + " は " + string(permission) + " を持たない"
+			if wantAllow {
+				name = "正常系：" + tt.role + " は " + string(permission) + " を持つ"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				hu := &householdUsecase{hr: mockHouseholdRepo, hrr: mockRoleRepo}
+
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 2).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+					*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.role}
+				}).Return(nil).Times(1)
+				if _, ok := model.BuiltinRolePermissions(tt.role); !ok {
+					mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, tt.role).DoAndReturn(func(role *model.HouseholdRole, householdID int, name string) error {
+						if name != shopper.Name {
+							return gorm.ErrRecordNotFound
+						}
+						*role = shopper
+						return nil
+					}).Times(1)
+				}
+
+				_, err := hu.Authorize(1, 2, permission)
+				if wantAllow && err != nil {
+					t.Errorf("householdUsecase.Authorize() error = %v, want nil", err)
+				}
+				if !wantAllow && !errors.Is(err, model.ErrForbidden) {
+					t.Errorf("householdUsecase.Authorize() error = %v, want %v", err, model.ErrForbidden)
+				}
+			})
+		}
+	}
+}
+
+func Test_householdUsecase_UpdateMemberRole_Owner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+
+	manager := model.HouseholdRole{ID: 1, HouseholdID: 1, Name: "manager", Permissions: "member:manage"}
+
+	tests := []struct {
+		name       string
+		targetRole string
+		role       string
+		wantErr    error
+	}{
+		{
+			name:       "正常系：member:manage を持つカスタムロールはメンバーをカスタムロールに変更できる",
+			targetRole: model.HouseholdRoleViewer,
+			role:       manager.Name,
+		},
+		{
+			name:       "異常系：オーナー以外はオーナーを任命できない",
+			targetRole: model.HouseholdRoleMember,
+			role:       model.HouseholdRoleOwner,
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：オーナー以外はオーナーを降格できない",
+			targetRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleMember,
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：自分が持たない権限のロールは与えられない",
+			targetRole: model.HouseholdRoleViewer,
+			role:       model.HouseholdRoleMember,
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:    "異常系：存在しないロールには変更できない",
+			role:    "unknown",
+			wantErr: model.ErrHouseholdRoleNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hrr: mockRoleRepo, hv: validator.NewHouseholdValidator()}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: manager.Name}
+			}).Return(nil).Times(1)
+			mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, gomock.Any()).DoAndReturn(func(role *model.HouseholdRole, householdID int, name string) error {
+				if name != manager.Name {
+					return gorm.ErrRecordNotFound
+				}
+				*role = manager
+				return nil
+			}).AnyTimes()
+			if tt.targetRole != "" {
+				mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 2).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+					*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.targetRole}
+				}).Return(nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockHouseholdRepo.EXPECT().UpdateMemberRole(1, 2, tt.role).Return(nil).Times(1)
+			}
+
+			if err := hu.UpdateMemberRole(1, 1, 2, tt.role); !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase.UpdateMemberRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_CreateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+
+	tests := []struct {
+		name       string
+		callerRole string
+		role       model.HouseholdRoleRequest
+		exists     bool
+		wantErr    error
+		wantValid  bool
+	}{
+		{
+			name:       "正常系：カスタムロールを作成できる",
+			callerRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleRequest{Name: "shopper", Permissions: []model.Permission{model.PermissionFoodRead, model.PermissionFoodWrite}},
+			wantValid:  true,
+		},
+		{
+			name:       "異常系：household:manage がなければ作成できない",
+			callerRole: model.HouseholdRoleMember,
+			role:       model.HouseholdRoleRequest{Name: "shopper", Permissions: []model.Permission{model.PermissionFoodRead}},
+			wantErr:    model.ErrForbidden,
+		},
+		{
+			name:       "異常系：組み込みロールと同じ名前は使えない",
+			callerRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleRequest{Name: model.HouseholdRoleViewer, Permissions: []model.Permission{model.PermissionFoodRead}},
+		},
+		{
+			name:       "異常系：存在しない権限は指定できない",
+			callerRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleRequest{Name: "shopper", Permissions: []model.Permission{"food:eat"}},
+		},
+		{
+			name:       "異常系：同じ名前のロールは作成できない",
+			callerRole: model.HouseholdRoleOwner,
+			role:       model.HouseholdRoleRequest{Name: "shopper", Permissions: []model.Permission{model.PermissionFoodRead}},
+			exists:     true,
+			wantErr:    model.ErrHouseholdRoleExists,
+			wantValid:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hrr: mockRoleRepo, hv: validator.NewHouseholdValidator()}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: tt.callerRole}
+			}).Return(nil).Times(1)
+			if tt.wantValid {
+				if tt.exists {
+					mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, tt.role.Name).Return(nil).Times(1)
+				} else {
+					mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, tt.role.Name).Return(gorm.ErrRecordNotFound).Times(1)
+					mockRoleRepo.EXPECT().CreateRole(gomock.Any()).Do(func(role *model.HouseholdRole) {
+						role.ID = 1
+					}).Return(nil).Times(1)
+				}
+			}
+
+			got, err := hu.CreateRole(1, 1, tt.role)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("householdUsecase.CreateRole() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case !tt.wantValid:
+				if err == nil {
+					t.Errorf("householdUsecase.CreateRole() error = nil, want validation error")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("householdUsecase.CreateRole() error = %v", err)
+				}
+				if got.ID != 1 || got.Name != tt.role.Name || len(got.Permissions) != len(tt.role.Permissions) {
+					t.Errorf("householdUsecase.CreateRole() = %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_Role_Escalation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+
+	admin := model.HouseholdRole{ID: 1, HouseholdID: 1, Name: "admin", Permissions: "household:manage,food:read"}
+	shopper := model.HouseholdRole{ID: 2, HouseholdID: 1, Name: "shopper", Permissions: "food:read"}
+	roles := map[int]model.HouseholdRole{admin.ID: admin, shopper.ID: shopper}
+
+	tests := []struct {
+		name        string
+		roleID      int
+		permissions []model.Permission
+		wantErr     error
+	}{
+		{
+			name:        "正常系：自分が持つ権限だけのロールを作成できる",
+			permissions: []model.Permission{model.PermissionFoodRead},
+		},
+		{
+			name:        "異常系：自分が持たない権限のロールは作成できない",
+			permissions: []model.Permission{model.PermissionFoodRead, model.PermissionMemberManage},
+			wantErr:     model.ErrForbidden,
+		},
+		{
+			name:        "正常系：他のロールに自分が持つ権限を与えられる",
+			roleID:      shopper.ID,
+			permissions: []model.Permission{model.PermissionFoodRead},
+		},
+		{
+			name:        "異常系：他のロールに自分が持たない権限は与えられない",
+			roleID:      shopper.ID,
+			permissions: []model.Permission{model.PermissionFoodRead, model.PermissionAuditRead},
+			wantErr:     model.ErrForbidden,
+		},
+		{
+			name:        "異常系：自分のロールに権限を追加できない",
+			roleID:      admin.ID,
+			permissions: []model.Permission{model.PermissionHouseholdManage, model.PermissionFoodRead, model.PermissionMemberManage},
+			wantErr:     model.ErrForbidden,
+		},
+		{
+			name:        "異常系：自分のロールは変更できない",
+			roleID:      admin.ID,
+			permissions: []model.Permission{model.PermissionHouseholdManage},
+			wantErr:     model.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hrr: mockRoleRepo, hv: validator.NewHouseholdValidator()}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: admin.Name}
+			}).Return(nil).Times(1)
+			mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, admin.Name).SetArg(0, admin).Return(nil).Times(1)
+
+			var err error
+			if tt.roleID == 0 {
+				if tt.wantErr == nil {
+					mockRoleRepo.EXPECT().GetRoleByName(gomock.Any(), 1, "buyer").Return(gorm.ErrRecordNotFound).Times(1)
+					mockRoleRepo.EXPECT().CreateRole(gomock.Any()).Return(nil).Times(1)
+				}
+				_, err = hu.CreateRole(1, 1, model.HouseholdRoleRequest{Name: "buyer", Permissions: tt.permissions})
+			} else {
+				mockRoleRepo.EXPECT().GetRoleByID(gomock.Any(), 1, tt.roleID).SetArg(0, roles[tt.roleID]).Return(nil).Times(1)
+				if tt.wantErr == nil {
+					mockRoleRepo.EXPECT().UpdateRolePermissions(1, tt.roleID, model.JoinPermissions(tt.permissions)).Return(nil).Times(1)
+				}
+				_, err = hu.UpdateRole(1, 1, tt.roleID, model.HouseholdRoleRequest{Permissions: tt.permissions})
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase role error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_DeleteRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHouseholdRepo := mocks.NewMockIHouseholdRepository(ctrl)
+	mockRoleRepo := mocks.NewMockIHouseholdRoleRepository(ctrl)
+
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "正常系：カスタムロールを削除できる"},
+		{name: "異常系：使用中のロールは削除できない", repoErr: model.ErrHouseholdRoleInUse, wantErr: model.ErrHouseholdRoleInUse},
+		{name: "異常系：存在しないロールは削除できない", repoErr: gorm.ErrRecordNotFound, wantErr: model.ErrHouseholdRoleNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hu := &householdUsecase{hr: mockHouseholdRepo, hrr: mockRoleRepo}
+
+			mockHouseholdRepo.EXPECT().GetMembership(gomock.Any(), 1, 1).Do(func(member *model.HouseholdMember, householdID int, userID int) {
+				*member = model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: model.HouseholdRoleOwner}
+			}).Return(nil).Times(1)
+			mockRoleRepo.EXPECT().DeleteRole(1, 5).Return(tt.repoErr).Times(1)
+
+			if err := hu.DeleteRole(1, 1, 5); !errors.Is(err, tt.wantErr) {
+				t.Errorf("householdUsecase.DeleteRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_householdUsecase_AuthorizeActive_APIKey(t *testing.T) {
+	hu := &householdUsecase{}
+	user := model.AuthUser{ID: 1, APIKey: &model.APIKeyScope{ID: 1, HouseholdID: 2, Permissions: []model.Permission{model.PermissionFoodRead, model.PermissionFoodWrite}}}
+
+	tests := []struct {
+		permission model.Permission
+		wantErr    error
+	}{
+		{permission: model.PermissionFoodRead},
+		{permission: model.PermissionFoodWrite},
+		{permission: model.PermissionFoodDelete, wantErr: model.ErrForbidden},
+		{permission: model.PermissionMemberInvite, wantErr: model.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.permission), func(t *testing.T) {
+			got, err := hu.AuthorizeActive(user, tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("householdUsecase.AuthorizeActive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// APIキーの世帯が使われる
+			if tt.wantErr == nil && got.HouseholdID != 2 {
+				t.Errorf("householdUsecase.AuthorizeActive() household = %v, want 2", got.HouseholdID)
+			}
+		})
+	}
+}
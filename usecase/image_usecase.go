@@ -4,37 +4,109 @@ import (
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/repository"
 	"errors"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type IImageUsecase interface {
-	UploadImage(file model.Image) (*model.Image, error)
-	FetchImage(imageURL string) (*model.Image, error)
+	UploadImage(file model.Image, user model.AuthUser) (*model.Image, error)
+	FetchImage(imageURL string, user model.AuthUser) (*model.Image, error)
 }
 
 type imageUsecase struct {
 	ir repository.IImageRepository
+	hr repository.IHouseholdRepository
+	hu IHouseholdUsecase
+	al IAuditUsecase
+}
+
+func NewImageUsecase(ir repository.IImageRepository, hr repository.IHouseholdRepository, hu IHouseholdUsecase, al IAuditUsecase) IImageUsecase {
+	return &imageUsecase{ir, hr, hu, al}
+}
+
+// imageOwnerPrefix returns the filename prefix of images uploaded by the user.
+func imageOwnerPrefix(userID uint) string {
+	return strconv.FormatUint(uint64(userID), 10) + "_"
+}
+
+// imageOwnerID returns the ID of the user who uploaded the image, from the
+// prefix of its filename.
+func imageOwnerID(filename string) (int, bool) {
+	owner, _, found := strings.Cut(filename, "_")
+	if !found {
+		return 0, false
+	}
+	ownerID, err := strconv.Atoi(owner)
+	if err != nil {
+		return 0, false
+	}
+	return ownerID, true
 }
 
-func NewImageUsecase(ir repository.IImageRepository) IImageUsecase {
-	return &imageUsecase{ir}
+// canFetchImage reports whether the user uploaded the image or shares a
+// household with the user who did. Callers authenticated with an API key can
+// only fetch images uploaded by members of the household of the key.
+func (iu *imageUsecase) canFetchImage(filename string, user model.AuthUser) (bool, error) {
+	if user.APIKey == nil && strings.HasPrefix(filename, imageOwnerPrefix(uint(user.ID))) {
+		return true, nil
+	}
+	ownerID, ok := imageOwnerID(filename)
+	if !ok {
+		return false, nil
+	}
+	if user.APIKey != nil {
+		err := iu.hr.GetMembership(&model.HouseholdMember{}, user.APIKey.HouseholdID, ownerID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return iu.hr.ShareHousehold(user.ID, ownerID)
 }
 
-func (iu *imageUsecase) UploadImage(file model.Image) (*model.Image, error) {
+func (iu *imageUsecase) UploadImage(file model.Image, user model.AuthUser) (*model.Image, error) {
 	if file.ImageFile == nil {
 		return nil, errors.New("no image file")
 	}
+	member, err := iu.hu.AuthorizeActive(user, model.PermissionFoodWrite)
+	if err != nil {
+		return nil, err
+	}
 
 	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-	file.Filename = timestamp + "_" + file.Filename
+	file.Filename = imageOwnerPrefix(uint(user.ID)) + timestamp + "_" + filepath.Base(file.Filename)
 
-	return iu.ir.UploadImage(&file)
+	image, err := iu.ir.UploadImage(&file)
+	if err != nil {
+		return nil, err
+	}
+	iu.al.Record(model.AuditEntry{
+		Actor:       user,
+		Action:      model.AuditActionImageUpload,
+		EntityType:  model.AuditEntityImage,
+		EntityID:    file.Filename,
+		HouseholdID: &member.HouseholdID,
+		After:       map[string]string{"filename": file.Filename},
+	})
+	return image, nil
 }
 
-func (iu *imageUsecase) FetchImage(imageURL string) (*model.Image, error) {
+func (iu *imageUsecase) FetchImage(imageURL string, user model.AuthUser) (*model.Image, error) {
+	filename := filepath.Base(imageURL)
+	allowed, err := iu.canFetchImage(filename, user)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, model.ErrForbidden
+	}
+
 	image := model.Image{}
-	image.Filename = imageURL
+	image.Filename = filename
 	imageFile, err := iu.ir.FetchImage(&image)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultLoginFailureWindow = 24 * time.Hour
+
+// ILoginThrottleUsecase limits password attempts per account and per client IP.
+type ILoginThrottleUsecase interface {
+	// Check returns a *model.LoginLockedError while the account or IP is locked.
+	Check(email string, ip string) error
+	// RecordFailure counts a failed attempt. It reports true when the account
+	// has just reached the lockout threshold.
+	RecordFailure(email string, ip string) (bool, error)
+	RecordSuccess(email string) error
+	Unlock(email string) error
+	SweepLoginAttempts() (int64, error)
+}
+
+// loginThrottlePolicy delays attempts exponentially from the backoffAfter-th
+// consecutive failure and locks for lockoutDuration from the lockoutAfter-th.
+type loginThrottlePolicy struct {
+	backoffAfter    int
+	backoffBase     time.Duration
+	lockoutAfter    int
+	lockoutDuration time.Duration
+}
+
+// lockFor returns how long to lock after the given number of failures.
+func (p loginThrottlePolicy) lockFor(failures int) time.Duration {
+	if failures >= p.lockoutAfter {
+		return p.lockoutDuration
+	}
+	if failures < p.backoffAfter {
+		return 0
+	}
+	d := p.backoffBase << (failures - p.backoffAfter)
+	if d <= 0 || d > p.lockoutDuration {
+		return p.lockoutDuration
+	}
+	return d
+}
+
+type loginThrottleUsecase struct {
+	lr      repository.ILoginAttemptRepository
+	account loginThrottlePolicy
+	ip      loginThrottlePolicy
+	window  time.Duration
+}
+
+// NewLoginThrottleUsecase creates a new instance of the loginThrottleUsecase struct.
+// Thresholds are read from LOGIN_BACKOFF_AFTER, LOGIN_LOCKOUT_AFTER,
+// LOGIN_IP_BACKOFF_AFTER and LOGIN_IP_LOCKOUT_AFTER, durations from
+// LOGIN_BACKOFF_BASE, LOGIN_LOCKOUT_DURATION and LOGIN_FAILURE_WINDOW.
+func NewLoginThrottleUsecase(lr repository.ILoginAttemptRepository) ILoginThrottleUsecase {
+	backoffBase := durationFromEnv("LOGIN_BACKOFF_BASE", time.Second)
+	lockoutDuration := durationFromEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+	return &loginThrottleUsecase{
+		lr: lr,
+		account: loginThrottlePolicy{
+			backoffAfter:    intFromEnv("LOGIN_BACKOFF_AFTER", 3),
+			backoffBase:     backoffBase,
+			lockoutAfter:    intFromEnv("LOGIN_LOCKOUT_AFTER", 10),
+			lockoutDuration: lockoutDuration,
+		},
+		// 同じ IP から複数のアカウントを試す攻撃向けに、アカウントより緩い閾値を使う
+		ip: loginThrottlePolicy{
+			backoffAfter:    intFromEnv("LOGIN_IP_BACKOFF_AFTER", 20),
+			backoffBase:     backoffBase,
+			lockoutAfter:    intFromEnv("LOGIN_IP_LOCKOUT_AFTER", 100),
+			lockoutDuration: lockoutDuration,
+		},
+		window: durationFromEnv("LOGIN_FAILURE_WINDOW", defaultLoginFailureWindow),
+	}
+}
+
+func (lu *loginThrottleUsecase) Check(email string, ip string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, key := range [][2]string{{model.LoginAttemptScopeAccount, email}, {model.LoginAttemptScopeIP, ip}} {
+		attempt := model.LoginAttempt{}
+		if err := lu.lr.GetLoginAttempt(&attempt, key[0], key[1]); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return err
+		}
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			if d := attempt.LockedUntil.Sub(now); d > retryAfter {
+				retryAfter = d
+			}
+		}
+	}
+	if retryAfter > 0 {
+		return &model.LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (lu *loginThrottleUsecase) RecordFailure(email string, ip string) (bool, error) {
+	failures, err := lu.recordFailure(model.LoginAttemptScopeAccount, email, lu.account)
+	if err != nil {
+		return false, err
+	}
+	if _, err := lu.recordFailure(model.LoginAttemptScopeIP, ip, lu.ip); err != nil {
+		return false, err
+	}
+	return failures == lu.account.lockoutAfter, nil
+}
+
+// recordFailure increments the counter and locks it as the policy requires.
+// It returns the number of consecutive failures.
+func (lu *loginThrottleUsecase) recordFailure(scope string, identifier string, policy loginThrottlePolicy) (int, error) {
+	now := time.Now()
+	attempt := model.LoginAttempt{}
+	if err := lu.lr.IncrementLoginFailures(&attempt, scope, identifier, now, lu.window); err != nil {
+		return 0, err
+	}
+	if d := policy.lockFor(attempt.Failures); d > 0 {
+		if err := lu.lr.LockLoginAttempt(attempt.ID, now.Add(d)); err != nil {
+			return 0, err
+		}
+	}
+	return attempt.Failures, nil
+}
+
+// RecordSuccess resets the account counter. The IP counter is kept so that a
+// successful login to one account does not reset attempts against others.
+func (lu *loginThrottleUsecase) RecordSuccess(email string) error {
+	return lu.lr.ResetLoginAttempt(model.LoginAttemptScopeAccount, email)
+}
+
+func (lu *loginThrottleUsecase) Unlock(email string) error {
+	return lu.lr.ResetLoginAttempt(model.LoginAttemptScopeAccount, email)
+}
+
+func (lu *loginThrottleUsecase) SweepLoginAttempts() (int64, error) {
+	return lu.lr.DeleteStaleLoginAttempts(time.Now().Add(-lu.window))
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var testThrottlePolicy = loginThrottlePolicy{
+	backoffAfter:    3,
+	backoffBase:     time.Second,
+	lockoutAfter:    10,
+	lockoutDuration: 15 * time.Minute,
+}
+
+func Test_loginThrottlePolicy_lockFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "正常系：閾値未満ではロックしない", failures: 2, want: 0},
+		{name: "正常系：閾値からロック時間が始まる", failures: 3, want: time.Second},
+		{name: "正常系：失敗するたびにロック時間が倍になる", failures: 5, want: 4 * time.Second},
+		{name: "正常系：ロックアウトの閾値で一定時間ロックする", failures: 10, want: 15 * time.Minute},
+		{name: "正常系：ロックアウト後もロックする", failures: 11, want: 15 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testThrottlePolicy.lockFor(tt.failures); got != tt.want {
+				t.Errorf("loginThrottlePolicy.lockFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loginThrottleUsecase_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockILoginAttemptRepository(ctrl)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		account *model.LoginAttempt
+		ip      *model.LoginAttempt
+		wantErr error
+	}{
+		{
+			name:    "正常系：失敗の記録がなければログインできる",
+			wantErr: nil,
+		},
+		{
+			name:    "正常系：ロックが切れていればログインできる",
+			account: &model.LoginAttempt{Failures: 3, LockedUntil: &past},
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：アカウントがロックされている",
+			account: &model.LoginAttempt{Failures: 10, LockedUntil: &future},
+			wantErr: model.ErrLoginLocked,
+		},
+		{
+			name:    "異常系：IP アドレスがロックされている",
+			ip:      &model.LoginAttempt{Failures: 100, LockedUntil: &future},
+			wantErr: model.ErrLoginLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lu := &loginThrottleUsecase{lr: mockRepo, account: testThrottlePolicy, ip: testThrottlePolicy, window: time.Hour}
+
+			for scope, stored := range map[string]*model.LoginAttempt{model.LoginAttemptScopeAccount: tt.account, model.LoginAttemptScopeIP: tt.ip} {
+				stored := stored
+				err := error(nil)
+				if stored == nil {
+					err = gorm.ErrRecordNotFound
+				}
+				mockRepo.EXPECT().GetLoginAttempt(gomock.Any(), scope, gomock.Any()).Do(func(attempt *model.LoginAttempt, scope string, identifier string) {
+					if stored != nil {
+						*attempt = *stored
+					}
+				}).Return(err).Times(1)
+			}
+
+			err := lu.Check("sample@test.com", "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("loginThrottleUsecase.Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var locked *model.LoginLockedError
+			if errors.As(err, &locked) && (locked.RetryAfter <= 0 || locked.RetryAfter > time.Minute) {
+				t.Errorf("loginThrottleUsecase.Check() RetryAfter = %v", locked.RetryAfter)
+			}
+		})
+	}
+}
+
+func Test_loginThrottleUsecase_RecordFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockILoginAttemptRepository(ctrl)
+
+	tests := []struct {
+		name       string
+		failures   int
+		wantLock   bool
+		wantLocked bool
+	}{
+		{name: "正常系：閾値未満ではロックしない", failures: 1, wantLock: false, wantLocked: false},
+		{name: "正常系：閾値を超えると一時的にロックする", failures: 4, wantLock: true, wantLocked: false},
+		{name: "正常系：ロックアウトの閾値に達したことを通知する", failures: 10, wantLock: true, wantLocked: true},
+		{name: "正常系：ロックアウト後の失敗では再通知しない", failures: 11, wantLock: true, wantLocked: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lu := &loginThrottleUsecase{lr: mockRepo, account: testThrottlePolicy, ip: testThrottlePolicy, window: time.Hour}
+
+			mockRepo.EXPECT().IncrementLoginFailures(gomock.Any(), model.LoginAttemptScopeAccount, "sample@test.com", gomock.Any(), time.Hour).Do(func(attempt *model.LoginAttempt, scope string, identifier string, now time.Time, window time.Duration) {
+				*attempt = model.LoginAttempt{ID: 1, Failures: tt.failures}
+			}).Return(nil).Times(1)
+			mockRepo.EXPECT().IncrementLoginFailures(gomock.Any(), model.LoginAttemptScopeIP, "192.0.2.1", gomock.Any(), time.Hour).Do(func(attempt *model.LoginAttempt, scope string, identifier string, now time.Time, window time.Duration) {
+				*attempt = model.LoginAttempt{ID: 2, Failures: 1}
+			}).Return(nil).Times(1)
+			if tt.wantLock {
+				mockRepo.EXPECT().LockLoginAttempt(1, gomock.Any()).Return(nil).Times(1)
+			}
+
+			locked, err := lu.RecordFailure("sample@test.com", "192.0.2.1")
+			if err != nil {
+				t.Fatalf("loginThrottleUsecase.RecordFailure() error = %v", err)
+			}
+			if locked != tt.wantLocked {
+				t.Errorf("loginThrottleUsecase.RecordFailure() = %v, want %v", locked, tt.wantLocked)
+			}
+		})
+	}
+}
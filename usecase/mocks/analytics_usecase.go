@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/analytics_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/analytics_usecase.go -destination usecase/mocks/analytics_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAnalyticsUsecase is a mock of IAnalyticsUsecase interface.
+type MockIAnalyticsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAnalyticsUsecaseMockRecorder
+}
+
+// MockIAnalyticsUsecaseMockRecorder is the mock recorder for MockIAnalyticsUsecase.
+type MockIAnalyticsUsecaseMockRecorder struct {
+	mock *MockIAnalyticsUsecase
+}
+
+// NewMockIAnalyticsUsecase creates a new mock instance.
+func NewMockIAnalyticsUsecase(ctrl *gomock.Controller) *MockIAnalyticsUsecase {
+	mock := &MockIAnalyticsUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAnalyticsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAnalyticsUsecase) EXPECT() *MockIAnalyticsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetTopWastedItems mocks base method.
+func (m *MockIAnalyticsUsecase) GetTopWastedItems(user model.AuthUser, query model.WasteQuery) (model.WastedItemResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopWastedItems", user, query)
+	ret0, _ := ret[0].(model.WastedItemResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopWastedItems indicates an expected call of GetTopWastedItems.
+func (mr *MockIAnalyticsUsecaseMockRecorder) GetTopWastedItems(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopWastedItems", reflect.TypeOf((*MockIAnalyticsUsecase)(nil).GetTopWastedItems), user, query)
+}
+
+// GetWasteGroups mocks base method.
+func (m *MockIAnalyticsUsecase) GetWasteGroups(user model.AuthUser, groupBy string, query model.WasteQuery) (model.WasteGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteGroups", user, groupBy, query)
+	ret0, _ := ret[0].(model.WasteGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWasteGroups indicates an expected call of GetWasteGroups.
+func (mr *MockIAnalyticsUsecaseMockRecorder) GetWasteGroups(user, groupBy, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteGroups", reflect.TypeOf((*MockIAnalyticsUsecase)(nil).GetWasteGroups), user, groupBy, query)
+}
+
+// GetWasteSummary mocks base method.
+func (m *MockIAnalyticsUsecase) GetWasteSummary(user model.AuthUser, query model.WasteQuery) (model.WasteSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWasteSummary", user, query)
+	ret0, _ := ret[0].(model.WasteSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWasteSummary indicates an expected call of GetWasteSummary.
+func (mr *MockIAnalyticsUsecaseMockRecorder) GetWasteSummary(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWasteSummary", reflect.TypeOf((*MockIAnalyticsUsecase)(nil).GetWasteSummary), user, query)
+}
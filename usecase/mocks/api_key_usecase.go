@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/api_key_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/api_key_usecase.go -destination usecase/mocks/api_key_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAPIKeyUsecase is a mock of IAPIKeyUsecase interface.
+type MockIAPIKeyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyUsecaseMockRecorder
+}
+
+// MockIAPIKeyUsecaseMockRecorder is the mock recorder for MockIAPIKeyUsecase.
+type MockIAPIKeyUsecaseMockRecorder struct {
+	mock *MockIAPIKeyUsecase
+}
+
+// NewMockIAPIKeyUsecase creates a new mock instance.
+func NewMockIAPIKeyUsecase(ctrl *gomock.Controller) *MockIAPIKeyUsecase {
+	mock := &MockIAPIKeyUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyUsecase) EXPECT() *MockIAPIKeyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIAPIKeyUsecase) Authenticate(key string) (model.AuthUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", key)
+	ret0, _ := ret[0].(model.AuthUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIAPIKeyUsecaseMockRecorder) Authenticate(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIAPIKeyUsecase)(nil).Authenticate), key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockIAPIKeyUsecase) CreateAPIKey(householdID, userID int, key model.APIKeyRequest) (model.APIKeyCreatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", householdID, userID, key)
+	ret0, _ := ret[0].(model.APIKeyCreatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockIAPIKeyUsecaseMockRecorder) CreateAPIKey(householdID, userID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockIAPIKeyUsecase)(nil).CreateAPIKey), householdID, userID, key)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockIAPIKeyUsecase) GetAPIKeys(householdID, userID int) ([]model.APIKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", householdID, userID)
+	ret0, _ := ret[0].([]model.APIKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockIAPIKeyUsecaseMockRecorder) GetAPIKeys(householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockIAPIKeyUsecase)(nil).GetAPIKeys), householdID, userID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockIAPIKeyUsecase) RevokeAPIKey(householdID, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", householdID, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockIAPIKeyUsecaseMockRecorder) RevokeAPIKey(householdID, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockIAPIKeyUsecase)(nil).RevokeAPIKey), householdID, userID, id)
+}
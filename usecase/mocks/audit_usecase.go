@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/audit_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/audit_usecase.go -destination usecase/mocks/audit_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditUsecase is a mock of IAuditUsecase interface.
+type MockIAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditUsecaseMockRecorder
+}
+
+// MockIAuditUsecaseMockRecorder is the mock recorder for MockIAuditUsecase.
+type MockIAuditUsecaseMockRecorder struct {
+	mock *MockIAuditUsecase
+}
+
+// NewMockIAuditUsecase creates a new mock instance.
+func NewMockIAuditUsecase(ctrl *gomock.Controller) *MockIAuditUsecase {
+	mock := &MockIAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditUsecase) EXPECT() *MockIAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockIAuditUsecase) GetAuditLogs(user model.AuthUser, query model.AuditLogQuery) (model.AuditLogPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", user, query)
+	ret0, _ := ret[0].(model.AuditLogPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockIAuditUsecaseMockRecorder) GetAuditLogs(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIAuditUsecase)(nil).GetAuditLogs), user, query)
+}
+
+// Record mocks base method.
+func (m *MockIAuditUsecase) Record(entry model.AuditEntry) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", entry)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockIAuditUsecaseMockRecorder) Record(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockIAuditUsecase)(nil).Record), entry)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/auth_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/auth_usecase.go -destination usecase/mocks/auth_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuthUsecase is a mock of IAuthUsecase interface.
+type MockIAuthUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthUsecaseMockRecorder
+}
+
+// MockIAuthUsecaseMockRecorder is the mock recorder for MockIAuthUsecase.
+type MockIAuthUsecaseMockRecorder struct {
+	mock *MockIAuthUsecase
+}
+
+// NewMockIAuthUsecase creates a new mock instance.
+func NewMockIAuthUsecase(ctrl *gomock.Controller) *MockIAuthUsecase {
+	mock := &MockIAuthUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAuthUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthUsecase) EXPECT() *MockIAuthUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIAuthUsecase) Authenticate(accessToken string) (model.AuthUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", accessToken)
+	ret0, _ := ret[0].(model.AuthUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIAuthUsecaseMockRecorder) Authenticate(accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIAuthUsecase)(nil).Authenticate), accessToken)
+}
+
+// IssueMFAToken mocks base method.
+func (m *MockIAuthUsecase) IssueMFAToken(user model.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueMFAToken", user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueMFAToken indicates an expected call of IssueMFAToken.
+func (mr *MockIAuthUsecaseMockRecorder) IssueMFAToken(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueMFAToken", reflect.TypeOf((*MockIAuthUsecase)(nil).IssueMFAToken), user)
+}
+
+// IssueTokens mocks base method.
+func (m *MockIAuthUsecase) IssueTokens(user model.User) (model.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueTokens", user)
+	ret0, _ := ret[0].(model.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueTokens indicates an expected call of IssueTokens.
+func (mr *MockIAuthUsecaseMockRecorder) IssueTokens(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueTokens", reflect.TypeOf((*MockIAuthUsecase)(nil).IssueTokens), user)
+}
+
+// Logout mocks base method.
+func (m *MockIAuthUsecase) Logout(refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIAuthUsecaseMockRecorder) Logout(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIAuthUsecase)(nil).Logout), refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockIAuthUsecase) LogoutAll(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockIAuthUsecaseMockRecorder) LogoutAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockIAuthUsecase)(nil).LogoutAll), userID)
+}
+
+// RefreshTokens mocks base method.
+func (m *MockIAuthUsecase) RefreshTokens(refreshToken string) (model.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", refreshToken)
+	ret0, _ := ret[0].(model.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockIAuthUsecaseMockRecorder) RefreshTokens(refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockIAuthUsecase)(nil).RefreshTokens), refreshToken)
+}
+
+// SweepExpiredRefreshTokens mocks base method.
+func (m *MockIAuthUsecase) SweepExpiredRefreshTokens() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredRefreshTokens")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiredRefreshTokens indicates an expected call of SweepExpiredRefreshTokens.
+func (mr *MockIAuthUsecaseMockRecorder) SweepExpiredRefreshTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredRefreshTokens", reflect.TypeOf((*MockIAuthUsecase)(nil).SweepExpiredRefreshTokens))
+}
+
+// VerifyMFAToken mocks base method.
+func (m *MockIAuthUsecase) VerifyMFAToken(mfaToken string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFAToken", mfaToken)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFAToken indicates an expected call of VerifyMFAToken.
+func (mr *MockIAuthUsecaseMockRecorder) VerifyMFAToken(mfaToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFAToken", reflect.TypeOf((*MockIAuthUsecase)(nil).VerifyMFAToken), mfaToken)
+}
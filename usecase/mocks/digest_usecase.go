@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/digest_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/digest_usecase.go -destination usecase/mocks/digest_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDigestUsecase is a mock of IDigestUsecase interface.
+type MockIDigestUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDigestUsecaseMockRecorder
+}
+
+// MockIDigestUsecaseMockRecorder is the mock recorder for MockIDigestUsecase.
+type MockIDigestUsecaseMockRecorder struct {
+	mock *MockIDigestUsecase
+}
+
+// NewMockIDigestUsecase creates a new mock instance.
+func NewMockIDigestUsecase(ctrl *gomock.Controller) *MockIDigestUsecase {
+	mock := &MockIDigestUsecase{ctrl: ctrl}
+	mock.recorder = &MockIDigestUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDigestUsecase) EXPECT() *MockIDigestUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockIDigestUsecase) Preview(userID int, query model.DigestPreviewQuery) (model.DigestPreviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", userID, query)
+	ret0, _ := ret[0].(model.DigestPreviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockIDigestUsecaseMockRecorder) Preview(userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockIDigestUsecase)(nil).Preview), userID, query)
+}
+
+// SendDigests mocks base method.
+func (m *MockIDigestUsecase) SendDigests() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDigests")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDigests indicates an expected call of SendDigests.
+func (mr *MockIDigestUsecaseMockRecorder) SendDigests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDigests", reflect.TypeOf((*MockIDigestUsecase)(nil).SendDigests))
+}
+
+// SweepDigestDeliveries mocks base method.
+func (m *MockIDigestUsecase) SweepDigestDeliveries() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepDigestDeliveries")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepDigestDeliveries indicates an expected call of SweepDigestDeliveries.
+func (mr *MockIDigestUsecaseMockRecorder) SweepDigestDeliveries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepDigestDeliveries", reflect.TypeOf((*MockIDigestUsecase)(nil).SweepDigestDeliveries))
+}
@@ -39,61 +39,211 @@ func (m *MockIFoodUsecase) EXPECT() *MockIFoodUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ConsumeFood mocks base method.
+func (m *MockIFoodUsecase) ConsumeFood(id uint, req model.ConsumeFoodRequest, user model.AuthUser) (model.FoodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeFood", id, req, user)
+	ret0, _ := ret[0].(model.FoodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeFood indicates an expected call of ConsumeFood.
+func (mr *MockIFoodUsecaseMockRecorder) ConsumeFood(id, req, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeFood", reflect.TypeOf((*MockIFoodUsecase)(nil).ConsumeFood), id, req, user)
+}
+
 // CreateFood mocks base method.
-func (m *MockIFoodUsecase) CreateFood(food model.Food) (model.FoodResponse, error) {
+func (m *MockIFoodUsecase) CreateFood(food model.Food, user model.AuthUser) (model.FoodResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFood", food)
+	ret := m.ctrl.Call(m, "CreateFood", food, user)
 	ret0, _ := ret[0].(model.FoodResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateFood indicates an expected call of CreateFood.
-func (mr *MockIFoodUsecaseMockRecorder) CreateFood(food any) *gomock.Call {
+func (mr *MockIFoodUsecaseMockRecorder) CreateFood(food, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFood", reflect.TypeOf((*MockIFoodUsecase)(nil).CreateFood), food)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFood", reflect.TypeOf((*MockIFoodUsecase)(nil).CreateFood), food, user)
 }
 
 // DeleteFood mocks base method.
-func (m *MockIFoodUsecase) DeleteFood(id uint) error {
+func (m *MockIFoodUsecase) DeleteFood(id uint, match *model.FoodVersionMatch, user model.AuthUser) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFood", id)
+	ret := m.ctrl.Call(m, "DeleteFood", id, match, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFood indicates an expected call of DeleteFood.
-func (mr *MockIFoodUsecaseMockRecorder) DeleteFood(id any) *gomock.Call {
+func (mr *MockIFoodUsecaseMockRecorder) DeleteFood(id, match, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFood", reflect.TypeOf((*MockIFoodUsecase)(nil).DeleteFood), id, match, user)
+}
+
+// DisposeFood mocks base method.
+func (m *MockIFoodUsecase) DisposeFood(id uint, req model.DisposeFoodRequest, user model.AuthUser) (model.FoodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisposeFood", id, req, user)
+	ret0, _ := ret[0].(model.FoodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisposeFood indicates an expected call of DisposeFood.
+func (mr *MockIFoodUsecaseMockRecorder) DisposeFood(id, req, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisposeFood", reflect.TypeOf((*MockIFoodUsecase)(nil).DisposeFood), id, req, user)
+}
+
+// GetExpiredFoods mocks base method.
+func (m *MockIFoodUsecase) GetExpiredFoods(user model.AuthUser) (model.FoodExpirationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredFoods", user)
+	ret0, _ := ret[0].(model.FoodExpirationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredFoods indicates an expected call of GetExpiredFoods.
+func (mr *MockIFoodUsecaseMockRecorder) GetExpiredFoods(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredFoods", reflect.TypeOf((*MockIFoodUsecase)(nil).GetExpiredFoods), user)
+}
+
+// GetExpiringFoods mocks base method.
+func (m *MockIFoodUsecase) GetExpiringFoods(user model.AuthUser, days int) (model.FoodExpirationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringFoods", user, days)
+	ret0, _ := ret[0].(model.FoodExpirationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringFoods indicates an expected call of GetExpiringFoods.
+func (mr *MockIFoodUsecaseMockRecorder) GetExpiringFoods(user, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringFoods", reflect.TypeOf((*MockIFoodUsecase)(nil).GetExpiringFoods), user, days)
+}
+
+// GetFood mocks base method.
+func (m *MockIFoodUsecase) GetFood(id uint, user model.AuthUser) (model.FoodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFood", id, user)
+	ret0, _ := ret[0].(model.FoodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFood indicates an expected call of GetFood.
+func (mr *MockIFoodUsecaseMockRecorder) GetFood(id, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFood", reflect.TypeOf((*MockIFoodUsecase)(nil).DeleteFood), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFood", reflect.TypeOf((*MockIFoodUsecase)(nil).GetFood), id, user)
+}
+
+// GetFoodHistory mocks base method.
+func (m *MockIFoodUsecase) GetFoodHistory(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodHistory", user, query)
+	ret0, _ := ret[0].(model.FoodPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodHistory indicates an expected call of GetFoodHistory.
+func (mr *MockIFoodUsecaseMockRecorder) GetFoodHistory(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodHistory", reflect.TypeOf((*MockIFoodUsecase)(nil).GetFoodHistory), user, query)
 }
 
 // GetFoodsByUserID mocks base method.
-func (m *MockIFoodUsecase) GetFoodsByUserID(userID uint) ([]model.FoodResponse, error) {
+func (m *MockIFoodUsecase) GetFoodsByUserID(user model.AuthUser, query model.FoodQuery) (model.FoodPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFoodsByUserID", userID)
-	ret0, _ := ret[0].([]model.FoodResponse)
+	ret := m.ctrl.Call(m, "GetFoodsByUserID", user, query)
+	ret0, _ := ret[0].(model.FoodPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFoodsByUserID indicates an expected call of GetFoodsByUserID.
-func (mr *MockIFoodUsecaseMockRecorder) GetFoodsByUserID(userID any) *gomock.Call {
+func (mr *MockIFoodUsecaseMockRecorder) GetFoodsByUserID(user, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsByUserID", reflect.TypeOf((*MockIFoodUsecase)(nil).GetFoodsByUserID), user, query)
+}
+
+// GetTrashedFoods mocks base method.
+func (m *MockIFoodUsecase) GetTrashedFoods(user model.AuthUser) (model.FoodTrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedFoods", user)
+	ret0, _ := ret[0].(model.FoodTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedFoods indicates an expected call of GetTrashedFoods.
+func (mr *MockIFoodUsecaseMockRecorder) GetTrashedFoods(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedFoods", reflect.TypeOf((*MockIFoodUsecase)(nil).GetTrashedFoods), user)
+}
+
+// PatchFood mocks base method.
+func (m *MockIFoodUsecase) PatchFood(id uint, patch model.FoodPatch, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchFood", id, patch, match, user)
+	ret0, _ := ret[0].(model.FoodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchFood indicates an expected call of PatchFood.
+func (mr *MockIFoodUsecaseMockRecorder) PatchFood(id, patch, match, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFood", reflect.TypeOf((*MockIFoodUsecase)(nil).PatchFood), id, patch, match, user)
+}
+
+// PurgeTrashedFoods mocks base method.
+func (m *MockIFoodUsecase) PurgeTrashedFoods() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedFoods")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedFoods indicates an expected call of PurgeTrashedFoods.
+func (mr *MockIFoodUsecaseMockRecorder) PurgeTrashedFoods() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedFoods", reflect.TypeOf((*MockIFoodUsecase)(nil).PurgeTrashedFoods))
+}
+
+// RestoreFood mocks base method.
+func (m *MockIFoodUsecase) RestoreFood(id uint, user model.AuthUser) (model.FoodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFood", id, user)
+	ret0, _ := ret[0].(model.FoodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFood indicates an expected call of RestoreFood.
+func (mr *MockIFoodUsecaseMockRecorder) RestoreFood(id, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodsByUserID", reflect.TypeOf((*MockIFoodUsecase)(nil).GetFoodsByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFood", reflect.TypeOf((*MockIFoodUsecase)(nil).RestoreFood), id, user)
 }
 
 // UpdateFood mocks base method.
-func (m *MockIFoodUsecase) UpdateFood(food model.Food, id uint) (model.FoodResponse, error) {
+func (m *MockIFoodUsecase) UpdateFood(food model.Food, id uint, match *model.FoodVersionMatch, user model.AuthUser) (model.FoodResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFood", food, id)
+	ret := m.ctrl.Call(m, "UpdateFood", food, id, match, user)
 	ret0, _ := ret[0].(model.FoodResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateFood indicates an expected call of UpdateFood.
-func (mr *MockIFoodUsecaseMockRecorder) UpdateFood(food, id any) *gomock.Call {
+func (mr *MockIFoodUsecaseMockRecorder) UpdateFood(food, id, match, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFood", reflect.TypeOf((*MockIFoodUsecase)(nil).UpdateFood), food, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFood", reflect.TypeOf((*MockIFoodUsecase)(nil).UpdateFood), food, id, match, user)
 }
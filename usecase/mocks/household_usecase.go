@@ -0,0 +1,319 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/household_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/household_usecase.go -destination usecase/mocks/household_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHouseholdUsecase is a mock of IHouseholdUsecase interface.
+type MockIHouseholdUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHouseholdUsecaseMockRecorder
+}
+
+// MockIHouseholdUsecaseMockRecorder is the mock recorder for MockIHouseholdUsecase.
+type MockIHouseholdUsecaseMockRecorder struct {
+	mock *MockIHouseholdUsecase
+}
+
+// NewMockIHouseholdUsecase creates a new mock instance.
+func NewMockIHouseholdUsecase(ctrl *gomock.Controller) *MockIHouseholdUsecase {
+	mock := &MockIHouseholdUsecase{ctrl: ctrl}
+	mock.recorder = &MockIHouseholdUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHouseholdUsecase) EXPECT() *MockIHouseholdUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockIHouseholdUsecase) AcceptInvitation(token string, userID int) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", token, userID)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockIHouseholdUsecaseMockRecorder) AcceptInvitation(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockIHouseholdUsecase)(nil).AcceptInvitation), token, userID)
+}
+
+// ActiveMembership mocks base method.
+func (m *MockIHouseholdUsecase) ActiveMembership(userID int) (model.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveMembership", userID)
+	ret0, _ := ret[0].(model.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveMembership indicates an expected call of ActiveMembership.
+func (mr *MockIHouseholdUsecaseMockRecorder) ActiveMembership(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveMembership", reflect.TypeOf((*MockIHouseholdUsecase)(nil).ActiveMembership), userID)
+}
+
+// Authorize mocks base method.
+func (m *MockIHouseholdUsecase) Authorize(id, userID int, permission model.Permission) (model.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", id, userID, permission)
+	ret0, _ := ret[0].(model.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockIHouseholdUsecaseMockRecorder) Authorize(id, userID, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockIHouseholdUsecase)(nil).Authorize), id, userID, permission)
+}
+
+// AuthorizeActive mocks base method.
+func (m *MockIHouseholdUsecase) AuthorizeActive(user model.AuthUser, permission model.Permission) (model.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeActive", user, permission)
+	ret0, _ := ret[0].(model.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeActive indicates an expected call of AuthorizeActive.
+func (mr *MockIHouseholdUsecaseMockRecorder) AuthorizeActive(user, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeActive", reflect.TypeOf((*MockIHouseholdUsecase)(nil).AuthorizeActive), user, permission)
+}
+
+// CreateHousehold mocks base method.
+func (m *MockIHouseholdUsecase) CreateHousehold(household model.Household, userID int) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHousehold", household, userID)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHousehold indicates an expected call of CreateHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) CreateHousehold(household, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).CreateHousehold), household, userID)
+}
+
+// CreateRole mocks base method.
+func (m *MockIHouseholdUsecase) CreateRole(id, userID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", id, userID, role)
+	ret0, _ := ret[0].(model.HouseholdRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) CreateRole(id, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).CreateRole), id, userID, role)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockIHouseholdUsecase) DeclineInvitation(token string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", token, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockIHouseholdUsecaseMockRecorder) DeclineInvitation(token, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockIHouseholdUsecase)(nil).DeclineInvitation), token, userID)
+}
+
+// DeleteRole mocks base method.
+func (m *MockIHouseholdUsecase) DeleteRole(id, userID, roleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", id, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) DeleteRole(id, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).DeleteRole), id, userID, roleID)
+}
+
+// GetHousehold mocks base method.
+func (m *MockIHouseholdUsecase) GetHousehold(id, userID int) (model.HouseholdDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHousehold", id, userID)
+	ret0, _ := ret[0].(model.HouseholdDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHousehold indicates an expected call of GetHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetHousehold(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetHousehold), id, userID)
+}
+
+// GetHouseholds mocks base method.
+func (m *MockIHouseholdUsecase) GetHouseholds(userID int) ([]model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseholds", userID)
+	ret0, _ := ret[0].([]model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseholds indicates an expected call of GetHouseholds.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetHouseholds(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseholds", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetHouseholds), userID)
+}
+
+// GetRoles mocks base method.
+func (m *MockIHouseholdUsecase) GetRoles(id, userID int) ([]model.HouseholdRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoles", id, userID)
+	ret0, _ := ret[0].([]model.HouseholdRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockIHouseholdUsecaseMockRecorder) GetRoles(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockIHouseholdUsecase)(nil).GetRoles), id, userID)
+}
+
+// InviteMember mocks base method.
+func (m *MockIHouseholdUsecase) InviteMember(id, userID int, invitation model.HouseholdInvitationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", id, userID, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockIHouseholdUsecaseMockRecorder) InviteMember(id, userID, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*MockIHouseholdUsecase)(nil).InviteMember), id, userID, invitation)
+}
+
+// Members mocks base method.
+func (m *MockIHouseholdUsecase) Members(id int) ([]model.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Members", id)
+	ret0, _ := ret[0].([]model.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Members indicates an expected call of Members.
+func (mr *MockIHouseholdUsecaseMockRecorder) Members(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockIHouseholdUsecase)(nil).Members), id)
+}
+
+// Memberships mocks base method.
+func (m *MockIHouseholdUsecase) Memberships(userID int) ([]model.HouseholdMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Memberships", userID)
+	ret0, _ := ret[0].([]model.HouseholdMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Memberships indicates an expected call of Memberships.
+func (mr *MockIHouseholdUsecaseMockRecorder) Memberships(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Memberships", reflect.TypeOf((*MockIHouseholdUsecase)(nil).Memberships), userID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockIHouseholdUsecase) RemoveMember(id, userID, memberID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", id, userID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockIHouseholdUsecaseMockRecorder) RemoveMember(id, userID, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockIHouseholdUsecase)(nil).RemoveMember), id, userID, memberID)
+}
+
+// SwitchHousehold mocks base method.
+func (m *MockIHouseholdUsecase) SwitchHousehold(id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwitchHousehold", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwitchHousehold indicates an expected call of SwitchHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) SwitchHousehold(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwitchHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).SwitchHousehold), id, userID)
+}
+
+// UpdateHousehold mocks base method.
+func (m *MockIHouseholdUsecase) UpdateHousehold(household model.Household, id, userID int) (model.HouseholdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHousehold", household, id, userID)
+	ret0, _ := ret[0].(model.HouseholdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHousehold indicates an expected call of UpdateHousehold.
+func (mr *MockIHouseholdUsecaseMockRecorder) UpdateHousehold(household, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHousehold", reflect.TypeOf((*MockIHouseholdUsecase)(nil).UpdateHousehold), household, id, userID)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockIHouseholdUsecase) UpdateMemberRole(id, userID, memberID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", id, userID, memberID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) UpdateMemberRole(id, userID, memberID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).UpdateMemberRole), id, userID, memberID, role)
+}
+
+// UpdateRole mocks base method.
+func (m *MockIHouseholdUsecase) UpdateRole(id, userID, roleID int, role model.HouseholdRoleRequest) (model.HouseholdRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", id, userID, roleID, role)
+	ret0, _ := ret[0].(model.HouseholdRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockIHouseholdUsecaseMockRecorder) UpdateRole(id, userID, roleID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockIHouseholdUsecase)(nil).UpdateRole), id, userID, roleID, role)
+}
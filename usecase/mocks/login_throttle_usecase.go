@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/login_throttle_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/login_throttle_usecase.go -destination usecase/mocks/login_throttle_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILoginThrottleUsecase is a mock of ILoginThrottleUsecase interface.
+type MockILoginThrottleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginThrottleUsecaseMockRecorder
+}
+
+// MockILoginThrottleUsecaseMockRecorder is the mock recorder for MockILoginThrottleUsecase.
+type MockILoginThrottleUsecaseMockRecorder struct {
+	mock *MockILoginThrottleUsecase
+}
+
+// NewMockILoginThrottleUsecase creates a new mock instance.
+func NewMockILoginThrottleUsecase(ctrl *gomock.Controller) *MockILoginThrottleUsecase {
+	mock := &MockILoginThrottleUsecase{ctrl: ctrl}
+	mock.recorder = &MockILoginThrottleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginThrottleUsecase) EXPECT() *MockILoginThrottleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockILoginThrottleUsecase) Check(email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockILoginThrottleUsecaseMockRecorder) Check(email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockILoginThrottleUsecase)(nil).Check), email, ip)
+}
+
+// RecordFailure mocks base method.
+func (m *MockILoginThrottleUsecase) RecordFailure(email, ip string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", email, ip)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockILoginThrottleUsecaseMockRecorder) RecordFailure(email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockILoginThrottleUsecase)(nil).RecordFailure), email, ip)
+}
+
+// RecordSuccess mocks base method.
+func (m *MockILoginThrottleUsecase) RecordSuccess(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSuccess", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockILoginThrottleUsecaseMockRecorder) RecordSuccess(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockILoginThrottleUsecase)(nil).RecordSuccess), email)
+}
+
+// SweepLoginAttempts mocks base method.
+func (m *MockILoginThrottleUsecase) SweepLoginAttempts() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepLoginAttempts")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepLoginAttempts indicates an expected call of SweepLoginAttempts.
+func (mr *MockILoginThrottleUsecaseMockRecorder) SweepLoginAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepLoginAttempts", reflect.TypeOf((*MockILoginThrottleUsecase)(nil).SweepLoginAttempts))
+}
+
+// Unlock mocks base method.
+func (m *MockILoginThrottleUsecase) Unlock(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockILoginThrottleUsecaseMockRecorder) Unlock(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockILoginThrottleUsecase)(nil).Unlock), email)
+}
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/notification_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/notification_usecase.go -destination usecase/mocks/notification_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClock is a mock of Clock interface.
+type MockClock struct {
+	ctrl     *gomock.Controller
+	recorder *MockClockMockRecorder
+}
+
+// MockClockMockRecorder is the mock recorder for MockClock.
+type MockClockMockRecorder struct {
+	mock *MockClock
+}
+
+// NewMockClock creates a new mock instance.
+func NewMockClock(ctrl *gomock.Controller) *MockClock {
+	mock := &MockClock{ctrl: ctrl}
+	mock.recorder = &MockClockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClock) EXPECT() *MockClockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *MockClock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockClockMockRecorder) Now() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*MockClock)(nil).Now))
+}
+
+// MockINotificationUsecase is a mock of INotificationUsecase interface.
+type MockINotificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationUsecaseMockRecorder
+}
+
+// MockINotificationUsecaseMockRecorder is the mock recorder for MockINotificationUsecase.
+type MockINotificationUsecaseMockRecorder struct {
+	mock *MockINotificationUsecase
+}
+
+// NewMockINotificationUsecase creates a new mock instance.
+func NewMockINotificationUsecase(ctrl *gomock.Controller) *MockINotificationUsecase {
+	mock := &MockINotificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockINotificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationUsecase) EXPECT() *MockINotificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeletePreference mocks base method.
+func (m *MockINotificationUsecase) DeletePreference(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreference", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePreference indicates an expected call of DeletePreference.
+func (mr *MockINotificationUsecaseMockRecorder) DeletePreference(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreference", reflect.TypeOf((*MockINotificationUsecase)(nil).DeletePreference), userID)
+}
+
+// GetPreference mocks base method.
+func (m *MockINotificationUsecase) GetPreference(userID int) (model.NotificationPreferenceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", userID)
+	ret0, _ := ret[0].(model.NotificationPreferenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockINotificationUsecaseMockRecorder) GetPreference(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockINotificationUsecase)(nil).GetPreference), userID)
+}
+
+// NotifyExpiringFoods mocks base method.
+func (m *MockINotificationUsecase) NotifyExpiringFoods() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyExpiringFoods")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotifyExpiringFoods indicates an expected call of NotifyExpiringFoods.
+func (mr *MockINotificationUsecaseMockRecorder) NotifyExpiringFoods() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyExpiringFoods", reflect.TypeOf((*MockINotificationUsecase)(nil).NotifyExpiringFoods))
+}
+
+// SweepExpiryAlerts mocks base method.
+func (m *MockINotificationUsecase) SweepExpiryAlerts() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiryAlerts")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiryAlerts indicates an expected call of SweepExpiryAlerts.
+func (mr *MockINotificationUsecaseMockRecorder) SweepExpiryAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiryAlerts", reflect.TypeOf((*MockINotificationUsecase)(nil).SweepExpiryAlerts))
+}
+
+// UpdatePreference mocks base method.
+func (m *MockINotificationUsecase) UpdatePreference(userID int, pref model.NotificationPreferenceRequest) (model.NotificationPreferenceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", userID, pref)
+	ret0, _ := ret[0].(model.NotificationPreferenceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockINotificationUsecaseMockRecorder) UpdatePreference(userID, pref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockINotificationUsecase)(nil).UpdatePreference), userID, pref)
+}
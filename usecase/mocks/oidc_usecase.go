@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/oidc_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/oidc_usecase.go -destination usecase/mocks/oidc_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOIDCUsecase is a mock of IOIDCUsecase interface.
+type MockIOIDCUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOIDCUsecaseMockRecorder
+}
+
+// MockIOIDCUsecaseMockRecorder is the mock recorder for MockIOIDCUsecase.
+type MockIOIDCUsecaseMockRecorder struct {
+	mock *MockIOIDCUsecase
+}
+
+// NewMockIOIDCUsecase creates a new mock instance.
+func NewMockIOIDCUsecase(ctrl *gomock.Controller) *MockIOIDCUsecase {
+	mock := &MockIOIDCUsecase{ctrl: ctrl}
+	mock.recorder = &MockIOIDCUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOIDCUsecase) EXPECT() *MockIOIDCUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CompleteLogin mocks base method.
+func (m *MockIOIDCUsecase) CompleteLogin(provider, code, state, binding string) (model.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLogin", provider, code, state, binding)
+	ret0, _ := ret[0].(model.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteLogin indicates an expected call of CompleteLogin.
+func (mr *MockIOIDCUsecaseMockRecorder) CompleteLogin(provider, code, state, binding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLogin", reflect.TypeOf((*MockIOIDCUsecase)(nil).CompleteLogin), provider, code, state, binding)
+}
+
+// GetIdentities mocks base method.
+func (m *MockIOIDCUsecase) GetIdentities(userID int) ([]model.UserIdentityResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentities", userID)
+	ret0, _ := ret[0].([]model.UserIdentityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentities indicates an expected call of GetIdentities.
+func (mr *MockIOIDCUsecaseMockRecorder) GetIdentities(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentities", reflect.TypeOf((*MockIOIDCUsecase)(nil).GetIdentities), userID)
+}
+
+// GetProviders mocks base method.
+func (m *MockIOIDCUsecase) GetProviders() []model.OIDCProviderResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviders")
+	ret0, _ := ret[0].([]model.OIDCProviderResponse)
+	return ret0
+}
+
+// GetProviders indicates an expected call of GetProviders.
+func (mr *MockIOIDCUsecaseMockRecorder) GetProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviders", reflect.TypeOf((*MockIOIDCUsecase)(nil).GetProviders))
+}
+
+// StartLogin mocks base method.
+func (m *MockIOIDCUsecase) StartLogin(provider string) (model.OIDCAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLogin", provider)
+	ret0, _ := ret[0].(model.OIDCAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartLogin indicates an expected call of StartLogin.
+func (mr *MockIOIDCUsecaseMockRecorder) StartLogin(provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLogin", reflect.TypeOf((*MockIOIDCUsecase)(nil).StartLogin), provider)
+}
+
+// SweepExpiredLoginStates mocks base method.
+func (m *MockIOIDCUsecase) SweepExpiredLoginStates() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredLoginStates")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiredLoginStates indicates an expected call of SweepExpiredLoginStates.
+func (mr *MockIOIDCUsecaseMockRecorder) SweepExpiredLoginStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredLoginStates", reflect.TypeOf((*MockIOIDCUsecase)(nil).SweepExpiredLoginStates))
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockIOIDCUsecase) UnlinkIdentity(userID int, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockIOIDCUsecaseMockRecorder) UnlinkIdentity(userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockIOIDCUsecase)(nil).UnlinkIdentity), userID, provider)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/push_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/push_usecase.go -destination usecase/mocks/push_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	notifier "RefrigeratorWatchdog-server/notifier"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPushUsecase is a mock of IPushUsecase interface.
+type MockIPushUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPushUsecaseMockRecorder
+}
+
+// MockIPushUsecaseMockRecorder is the mock recorder for MockIPushUsecase.
+type MockIPushUsecaseMockRecorder struct {
+	mock *MockIPushUsecase
+}
+
+// NewMockIPushUsecase creates a new mock instance.
+func NewMockIPushUsecase(ctrl *gomock.Controller) *MockIPushUsecase {
+	mock := &MockIPushUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPushUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPushUsecase) EXPECT() *MockIPushUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetVAPIDPublicKey mocks base method.
+func (m *MockIPushUsecase) GetVAPIDPublicKey() model.VAPIDPublicKeyResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVAPIDPublicKey")
+	ret0, _ := ret[0].(model.VAPIDPublicKeyResponse)
+	return ret0
+}
+
+// GetVAPIDPublicKey indicates an expected call of GetVAPIDPublicKey.
+func (mr *MockIPushUsecaseMockRecorder) GetVAPIDPublicKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVAPIDPublicKey", reflect.TypeOf((*MockIPushUsecase)(nil).GetVAPIDPublicKey))
+}
+
+// Notify mocks base method.
+func (m *MockIPushUsecase) Notify(notification notifier.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockIPushUsecaseMockRecorder) Notify(notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockIPushUsecase)(nil).Notify), notification)
+}
+
+// Subscribe mocks base method.
+func (m *MockIPushUsecase) Subscribe(userID int, sub model.PushSubscriptionRequest, userAgent string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID, sub, userAgent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIPushUsecaseMockRecorder) Subscribe(userID, sub, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIPushUsecase)(nil).Subscribe), userID, sub, userAgent)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockIPushUsecase) Unsubscribe(userID int, endpoint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", userID, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockIPushUsecaseMockRecorder) Unsubscribe(userID, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockIPushUsecase)(nil).Unsubscribe), userID, endpoint)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/two_factor_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/two_factor_usecase.go -destination usecase/mocks/two_factor_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITwoFactorUsecase is a mock of ITwoFactorUsecase interface.
+type MockITwoFactorUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITwoFactorUsecaseMockRecorder
+}
+
+// MockITwoFactorUsecaseMockRecorder is the mock recorder for MockITwoFactorUsecase.
+type MockITwoFactorUsecaseMockRecorder struct {
+	mock *MockITwoFactorUsecase
+}
+
+// NewMockITwoFactorUsecase creates a new mock instance.
+func NewMockITwoFactorUsecase(ctrl *gomock.Controller) *MockITwoFactorUsecase {
+	mock := &MockITwoFactorUsecase{ctrl: ctrl}
+	mock.recorder = &MockITwoFactorUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITwoFactorUsecase) EXPECT() *MockITwoFactorUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockITwoFactorUsecase) ConfirmTOTP(userID int, code string) (model.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", userID, code)
+	ret0, _ := ret[0].(model.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockITwoFactorUsecaseMockRecorder) ConfirmTOTP(userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockITwoFactorUsecase)(nil).ConfirmTOTP), userID, code)
+}
+
+// Disable mocks base method.
+func (m *MockITwoFactorUsecase) Disable(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockITwoFactorUsecaseMockRecorder) Disable(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockITwoFactorUsecase)(nil).Disable), userID)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockITwoFactorUsecase) EnrollTOTP(userID int) (model.TOTPEnrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", userID)
+	ret0, _ := ret[0].(model.TOTPEnrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockITwoFactorUsecaseMockRecorder) EnrollTOTP(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockITwoFactorUsecase)(nil).EnrollTOTP), userID)
+}
+
+// VerifyCode mocks base method.
+func (m *MockITwoFactorUsecase) VerifyCode(user model.User, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCode", user, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCode indicates an expected call of VerifyCode.
+func (mr *MockITwoFactorUsecaseMockRecorder) VerifyCode(user, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCode", reflect.TypeOf((*MockITwoFactorUsecase)(nil).VerifyCode), user, code)
+}
@@ -40,32 +40,60 @@ func (m *MockIUserUsecase) EXPECT() *MockIUserUsecaseMockRecorder {
 }
 
 // CreateUser mocks base method.
-func (m *MockIUserUsecase) CreateUser(user model.User) (model.UserResponse, error) {
+func (m *MockIUserUsecase) CreateUser(user model.User, meta model.RequestMeta) (model.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret := m.ctrl.Call(m, "CreateUser", user, meta)
 	ret0, _ := ret[0].(model.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockIUserUsecaseMockRecorder) CreateUser(user any) *gomock.Call {
+func (mr *MockIUserUsecaseMockRecorder) CreateUser(user, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIUserUsecase)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIUserUsecase)(nil).CreateUser), user, meta)
 }
 
 // DeleteUser mocks base method.
-func (m *MockIUserUsecase) DeleteUser(user model.User) error {
+func (m *MockIUserUsecase) DeleteUser(user model.User, meta model.RequestMeta) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", user)
+	ret := m.ctrl.Call(m, "DeleteUser", user, meta)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockIUserUsecaseMockRecorder) DeleteUser(user any) *gomock.Call {
+func (mr *MockIUserUsecaseMockRecorder) DeleteUser(user, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserUsecase)(nil).DeleteUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIUserUsecase)(nil).DeleteUser), user, meta)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockIUserUsecase) DisableTwoFactor(userID int, password string, meta model.RequestMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", userID, password, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockIUserUsecaseMockRecorder) DisableTwoFactor(userID, password, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockIUserUsecase)(nil).DisableTwoFactor), userID, password, meta)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockIUserUsecase) ForgotPassword(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockIUserUsecaseMockRecorder) ForgotPassword(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockIUserUsecase)(nil).ForgotPassword), email)
 }
 
 // GetUserByEmail mocks base method.
@@ -83,17 +111,103 @@ func (mr *MockIUserUsecaseMockRecorder) GetUserByEmail(email any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockIUserUsecase)(nil).GetUserByEmail), email)
 }
 
+// LoginUser mocks base method.
+func (m *MockIUserUsecase) LoginUser(user model.User, decodedEmail, ip string) (model.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", user, decodedEmail, ip)
+	ret0, _ := ret[0].(model.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockIUserUsecaseMockRecorder) LoginUser(user, decodedEmail, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIUserUsecase)(nil).LoginUser), user, decodedEmail, ip)
+}
+
+// LoginUserTwoFactor mocks base method.
+func (m *MockIUserUsecase) LoginUserTwoFactor(mfaToken, code, ip string) (model.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUserTwoFactor", mfaToken, code, ip)
+	ret0, _ := ret[0].(model.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUserTwoFactor indicates an expected call of LoginUserTwoFactor.
+func (mr *MockIUserUsecaseMockRecorder) LoginUserTwoFactor(mfaToken, code, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUserTwoFactor", reflect.TypeOf((*MockIUserUsecase)(nil).LoginUserTwoFactor), mfaToken, code, ip)
+}
+
+// ResendVerificationEmail mocks base method.
+func (m *MockIUserUsecase) ResendVerificationEmail(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockIUserUsecaseMockRecorder) ResendVerificationEmail(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockIUserUsecase)(nil).ResendVerificationEmail), userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockIUserUsecase) ResetPassword(token, password string, meta model.RequestMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, password, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIUserUsecaseMockRecorder) ResetPassword(token, password, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIUserUsecase)(nil).ResetPassword), token, password, meta)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockIUserUsecase) UnlockAccount(token string, meta model.RequestMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", token, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockIUserUsecaseMockRecorder) UnlockAccount(token, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockIUserUsecase)(nil).UnlockAccount), token, meta)
+}
+
 // UpdateUser mocks base method.
-func (m *MockIUserUsecase) UpdateUser(user model.User, email string) (model.UserResponse, error) {
+func (m *MockIUserUsecase) UpdateUser(user model.User, email string, meta model.RequestMeta) (model.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", user, email)
+	ret := m.ctrl.Call(m, "UpdateUser", user, email, meta)
 	ret0, _ := ret[0].(model.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockIUserUsecaseMockRecorder) UpdateUser(user, email any) *gomock.Call {
+func (mr *MockIUserUsecaseMockRecorder) UpdateUser(user, email, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateUser), user, email, meta)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockIUserUsecase) VerifyEmail(token string, meta model.RequestMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockIUserUsecaseMockRecorder) VerifyEmail(token, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateUser), user, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockIUserUsecase)(nil).VerifyEmail), token, meta)
 }
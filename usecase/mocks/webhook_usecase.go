@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usecase/webhook_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./usecase/webhook_usecase.go -destination usecase/mocks/webhook_usecase.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	model "RefrigeratorWatchdog-server/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookUsecase is a mock of IWebhookUsecase interface.
+type MockIWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookUsecaseMockRecorder
+}
+
+// MockIWebhookUsecaseMockRecorder is the mock recorder for MockIWebhookUsecase.
+type MockIWebhookUsecaseMockRecorder struct {
+	mock *MockIWebhookUsecase
+}
+
+// NewMockIWebhookUsecase creates a new mock instance.
+func NewMockIWebhookUsecase(ctrl *gomock.Controller) *MockIWebhookUsecase {
+	mock := &MockIWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockIWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookUsecase) EXPECT() *MockIWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockIWebhookUsecase) CreateWebhook(householdID, userID int, webhook model.WebhookRequest) (model.WebhookCreatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", householdID, userID, webhook)
+	ret0, _ := ret[0].(model.WebhookCreatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockIWebhookUsecaseMockRecorder) CreateWebhook(householdID, userID, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockIWebhookUsecase)(nil).CreateWebhook), householdID, userID, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockIWebhookUsecase) DeleteWebhook(householdID, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", householdID, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockIWebhookUsecaseMockRecorder) DeleteWebhook(householdID, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockIWebhookUsecase)(nil).DeleteWebhook), householdID, userID, id)
+}
+
+// DeliverPendingWebhooks mocks base method.
+func (m *MockIWebhookUsecase) DeliverPendingWebhooks() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverPendingWebhooks")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverPendingWebhooks indicates an expected call of DeliverPendingWebhooks.
+func (mr *MockIWebhookUsecaseMockRecorder) DeliverPendingWebhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverPendingWebhooks", reflect.TypeOf((*MockIWebhookUsecase)(nil).DeliverPendingWebhooks))
+}
+
+// GetDeliveries mocks base method.
+func (m *MockIWebhookUsecase) GetDeliveries(householdID, userID, id int) ([]model.WebhookDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", householdID, userID, id)
+	ret0, _ := ret[0].([]model.WebhookDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockIWebhookUsecaseMockRecorder) GetDeliveries(householdID, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockIWebhookUsecase)(nil).GetDeliveries), householdID, userID, id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockIWebhookUsecase) GetWebhooks(householdID, userID int) ([]model.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", householdID, userID)
+	ret0, _ := ret[0].([]model.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockIWebhookUsecaseMockRecorder) GetWebhooks(householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockIWebhookUsecase)(nil).GetWebhooks), householdID, userID)
+}
+
+// Publish mocks base method.
+func (m *MockIWebhookUsecase) Publish(event model.WebhookEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIWebhookUsecaseMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIWebhookUsecase)(nil).Publish), event)
+}
+
+// SendTestEvent mocks base method.
+func (m *MockIWebhookUsecase) SendTestEvent(householdID, userID, id int) (model.WebhookDeliveryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTestEvent", householdID, userID, id)
+	ret0, _ := ret[0].(model.WebhookDeliveryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTestEvent indicates an expected call of SendTestEvent.
+func (mr *MockIWebhookUsecaseMockRecorder) SendTestEvent(householdID, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTestEvent", reflect.TypeOf((*MockIWebhookUsecase)(nil).SendTestEvent), householdID, userID, id)
+}
+
+// SweepWebhookDeliveries mocks base method.
+func (m *MockIWebhookUsecase) SweepWebhookDeliveries() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepWebhookDeliveries")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepWebhookDeliveries indicates an expected call of SweepWebhookDeliveries.
+func (mr *MockIWebhookUsecaseMockRecorder) SweepWebhookDeliveries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepWebhookDeliveries", reflect.TypeOf((*MockIWebhookUsecase)(nil).SweepWebhookDeliveries))
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockIWebhookUsecase) UpdateWebhook(householdID, userID, id int, webhook model.WebhookRequest) (model.WebhookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", householdID, userID, id, webhook)
+	ret0, _ := ret[0].(model.WebhookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockIWebhookUsecaseMockRecorder) UpdateWebhook(householdID, userID, id, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockIWebhookUsecase)(nil).UpdateWebhook), householdID, userID, id, webhook)
+}
@@ -0,0 +1,434 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// expiryNotificationLease is the lease that elects the replica scanning for expiring foods.
+	expiryNotificationLease = "expiry-notifications"
+	// defaultExpiryNotificationLeaseTTL must be longer than a scan takes.
+	defaultExpiryNotificationLeaseTTL = 10 * time.Minute
+	// expiryAlertRetention is how long after the expiration date sent alerts are kept.
+	expiryAlertRetention = 48 * time.Hour
+	// webhookExpiringDays is how many days before the expiration date, in the
+	// default time zone, food.expiring is sent to webhooks.
+	webhookExpiringDays = 1
+)
+
+// Clock tells the current time. It is replaced in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock of the system.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// INotificationUsecase manages notification preferences and notifies users
+// of foods that reach their lead times.
+type INotificationUsecase interface {
+	GetPreference(userID int) (model.NotificationPreferenceResponse, error)
+	UpdatePreference(userID int, pref model.NotificationPreferenceRequest) (model.NotificationPreferenceResponse, error)
+	// DeletePreference resets the preferences of the user to the defaults.
+	DeletePreference(userID int) error
+	// NotifyExpiringFoods scans every household once and notifies its members
+	// of the foods that reached one of their lead times since the last scan.
+	// It returns the number of notifications sent. When another replica holds
+	// the lease, nothing is done.
+	NotifyExpiringFoods() (int, error)
+	SweepExpiryAlerts() (int64, error)
+}
+
+type notificationUsecase struct {
+	nr    repository.INotificationRepository
+	lr    repository.ILeaseRepository
+	fr    repository.IFoodRepository
+	hu    IHouseholdUsecase
+	wu    IWebhookUsecase
+	nv    validator.INotificationValidator
+	n     notifier.INotifier
+	clock Clock
+	// owner identifies this replica in leases
+	owner    string
+	leaseTTL time.Duration
+	location *time.Location
+}
+
+// NewNotificationUsecase creates a new instance of the notificationUsecase struct.
+// EXPIRY_NOTIFICATION_LEASE_TTL sets how long a replica keeps scanning to itself.
+func NewNotificationUsecase(nr repository.INotificationRepository, lr repository.ILeaseRepository, fr repository.IFoodRepository, hu IHouseholdUsecase, wu IWebhookUsecase, nv validator.INotificationValidator, n notifier.INotifier, clock Clock) INotificationUsecase {
+	owner, err := randomToken(16)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return &notificationUsecase{
+		nr:       nr,
+		lr:       lr,
+		fr:       fr,
+		hu:       hu,
+		wu:       wu,
+		nv:       nv,
+		n:        n,
+		clock:    clock,
+		owner:    owner,
+		leaseTTL: durationFromEnv("EXPIRY_NOTIFICATION_LEASE_TTL", defaultExpiryNotificationLeaseTTL),
+		location: defaultLocation(),
+	}
+}
+
+func (nu *notificationUsecase) GetPreference(userID int) (model.NotificationPreferenceResponse, error) {
+	pref, err := nu.preference(userID)
+	if err != nil {
+		return model.NotificationPreferenceResponse{}, err
+	}
+	return notificationPreferenceResponse(pref), nil
+}
+
+func notificationPreferenceResponse(pref model.NotificationPreference) model.NotificationPreferenceResponse {
+	res := model.NotificationPreferenceResponse{
+		LeadDays:        pref.LeadDays,
+		Channels:        pref.Channels,
+		Delivery:        pref.Delivery,
+		DigestFrequency: pref.DigestFrequency,
+		DigestHour:      pref.DigestHour,
+		DigestWeekday:   pref.DigestWeekday,
+		Language:        pref.Language,
+		MutedTags:       pref.MutedTags,
+		WebhookURL:      pref.WebhookURL,
+		WebhookSecret:   pref.WebhookSecret,
+	}
+	if pref.HasQuietHours() {
+		res.QuietHours = &model.QuietHours{Start: pref.QuietHoursStart, End: pref.QuietHoursEnd}
+	}
+	return res
+}
+
+func (nu *notificationUsecase) preference(userID int) (model.NotificationPreference, error) {
+	return notificationPreference(nu.nr, userID)
+}
+
+// notificationPreference returns the preferences of the user, or the
+// defaults when the user has not saved any.
+func notificationPreference(nr repository.INotificationRepository, userID int) (model.NotificationPreference, error) {
+	pref := model.NotificationPreference{}
+	if err := nr.GetPreference(&pref, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DefaultNotificationPreference(userID), nil
+		}
+		return model.NotificationPreference{}, err
+	}
+	return pref, nil
+}
+
+func (nu *notificationUsecase) UpdatePreference(userID int, req model.NotificationPreferenceRequest) (model.NotificationPreferenceResponse, error) {
+	if err := nu.nv.ValidateNotificationPreference(req); err != nil {
+		return model.NotificationPreferenceResponse{}, err
+	}
+	current, err := nu.preference(userID)
+	if err != nil {
+		return model.NotificationPreferenceResponse{}, err
+	}
+
+	pref := model.DefaultNotificationPreference(userID)
+	pref.LeadDays = normalizeLeadDays(req.LeadDays)
+	if req.Channels != nil {
+		pref.Channels = uniqueStrings(req.Channels)
+	}
+	if req.Delivery != "" {
+		pref.Delivery = req.Delivery
+	}
+	if req.DigestFrequency != "" {
+		pref.DigestFrequency = req.DigestFrequency
+	}
+	if req.DigestHour != nil {
+		pref.DigestHour = *req.DigestHour
+	}
+	if req.DigestWeekday != nil {
+		pref.DigestWeekday = *req.DigestWeekday
+	}
+	if req.Language != "" {
+		pref.Language = req.Language
+	}
+	if req.QuietHours != nil {
+		pref.QuietHoursStart, pref.QuietHoursEnd = req.QuietHours.Start, req.QuietHours.End
+	}
+	if req.MutedTags != nil {
+		pref.MutedTags = uniqueStrings(req.MutedTags)
+	}
+	// 同じ URL のままなら署名の秘密鍵も変えない
+	pref.WebhookURL = req.WebhookURL
+	if pref.WebhookURL != "" {
+		pref.WebhookSecret = current.WebhookSecret
+		if pref.WebhookURL != current.WebhookURL || pref.WebhookSecret == "" {
+			secret, err := randomToken(24)
+			if err != nil {
+				return model.NotificationPreferenceResponse{}, err
+			}
+			pref.WebhookSecret = webhookSecretPrefix + secret
+		}
+	}
+
+	if err := nu.nr.SavePreference(&pref); err != nil {
+		return model.NotificationPreferenceResponse{}, err
+	}
+	return notificationPreferenceResponse(pref), nil
+}
+
+func (nu *notificationUsecase) DeletePreference(userID int) error {
+	return nu.nr.DeletePreference(userID)
+}
+
+// uniqueStrings removes duplicates, keeping the order.
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// normalizeLeadDays removes duplicates and sorts the lead days, largest first.
+func normalizeLeadDays(leadDays []int) []int {
+	seen := map[int]bool{}
+	normalized := []int{}
+	for _, days := range leadDays {
+		if !seen[days] {
+			seen[days] = true
+			normalized = append(normalized, days)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(normalized)))
+	return normalized
+}
+
+// leadTimeReached returns the smallest lead time that the food, which expires
+// in daysRemaining days, has reached. Reaching a smaller lead time later sends
+// another alert, while lead times passed in between, for example while the
+// server was down, are not sent late.
+func leadTimeReached(leadDays []int, daysRemaining int) (int, bool) {
+	reached, ok := 0, false
+	for _, days := range leadDays {
+		if daysRemaining <= days && (!ok || days < reached) {
+			reached, ok = days, true
+		}
+	}
+	return reached, ok
+}
+
+func (nu *notificationUsecase) NotifyExpiringFoods() (int, error) {
+	now := nu.clock.Now()
+	acquired, err := nu.lr.AcquireLease(expiryNotificationLease, nu.owner, now, nu.leaseTTL)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+
+	// 今日の始まりはどのタイムゾーンでも24時間以内に収まる
+	foods := []model.Food{}
+	if err := nu.fr.GetFoodsExpiringBetween(&foods, now.Add(-24*time.Hour), now.AddDate(0, 0, model.MaxNotificationLeadDays+2)); err != nil {
+		return 0, err
+	}
+	nu.publishExpiringFoods(foods, now)
+	households := map[int][]model.Food{}
+	householdIDs := []int{}
+	for _, food := range foods {
+		if _, ok := households[food.HouseholdID]; !ok {
+			householdIDs = append(householdIDs, food.HouseholdID)
+		}
+		households[food.HouseholdID] = append(households[food.HouseholdID], food)
+	}
+
+	// 途中で失敗しても、確保済みの通知は送れるように処理を続ける
+	pending := []*pendingNotification{}
+	byUser := map[int]*pendingNotification{}
+	for _, householdID := range householdIDs {
+		members, err := nu.hu.Members(householdID)
+		if err != nil {
+			log.Println("failed to get household members:", err)
+			continue
+		}
+		prefs, err := nu.preferences(members)
+		if err != nil {
+			log.Println("failed to get notification preferences:", err)
+			continue
+		}
+		for _, member := range members {
+			if !member.Can(model.PermissionFoodRead) {
+				continue
+			}
+			loc := userTimeZone(member.User, nu.location)
+			pref := prefs[member.UserID]
+			if !notificationDue(pref, now.In(loc)) {
+				continue
+			}
+			today := startOfDay(now, loc)
+			for _, food := range households[householdID] {
+				if pref.Mutes(food.Tag) {
+					continue
+				}
+				daysRemaining := daysBetween(today, food.ExpirationDate.In(loc))
+				if daysRemaining < 0 {
+					continue
+				}
+				lead, ok := leadTimeReached(pref.LeadDays, daysRemaining)
+				if !ok {
+					continue
+				}
+				alert := model.ExpiryAlert{FoodID: food.ID, UserID: member.UserID, LeadDays: lead, ExpirationDate: *food.ExpirationDate, SentAt: now}
+				claimed, err := nu.nr.ClaimExpiryAlert(&alert)
+				if err != nil {
+					log.Println("failed to claim expiry alert:", err)
+					continue
+				}
+				if !claimed {
+					continue
+				}
+
+				p, ok := byUser[member.UserID]
+				if !ok {
+					p = &pendingNotification{user: member.User, pref: pref, location: loc}
+					byUser[member.UserID] = p
+					pending = append(pending, p)
+				}
+				p.alertIDs = append(p.alertIDs, alert.ID)
+				p.foods = append(p.foods, notifier.ExpiringFood{
+					FoodID:         food.ID,
+					HouseholdID:    food.HouseholdID,
+					Name:           food.Name,
+					ExpirationDate: *food.ExpirationDate,
+					DaysRemaining:  daysRemaining,
+				})
+			}
+		}
+	}
+
+	sent := 0
+	for _, p := range pending {
+		notification := expiryNotification(p.user, p.location, p.foods)
+		notification.Channels = p.pref.Channels
+		notification.WebhookURL, notification.WebhookSecret = p.pref.WebhookURL, p.pref.WebhookSecret
+		if err := nu.n.Notify(notification); err != nil {
+			log.Println("failed to send expiry notification:", err)
+			// 次回の実行で再送できるように記録を消す
+			for _, id := range p.alertIDs {
+				if err := nu.nr.DeleteExpiryAlert(id); err != nil {
+					log.Println("failed to release expiry alert:", err)
+				}
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// notificationDue reports whether the user, at local in the time zone of the
+// user, can be alerted. Alerts are not claimed during the quiet hours, so
+// that they are claimed and sent together when the quiet hours end. Users
+// who get digests are never alerted about each food.
+func notificationDue(pref model.NotificationPreference, local time.Time) bool {
+	if len(pref.Channels) == 0 || pref.Delivery == model.NotificationDeliveryDigest {
+		return false
+	}
+	return !pref.InQuietHours(local)
+}
+
+// publishExpiringFoods sends food.expiring to the webhooks of the households
+// of the foods that expire within webhookExpiringDays. The event ID is derived
+// from the food and its expiration date, so every scan publishes the same
+// events and each is queued only once.
+func (nu *notificationUsecase) publishExpiringFoods(foods []model.Food, now time.Time) {
+	today := startOfDay(now, nu.location)
+	for _, food := range foods {
+		daysRemaining := daysBetween(today, food.ExpirationDate.In(nu.location))
+		if daysRemaining < 0 || daysRemaining > webhookExpiringDays {
+			continue
+		}
+		nu.wu.Publish(model.WebhookEvent{
+			ID:          fmt.Sprintf("%sexpiring_%d_%s", webhookEventIDPrefix, food.ID, food.ExpirationDate.UTC().Format("20060102")),
+			Type:        model.WebhookEventFoodExpiring,
+			HouseholdID: food.HouseholdID,
+			OccurredAt:  now,
+			Data:        model.WebhookFoodData{Food: foodResponse(food), DaysRemaining: &daysRemaining},
+		})
+	}
+}
+
+// pendingNotification collects the alerts claimed for a user in one scan.
+type pendingNotification struct {
+	user     model.User
+	pref     model.NotificationPreference
+	location *time.Location
+	alertIDs []int
+	foods    []notifier.ExpiringFood
+}
+
+// preferences returns the preferences of the members by user ID, with the
+// defaults for members who have not saved any.
+func (nu *notificationUsecase) preferences(members []model.HouseholdMember) (map[int]model.NotificationPreference, error) {
+	userIDs := []int{}
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	saved := []model.NotificationPreference{}
+	if err := nu.nr.GetPreferencesByUserIDs(&saved, userIDs); err != nil {
+		return nil, err
+	}
+	prefs := map[int]model.NotificationPreference{}
+	for _, userID := range userIDs {
+		prefs[userID] = model.DefaultNotificationPreference(userID)
+	}
+	for _, pref := range saved {
+		prefs[pref.UserID] = pref
+	}
+	return prefs, nil
+}
+
+// expiryNotification returns the notification telling the user about the
+// foods, with the dates in the time zone of the user.
+func expiryNotification(user model.User, loc *time.Location, foods []notifier.ExpiringFood) notifier.Notification {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s 様\n\n以下の食材の期限が近づいています。\n\n", user.Username)
+	for _, food := range foods {
+		when := fmt.Sprintf("あと%d日", food.DaysRemaining)
+		if food.DaysRemaining == 0 {
+			when = "今日まで"
+		}
+		fmt.Fprintf(&b, "・%s（%s、%s）\n", food.Name, food.ExpirationDate.In(loc).Format("1月2日"), when)
+	}
+	return notifier.Notification{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Kind:    notifier.KindFoodExpiring,
+		Subject: fmt.Sprintf("期限が近い食材が%d件あります", len(foods)),
+		Body:    b.String(),
+		Foods:   foods,
+	}
+}
+
+// SweepExpiryAlerts deletes the alerts of foods that expired long enough ago
+// that they are no longer scanned.
+func (nu *notificationUsecase) SweepExpiryAlerts() (int64, error) {
+	return nu.nr.DeleteExpiryAlertsBefore(nu.clock.Now().Add(-expiryAlertRetention))
+}
@@ -0,0 +1,423 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.now
+}
+
+func Test_notificationUsecase_UpdatePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockINotificationRepository(ctrl)
+	hour := func(h int) *int { return &h }
+	withDefaults := func(f func(pref *model.NotificationPreference)) model.NotificationPreference {
+		pref := model.DefaultNotificationPreference(1)
+		f(&pref)
+		return pref
+	}
+
+	tests := []struct {
+		name          string
+		req           model.NotificationPreferenceRequest
+		current       *model.NotificationPreference
+		want          model.NotificationPreference
+		wantNewSecret bool
+		wantErr       bool
+	}{
+		{
+			name: "正常系：重複を除いて大きい順に並べる",
+			req:  model.NotificationPreferenceRequest{LeadDays: []int{0, 3, 1, 3}},
+			want: withDefaults(func(p *model.NotificationPreference) { p.LeadDays = []int{3, 1, 0} }),
+		},
+		{name: "正常系：空にすると通知しない", req: model.NotificationPreferenceRequest{LeadDays: []int{}}, want: withDefaults(func(p *model.NotificationPreference) { p.LeadDays = []int{} })},
+		{
+			name: "正常系：通知先、まとめ通知、おやすみ時間、除外するタグを設定できる",
+			req: model.NotificationPreferenceRequest{
+				LeadDays:        []int{1},
+				Channels:        []string{"push", "push"},
+				Delivery:        model.NotificationDeliveryDigest,
+				DigestFrequency: model.DigestFrequencyWeekly,
+				DigestHour:      hour(0),
+				DigestWeekday:   hour(5),
+				Language:        model.LanguageEnglish,
+				QuietHours:      &model.QuietHours{Start: "22:00", End: "07:00"},
+				MutedTags:       []string{"調味料"},
+			},
+			want: withDefaults(func(p *model.NotificationPreference) {
+				p.LeadDays = []int{1}
+				p.Channels = []string{"push"}
+				p.Delivery = model.NotificationDeliveryDigest
+				p.DigestFrequency = model.DigestFrequencyWeekly
+				p.DigestHour = 0
+				p.DigestWeekday = 5
+				p.Language = model.LanguageEnglish
+				p.QuietHoursStart, p.QuietHoursEnd = "22:00", "07:00"
+				p.MutedTags = []string{"調味料"}
+			}),
+		},
+		{
+			name:          "正常系：Webhook の URL を設定すると秘密鍵を発行する",
+			req:           model.NotificationPreferenceRequest{LeadDays: []int{1}, Channels: []string{"webhook"}, WebhookURL: "https://example.com/hooks/fridge"},
+			wantNewSecret: true,
+		},
+		{
+			name:    "正常系：Webhook の URL が同じなら秘密鍵を変えない",
+			req:     model.NotificationPreferenceRequest{LeadDays: []int{1}, Channels: []string{"webhook"}, WebhookURL: "https://example.com/hooks/fridge"},
+			current: &model.NotificationPreference{UserID: 1, WebhookURL: "https://example.com/hooks/fridge", WebhookSecret: "whsec_current"},
+			want: withDefaults(func(p *model.NotificationPreference) {
+				p.LeadDays = []int{1}
+				p.Channels = []string{"webhook"}
+				p.WebhookURL, p.WebhookSecret = "https://example.com/hooks/fridge", "whsec_current"
+			}),
+		},
+		{name: "異常系：31日以上前は指定できない", req: model.NotificationPreferenceRequest{LeadDays: []int{31}}, wantErr: true},
+		{name: "異常系：負の日数は指定できない", req: model.NotificationPreferenceRequest{LeadDays: []int{-1}}, wantErr: true},
+		{name: "異常系：6件以上は指定できない", req: model.NotificationPreferenceRequest{LeadDays: []int{0, 1, 2, 3, 4, 5}}, wantErr: true},
+		{name: "異常系：未知の通知先", req: model.NotificationPreferenceRequest{Channels: []string{"sms"}}, wantErr: true},
+		{name: "異常系：Webhook の URL がない", req: model.NotificationPreferenceRequest{Channels: []string{"webhook"}}, wantErr: true},
+		{name: "異常系：まとめ通知の時刻が範囲外", req: model.NotificationPreferenceRequest{DigestHour: hour(24)}, wantErr: true},
+		{name: "異常系：おやすみ時間の形式が不正", req: model.NotificationPreferenceRequest{QuietHours: &model.QuietHours{Start: "22時", End: "07:00"}}, wantErr: true},
+		{name: "異常系：おやすみ時間の開始と終了が同じ", req: model.NotificationPreferenceRequest{QuietHours: &model.QuietHours{Start: "07:00", End: "07:00"}}, wantErr: true},
+		{name: "異常系：未知のまとめ通知の頻度", req: model.NotificationPreferenceRequest{DigestFrequency: "monthly"}, wantErr: true},
+		{name: "異常系：週間まとめの曜日が範囲外", req: model.NotificationPreferenceRequest{DigestWeekday: hour(7)}, wantErr: true},
+		{name: "異常系：未知の言語", req: model.NotificationPreferenceRequest{Language: "fr"}, wantErr: true},
+		{name: "異常系：存在しないタグ", req: model.NotificationPreferenceRequest{MutedTags: []string{"お菓子"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nu := &notificationUsecase{nr: mockRepo, nv: validator.NewNotificationValidator()}
+			if !tt.wantErr {
+				mockRepo.EXPECT().GetPreference(gomock.Any(), 1).DoAndReturn(func(pref *model.NotificationPreference, userID int) error {
+					if tt.current == nil {
+						return gorm.ErrRecordNotFound
+					}
+					*pref = *tt.current
+					return nil
+				}).Times(1)
+				mockRepo.EXPECT().SavePreference(gomock.Any()).DoAndReturn(func(pref *model.NotificationPreference) error {
+					if tt.wantNewSecret {
+						if !strings.HasPrefix(pref.WebhookSecret, webhookSecretPrefix) || len(pref.WebhookSecret) < 32 {
+							t.Errorf("webhook secret = %v", pref.WebhookSecret)
+						}
+						return nil
+					}
+					if !reflect.DeepEqual(*pref, tt.want) {
+						t.Errorf("SavePreference() = %+v, want %+v", *pref, tt.want)
+					}
+					return nil
+				}).Times(1)
+			}
+
+			got, err := nu.UpdatePreference(1, tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("notificationUsecase.UpdatePreference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !tt.wantNewSecret && !reflect.DeepEqual(got, notificationPreferenceResponse(tt.want)) {
+				t.Errorf("notificationUsecase.UpdatePreference() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_notificationUsecase_GetPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockINotificationRepository(ctrl)
+
+	tests := []struct {
+		name    string
+		saved   []int
+		repoErr error
+		want    []int
+	}{
+		{name: "正常系：保存した設定を返す", saved: []int{3, 0}, want: []int{3, 0}},
+		{name: "正常系：未設定なら前日に通知する", repoErr: gorm.ErrRecordNotFound, want: model.DefaultNotificationLeadDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nu := &notificationUsecase{nr: mockRepo}
+			mockRepo.EXPECT().GetPreference(gomock.Any(), 1).Do(func(pref *model.NotificationPreference, userID int) {
+				pref.UserID = userID
+				pref.LeadDays = tt.saved
+			}).Return(tt.repoErr).Times(1)
+
+			got, err := nu.GetPreference(1)
+			if err != nil {
+				t.Fatalf("notificationUsecase.GetPreference() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.LeadDays, tt.want) {
+				t.Errorf("notificationUsecase.GetPreference() = %v, want %v", got.LeadDays, tt.want)
+			}
+		})
+	}
+}
+
+func Test_leadTimeReached(t *testing.T) {
+	tests := []struct {
+		name          string
+		leadDays      []int
+		daysRemaining int
+		want          int
+		wantOK        bool
+	}{
+		{name: "正常系：ちょうど3日前", leadDays: []int{3, 1}, daysRemaining: 3, want: 3, wantOK: true},
+		{name: "正常系：2日前は3日前の通知に含まれる", leadDays: []int{3, 1}, daysRemaining: 2, want: 3, wantOK: true},
+		{name: "正常系：前日は小さい方の通知になる", leadDays: []int{3, 1}, daysRemaining: 1, want: 1, wantOK: true},
+		{name: "正常系：まだ通知しない", leadDays: []int{3, 1}, daysRemaining: 4},
+		{name: "正常系：通知を切っている", leadDays: []int{}, daysRemaining: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := leadTimeReached(tt.leadDays, tt.daysRemaining)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("leadTimeReached() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_notificationUsecase_NotifyExpiringFoods(t *testing.T) {
+	// 2024-10-01 20:00 UTC は東京では 10月2日 05:00
+	now := time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) *time.Time {
+		d := time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	tokyo := model.User{ID: 1, Username: "山田太郎", Email: "yamada@test.com", TimeZone: "Asia/Tokyo"}
+	utc := model.User{ID: 2, Username: "佐藤花子", Email: "sato@test.com"}
+	reader := []model.Permission{model.PermissionFoodRead}
+	foods := []model.Food{
+		{ID: 1, HouseholdID: 1, Name: "牛乳", Tag: "乳製品", ExpirationDate: date(10, 3)},
+		{ID: 2, HouseholdID: 1, Name: "卵", Tag: "卵", ExpirationDate: date(10, 10)},
+	}
+	// sato は UTC なので、現在は 20:00
+	sato := func(f func(pref *model.NotificationPreference)) []model.NotificationPreference {
+		pref := model.DefaultNotificationPreference(2)
+		f(&pref)
+		return []model.NotificationPreference{pref}
+	}
+
+	tests := []struct {
+		name      string
+		acquired  bool
+		members   []model.HouseholdMember
+		prefs     []model.NotificationPreference
+		claimed   map[int]bool // user ID -> 他のレプリカに確保されていない
+		notifyErr error
+		wantSent  int
+		wantTo    []string
+		wantLeads map[int][]int // user ID -> 確保した通知の日数
+	}{
+		{
+			name:     "正常系：リースを取れなければ何もしない",
+			acquired: false,
+		},
+		{
+			name:     "正常系：ユーザーのタイムゾーンで残り日数を数える",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 1, User: tokyo, Permissions: reader},
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			claimed:   map[int]bool{1: true, 2: true},
+			wantSent:  1,
+			wantTo:    []string{"yamada@test.com"},
+			wantLeads: map[int][]int{1: {1}},
+		},
+		{
+			name:     "正常系：設定した日数のうち最も小さいものだけを通知する",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			prefs:     sato(func(p *model.NotificationPreference) { p.LeadDays = []int{14, 3, 2} }),
+			claimed:   map[int]bool{2: true},
+			wantSent:  1,
+			wantTo:    []string{"sato@test.com"},
+			wantLeads: map[int][]int{2: {2, 14}},
+		},
+		{
+			name:     "正常系：除外したタグの食材は通知しない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			prefs: sato(func(p *model.NotificationPreference) {
+				p.LeadDays = []int{14, 3, 2}
+				p.MutedTags = []string{"乳製品"}
+			}),
+			claimed:   map[int]bool{2: true},
+			wantSent:  1,
+			wantTo:    []string{"sato@test.com"},
+			wantLeads: map[int][]int{2: {14}},
+		},
+		{
+			name:     "正常系：おやすみ時間中は通知を確保しない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			prefs: sato(func(p *model.NotificationPreference) {
+				p.LeadDays = []int{3}
+				p.QuietHoursStart, p.QuietHoursEnd = "19:00", "07:00"
+			}),
+		},
+		{
+			name:     "正常系：まとめ通知を選んだユーザーには食材ごとに通知しない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			prefs: sato(func(p *model.NotificationPreference) {
+				p.LeadDays = []int{3}
+				p.Delivery = model.NotificationDeliveryDigest
+				p.DigestHour = 20
+			}),
+		},
+		{
+			name:     "正常系：通知先がなければ通知しない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 2, User: utc, Permissions: reader},
+			},
+			prefs: sato(func(p *model.NotificationPreference) { p.LeadDays = []int{3}; p.Channels = []string{} }),
+		},
+		{
+			name:     "正常系：他のレプリカが確保した通知は送らない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 1, User: tokyo, Permissions: reader},
+			},
+			claimed:   map[int]bool{1: false},
+			wantLeads: map[int][]int{1: {1}},
+		},
+		{
+			name:     "正常系：食材を見られないメンバーには通知しない",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 1, User: tokyo},
+			},
+		},
+		{
+			name:     "異常系：送信に失敗したら確保した通知を戻す",
+			acquired: true,
+			members: []model.HouseholdMember{
+				{UserID: 1, User: tokyo, Permissions: reader},
+			},
+			claimed:   map[int]bool{1: true},
+			notifyErr: errors.New("smtp unavailable"),
+			wantLeads: map[int][]int{1: {1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockINotificationRepository(ctrl)
+			mockLease := mocks.NewMockILeaseRepository(ctrl)
+			mockFood := mocks.NewMockIFoodRepository(ctrl)
+			mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+			mn := notifier.NewMemoryNotifier()
+			mn.Err = tt.notifyErr
+			nu := &notificationUsecase{nr: mockRepo, lr: mockLease, fr: mockFood, hu: mockHousehold, wu: newNopWebhookUsecase(ctrl), n: mn, clock: fakeClock{now}, owner: "replica-1", leaseTTL: time.Minute, location: time.UTC}
+
+			mockLease.EXPECT().AcquireLease(expiryNotificationLease, "replica-1", now, time.Minute).Return(tt.acquired, nil).Times(1)
+			if tt.acquired {
+				mockFood.EXPECT().GetFoodsExpiringBetween(gomock.Any(), now.Add(-24*time.Hour), now.AddDate(0, 0, model.MaxNotificationLeadDays+2)).SetArg(0, foods).Return(nil).Times(1)
+				mockHousehold.EXPECT().Members(1).Return(tt.members, nil).Times(1)
+				mockRepo.EXPECT().GetPreferencesByUserIDs(gomock.Any(), gomock.Any()).SetArg(0, tt.prefs).Return(nil).Times(1)
+			}
+			gotLeads := map[int][]int{}
+			nextID := 0
+			mockRepo.EXPECT().ClaimExpiryAlert(gomock.Any()).DoAndReturn(func(alert *model.ExpiryAlert) (bool, error) {
+				nextID++
+				alert.ID = nextID
+				gotLeads[alert.UserID] = append(gotLeads[alert.UserID], alert.LeadDays)
+				return tt.claimed[alert.UserID], nil
+			}).AnyTimes()
+			if tt.notifyErr != nil {
+				mockRepo.EXPECT().DeleteExpiryAlert(gomock.Any()).Return(nil).Times(len(tt.wantLeads[1]))
+			}
+
+			sent, err := nu.NotifyExpiringFoods()
+			if err != nil {
+				t.Fatalf("notificationUsecase.NotifyExpiringFoods() error = %v", err)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("notificationUsecase.NotifyExpiringFoods() = %v, want %v", sent, tt.wantSent)
+			}
+			for _, to := range tt.wantTo {
+				found := false
+				for _, n := range mn.Sent() {
+					found = found || (n.Email == to && len(n.Channels) > 0)
+				}
+				if !found {
+					t.Errorf("notification to %v was not sent: %v", to, mn.Sent())
+				}
+			}
+			if tt.wantLeads == nil {
+				tt.wantLeads = map[int][]int{}
+			}
+			if !reflect.DeepEqual(gotLeads, tt.wantLeads) {
+				t.Errorf("claimed lead days = %v, want %v", gotLeads, tt.wantLeads)
+			}
+		})
+	}
+}
+
+func Test_notificationUsecase_publishExpiringFoods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 10, 1, 20, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) *time.Time {
+		d := time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	nu := &notificationUsecase{wu: mockWebhook, location: time.UTC}
+
+	got := []model.WebhookEvent{}
+	mockWebhook.EXPECT().Publish(gomock.Any()).Do(func(event model.WebhookEvent) {
+		got = append(got, event)
+	}).AnyTimes()
+
+	nu.publishExpiringFoods([]model.Food{
+		{ID: 1, HouseholdID: 1, Name: "昨日まで", ExpirationDate: date(9, 30)},
+		{ID: 2, HouseholdID: 1, Name: "今日まで", ExpirationDate: date(10, 1)},
+		{ID: 3, HouseholdID: 2, Name: "明日まで", ExpirationDate: date(10, 2)},
+		{ID: 4, HouseholdID: 1, Name: "明後日まで", ExpirationDate: date(10, 3)},
+	}, now)
+
+	if len(got) != 2 {
+		t.Fatalf("published %d events, want 2: %+v", len(got), got)
+	}
+	if got[0].ID != "evt_expiring_2_20241001" || got[0].Type != model.WebhookEventFoodExpiring || got[0].HouseholdID != 1 {
+		t.Errorf("event = %+v", got[0])
+	}
+	if data := got[1].Data.(model.WebhookFoodData); got[1].ID != "evt_expiring_3_20241002" || got[1].HouseholdID != 2 || *data.DaysRemaining != 1 {
+		t.Errorf("event = %+v", got[1])
+	}
+}
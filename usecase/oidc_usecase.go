@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/oidc"
+	"RefrigeratorWatchdog-server/repository"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultOIDCLoginStateTTL = 10 * time.Minute
+
+// IOIDCUsecase manages logins with OpenID Connect providers and the
+// identities linked to users.
+type IOIDCUsecase interface {
+	GetProviders() []model.OIDCProviderResponse
+	// StartLogin returns the authorization URL and the state, and a binding
+	// value that the browser must send back with the callback.
+	StartLogin(provider string) (model.OIDCAuthorizationResponse, error)
+	// CompleteLogin logs in the user of the provider account, linking it to
+	// the user with the same verified email address or creating a new user.
+	// The binding must be the one returned by StartLogin with the state.
+	CompleteLogin(provider string, code string, state string, binding string) (model.LoginResponse, error)
+	GetIdentities(userID int) ([]model.UserIdentityResponse, error)
+	UnlinkIdentity(userID int, provider string) error
+	SweepExpiredLoginStates() (int64, error)
+}
+
+type oidcUsecase struct {
+	ur        repository.IUserRepository
+	uir       repository.IUserIdentityRepository
+	au        IAuthUsecase
+	providers map[string]oidc.IProvider
+	stateTTL  time.Duration
+}
+
+// NewOIDCUsecase creates a new instance of the oidcUsecase struct.
+// The lifetime of a started login is read from OIDC_LOGIN_STATE_TTL.
+func NewOIDCUsecase(ur repository.IUserRepository, uir repository.IUserIdentityRepository, au IAuthUsecase, providers map[string]oidc.IProvider) IOIDCUsecase {
+	return &oidcUsecase{
+		ur:        ur,
+		uir:       uir,
+		au:        au,
+		providers: providers,
+		stateTTL:  durationFromEnv("OIDC_LOGIN_STATE_TTL", defaultOIDCLoginStateTTL),
+	}
+}
+
+func (ou *oidcUsecase) GetProviders() []model.OIDCProviderResponse {
+	names := make([]string, 0, len(ou.providers))
+	for name := range ou.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([]model.OIDCProviderResponse, 0, len(names))
+	for _, name := range names {
+		res = append(res, model.OIDCProviderResponse{Name: name})
+	}
+	return res
+}
+
+func (ou *oidcUsecase) StartLogin(provider string) (model.OIDCAuthorizationResponse, error) {
+	p, ok := ou.providers[provider]
+	if !ok {
+		return model.OIDCAuthorizationResponse{}, model.ErrOIDCProviderNotFound
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+	nonce, err := randomToken(32)
+	if err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+	codeVerifier, err := randomToken(48)
+	if err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+	binding, err := randomToken(32)
+	if err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+	authURL, err := p.AuthCodeURL(state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+
+	if err := ou.uir.CreateLoginState(&model.OIDCLoginState{
+		Provider:     provider,
+		StateHash:    hashToken(state),
+		BindingHash:  hashToken(binding),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(ou.stateTTL),
+	}); err != nil {
+		return model.OIDCAuthorizationResponse{}, err
+	}
+	return model.OIDCAuthorizationResponse{AuthorizationURL: authURL, State: state, Binding: binding}, nil
+}
+
+func (ou *oidcUsecase) CompleteLogin(provider string, code string, state string, binding string) (model.LoginResponse, error) {
+	p, ok := ou.providers[provider]
+	if !ok {
+		return model.LoginResponse{}, model.ErrOIDCProviderNotFound
+	}
+	loginState := model.OIDCLoginState{}
+	if err := ou.uir.ConsumeLoginState(&loginState, hashToken(state), provider); err != nil {
+		return model.LoginResponse{}, err
+	}
+	// 別のブラウザで始めたログインの認可コードを持ち込まれないようにする
+	if binding == "" || subtle.ConstantTimeCompare([]byte(loginState.BindingHash), []byte(hashToken(binding))) != 1 {
+		return model.LoginResponse{}, model.ErrInvalidToken
+	}
+
+	claims, err := p.Exchange(code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return model.LoginResponse{}, fmt.Errorf("%w: %v", model.ErrUnauthorized, err)
+	}
+
+	user, err := ou.findOrCreateUser(provider, claims)
+	if err != nil {
+		return model.LoginResponse{}, err
+	}
+
+	// パスワードでのログインと同様に、二要素認証が有効なら2段階目を求める
+	if user.TOTPEnabledAt != nil {
+		mfaToken, err := ou.au.IssueMFAToken(user)
+		if err != nil {
+			return model.LoginResponse{}, err
+		}
+		return model.LoginResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			User:        userResponse(user),
+		}, nil
+	}
+	tokens, err := ou.au.IssueTokens(user)
+	if err != nil {
+		return model.LoginResponse{}, err
+	}
+	return model.LoginResponse{
+		TokenResponse: &tokens,
+		User:          userResponse(user),
+	}, nil
+}
+
+// findOrCreateUser returns the user linked to the provider account. An
+// unknown account is linked to the user with the same email address, or a
+// new user is created, but only if the provider verified the address.
+func (ou *oidcUsecase) findOrCreateUser(provider string, claims oidc.Claims) (model.User, error) {
+	identity := model.UserIdentity{}
+	err := ou.uir.GetIdentity(&identity, provider, claims.Subject)
+	if err == nil {
+		if claims.Email != "" && claims.Email != identity.Email {
+			if err := ou.uir.UpdateIdentityEmail(identity.ID, claims.Email); err != nil {
+				return model.User{}, err
+			}
+		}
+		return identity.User, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.User{}, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return model.User{}, model.ErrOIDCEmailNotVerified
+	}
+	user := model.User{}
+	err = ou.ur.GetUserByEmail(&user, claims.Email)
+	switch {
+	case err == nil:
+		if err := ou.takeOverUnverifiedUser(&user); err != nil {
+			return model.User{}, err
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		now := time.Now()
+		user = model.User{
+			Username:   usernameFromClaims(claims),
+			Email:      claims.Email,
+			VerifiedAt: &now,
+		}
+		if err := ou.ur.CreateUser(&user); err != nil {
+			return model.User{}, err
+		}
+	default:
+		return model.User{}, err
+	}
+
+	if err := ou.uir.CreateIdentity(&model.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+// takeOverUnverifiedUser prepares a user whose email address was never
+// verified to be linked. Whoever signed up with the address may not own it,
+// so their password and sessions are revoked before the owner, proven by the
+// provider, is let in.
+func (ou *oidcUsecase) takeOverUnverifiedUser(user *model.User) error {
+	if user.VerifiedAt != nil {
+		return nil
+	}
+	now := time.Now()
+	if err := ou.ur.MarkUserVerified(user.ID, now); err != nil && !errors.Is(err, model.ErrEmailAlreadyVerified) {
+		return err
+	}
+	if err := ou.ur.ClearPassword(user.ID); err != nil {
+		return err
+	}
+	if err := ou.au.LogoutAll(user.ID); err != nil {
+		return err
+	}
+	user.VerifiedAt = &now
+	user.Password = ""
+	return nil
+}
+
+// usernameFromClaims returns the display name at the provider, or the local
+// part of the email address when the provider has none.
+func usernameFromClaims(claims oidc.Claims) string {
+	if name := strings.TrimSpace(claims.Name); name != "" {
+		return name
+	}
+	local, _, _ := strings.Cut(claims.Email, "@")
+	return local
+}
+
+func (ou *oidcUsecase) GetIdentities(userID int) ([]model.UserIdentityResponse, error) {
+	identities := []model.UserIdentity{}
+	if err := ou.uir.GetIdentitiesByUserID(&identities, userID); err != nil {
+		return nil, err
+	}
+	res := make([]model.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		res = append(res, model.UserIdentityResponse{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// UnlinkIdentity removes the identity unless it is the only way left for
+// the user to log in.
+func (ou *oidcUsecase) UnlinkIdentity(userID int, provider string) error {
+	user := model.User{}
+	if err := ou.ur.GetUserByID(&user, userID); err != nil {
+		return err
+	}
+	identities := []model.UserIdentity{}
+	if err := ou.uir.GetIdentitiesByUserID(&identities, userID); err != nil {
+		return err
+	}
+	linked := false
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			linked = true
+		}
+	}
+	if !linked {
+		return model.ErrUserIdentityNotFound
+	}
+	if user.Password == "" && len(identities) == 1 {
+		return model.ErrLastLoginMethod
+	}
+	return ou.uir.DeleteIdentity(userID, provider)
+}
+
+func (ou *oidcUsecase) SweepExpiredLoginStates() (int64, error) {
+	return ou.uir.DeleteExpiredLoginStates(time.Now())
+}
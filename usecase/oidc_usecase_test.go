@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/oidc"
+	"RefrigeratorWatchdog-server/oidc/oidctest"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_oidcUsecase_StartLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+	mockIdentityRepo := mocks.NewMockIUserIdentityRepository(ctrl)
+	ou := &oidcUsecase{
+		uir:       mockIdentityRepo,
+		providers: map[string]oidc.IProvider{"test": issuer.Provider("test", "http://localhost:3000/oauth/callback/test")},
+		stateTTL:  defaultOIDCLoginStateTTL,
+	}
+
+	t.Run("異常系：未設定のプロバイダ", func(t *testing.T) {
+		if _, err := ou.StartLogin("unknown"); !errors.Is(err, model.ErrOIDCProviderNotFound) {
+			t.Errorf("oidcUsecase.StartLogin() error = %v, wantErr %v", err, model.ErrOIDCProviderNotFound)
+		}
+	})
+
+	t.Run("正常系：stateのハッシュとPKCEの検証値を保存する", func(t *testing.T) {
+		var stored model.OIDCLoginState
+		mockIdentityRepo.EXPECT().CreateLoginState(gomock.Any()).Do(func(state *model.OIDCLoginState) {
+			stored = *state
+		}).Return(nil).Times(1)
+
+		got, err := ou.StartLogin("test")
+		if err != nil {
+			t.Fatalf("oidcUsecase.StartLogin() error = %v", err)
+		}
+		if stored.StateHash != hashToken(got.State) || stored.Provider != "test" || stored.Nonce == "" || stored.CodeVerifier == "" {
+			t.Errorf("stored state = %+v", stored)
+		}
+		if got.Binding == "" || got.Binding == got.State || stored.BindingHash != hashToken(got.Binding) {
+			t.Errorf("stored state = %+v", stored)
+		}
+		if !stored.ExpiresAt.After(time.Now()) {
+			t.Errorf("stored state expires at %v", stored.ExpiresAt)
+		}
+		// 発行者側ではPKCEのチャレンジとstateが受け取れる
+		if _, state, err := issuer.Authorize(got.AuthorizationURL, oidctest.User{Subject: "1234"}); err != nil || state != got.State {
+			t.Errorf("Issuer.Authorize() state = %v, error = %v", state, err)
+		}
+	})
+}
+
+func Test_oidcUsecase_CompleteLogin(t *testing.T) {
+	verifiedAt := time.Now()
+	enabledAt := time.Now()
+	verifiedUser := model.User{ID: 1, Username: "山田太郎", Email: "sample@test.com", Password: "hashed", VerifiedAt: &verifiedAt}
+	unverifiedUser := model.User{ID: 1, Username: "山田太郎", Email: "sample@test.com", Password: "hashed"}
+	twoFactorUser := model.User{ID: 1, Username: "山田太郎", Email: "sample@test.com", VerifiedAt: &verifiedAt, TOTPEnabledAt: &enabledAt}
+	providerUser := oidctest.User{Subject: "1234", Email: "sample@test.com", EmailVerified: true, Name: "山田太郎"}
+
+	tests := []struct {
+		name        string
+		user        oidctest.User
+		prepare     func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase)
+		wantErr     error
+		wantMFA     bool
+		wantUserID  int
+		wantCreated bool
+	}{
+		{
+			name: "正常系：連携済みのアカウントでログインできる",
+			user: providerUser,
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").SetArg(0, model.UserIdentity{ID: 1, UserID: 1, Email: "sample@test.com", User: verifiedUser}).Return(nil)
+				au.EXPECT().IssueTokens(verifiedUser).Return(model.TokenResponse{AccessToken: "access"}, nil)
+			},
+			wantUserID: 1,
+		},
+		{
+			name: "正常系：確認済みのメールアドレスで既存ユーザーに連携する",
+			user: providerUser,
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").Return(gorm.ErrRecordNotFound)
+				ur.EXPECT().GetUserByEmail(gomock.Any(), "sample@test.com").SetArg(0, verifiedUser).Return(nil)
+				uir.EXPECT().CreateIdentity(&model.UserIdentity{UserID: 1, Provider: "test", Subject: "1234", Email: "sample@test.com"}).Return(nil)
+				au.EXPECT().IssueTokens(verifiedUser).Return(model.TokenResponse{AccessToken: "access"}, nil)
+			},
+			wantUserID: 1,
+		},
+		{
+			name: "正常系：未確認の既存ユーザーはパスワードとセッションを無効にしてから連携する",
+			user: providerUser,
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").Return(gorm.ErrRecordNotFound)
+				ur.EXPECT().GetUserByEmail(gomock.Any(), "sample@test.com").SetArg(0, unverifiedUser).Return(nil)
+				ur.EXPECT().MarkUserVerified(1, gomock.Any()).Return(nil)
+				ur.EXPECT().ClearPassword(1).Return(nil)
+				au.EXPECT().LogoutAll(1).Return(nil)
+				uir.EXPECT().CreateIdentity(gomock.Any()).Return(nil)
+				au.EXPECT().IssueTokens(gomock.Any()).DoAndReturn(func(user model.User) (model.TokenResponse, error) {
+					if user.Password != "" || user.VerifiedAt == nil {
+						t.Errorf("IssueTokens() user = %+v", user)
+					}
+					return model.TokenResponse{AccessToken: "access"}, nil
+				})
+			},
+			wantUserID: 1,
+		},
+		{
+			name: "正常系：未登録のメールアドレスならユーザーを作成する",
+			user: providerUser,
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").Return(gorm.ErrRecordNotFound)
+				ur.EXPECT().GetUserByEmail(gomock.Any(), "sample@test.com").Return(gorm.ErrRecordNotFound)
+				ur.EXPECT().CreateUser(gomock.Any()).Do(func(user *model.User) {
+					if user.Username != "山田太郎" || user.Email != "sample@test.com" || user.Password != "" || user.VerifiedAt == nil {
+						t.Errorf("CreateUser() user = %+v", user)
+					}
+					user.ID = 2
+				}).Return(nil)
+				uir.EXPECT().CreateIdentity(&model.UserIdentity{UserID: 2, Provider: "test", Subject: "1234", Email: "sample@test.com"}).Return(nil)
+				au.EXPECT().IssueTokens(gomock.Any()).Return(model.TokenResponse{AccessToken: "access"}, nil)
+			},
+			wantUserID: 2,
+		},
+		{
+			name: "異常系：プロバイダが確認していないメールアドレスでは連携しない",
+			user: oidctest.User{Subject: "1234", Email: "sample@test.com", EmailVerified: false},
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").Return(gorm.ErrRecordNotFound)
+			},
+			wantErr: model.ErrOIDCEmailNotVerified,
+		},
+		{
+			name: "正常系：二要素認証が有効なら2段階目を求める",
+			user: providerUser,
+			prepare: func(ur *mocks.MockIUserRepository, uir *mocks.MockIUserIdentityRepository, au *usecasemocks.MockIAuthUsecase) {
+				uir.EXPECT().GetIdentity(gomock.Any(), "test", "1234").SetArg(0, model.UserIdentity{ID: 1, UserID: 1, Email: "sample@test.com", User: twoFactorUser}).Return(nil)
+				au.EXPECT().IssueMFAToken(twoFactorUser).Return("mfa", nil)
+			},
+			wantMFA:    true,
+			wantUserID: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			issuer := oidctest.NewIssuer("client", "secret")
+			defer issuer.Close()
+			mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+			mockIdentityRepo := mocks.NewMockIUserIdentityRepository(ctrl)
+			mockAuth := usecasemocks.NewMockIAuthUsecase(ctrl)
+			ou := &oidcUsecase{
+				ur:        mockUserRepo,
+				uir:       mockIdentityRepo,
+				au:        mockAuth,
+				providers: map[string]oidc.IProvider{"test": issuer.Provider("test", "http://localhost:3000/oauth/callback/test")},
+				stateTTL:  defaultOIDCLoginStateTTL,
+			}
+
+			var stored model.OIDCLoginState
+			mockIdentityRepo.EXPECT().CreateLoginState(gomock.Any()).Do(func(state *model.OIDCLoginState) {
+				stored = *state
+			}).Return(nil)
+			start, err := ou.StartLogin("test")
+			if err != nil {
+				t.Fatalf("oidcUsecase.StartLogin() error = %v", err)
+			}
+			code, state, err := issuer.Authorize(start.AuthorizationURL, tt.user)
+			if err != nil {
+				t.Fatalf("Issuer.Authorize() error = %v", err)
+			}
+			mockIdentityRepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken(state), "test").SetArg(0, stored).Return(nil)
+			tt.prepare(mockUserRepo, mockIdentityRepo, mockAuth)
+
+			got, err := ou.CompleteLogin("test", code, state, start.Binding)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("oidcUsecase.CompleteLogin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.MFARequired != tt.wantMFA || (got.TokenResponse == nil) != tt.wantMFA {
+				t.Errorf("oidcUsecase.CompleteLogin() = %+v", got)
+			}
+			if got.User.ID != tt.wantUserID {
+				t.Errorf("oidcUsecase.CompleteLogin() user = %v, want %v", got.User.ID, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func Test_oidcUsecase_CompleteLogin_InvalidState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+	mockIdentityRepo := mocks.NewMockIUserIdentityRepository(ctrl)
+	ou := &oidcUsecase{
+		uir:       mockIdentityRepo,
+		providers: map[string]oidc.IProvider{"test": issuer.Provider("test", "http://localhost:3000/oauth/callback/test")},
+	}
+
+	mockIdentityRepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken("forged"), "test").Return(model.ErrInvalidToken)
+	if _, err := ou.CompleteLogin("test", "code", "forged", "binding"); !errors.Is(err, model.ErrInvalidToken) {
+		t.Errorf("oidcUsecase.CompleteLogin() error = %v, wantErr %v", err, model.ErrInvalidToken)
+	}
+
+	// 別のブラウザで始めたログインは、state が正しくても完了できない
+	loginState := model.OIDCLoginState{BindingHash: hashToken("binding"), Nonce: "nonce", CodeVerifier: "verifier"}
+	for _, binding := range []string{"other", ""} {
+		mockIdentityRepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken("state"), "test").SetArg(0, loginState).Return(nil)
+		if _, err := ou.CompleteLogin("test", "code", "state", binding); !errors.Is(err, model.ErrInvalidToken) {
+			t.Errorf("oidcUsecase.CompleteLogin() binding %q error = %v, wantErr %v", binding, err, model.ErrInvalidToken)
+		}
+	}
+
+	// 発行者が認可コードを拒否した場合は認証失敗として扱う
+	mockIdentityRepo.EXPECT().ConsumeLoginState(gomock.Any(), hashToken("state"), "test").SetArg(0, loginState).Return(nil)
+	if _, err := ou.CompleteLogin("test", "unknown-code", "state", "binding"); !errors.Is(err, model.ErrUnauthorized) {
+		t.Errorf("oidcUsecase.CompleteLogin() error = %v, wantErr %v", err, model.ErrUnauthorized)
+	}
+}
+
+func Test_oidcUsecase_UnlinkIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockIUserRepository(ctrl)
+	mockIdentityRepo := mocks.NewMockIUserIdentityRepository(ctrl)
+
+	google := model.UserIdentity{ID: 1, UserID: 1, Provider: "google"}
+	line := model.UserIdentity{ID: 2, UserID: 1, Provider: "line"}
+
+	tests := []struct {
+		name       string
+		password   string
+		identities []model.UserIdentity
+		wantDelete bool
+		wantErr    error
+	}{
+		{name: "正常系：パスワードがあれば最後の連携も解除できる", password: "hashed", identities: []model.UserIdentity{google}, wantDelete: true},
+		{name: "正常系：他の連携が残るなら解除できる", identities: []model.UserIdentity{google, line}, wantDelete: true},
+		{name: "異常系：パスワードがなければ最後の連携は解除できない", identities: []model.UserIdentity{google}, wantErr: model.ErrLastLoginMethod},
+		{name: "異常系：連携していないプロバイダ", password: "hashed", identities: []model.UserIdentity{line}, wantErr: model.ErrUserIdentityNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ou := &oidcUsecase{ur: mockUserRepo, uir: mockIdentityRepo}
+
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), 1).SetArg(0, model.User{ID: 1, Password: tt.password}).Return(nil)
+			mockIdentityRepo.EXPECT().GetIdentitiesByUserID(gomock.Any(), 1).SetArg(0, tt.identities).Return(nil)
+			if tt.wantDelete {
+				mockIdentityRepo.EXPECT().DeleteIdentity(1, "google").Return(nil)
+			}
+
+			if err := ou.UnlinkIdentity(1, "google"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("oidcUsecase.UnlinkIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"RefrigeratorWatchdog-server/webpush"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// pushTTL is how long push services keep a notification for a browser
+	// that is offline. Expiry alerts are stale after a day.
+	pushTTL = 24 * time.Hour
+	// maxPushBodyLength keeps the payload well within webpush.MaxPayloadSize.
+	maxPushBodyLength = 1000
+)
+
+// IPushUsecase manages the Web Push subscriptions of users and sends
+// notifications to their browsers.
+type IPushUsecase interface {
+	GetVAPIDPublicKey() model.VAPIDPublicKeyResponse
+	Subscribe(userID int, sub model.PushSubscriptionRequest, userAgent string) error
+	Unsubscribe(userID int, endpoint string) error
+	// Notify sends the notification to every browser the user subscribed,
+	// so that the usecase can be used as a notifier.INotifier. Subscriptions
+	// that the push service reports as gone are deleted. It fails only when
+	// no browser could be reached.
+	Notify(notification notifier.Notification) error
+}
+
+type pushUsecase struct {
+	pr        repository.IPushSubscriptionRepository
+	pv        validator.IPushValidator
+	s         webpush.ISender
+	publicKey string
+}
+
+// NewPushUsecase creates a new instance of the pushUsecase struct.
+// VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY set the VAPID key pair; without them
+// a key pair is generated on the first start and stored in the database.
+// VAPID_SUBJECT is the contact URL sent to push services, by default
+// "mailto:" followed by MAIL_FROM.
+func NewPushUsecase(pr repository.IPushSubscriptionRepository, pv validator.IPushValidator) IPushUsecase {
+	keys, err := loadVAPIDKeys(pr)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	s, err := webpush.NewSender(keys, vapidSubject(), nil)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return &pushUsecase{pr, pv, s, keys.PublicKey}
+}
+
+// loadVAPIDKeys returns the keys set in the environment or stored in the database.
+func loadVAPIDKeys(pr repository.IPushSubscriptionRepository) (webpush.VAPIDKeys, error) {
+	publicKey, privateKey := os.Getenv("VAPID_PUBLIC_KEY"), os.Getenv("VAPID_PRIVATE_KEY")
+	if publicKey != "" || privateKey != "" {
+		if publicKey == "" || privateKey == "" {
+			return webpush.VAPIDKeys{}, errors.New("VAPID_PUBLIC_KEY and VAPID_PRIVATE_KEY must be set together")
+		}
+		return webpush.VAPIDKeys{PublicKey: publicKey, PrivateKey: privateKey}, nil
+	}
+
+	generated, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		return webpush.VAPIDKeys{}, err
+	}
+	// 他のレプリカが先に保存していれば、そちらの鍵を使う
+	key := model.VAPIDKey{PublicKey: generated.PublicKey, PrivateKey: generated.PrivateKey}
+	if err := pr.GetOrCreateVAPIDKey(&key); err != nil {
+		return webpush.VAPIDKeys{}, err
+	}
+	return webpush.VAPIDKeys{PublicKey: key.PublicKey, PrivateKey: key.PrivateKey}, nil
+}
+
+func vapidSubject() string {
+	if s := os.Getenv("VAPID_SUBJECT"); s != "" {
+		return s
+	}
+	if from := os.Getenv("MAIL_FROM"); from != "" {
+		return "mailto:" + from
+	}
+	return frontendURL()
+}
+
+func (pu *pushUsecase) GetVAPIDPublicKey() model.VAPIDPublicKeyResponse {
+	return model.VAPIDPublicKeyResponse{PublicKey: pu.publicKey}
+}
+
+func (pu *pushUsecase) Subscribe(userID int, req model.PushSubscriptionRequest, userAgent string) error {
+	if err := pu.pv.ValidatePushSubscription(req); err != nil {
+		return err
+	}
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	return pu.pr.SaveSubscription(&model.PushSubscription{
+		UserID:       userID,
+		Endpoint:     req.Endpoint,
+		EndpointHash: hashToken(req.Endpoint),
+		P256dh:       req.Keys.P256dh,
+		Auth:         req.Keys.Auth,
+		UserAgent:    userAgent,
+	})
+}
+
+func (pu *pushUsecase) Unsubscribe(userID int, endpoint string) error {
+	if endpoint == "" {
+		return model.ErrPushSubscriptionNotFound
+	}
+	return pu.pr.DeleteSubscription(userID, hashToken(endpoint))
+}
+
+// pushPayload is the JSON sent to the service worker of the frontend.
+type pushPayload struct {
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	Kind    string `json:"kind"`
+	URL     string `json:"url"`
+	FoodIDs []int  `json:"food_ids,omitempty"`
+}
+
+// newPushPayload returns the payload of the notification. Foods are listed
+// by name, as the payload must stay small.
+func newPushPayload(notification notifier.Notification) pushPayload {
+	payload := pushPayload{
+		Title: notification.Subject,
+		Body:  notification.Body,
+		Kind:  notification.Kind,
+		URL:   frontendURL(),
+	}
+	if notification.Summary != "" {
+		payload.Body = notification.Summary
+	}
+	if len(notification.Foods) > 0 {
+		names := []string{}
+		for _, food := range notification.Foods {
+			when := fmt.Sprintf("あと%d日", food.DaysRemaining)
+			if food.DaysRemaining == 0 {
+				when = "今日まで"
+			}
+			names = append(names, fmt.Sprintf("%s（%s）", food.Name, when))
+			payload.FoodIDs = append(payload.FoodIDs, food.FoodID)
+		}
+		payload.Body = strings.Join(names, "、")
+	}
+	payload.Body = truncateUTF8(payload.Body, maxPushBodyLength)
+	return payload
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func (pu *pushUsecase) Notify(notification notifier.Notification) error {
+	subs := []model.PushSubscription{}
+	if err := pu.pr.GetSubscriptionsByUserID(&subs, notification.UserID); err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(newPushPayload(notification))
+	if err != nil {
+		return err
+	}
+
+	sent, failed := 0, []error{}
+	for _, sub := range subs {
+		code, err := pu.s.Send(webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, webpush.Message{
+			Payload: payload,
+			TTL:     pushTTL,
+			Urgency: webpush.UrgencyNormal,
+		})
+		switch {
+		case err == nil:
+			sent++
+		case webpush.Gone(code):
+			// ブラウザ側で購読が解除されたか失効している
+			if err := pu.pr.DeleteSubscriptionByID(sub.ID); err != nil {
+				log.Println("failed to delete push subscription:", err)
+			}
+		default:
+			failed = append(failed, err)
+		}
+	}
+	if sent == 0 && len(failed) > 0 {
+		return errors.Join(failed...)
+	}
+	return nil
+}
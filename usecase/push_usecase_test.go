@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/notifier"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"RefrigeratorWatchdog-server/webpush"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// RFC 8291 Appendix A の購読キー
+const (
+	testP256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	testAuth   = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+func Test_pushUsecase_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		req      model.PushSubscriptionRequest
+		mockCall bool
+		wantErr  bool
+	}{
+		{
+			name:     "正常系：購読を登録できる",
+			req:      model.PushSubscriptionRequest{Endpoint: "https://push.example.com/abc", Keys: model.PushSubscriptionKeys{P256dh: testP256dh, Auth: testAuth}},
+			mockCall: true,
+		},
+		{
+			name:    "異常系：https ではないエンドポイント",
+			req:     model.PushSubscriptionRequest{Endpoint: "http://push.example.com/abc", Keys: model.PushSubscriptionKeys{P256dh: testP256dh, Auth: testAuth}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：auth の長さが不正",
+			req:     model.PushSubscriptionRequest{Endpoint: "https://push.example.com/abc", Keys: model.PushSubscriptionKeys{P256dh: testP256dh, Auth: "AAAA"}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：p256dh が公開鍵ではない",
+			req:     model.PushSubscriptionRequest{Endpoint: "https://push.example.com/abc", Keys: model.PushSubscriptionKeys{P256dh: testAuth, Auth: testAuth}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIPushSubscriptionRepository(ctrl)
+			if tt.mockCall {
+				mockRepo.EXPECT().SaveSubscription(gomock.Any()).DoAndReturn(func(sub *model.PushSubscription) error {
+					if sub.UserID != 1 || sub.EndpointHash != hashToken(tt.req.Endpoint) || len(sub.UserAgent) != maxUserAgentLength {
+						t.Errorf("SaveSubscription() = %+v", sub)
+					}
+					return nil
+				}).Times(1)
+			}
+			pu := &pushUsecase{pr: mockRepo, pv: validator.NewPushValidator(), s: webpush.NewMemorySender()}
+
+			err := pu.Subscribe(1, tt.req, strings.Repeat("a", 300))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("pushUsecase.Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_pushUsecase_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subs := []model.PushSubscription{
+		{ID: 1, UserID: 1, Endpoint: "https://push.example.com/1", P256dh: testP256dh, Auth: testAuth},
+		{ID: 2, UserID: 1, Endpoint: "https://push.example.com/2", P256dh: testP256dh, Auth: testAuth},
+	}
+	notification := notifier.Notification{
+		UserID:  1,
+		Kind:    notifier.KindFoodExpiring,
+		Subject: "賞味期限が近い食材があります",
+		Foods: []notifier.ExpiringFood{
+			{FoodID: 10, Name: "牛乳", DaysRemaining: 1},
+			{FoodID: 11, Name: "卵", DaysRemaining: 0},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		subs        []model.PushSubscription
+		codes       map[string]int
+		wantDeleted []int
+		wantSent    int
+		wantErr     bool
+	}{
+		{name: "正常系：すべてのブラウザに送信できる", subs: subs, wantSent: 2},
+		{name: "正常系：購読がなければ何もしない", subs: []model.PushSubscription{}},
+		{
+			name:        "正常系：410 の購読は削除される",
+			subs:        subs,
+			codes:       map[string]int{"https://push.example.com/1": http.StatusGone},
+			wantDeleted: []int{1},
+			wantSent:    1,
+		},
+		{
+			name:        "正常系：404 の購読も削除され、エラーにはならない",
+			subs:        subs,
+			codes:       map[string]int{"https://push.example.com/1": http.StatusNotFound, "https://push.example.com/2": http.StatusGone},
+			wantDeleted: []int{1, 2},
+		},
+		{
+			name:    "異常系：どのブラウザにも送信できない",
+			subs:    subs,
+			codes:   map[string]int{"https://push.example.com/1": http.StatusTooManyRequests, "https://push.example.com/2": http.StatusInternalServerError},
+			wantErr: true,
+		},
+		{
+			name:     "正常系：一部のブラウザに送信できればエラーにはならない",
+			subs:     subs,
+			codes:    map[string]int{"https://push.example.com/1": http.StatusTooManyRequests},
+			wantSent: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIPushSubscriptionRepository(ctrl)
+			mockRepo.EXPECT().GetSubscriptionsByUserID(gomock.Any(), 1).SetArg(0, tt.subs).Return(nil).Times(1)
+			for _, id := range tt.wantDeleted {
+				mockRepo.EXPECT().DeleteSubscriptionByID(id).Return(nil).Times(1)
+			}
+			ms := webpush.NewMemorySender()
+			for endpoint, code := range tt.codes {
+				ms.Codes[endpoint] = code
+			}
+			pu := &pushUsecase{pr: mockRepo, pv: validator.NewPushValidator(), s: ms}
+
+			err := pu.Notify(notification)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pushUsecase.Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			sent := ms.Sent()
+			if len(sent) != tt.wantSent {
+				t.Fatalf("sent = %v, want %v", len(sent), tt.wantSent)
+			}
+			for _, m := range sent {
+				payload := pushPayload{}
+				if err := json.Unmarshal(m.Message.Payload, &payload); err != nil {
+					t.Fatal(err)
+				}
+				if payload.Title != notification.Subject || payload.Body != "牛乳（あと1日）、卵（今日まで）" || len(payload.FoodIDs) != 2 {
+					t.Errorf("payload = %+v", payload)
+				}
+				if m.Message.TTL != pushTTL {
+					t.Errorf("ttl = %v", m.Message.TTL)
+				}
+			}
+		})
+	}
+}
+
+func Test_truncateUTF8(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		n    int
+		want string
+	}{
+		{name: "正常系：短い文字列はそのまま", s: "牛乳", n: 10, want: "牛乳"},
+		{name: "正常系：文字の途中で切らない", s: "牛乳", n: 4, want: "牛"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateUTF8(tt.s, tt.n); got != tt.want {
+				t.Errorf("truncateUTF8() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	defaultTOTPIssuer = "RefrigeratorWatchdog"
+	totpPeriod        = 30 * time.Second
+	// totpSkew is the number of time steps accepted before and after the current one.
+	totpSkew          = 1
+	recoveryCodeCount = 10
+)
+
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// ITwoFactorUsecase manages TOTP two-factor authentication and recovery codes.
+type ITwoFactorUsecase interface {
+	EnrollTOTP(userID int) (model.TOTPEnrollResponse, error)
+	ConfirmTOTP(userID int, code string) (model.RecoveryCodesResponse, error)
+	// VerifyCode accepts either a TOTP code or an unused recovery code.
+	VerifyCode(user model.User, code string) error
+	Disable(userID int) error
+}
+
+type twoFactorUsecase struct {
+	ur     repository.IUserRepository
+	rcr    repository.IRecoveryCodeRepository
+	issuer string
+}
+
+// NewTwoFactorUsecase creates a new instance of the twoFactorUsecase struct.
+// The issuer shown in authenticator apps is read from TOTP_ISSUER.
+func NewTwoFactorUsecase(ur repository.IUserRepository, rcr repository.IRecoveryCodeRepository) ITwoFactorUsecase {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+	return &twoFactorUsecase{ur, rcr, issuer}
+}
+
+// EnrollTOTP generates a new pending secret. Two-factor authentication is not
+// required at login until the secret is confirmed with ConfirmTOTP.
+func (tu *twoFactorUsecase) EnrollTOTP(userID int) (model.TOTPEnrollResponse, error) {
+	user := model.User{}
+	if err := tu.ur.GetUserByID(&user, userID); err != nil {
+		return model.TOTPEnrollResponse{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return model.TOTPEnrollResponse{}, model.ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      tu.issuer,
+		AccountName: user.Email,
+		Period:      uint(totpPeriod.Seconds()),
+	})
+	if err != nil {
+		return model.TOTPEnrollResponse{}, err
+	}
+	if err := tu.ur.SetTOTPSecret(user.ID, key.Secret()); err != nil {
+		return model.TOTPEnrollResponse{}, err
+	}
+
+	return model.TOTPEnrollResponse{
+		Secret:     key.Secret(),
+		OtpauthURL: key.URL(),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication when the code matches the
+// pending secret and returns a new set of recovery codes.
+func (tu *twoFactorUsecase) ConfirmTOTP(userID int, code string) (model.RecoveryCodesResponse, error) {
+	user := model.User{}
+	if err := tu.ur.GetUserByID(&user, userID); err != nil {
+		return model.RecoveryCodesResponse{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return model.RecoveryCodesResponse{}, model.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return model.RecoveryCodesResponse{}, model.ErrTwoFactorNotEnrolled
+	}
+
+	counter, ok := verifyTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return model.RecoveryCodesResponse{}, model.ErrInvalidTOTPCode
+	}
+	if err := tu.ur.EnableTOTP(user.ID, counter, time.Now()); err != nil {
+		return model.RecoveryCodesResponse{}, err
+	}
+
+	codes, err := tu.generateRecoveryCodes(user.ID)
+	if err != nil {
+		return model.RecoveryCodesResponse{}, err
+	}
+	return model.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (tu *twoFactorUsecase) VerifyCode(user model.User, code string) error {
+	if user.TOTPEnabledAt == nil {
+		return model.ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		counter, ok := verifyTOTP(user.TOTPSecret, code, time.Now())
+		if !ok {
+			return model.ErrInvalidTOTPCode
+		}
+		// 同じコードを二度使えないように、使用済みのタイムステップを記録する
+		return tu.ur.UseTOTPCounter(user.ID, counter)
+	}
+	return tu.rcr.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+}
+
+func (tu *twoFactorUsecase) Disable(userID int) error {
+	if err := tu.ur.DisableTOTP(userID); err != nil {
+		return err
+	}
+	return tu.rcr.DeleteRecoveryCodes(userID)
+}
+
+// generateRecoveryCodes replaces the recovery codes of the user and returns
+// them in plain text. Only their hashes are stored.
+func (tu *twoFactorUsecase) generateRecoveryCodes(userID int) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]model.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)
+		codes = append(codes, code[:4]+"-"+code[4:])
+		records = append(records, model.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashToken(code),
+		})
+	}
+	if err := tu.rcr.ReplaceRecoveryCodes(userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyTOTP checks the code against the time steps around now and returns
+// the matching time step.
+func verifyTOTP(secret string, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / int64(totpPeriod.Seconds())
+	for counter := current - totpSkew; counter <= current+totpSkew; counter++ {
+		expected, err := hotp.GenerateCodeCustom(secret, uint64(counter), hotp.ValidateOpts{
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// isTOTPCode reports whether the code looks like a 6-digit TOTP code rather
+// than a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRecoveryCode removes separators so that "ABCD-EFGH" and "abcdefgh"
+// are treated as the same code.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
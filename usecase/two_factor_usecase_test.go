@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"go.uber.org/mock/gomock"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+func Test_verifyTOTP(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 15, 0, time.UTC)
+	counter := now.Unix() / 30
+
+	code := func(t time.Time) string {
+		c, err := totp.GenerateCode(testTOTPSecret, t)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name        string
+		code        string
+		wantCounter int64
+		wantOK      bool
+	}{
+		{name: "正常系：現在のコードを受け付ける", code: code(now), wantCounter: counter, wantOK: true},
+		{name: "正常系：1つ前のコードを受け付ける", code: code(now.Add(-30 * time.Second)), wantCounter: counter - 1, wantOK: true},
+		{name: "正常系：1つ後のコードを受け付ける", code: code(now.Add(30 * time.Second)), wantCounter: counter + 1, wantOK: true},
+		{name: "異常系：2つ前のコードは受け付けない", code: code(now.Add(-60 * time.Second)), wantOK: false},
+		{name: "異常系：不正なコード", code: "abcdef", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := verifyTOTP(testTOTPSecret, tt.code, now)
+			if ok != tt.wantOK || got != tt.wantCounter {
+				t.Errorf("verifyTOTP() = %v, %v, want %v, %v", got, ok, tt.wantCounter, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_twoFactorUsecase_EnrollTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+
+	enabledAt := time.Now()
+
+	tests := []struct {
+		name    string
+		user    model.User
+		wantErr error
+	}{
+		{
+			name:    "正常系：シークレットと otpauth URI を取得できる",
+			user:    model.User{ID: 1, Email: "sample@test.com"},
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：有効化済みの場合は登録できない",
+			user:    model.User{ID: 1, Email: "sample@test.com", TOTPSecret: testTOTPSecret, TOTPEnabledAt: &enabledAt},
+			wantErr: model.ErrTwoFactorAlreadyEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := &twoFactorUsecase{ur: mockRepo, issuer: "RefrigeratorWatchdog"}
+
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Do(func(user *model.User, id int) {
+				*user = tt.user
+			}).Return(nil).Times(1)
+			var stored string
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().SetTOTPSecret(tt.user.ID, gomock.Any()).Do(func(id int, secret string) {
+					stored = secret
+				}).Return(nil).Times(1)
+			}
+
+			got, err := tu.EnrollTOTP(tt.user.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("twoFactorUsecase.EnrollTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Secret == "" || got.Secret != stored {
+				t.Errorf("twoFactorUsecase.EnrollTOTP() secret = %v, stored %v", got.Secret, stored)
+			}
+			if !strings.HasPrefix(got.OtpauthURL, "otpauth://totp/RefrigeratorWatchdog:sample@test.com?") || !strings.Contains(got.OtpauthURL, "secret="+got.Secret) {
+				t.Errorf("twoFactorUsecase.EnrollTOTP() otpauth_url = %v", got.OtpauthURL)
+			}
+		})
+	}
+}
+
+func Test_twoFactorUsecase_ConfirmTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockCodeRepo := mocks.NewMockIRecoveryCodeRepository(ctrl)
+
+	validCode, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	if err != nil {
+		t.Fatalf("totp.GenerateCode() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		user    model.User
+		code    string
+		wantErr error
+	}{
+		{
+			name:    "正常系：コードを確認して二要素認証を有効にできる",
+			user:    model.User{ID: 1, TOTPSecret: testTOTPSecret},
+			code:    validCode,
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：コードが間違っている",
+			user:    model.User{ID: 1, TOTPSecret: testTOTPSecret},
+			code:    "000000",
+			wantErr: model.ErrInvalidTOTPCode,
+		},
+		{
+			name:    "異常系：登録を開始していない",
+			user:    model.User{ID: 1},
+			code:    validCode,
+			wantErr: model.ErrTwoFactorNotEnrolled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 偶然 "000000" が有効なコードになる場合はスキップする
+			if tt.code == "000000" && validCode == "000000" {
+				t.Skip()
+			}
+			tu := &twoFactorUsecase{ur: mockRepo, rcr: mockCodeRepo}
+
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Do(func(user *model.User, id int) {
+				*user = tt.user
+			}).Return(nil).Times(1)
+			var stored []model.RecoveryCode
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().EnableTOTP(tt.user.ID, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockCodeRepo.EXPECT().ReplaceRecoveryCodes(tt.user.ID, gomock.Any()).Do(func(userID int, codes []model.RecoveryCode) {
+					stored = codes
+				}).Return(nil).Times(1)
+			}
+
+			got, err := tu.ConfirmTOTP(tt.user.ID, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("twoFactorUsecase.ConfirmTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got.RecoveryCodes) != recoveryCodeCount || len(stored) != recoveryCodeCount {
+				t.Fatalf("twoFactorUsecase.ConfirmTOTP() returned %d codes, stored %d", len(got.RecoveryCodes), len(stored))
+			}
+			// 平文ではなくハッシュが保存される
+			for i, code := range got.RecoveryCodes {
+				if stored[i].CodeHash != hashToken(normalizeRecoveryCode(code)) {
+					t.Errorf("twoFactorUsecase.ConfirmTOTP() stored %v for code %v", stored[i].CodeHash, code)
+				}
+			}
+		})
+	}
+}
+
+func Test_twoFactorUsecase_VerifyCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockCodeRepo := mocks.NewMockIRecoveryCodeRepository(ctrl)
+
+	enabledAt := time.Now()
+	user := model.User{ID: 1, TOTPSecret: testTOTPSecret, TOTPEnabledAt: &enabledAt}
+
+	validCode, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	if err != nil {
+		t.Fatalf("totp.GenerateCode() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		user       model.User
+		code       string
+		useCounter error
+		useCode    error
+		wantErr    error
+	}{
+		{
+			name:    "正常系：TOTP コードで認証できる",
+			user:    user,
+			code:    validCode,
+			wantErr: nil,
+		},
+		{
+			name:       "異常系：使用済みの TOTP コードは使えない",
+			user:       user,
+			code:       validCode,
+			useCounter: model.ErrInvalidTOTPCode,
+			wantErr:    model.ErrInvalidTOTPCode,
+		},
+		{
+			name:    "正常系：リカバリーコードで認証できる",
+			user:    user,
+			code:    "ABCD-EFGH",
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：使用済みのリカバリーコードは使えない",
+			user:    user,
+			code:    "abcd-efgh",
+			useCode: model.ErrInvalidTOTPCode,
+			wantErr: model.ErrInvalidTOTPCode,
+		},
+		{
+			name:    "異常系：二要素認証が無効",
+			user:    model.User{ID: 1},
+			code:    validCode,
+			wantErr: model.ErrTwoFactorNotEnabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tu := &twoFactorUsecase{ur: mockRepo, rcr: mockCodeRepo}
+
+			if tt.user.TOTPEnabledAt != nil {
+				if isTOTPCode(tt.code) {
+					mockRepo.EXPECT().UseTOTPCounter(tt.user.ID, gomock.Any()).Return(tt.useCounter).Times(1)
+				} else {
+					mockCodeRepo.EXPECT().UseRecoveryCode(tt.user.ID, hashToken("abcdefgh")).Return(tt.useCode).Times(1)
+				}
+			}
+
+			if err := tu.VerifyCode(tt.user, tt.code); !errors.Is(err, tt.wantErr) {
+				t.Errorf("twoFactorUsecase.VerifyCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
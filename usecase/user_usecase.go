@@ -1,26 +1,54 @@
 package usecase
 
 import (
+	"RefrigeratorWatchdog-server/mailer"
 	"RefrigeratorWatchdog-server/model"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
 
 	"RefrigeratorWatchdog-server/repository"
 	"RefrigeratorWatchdog-server/validator"
 
 	"golang.org/x/crypto/bcrypt"
 	"errors"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAccountUnlockTokenTTL           = 24 * time.Hour
+	defaultPasswordResetTokenTTL           = time.Hour
+	defaultEmailVerificationTokenTTL       = 24 * time.Hour
+	defaultEmailVerificationResendInterval = time.Minute
 )
 
 type IUserUsecase interface {
 	GetUserByEmail(email string) (model.UserResponse, error)
-	CreateUser(user model.User) (model.UserResponse, error)
-	UpdateUser(user model.User, email string) (model.UserResponse, error)
-	DeleteUser(user model.User) error
-	LoginUser(user model.User,decodedEmail string) (model.UserResponse, error)
+	CreateUser(user model.User, meta model.RequestMeta) (model.UserResponse, error)
+	UpdateUser(user model.User, email string, meta model.RequestMeta) (model.UserResponse, error)
+	DeleteUser(user model.User, meta model.RequestMeta) error
+	LoginUser(user model.User,decodedEmail string, ip string) (model.LoginResponse, error)
+	ForgotPassword(email string) error
+	ResetPassword(token string, password string, meta model.RequestMeta) error
+	VerifyEmail(token string, meta model.RequestMeta) error
+	ResendVerificationEmail(userID int) error
+	UnlockAccount(token string, meta model.RequestMeta) error
+	LoginUserTwoFactor(mfaToken string, code string, ip string) (model.LoginResponse, error)
+	DisableTwoFactor(userID int, password string, meta model.RequestMeta) error
 }
 
 type userUsecase struct {
-	ur repository.IUserRepository
-	uv validator.IUserValidator
+	ur  repository.IUserRepository
+	uv  validator.IUserValidator
+	au  IAuthUsecase
+	utr repository.IUserTokenRepository
+	m   mailer.IMailer
+	lt  ILoginThrottleUsecase
+	tf  ITwoFactorUsecase
+	al  IAuditUsecase
 }
 
 func hashPassword(password string) string {
@@ -32,8 +60,24 @@ func comparePassword(hashedPassword string, password string) bool {
 	return err == nil
 }
 
-func NewUserUsecase(ur repository.IUserRepository, uv validator.IUserValidator) IUserUsecase {
-	return &userUsecase{ur, uv}
+func NewUserUsecase(ur repository.IUserRepository, uv validator.IUserValidator, au IAuthUsecase, utr repository.IUserTokenRepository, m mailer.IMailer, lt ILoginThrottleUsecase, tf ITwoFactorUsecase, al IAuditUsecase) IUserUsecase {
+	return &userUsecase{ur, uv, au, utr, m, lt, tf, al}
+}
+
+// frontendURL returns the base URL of the frontend used in emailed links.
+func frontendURL() string {
+	if u := os.Getenv("FRONTEND_URL"); u != "" {
+		return u
+	}
+	return "http://localhost:3000"
+}
+
+// apiURL returns the base URL of this server used in emailed links.
+func apiURL() string {
+	if u := os.Getenv("API_URL"); u != "" {
+		return u
+	}
+	return "http://localhost:" + os.Getenv("PORT")
 }
 
 func (uu *userUsecase) GetUserByEmail(email string) (model.UserResponse, error) {
@@ -41,36 +85,71 @@ func (uu *userUsecase) GetUserByEmail(email string) (model.UserResponse, error)
 	if err := uu.ur.GetUserByEmail(&user, email); err != nil {
 		return model.UserResponse{}, err
 	}
+	return userResponse(user), nil
+}
+
+// userResponse converts a user loaded from the database into its response.
+func userResponse(user model.User) model.UserResponse {
 	return model.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		CreatedAt: user.CreatedAt,
-	}, nil
+		ID:               user.ID,
+		Username:         user.Username,
+		Email:            user.Email,
+		CreatedAt:        user.CreatedAt,
+		VerifiedAt:       user.VerifiedAt,
+		TwoFactorEnabled: user.TOTPEnabledAt != nil,
+		TimeZone:         user.TimeZone,
+	}
+}
+
+// userAuditEntry returns the audit log entry of a change to the account of
+// the user, made by the user.
+func userAuditEntry(user model.User, meta model.RequestMeta, action string, before interface{}, after interface{}) model.AuditEntry {
+	return model.AuditEntry{
+		Actor:      model.AuthUser{ID: user.ID, Email: user.Email, Meta: meta},
+		Action:     action,
+		EntityType: model.AuditEntityUser,
+		EntityID:   strconv.Itoa(user.ID),
+		Before:     before,
+		After:      after,
+	}
 }
 
-func (uu *userUsecase) CreateUser(user model.User) (model.UserResponse, error) {
+func (uu *userUsecase) CreateUser(user model.User, meta model.RequestMeta) (model.UserResponse, error) {
 	if err := uu.uv.ValidateUser(user); err != nil {
 		return model.UserResponse{}, err
 	}
 	user.Password = hashPassword(user.Password)
+	user.VerifiedAt = nil
 	if err := uu.ur.CreateUser(&user); err != nil {
 		return model.UserResponse{}, err
 	}
+	uu.al.Record(userAuditEntry(user, meta, model.AuditActionUserCreate, nil, userResponse(user)))
+
+	// メール送信に失敗してもユーザー作成は成功とし、再送信で対応する
+	if err := uu.sendVerificationEmail(user); err != nil {
+		log.Println("failed to send verification email:", err)
+	}
 
 	return model.UserResponse{
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
+		TimeZone:  user.TimeZone,
 	}, nil
 }
 
-func (uu *userUsecase) UpdateUser(user model.User, email string) (model.UserResponse, error) {
+func (uu *userUsecase) UpdateUser(user model.User, email string, meta model.RequestMeta) (model.UserResponse, error) {
 	// ユーザー情報の検証
 	if err := uu.uv.ValidateUser(user); err != nil {
 		return model.UserResponse{}, err
 	}
+	before := model.User{}
+	if err := uu.ur.GetUserByEmail(&before, email); err != nil {
+		return model.UserResponse{}, err
+	}
+	passwordChanged := user.Password != "" && !comparePassword(before.Password, user.Password)
+	emailChanged := user.Email != before.Email
 
 	// パスワードが更新されている場合はハッシュ化
 	if user.Password != "" {
@@ -80,6 +159,34 @@ func (uu *userUsecase) UpdateUser(user model.User, email string) (model.UserResp
 	if err := uu.ur.UpdateUser(&user, email); err != nil {
 		return model.UserResponse{}, err
 	}
+	// 新しいメールアドレスは確認されるまで未確認として扱う
+	if emailChanged {
+		if err := uu.ur.UnmarkUserVerified(before.ID); err != nil {
+			return model.UserResponse{}, err
+		}
+	}
+	after := model.User{}
+	if err := uu.ur.GetUserByID(&after, before.ID); err != nil {
+		return model.UserResponse{}, err
+	}
+	entry := userAuditEntry(after, meta, model.AuditActionUserUpdate, userResponse(before), userResponse(after))
+	if passwordChanged {
+		entry.Redacted = []string{"password"}
+	}
+	uu.al.Record(entry)
+
+	if emailChanged {
+		// メール送信に失敗しても更新は成功とし、再送信で対応する
+		if err := uu.sendVerificationEmail(after); err != nil {
+			log.Println("failed to send verification email:", err)
+		}
+	}
+	// 他の端末のセッションを、パスワード再設定と同じく無効にする
+	if passwordChanged {
+		if err := uu.au.LogoutAll(before.ID); err != nil {
+			return model.UserResponse{}, err
+		}
+	}
 
 	// 更新されたユーザー情報を返す
 	return model.UserResponse{
@@ -87,39 +194,319 @@ func (uu *userUsecase) UpdateUser(user model.User, email string) (model.UserResp
 		Username:  user.Username,
 		Email:     user.Email,
 		CreatedAt: user.CreatedAt,
+		TimeZone:  after.TimeZone,
 	}, nil
 }
 
-func (uu *userUsecase) DeleteUser(user model.User) error {
+func (uu *userUsecase) DeleteUser(user model.User, meta model.RequestMeta) error {
+	if err := uu.lt.Check(user.Email, meta.IP); err != nil {
+		return err
+	}
 	getuser := model.User{}
 	if err := uu.ur.GetUserByEmail(&getuser, user.Email); err != nil {
 		return err
 	}
 	if !comparePassword(getuser.Password, user.Password) {
+		uu.recordLoginFailure(getuser, user.Email, meta.IP)
 		return model.ErrInvalidPassword
 	}
 
 	if err := uu.ur.DeleteUser(&user); err != nil {
 		return err
 	}
+	uu.al.Record(userAuditEntry(getuser, meta, model.AuditActionUserDelete, userResponse(getuser), nil))
 	return nil
 }
 
-func (uu *userUsecase) LoginUser(user model.User,decodedEmail string) (model.UserResponse, error) {
+func (uu *userUsecase) LoginUser(user model.User,decodedEmail string, ip string) (model.LoginResponse, error) {
+	// ロック中は bcrypt の比較を行わずに拒否する
+	if err := uu.lt.Check(decodedEmail, ip); err != nil {
+		return model.LoginResponse{}, err
+	}
 	getuser := model.User{}
 	if err := uu.ur.GetUserByEmail(&getuser, decodedEmail); err != nil {
-		return model.UserResponse{}, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			uu.recordLoginFailure(model.User{}, decodedEmail, ip)
+		}
+		return model.LoginResponse{}, err
 	}
 	if !comparePassword(getuser.Password, user.Password) {
-		return model.UserResponse{}, model.ErrInvalidPassword
+		uu.recordLoginFailure(getuser, decodedEmail, ip)
+		return model.LoginResponse{}, model.ErrInvalidPassword
 	}
 	if user.Username != getuser.Username {
-		return model.UserResponse{}, errors.New("invalid username")
+		uu.recordLoginFailure(getuser, decodedEmail, ip)
+		return model.LoginResponse{}, errors.New("invalid username")
 	}
-	return model.UserResponse{
-		ID:        getuser.ID,
-		Username:  getuser.Username,
-		Email:     getuser.Email,
-		CreatedAt: getuser.CreatedAt,
+
+	// 二要素認証が有効な場合はトークンを発行せず、2段階目のログインを求める
+	if getuser.TOTPEnabledAt != nil {
+		mfaToken, err := uu.au.IssueMFAToken(getuser)
+		if err != nil {
+			return model.LoginResponse{}, err
+		}
+		return model.LoginResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			User:        userResponse(getuser),
+		}, nil
+	}
+
+	return uu.completeLogin(getuser)
+}
+
+// completeLogin resets the failed login counter and issues tokens.
+func (uu *userUsecase) completeLogin(user model.User) (model.LoginResponse, error) {
+	if err := uu.lt.RecordSuccess(user.Email); err != nil {
+		return model.LoginResponse{}, err
+	}
+
+	tokens, err := uu.au.IssueTokens(user)
+	if err != nil {
+		return model.LoginResponse{}, err
+	}
+	return model.LoginResponse{
+		TokenResponse: &tokens,
+		User:          userResponse(user),
 	}, nil
 }
+
+// LoginUserTwoFactor completes a login started by LoginUser with a TOTP code
+// or a recovery code. Wrong codes count as failed login attempts.
+func (uu *userUsecase) LoginUserTwoFactor(mfaToken string, code string, ip string) (model.LoginResponse, error) {
+	userID, err := uu.au.VerifyMFAToken(mfaToken)
+	if err != nil {
+		return model.LoginResponse{}, err
+	}
+	user := model.User{}
+	if err := uu.ur.GetUserByID(&user, userID); err != nil {
+		return model.LoginResponse{}, err
+	}
+	if err := uu.lt.Check(user.Email, ip); err != nil {
+		return model.LoginResponse{}, err
+	}
+
+	if err := uu.tf.VerifyCode(user, code); err != nil {
+		if errors.Is(err, model.ErrInvalidTOTPCode) {
+			uu.recordLoginFailure(user, user.Email, ip)
+		}
+		return model.LoginResponse{}, err
+	}
+
+	return uu.completeLogin(user)
+}
+
+// DisableTwoFactor disables two-factor authentication after checking the
+// current password again.
+func (uu *userUsecase) DisableTwoFactor(userID int, password string, meta model.RequestMeta) error {
+	user := model.User{}
+	if err := uu.ur.GetUserByID(&user, userID); err != nil {
+		return err
+	}
+	if err := uu.lt.Check(user.Email, meta.IP); err != nil {
+		return err
+	}
+	if !comparePassword(user.Password, password) {
+		uu.recordLoginFailure(user, user.Email, meta.IP)
+		return model.ErrInvalidPassword
+	}
+	if err := uu.tf.Disable(user.ID); err != nil {
+		return err
+	}
+
+	after := user
+	after.TOTPEnabledAt = nil
+	uu.al.Record(userAuditEntry(user, meta, model.AuditActionUserTwoFactorDisable, userResponse(user), userResponse(after)))
+	return nil
+}
+
+// ForgotPassword emails a password reset link to the user. It returns nil for
+// unknown emails as well so that callers cannot probe registered addresses.
+func (uu *userUsecase) ForgotPassword(email string) error {
+	user := model.User{}
+	if err := uu.ur.GetUserByEmail(&user, email); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	ttl := durationFromEnv("PASSWORD_RESET_TOKEN_TTL", defaultPasswordResetTokenTTL)
+	token, err := uu.issueUserToken(user.ID, model.UserTokenPurposePasswordReset, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := frontendURL() + "/password/reset?token=" + url.QueryEscape(token)
+	return uu.m.Send(mailer.Mail{
+		To:      user.Email,
+		Subject: "パスワード再設定のご案内",
+		Body: fmt.Sprintf("%s 様\n\n以下のリンクからパスワードを再設定してください。\n%s\n\nこのリンクの有効期限は%d分です。心当たりがない場合はこのメールを破棄してください。\n",
+			user.Username, link, int(ttl.Minutes())),
+	})
+}
+
+// ResetPassword consumes the reset token, sets the new password and revokes
+// every refresh token of the user.
+func (uu *userUsecase) ResetPassword(token string, password string, meta model.RequestMeta) error {
+	if err := uu.uv.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	userToken, err := uu.consumeUserToken(token, model.UserTokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	if err := uu.ur.UpdateUser(&model.User{Password: hashPassword(password)}, userToken.User.Email); err != nil {
+		return err
+	}
+	entry := userAuditEntry(userToken.User, meta, model.AuditActionUserPasswordReset, nil, nil)
+	entry.Redacted = []string{"password"}
+	uu.al.Record(entry)
+	return uu.au.LogoutAll(userToken.UserID)
+}
+
+// issueUserToken stores a new single-use token for the purpose and returns it.
+// Tokens of the same purpose issued before are invalidated.
+func (uu *userUsecase) issueUserToken(userID int, purpose string, ttl time.Duration) (string, error) {
+	if err := uu.utr.InvalidateUserTokens(userID, purpose); err != nil {
+		return "", err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	if err := uu.utr.CreateUserToken(&model.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeUserToken marks a valid token of the purpose as used and returns it.
+func (uu *userUsecase) consumeUserToken(token string, purpose string) (model.UserToken, error) {
+	userToken := model.UserToken{}
+	if err := uu.utr.GetUserTokenByHash(&userToken, hashToken(token), purpose); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.UserToken{}, model.ErrInvalidToken
+		}
+		return model.UserToken{}, err
+	}
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		return model.UserToken{}, model.ErrInvalidToken
+	}
+	if err := uu.utr.UseUserToken(userToken.ID); err != nil {
+		return model.UserToken{}, err
+	}
+	return userToken, nil
+}
+
+// sendVerificationEmail issues a new verification token and emails the link.
+func (uu *userUsecase) sendVerificationEmail(user model.User) error {
+	ttl := durationFromEnv("EMAIL_VERIFICATION_TOKEN_TTL", defaultEmailVerificationTokenTTL)
+	token, err := uu.issueUserToken(user.ID, model.UserTokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := apiURL() + "/users/verify?token=" + url.QueryEscape(token)
+	return uu.m.Send(mailer.Mail{
+		To:      user.Email,
+		Subject: "メールアドレスの確認",
+		Body: fmt.Sprintf("%s 様\n\n以下のリンクからメールアドレスを確認してください。\n%s\n\nこのリンクの有効期限は%d時間です。\n",
+			user.Username, link, int(ttl.Hours())),
+	})
+}
+
+func (uu *userUsecase) VerifyEmail(token string, meta model.RequestMeta) error {
+	userToken, err := uu.consumeUserToken(token, model.UserTokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	verifiedAt := time.Now()
+	if err := uu.ur.MarkUserVerified(userToken.UserID, verifiedAt); err != nil {
+		return err
+	}
+
+	after := userToken.User
+	after.VerifiedAt = &verifiedAt
+	uu.al.Record(userAuditEntry(userToken.User, meta, model.AuditActionUserEmailVerify, userResponse(userToken.User), userResponse(after)))
+	return nil
+}
+
+// ResendVerificationEmail sends the verification email again. Requests made
+// within EMAIL_VERIFICATION_RESEND_INTERVAL of the previous one are rejected.
+func (uu *userUsecase) ResendVerificationEmail(userID int) error {
+	user := model.User{}
+	if err := uu.ur.GetUserByID(&user, userID); err != nil {
+		return err
+	}
+	if user.VerifiedAt != nil {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	latest := model.UserToken{}
+	err := uu.utr.GetLatestUserToken(&latest, userID, model.UserTokenPurposeEmailVerification)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	interval := durationFromEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", defaultEmailVerificationResendInterval)
+	if err == nil && time.Since(latest.CreatedAt) < interval {
+		return model.ErrTooManyRequests
+	}
+
+	return uu.sendVerificationEmail(user)
+}
+
+// recordLoginFailure counts a failed password attempt and emails an unlock
+// link when the account has just been locked. Errors are only logged so that
+// the caller still receives the authentication error.
+func (uu *userUsecase) recordLoginFailure(user model.User, email string, ip string) {
+	locked, err := uu.lt.RecordFailure(email, ip)
+	if err != nil {
+		log.Println("failed to record login failure:", err)
+		return
+	}
+	if locked && user.ID != 0 {
+		if err := uu.sendUnlockEmail(user); err != nil {
+			log.Println("failed to send unlock email:", err)
+		}
+	}
+}
+
+// sendUnlockEmail issues an account unlock token and emails the link.
+func (uu *userUsecase) sendUnlockEmail(user model.User) error {
+	ttl := durationFromEnv("ACCOUNT_UNLOCK_TOKEN_TTL", defaultAccountUnlockTokenTTL)
+	token, err := uu.issueUserToken(user.ID, model.UserTokenPurposeAccountUnlock, ttl)
+	if err != nil {
+		return err
+	}
+
+	link := apiURL() + "/users/unlock?token=" + url.QueryEscape(token)
+	return uu.m.Send(mailer.Mail{
+		To:      user.Email,
+		Subject: "アカウントがロックされました",
+		Body: fmt.Sprintf("%s 様\n\nログインの失敗が続いたため、アカウントを一時的にロックしました。\nご本人の操作であれば、以下のリンクからロックを解除できます。\n%s\n\n心当たりがない場合はパスワードの変更をおすすめします。\n",
+			user.Username, link),
+	})
+}
+
+// UnlockAccount consumes the unlock token and clears the failed login counter
+// of the account.
+func (uu *userUsecase) UnlockAccount(token string, meta model.RequestMeta) error {
+	userToken, err := uu.consumeUserToken(token, model.UserTokenPurposeAccountUnlock)
+	if err != nil {
+		return err
+	}
+	if err := uu.lt.Unlock(userToken.User.Email); err != nil {
+		return err
+	}
+	uu.al.Record(userAuditEntry(userToken.User, meta, model.AuditActionUserUnlock, nil, nil))
+	return nil
+}
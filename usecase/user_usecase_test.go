@@ -1,15 +1,20 @@
 package usecase
 
 import (
+	"RefrigeratorWatchdog-server/mailer"
 	"RefrigeratorWatchdog-server/model"
 	"RefrigeratorWatchdog-server/repository"
 	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
 	"RefrigeratorWatchdog-server/validator"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func Test_userUsecase_GetUserByEmail(t *testing.T) {
@@ -78,6 +83,7 @@ func Test_userUsecase_GetUserByEmail(t *testing.T) {
 			uu := &userUsecase{
 				ur: tt.fields.ur,
 				uv: tt.fields.uv,
+				al: newNopAuditUsecase(ctrl),
 			}
 
 			mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.args.email).Do(func(user *model.User, email string) {
@@ -101,6 +107,7 @@ func Test_userUsecase_CreateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
 	Validator := validator.NewUserValidator()
 
 	type fields struct {
@@ -156,9 +163,13 @@ func Test_userUsecase_CreateUser(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
 			uu := &userUsecase{
-				ur: tt.fields.ur,
-				uv: tt.fields.uv,
+				ur:  tt.fields.ur,
+				uv:  tt.fields.uv,
+				utr: mockTokenRepo,
+				m:   m,
+				al:  newNopAuditUsecase(ctrl),
 			}
 
 			if tt.wantErr == false {
@@ -166,8 +177,10 @@ func Test_userUsecase_CreateUser(t *testing.T) {
 				mockRepo.EXPECT().CreateUser(gomock.Any()).Do(func(user *model.User) {
 					*user = tt.args.user
 				}).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().InvalidateUserTokens(tt.args.user.ID, model.UserTokenPurposeEmailVerification).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().CreateUserToken(gomock.Any()).Return(nil).Times(1)
 
-				got, err := uu.CreateUser(tt.args.user)
+				got, err := uu.CreateUser(tt.args.user, model.RequestMeta{})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -175,8 +188,13 @@ func Test_userUsecase_CreateUser(t *testing.T) {
 				if !reflect.DeepEqual(got, tt.want) {
 					t.Errorf("userUsecase.CreateUser() = %v, want %v", got, tt.want)
 				}
+				// 確認メールが送信される
+				sent := m.Sent()
+				if len(sent) != 1 || sent[0].To != tt.args.user.Email || !strings.Contains(sent[0].Body, "/users/verify?token=") {
+					t.Errorf("userUsecase.CreateUser() sent %v", sent)
+				}
 			} else {
-				got, err := uu.CreateUser(tt.args.user)
+				got, err := uu.CreateUser(tt.args.user, model.RequestMeta{})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -236,6 +254,32 @@ func Test_userUsecase_UpdateUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系：タイムゾーンを設定できる",
+			fields: fields{
+				ur: mockRepo,
+				uv: Validator,
+			},
+			args: args{
+				user: model.User{
+					ID:        1,
+					Username:  "test",
+					Email:     "sample@test.com",
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					Password:  "password",
+					TimeZone:  "Asia/Tokyo",
+				},
+				email: "sample@test.com",
+			},
+			want: model.UserResponse{
+				ID:        1,
+				Username:  "test",
+				Email:     "sample@test.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				TimeZone:  "Asia/Tokyo",
+			},
+			wantErr: false,
+		},
 		{
 			name: "異常系：ユーザーを更新できない",
 			fields: fields{
@@ -249,21 +293,45 @@ func Test_userUsecase_UpdateUser(t *testing.T) {
 			want:    model.UserResponse{},
 			wantErr: true,
 		},
+		{
+			name: "異常系：存在しないタイムゾーン",
+			fields: fields{
+				ur: mockRepo,
+				uv: Validator,
+			},
+			args: args{
+				user: model.User{
+					Username: "test",
+					Email:    "sample@test.com",
+					Password: "password",
+					TimeZone: "Mars/Olympus_Mons",
+				},
+				email: "sample@test.com",
+			},
+			want:    model.UserResponse{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uu := &userUsecase{
 				ur: tt.fields.ur,
 				uv: tt.fields.uv,
+				al: newNopAuditUsecase(ctrl),
 			}
 
 			if tt.wantErr == false {
 				// ここでモックの期待値を設定する
+				// パスワードは変わっていない
+				stored := tt.args.user
+				stored.Password = hashPassword(tt.args.user.Password)
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.args.email).SetArg(0, stored).Return(nil).Times(1)
 				mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Do(func(user *model.User, email string) {
 					*user = tt.args.user
 				}).Return(nil).Times(1)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), tt.args.user.ID).SetArg(0, tt.args.user).Return(nil).Times(1)
 
-				got, err := uu.UpdateUser(tt.args.user, tt.args.email)
+				got, err := uu.UpdateUser(tt.args.user, tt.args.email, model.RequestMeta{})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
 					return
@@ -272,7 +340,7 @@ func Test_userUsecase_UpdateUser(t *testing.T) {
 					t.Errorf("userUsecase.UpdateUser() = %v, want %v", got, tt.want)
 				}
 			} else {
-				got, err := uu.UpdateUser(tt.args.user, tt.args.email)
+				got, err := uu.UpdateUser(tt.args.user, tt.args.email, model.RequestMeta{})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -283,11 +351,85 @@ func Test_userUsecase_UpdateUser(t *testing.T) {
 		})
 	}
 }
+func Test_userUsecase_UpdateUser_Credentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+	mockRefreshRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := model.User{ID: 1, Username: "test", Email: "old@test.com", Password: hashPassword("password"), VerifiedAt: &verifiedAt}
+
+	tests := []struct {
+		name          string
+		user          model.User
+		wantUnverify  bool
+		wantLogoutAll bool
+	}{
+		{
+			name:         "正常系：メールアドレスを変更すると未確認に戻り、確認メールを送る",
+			user:         model.User{Username: "test", Email: "new@test.com", Password: "password"},
+			wantUnverify: true,
+		},
+		{
+			name:          "正常系：パスワードを変更すると全てのセッションを無効にする",
+			user:          model.User{Username: "test", Email: "old@test.com", Password: "newpassword"},
+			wantLogoutAll: true,
+		},
+		{
+			name: "正常系：同じメールアドレスとパスワードなら確認もセッションもそのまま",
+			user: model.User{Username: "renamed", Email: "old@test.com", Password: "password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
+			uu := &userUsecase{
+				ur:  mockRepo,
+				uv:  validator.NewUserValidator(),
+				au:  &authUsecase{rr: mockRefreshRepo},
+				utr: mockTokenRepo,
+				m:   m,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockRepo.EXPECT().GetUserByEmail(gomock.Any(), stored.Email).SetArg(0, stored).Return(nil)
+			mockRepo.EXPECT().UpdateUser(gomock.Any(), stored.Email).Return(nil)
+			after := stored
+			after.Email = tt.user.Email
+			if tt.wantUnverify {
+				mockRepo.EXPECT().UnmarkUserVerified(stored.ID).Return(nil)
+				after.VerifiedAt = nil
+				mockTokenRepo.EXPECT().InvalidateUserTokens(stored.ID, model.UserTokenPurposeEmailVerification).Return(nil)
+				mockTokenRepo.EXPECT().CreateUserToken(gomock.Any()).Return(nil)
+			}
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), stored.ID).SetArg(0, after).Return(nil)
+			if tt.wantLogoutAll {
+				mockRefreshRepo.EXPECT().RevokeRefreshTokensByUserID(stored.ID).Return(nil)
+			}
+
+			if _, err := uu.UpdateUser(tt.user, stored.Email, model.RequestMeta{}); err != nil {
+				t.Fatalf("userUsecase.UpdateUser() error = %v", err)
+			}
+			sent := m.Sent()
+			if tt.wantUnverify != (len(sent) == 1) {
+				t.Fatalf("userUsecase.UpdateUser() sent %d mails", len(sent))
+			}
+			if tt.wantUnverify && sent[0].To != "new@test.com" {
+				t.Errorf("userUsecase.UpdateUser() sent verification to %v", sent[0].To)
+			}
+		})
+	}
+}
+
 func Test_userUsecase_DeleteUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
 	Validator := validator.NewUserValidator()
 
 	type fields struct {
@@ -327,8 +469,11 @@ func Test_userUsecase_DeleteUser(t *testing.T) {
 			uu := &userUsecase{
 				ur: tt.fields.ur,
 				uv: tt.fields.uv,
+				lt: mockThrottle,
+				al: newNopAuditUsecase(ctrl),
 			}
 
+			mockThrottle.EXPECT().Check(tt.args.user.Email, "192.0.2.1").Return(nil).Times(1)
 			if !tt.wantErr {
 				// ユーザーを削除する前に期待されるモックの設定
 				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.args.user.Email).Do(func(user *model.User, email string) {
@@ -337,12 +482,12 @@ func Test_userUsecase_DeleteUser(t *testing.T) {
 				}).Return(nil).Times(1)
 				mockRepo.EXPECT().DeleteUser(gomock.Any()).Return(nil).Times(1)
 
-				err := uu.DeleteUser(tt.args.user)
+				err := uu.DeleteUser(tt.args.user, model.RequestMeta{IP: "192.0.2.1"})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
 				}
 			} else {
-				err := uu.DeleteUser(tt.args.user)
+				err := uu.DeleteUser(tt.args.user, model.RequestMeta{IP: "192.0.2.1"})
 				if (err != nil) != tt.wantErr {
 					t.Errorf("userUsecase.DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
 				}
@@ -350,3 +495,698 @@ func Test_userUsecase_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_userUsecase_LoginUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockRefreshRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+	Validator := validator.NewUserValidator()
+	Auth := &authUsecase{rr: mockRefreshRepo, secret: []byte("secret"), accessTokenTTL: 15 * time.Minute, refreshTokenTTL: time.Hour}
+
+	storedUser := model.User{
+		ID:        1,
+		Username:  "test",
+		Email:     "sample@test.com",
+		CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Password:  hashPassword("password"),
+	}
+
+	type args struct {
+		user model.User
+	}
+	tests := []struct {
+		name     string
+		args     args
+		checkErr error
+		wantErr  error
+	}{
+		{
+			name: "正常系：ログインしてアクセストークンを取得できる",
+			args: args{
+				user: model.User{Username: "test", Email: "sample@test.com", Password: "password"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常系：パスワードが間違っている",
+			args: args{
+				user: model.User{Username: "test", Email: "sample@test.com", Password: "wrong"},
+			},
+			wantErr: model.ErrInvalidPassword,
+		},
+		{
+			name: "異常系：ロック中はパスワードを照合しない",
+			args: args{
+				user: model.User{Username: "test", Email: "sample@test.com", Password: "password"},
+			},
+			checkErr: &model.LoginLockedError{RetryAfter: time.Minute},
+			wantErr:  model.ErrLoginLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := &userUsecase{
+				ur: mockRepo,
+				uv: Validator,
+				au: Auth,
+				lt: mockThrottle,
+				al: newNopAuditUsecase(ctrl),
+			}
+
+			mockThrottle.EXPECT().Check(tt.args.user.Email, "192.0.2.1").Return(tt.checkErr).Times(1)
+			if tt.checkErr == nil {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.args.user.Email).Do(func(user *model.User, email string) {
+					*user = storedUser
+				}).Return(nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockThrottle.EXPECT().RecordSuccess(tt.args.user.Email).Return(nil).Times(1)
+				mockRefreshRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil).Times(1)
+			}
+			if errors.Is(tt.wantErr, model.ErrInvalidPassword) {
+				mockThrottle.EXPECT().RecordFailure(tt.args.user.Email, "192.0.2.1").Return(false, nil).Times(1)
+			}
+
+			got, err := uu.LoginUser(tt.args.user, tt.args.user.Email, "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.LoginUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			authUser, err := Auth.Authenticate(got.AccessToken)
+			if err != nil {
+				t.Fatalf("authUsecase.Authenticate() error = %v", err)
+			}
+			if authUser.ID != storedUser.ID || authUser.Email != storedUser.Email {
+				t.Errorf("authUsecase.Authenticate() = %v, want user %d", authUser, storedUser.ID)
+			}
+			if got.TokenType != "Bearer" || got.ExpiresIn != 900 || got.RefreshToken == "" {
+				t.Errorf("userUsecase.LoginUser() = %v", got)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+
+	storedUser := model.User{ID: 1, Username: "test", Email: "sample@test.com"}
+
+	tests := []struct {
+		name     string
+		email    string
+		findErr  error
+		wantMail bool
+	}{
+		{
+			name:     "正常系：再設定メールが送信される",
+			email:    "sample@test.com",
+			wantMail: true,
+		},
+		{
+			name:     "正常系：存在しないメールアドレスでもエラーにならない",
+			email:    "unknown@test.com",
+			findErr:  gorm.ErrRecordNotFound,
+			wantMail: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
+			uu := &userUsecase{
+				ur:  mockRepo,
+				uv:  validator.NewUserValidator(),
+				utr: mockTokenRepo,
+				m:   m,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.email).Do(func(user *model.User, email string) {
+				*user = storedUser
+			}).Return(tt.findErr).Times(1)
+			if tt.wantMail {
+				mockTokenRepo.EXPECT().InvalidateUserTokens(storedUser.ID, model.UserTokenPurposePasswordReset).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().CreateUserToken(gomock.Any()).Return(nil).Times(1)
+			}
+
+			if err := uu.ForgotPassword(tt.email); err != nil {
+				t.Errorf("userUsecase.ForgotPassword() error = %v", err)
+			}
+			sent := m.Sent()
+			if (len(sent) == 1) != tt.wantMail {
+				t.Fatalf("userUsecase.ForgotPassword() sent %d mails, wantMail %v", len(sent), tt.wantMail)
+			}
+			if tt.wantMail && (sent[0].To != storedUser.Email || !strings.Contains(sent[0].Body, "/password/reset?token=")) {
+				t.Errorf("userUsecase.ForgotPassword() sent %v", sent[0])
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+	mockRefreshRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+
+	usedAt := time.Now().Add(-time.Minute)
+	user := model.User{ID: 1, Username: "test", Email: "sample@test.com"}
+
+	tests := []struct {
+		name     string
+		password string
+		stored   model.UserToken
+		wantErr  error
+	}{
+		{
+			name:     "正常系：パスワードを再設定できる",
+			password: "newpassword",
+			stored:   model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), User: user},
+			wantErr:  nil,
+		},
+		{
+			name:     "異常系：使用済みのトークン",
+			password: "newpassword",
+			stored:   model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt, User: user},
+			wantErr:  model.ErrInvalidToken,
+		},
+		{
+			name:     "異常系：期限切れのトークン",
+			password: "newpassword",
+			stored:   model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(-time.Hour), User: user},
+			wantErr:  model.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := &userUsecase{
+				ur:  mockRepo,
+				uv:  validator.NewUserValidator(),
+				au:  &authUsecase{rr: mockRefreshRepo},
+				utr: mockTokenRepo,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockTokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), hashToken("token"), model.UserTokenPurposePasswordReset).Do(func(token *model.UserToken, tokenHash string, purpose string) {
+				*token = tt.stored
+			}).Return(nil).Times(1)
+			if tt.wantErr == nil {
+				mockTokenRepo.EXPECT().UseUserToken(tt.stored.ID).Return(nil).Times(1)
+				mockRepo.EXPECT().UpdateUser(gomock.Any(), user.Email).Do(func(u *model.User, email string) {
+					if !comparePassword(u.Password, tt.password) {
+						t.Errorf("UpdateUser() password is not the hash of %q", tt.password)
+					}
+				}).Return(nil).Times(1)
+				mockRefreshRepo.EXPECT().RevokeRefreshTokensByUserID(user.ID).Return(nil).Times(1)
+			}
+
+			if err := uu.ResetPassword("token", tt.password, model.RequestMeta{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.ResetPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		stored    model.UserToken
+		findErr   error
+		verifyErr error
+		wantErr   error
+	}{
+		{
+			name:    "正常系：メールアドレスを確認できる",
+			stored:  model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：存在しないトークン",
+			findErr: gorm.ErrRecordNotFound,
+			wantErr: model.ErrInvalidToken,
+		},
+		{
+			name:    "異常系：使用済みのトークン",
+			stored:  model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt},
+			wantErr: model.ErrInvalidToken,
+		},
+		{
+			name:    "異常系：期限切れのトークン",
+			stored:  model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(-time.Hour)},
+			wantErr: model.ErrInvalidToken,
+		},
+		{
+			name:      "異常系：確認済みのユーザー",
+			stored:    model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			verifyErr: model.ErrEmailAlreadyVerified,
+			wantErr:   model.ErrEmailAlreadyVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := &userUsecase{
+				ur:  mockRepo,
+				utr: mockTokenRepo,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockTokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), hashToken("token"), model.UserTokenPurposeEmailVerification).Do(func(token *model.UserToken, tokenHash string, purpose string) {
+				*token = tt.stored
+			}).Return(tt.findErr).Times(1)
+			if tt.wantErr == nil || tt.verifyErr != nil {
+				mockTokenRepo.EXPECT().UseUserToken(tt.stored.ID).Return(nil).Times(1)
+				mockRepo.EXPECT().MarkUserVerified(tt.stored.UserID, gomock.Any()).Return(tt.verifyErr).Times(1)
+			}
+
+			if err := uu.VerifyEmail("token", model.RequestMeta{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.VerifyEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ResendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+
+	verifiedAt := time.Now().Add(-time.Hour)
+	unverified := model.User{ID: 1, Username: "test", Email: "sample@test.com"}
+
+	tests := []struct {
+		name      string
+		user      model.User
+		latest    model.UserToken
+		latestErr error
+		wantErr   error
+	}{
+		{
+			name:    "正常系：確認メールを再送信できる",
+			user:    unverified,
+			latest:  model.UserToken{CreatedAt: time.Now().Add(-time.Hour)},
+			wantErr: nil,
+		},
+		{
+			name:      "正常系：過去のトークンがなくても再送信できる",
+			user:      unverified,
+			latestErr: gorm.ErrRecordNotFound,
+			wantErr:   nil,
+		},
+		{
+			name:    "異常系：直前に送信済みの場合は再送信できない",
+			user:    unverified,
+			latest:  model.UserToken{CreatedAt: time.Now()},
+			wantErr: model.ErrTooManyRequests,
+		},
+		{
+			name:    "異常系：確認済みのユーザーには再送信できない",
+			user:    model.User{ID: 1, Email: "sample@test.com", VerifiedAt: &verifiedAt},
+			wantErr: model.ErrEmailAlreadyVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
+			uu := &userUsecase{
+				ur:  mockRepo,
+				utr: mockTokenRepo,
+				m:   m,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), tt.user.ID).Do(func(user *model.User, id int) {
+				*user = tt.user
+			}).Return(nil).Times(1)
+			if tt.user.VerifiedAt == nil {
+				mockTokenRepo.EXPECT().GetLatestUserToken(gomock.Any(), tt.user.ID, model.UserTokenPurposeEmailVerification).Do(func(token *model.UserToken, userID int, purpose string) {
+					*token = tt.latest
+				}).Return(tt.latestErr).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockTokenRepo.EXPECT().InvalidateUserTokens(tt.user.ID, model.UserTokenPurposeEmailVerification).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().CreateUserToken(gomock.Any()).Return(nil).Times(1)
+			}
+
+			if err := uu.ResendVerificationEmail(tt.user.ID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.ResendVerificationEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (len(m.Sent()) == 1) != (tt.wantErr == nil) {
+				t.Errorf("userUsecase.ResendVerificationEmail() sent %d mails", len(m.Sent()))
+			}
+		})
+	}
+}
+
+func Test_userUsecase_LoginUser_Lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+
+	storedUser := model.User{ID: 1, Username: "test", Email: "sample@test.com", Password: hashPassword("password")}
+
+	tests := []struct {
+		name     string
+		email    string
+		findErr  error
+		locked   bool
+		wantMail bool
+	}{
+		{
+			name:     "正常系：ロックされた時にロック解除メールが送信される",
+			email:    storedUser.Email,
+			locked:   true,
+			wantMail: true,
+		},
+		{
+			name:     "正常系：ロックされていなければメールは送信されない",
+			email:    storedUser.Email,
+			locked:   false,
+			wantMail: false,
+		},
+		{
+			name:     "正常系：存在しないユーザーにはメールは送信されない",
+			email:    "unknown@test.com",
+			findErr:  gorm.ErrRecordNotFound,
+			locked:   true,
+			wantMail: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mailer.NewMemoryMailer()
+			uu := &userUsecase{
+				ur:  mockRepo,
+				utr: mockTokenRepo,
+				m:   m,
+				lt:  mockThrottle,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockThrottle.EXPECT().Check(tt.email, "192.0.2.1").Return(nil).Times(1)
+			mockRepo.EXPECT().GetUserByEmail(gomock.Any(), tt.email).Do(func(user *model.User, email string) {
+				if tt.findErr == nil {
+					*user = storedUser
+				}
+			}).Return(tt.findErr).Times(1)
+			mockThrottle.EXPECT().RecordFailure(tt.email, "192.0.2.1").Return(tt.locked, nil).Times(1)
+			if tt.wantMail {
+				mockTokenRepo.EXPECT().InvalidateUserTokens(storedUser.ID, model.UserTokenPurposeAccountUnlock).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().CreateUserToken(gomock.Any()).Return(nil).Times(1)
+			}
+
+			if _, err := uu.LoginUser(model.User{Username: "test", Email: tt.email, Password: "wrong"}, tt.email, "192.0.2.1"); err == nil {
+				t.Errorf("userUsecase.LoginUser() error = nil")
+			}
+			sent := m.Sent()
+			if (len(sent) == 1) != tt.wantMail {
+				t.Fatalf("userUsecase.LoginUser() sent %d mails, wantMail %v", len(sent), tt.wantMail)
+			}
+			if tt.wantMail && !strings.Contains(sent[0].Body, "/users/unlock?token=") {
+				t.Errorf("userUsecase.LoginUser() sent %v", sent[0])
+			}
+		})
+	}
+}
+
+func Test_userUsecase_UnlockAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockIUserTokenRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+
+	user := model.User{ID: 1, Username: "test", Email: "sample@test.com"}
+
+	tests := []struct {
+		name    string
+		stored  model.UserToken
+		wantErr error
+	}{
+		{
+			name:    "正常系：アカウントのロックを解除できる",
+			stored:  model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), User: user},
+			wantErr: nil,
+		},
+		{
+			name:    "異常系：期限切れのトークン",
+			stored:  model.UserToken{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(-time.Hour), User: user},
+			wantErr: model.ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := &userUsecase{
+				utr: mockTokenRepo,
+				lt:  mockThrottle,
+				al:  newNopAuditUsecase(ctrl),
+			}
+
+			mockTokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), hashToken("token"), model.UserTokenPurposeAccountUnlock).Do(func(token *model.UserToken, tokenHash string, purpose string) {
+				*token = tt.stored
+			}).Return(nil).Times(1)
+			if tt.wantErr == nil {
+				mockTokenRepo.EXPECT().UseUserToken(tt.stored.ID).Return(nil).Times(1)
+				mockThrottle.EXPECT().Unlock(user.Email).Return(nil).Times(1)
+			}
+
+			if err := uu.UnlockAccount("token", model.RequestMeta{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.UnlockAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_LoginUser_TwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockRefreshRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+	mockTwoFactor := usecasemocks.NewMockITwoFactorUsecase(ctrl)
+	Auth := &authUsecase{rr: mockRefreshRepo, secret: []byte("secret"), accessTokenTTL: 15 * time.Minute, refreshTokenTTL: time.Hour, mfaTokenTTL: 5 * time.Minute}
+
+	enabledAt := time.Now()
+	storedUser := model.User{ID: 1, Username: "test", Email: "sample@test.com", Password: hashPassword("password"), TOTPEnabledAt: &enabledAt}
+
+	uu := &userUsecase{
+		ur: mockRepo,
+		au: Auth,
+		lt: mockThrottle,
+		tf: mockTwoFactor,
+		al: newNopAuditUsecase(ctrl),
+	}
+
+	// 1段階目：パスワードが正しくてもトークンは発行されない
+	mockThrottle.EXPECT().Check(storedUser.Email, "192.0.2.1").Return(nil).Times(1)
+	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), storedUser.Email).Do(func(user *model.User, email string) {
+		*user = storedUser
+	}).Return(nil).Times(1)
+
+	first, err := uu.LoginUser(model.User{Username: "test", Email: storedUser.Email, Password: "password"}, storedUser.Email, "192.0.2.1")
+	if err != nil {
+		t.Fatalf("userUsecase.LoginUser() error = %v", err)
+	}
+	if !first.MFARequired || first.MFAToken == "" || first.TokenResponse != nil || !first.User.TwoFactorEnabled {
+		t.Fatalf("userUsecase.LoginUser() = %+v", first)
+	}
+
+	tests := []struct {
+		name      string
+		mfaToken  string
+		verifyErr error
+		wantErr   error
+	}{
+		{
+			name:      "異常系：コードが間違っている",
+			mfaToken:  first.MFAToken,
+			verifyErr: model.ErrInvalidTOTPCode,
+			wantErr:   model.ErrInvalidTOTPCode,
+		},
+		{
+			name:     "異常系：不正な MFA トークン",
+			mfaToken: "invalid",
+			wantErr:  model.ErrUnauthorized,
+		},
+		{
+			name:     "正常系：2段階目でトークンを取得できる",
+			mfaToken: first.MFAToken,
+			wantErr:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.wantErr, model.ErrUnauthorized) {
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), storedUser.ID).Do(func(user *model.User, id int) {
+					*user = storedUser
+				}).Return(nil).Times(1)
+				mockThrottle.EXPECT().Check(storedUser.Email, "192.0.2.1").Return(nil).Times(1)
+				mockTwoFactor.EXPECT().VerifyCode(storedUser, "123456").Return(tt.verifyErr).Times(1)
+			}
+			if tt.verifyErr != nil {
+				mockThrottle.EXPECT().RecordFailure(storedUser.Email, "192.0.2.1").Return(false, nil).Times(1)
+			}
+			if tt.wantErr == nil {
+				mockThrottle.EXPECT().RecordSuccess(storedUser.Email).Return(nil).Times(1)
+				mockRefreshRepo.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil).Times(1)
+			}
+
+			got, err := uu.LoginUserTwoFactor(tt.mfaToken, "123456", "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("userUsecase.LoginUserTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.TokenResponse == nil || got.AccessToken == "" || got.MFARequired) {
+				t.Errorf("userUsecase.LoginUserTwoFactor() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_DisableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+	mockTwoFactor := usecasemocks.NewMockITwoFactorUsecase(ctrl)
+
+	enabledAt := time.Now()
+	storedUser := model.User{ID: 1, Email: "sample@test.com", Password: hashPassword("password"), TOTPEnabledAt: &enabledAt}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "正常系：パスワードを再入力して無効にできる",
+			password: "password",
+			wantErr:  nil,
+		},
+		{
+			name:     "異常系：パスワードが間違っている",
+			password: "wrong",
+			wantErr:  model.ErrInvalidPassword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uu := &userUsecase{
+				ur: mockRepo,
+				lt: mockThrottle,
+				tf: mockTwoFactor,
+				al: newNopAuditUsecase(ctrl),
+			}
+
+			mockRepo.EXPECT().GetUserByID(gomock.Any(), storedUser.ID).Do(func(user *model.User, id int) {
+				*user = storedUser
+			}).Return(nil).Times(1)
+			mockThrottle.EXPECT().Check(storedUser.Email, "192.0.2.1").Return(nil).Times(1)
+			if tt.wantErr == nil {
+				mockTwoFactor.EXPECT().Disable(storedUser.ID).Return(nil).Times(1)
+			} else {
+				mockThrottle.EXPECT().RecordFailure(storedUser.Email, "192.0.2.1").Return(false, nil).Times(1)
+			}
+
+			if err := uu.DisableTwoFactor(storedUser.ID, tt.password, model.RequestMeta{IP: "192.0.2.1"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("userUsecase.DisableTwoFactor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_AuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIUserRepository(ctrl)
+	mockThrottle := usecasemocks.NewMockILoginThrottleUsecase(ctrl)
+	mockAudit := usecasemocks.NewMockIAuditUsecase(ctrl)
+	mockRefreshRepo := mocks.NewMockIRefreshTokenRepository(ctrl)
+	uu := &userUsecase{
+		ur: mockRepo,
+		uv: validator.NewUserValidator(),
+		au: &authUsecase{rr: mockRefreshRepo},
+		lt: mockThrottle,
+		al: mockAudit,
+	}
+	meta := model.RequestMeta{IP: "192.0.2.1", UserAgent: "test-agent", RequestID: "req-1"}
+	stored := model.User{ID: 1, Username: "test", Email: "sample@test.com", Password: hashPassword("password")}
+
+	t.Run("正常系：更新前後のユーザーを記録し、パスワードは値を残さない", func(t *testing.T) {
+		updated := stored
+		updated.Username = "renamed"
+		mockRepo.EXPECT().GetUserByEmail(gomock.Any(), stored.Email).SetArg(0, stored).Return(nil)
+		mockRepo.EXPECT().UpdateUser(gomock.Any(), stored.Email).Return(nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), stored.ID).SetArg(0, updated).Return(nil)
+		mockRefreshRepo.EXPECT().RevokeRefreshTokensByUserID(stored.ID).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionUserUpdate || entry.EntityType != model.AuditEntityUser || entry.EntityID != "1" {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+			if entry.Actor.ID != 1 || entry.Actor.Meta != meta || entry.HouseholdID != nil {
+				t.Errorf("Record() actor = %+v", entry.Actor)
+			}
+			if entry.Before != userResponse(stored) || entry.After != userResponse(updated) {
+				t.Errorf("Record() before = %+v, after = %+v", entry.Before, entry.After)
+			}
+			if !reflect.DeepEqual(entry.Redacted, []string{"password"}) {
+				t.Errorf("Record() redacted = %v", entry.Redacted)
+			}
+		})
+
+		if _, err := uu.UpdateUser(model.User{Username: "renamed", Email: stored.Email, Password: "newpassword"}, stored.Email, meta); err != nil {
+			t.Errorf("userUsecase.UpdateUser() error = %v", err)
+		}
+	})
+
+	t.Run("正常系：削除したユーザーを記録する", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(stored.Email, meta.IP).Return(nil)
+		mockRepo.EXPECT().GetUserByEmail(gomock.Any(), stored.Email).SetArg(0, stored).Return(nil)
+		mockRepo.EXPECT().DeleteUser(gomock.Any()).Return(nil)
+		mockAudit.EXPECT().Record(gomock.Any()).Do(func(entry model.AuditEntry) {
+			if entry.Action != model.AuditActionUserDelete || entry.Before != userResponse(stored) || entry.After != nil {
+				t.Errorf("Record() entry = %+v", entry)
+			}
+		})
+
+		if err := uu.DeleteUser(model.User{Email: stored.Email, Password: "password"}, meta); err != nil {
+			t.Errorf("userUsecase.DeleteUser() error = %v", err)
+		}
+	})
+
+	t.Run("異常系：パスワードが違えば削除も記録もしない", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(stored.Email, meta.IP).Return(nil)
+		mockRepo.EXPECT().GetUserByEmail(gomock.Any(), stored.Email).SetArg(0, stored).Return(nil)
+		mockThrottle.EXPECT().RecordFailure(stored.Email, meta.IP).Return(false, nil)
+
+		if err := uu.DeleteUser(model.User{Email: stored.Email, Password: "wrong"}, meta); !errors.Is(err, model.ErrInvalidPassword) {
+			t.Errorf("userUsecase.DeleteUser() error = %v, want %v", err, model.ErrInvalidPassword)
+		}
+	})
+}
@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository"
+	"RefrigeratorWatchdog-server/validator"
+	"RefrigeratorWatchdog-server/webhook"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// webhookSecretPrefix marks webhook secrets so that leaked secrets can be found by secret scanners.
+	webhookSecretPrefix = "whsec_"
+	// webhookEventIDPrefix marks the IDs of events.
+	webhookEventIDPrefix = "evt_"
+	// webhookMaxAttempts is how many times a delivery is attempted before it fails.
+	webhookMaxAttempts = 10
+	// webhookRetryDelay is the delay before the first retry; it doubles with
+	// every attempt up to webhookMaxRetryDelay.
+	webhookRetryDelay    = 30 * time.Second
+	webhookMaxRetryDelay = 6 * time.Hour
+	// webhookDeliveryLock must be longer than an attempt takes.
+	webhookDeliveryLock = time.Minute
+	// webhookDeliveryBatch is how many due deliveries a run attempts.
+	webhookDeliveryBatch = 100
+	// webhookDeliveryLogLimit is how many deliveries the delivery log shows.
+	webhookDeliveryLogLimit = 50
+	// webhookDeliveryRetention is how long finished deliveries are kept.
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+	// maxWebhookErrorLength is the size of the error column.
+	maxWebhookErrorLength = 255
+)
+
+// IWebhookUsecase manages the webhooks of households and delivers events to them.
+type IWebhookUsecase interface {
+	CreateWebhook(householdID int, userID int, webhook model.WebhookRequest) (model.WebhookCreatedResponse, error)
+	GetWebhooks(householdID int, userID int) ([]model.WebhookResponse, error)
+	UpdateWebhook(householdID int, userID int, id int, webhook model.WebhookRequest) (model.WebhookResponse, error)
+	DeleteWebhook(householdID int, userID int, id int) error
+	GetDeliveries(householdID int, userID int, id int) ([]model.WebhookDeliveryResponse, error)
+	// SendTestEvent delivers a ping event to the webhook right away, without
+	// retries, and returns the result.
+	SendTestEvent(householdID int, userID int, id int) (model.WebhookDeliveryResponse, error)
+	// Publish queues the event for the webhooks of its household that
+	// subscribe to it. Events with an ID already queued are skipped. Failures
+	// are logged and do not fail the change that emitted the event.
+	Publish(event model.WebhookEvent)
+	// DeliverPendingWebhooks attempts the deliveries that are due and returns
+	// the number that succeeded.
+	DeliverPendingWebhooks() (int, error)
+	SweepWebhookDeliveries() (int64, error)
+}
+
+type webhookUsecase struct {
+	wr    repository.IWebhookRepository
+	wv    validator.IWebhookValidator
+	hu    IHouseholdUsecase
+	s     webhook.ISender
+	clock Clock
+}
+
+// NewWebhookUsecase creates a new instance of the webhookUsecase struct.
+func NewWebhookUsecase(wr repository.IWebhookRepository, wv validator.IWebhookValidator, hu IHouseholdUsecase, s webhook.ISender, clock Clock) IWebhookUsecase {
+	return &webhookUsecase{wr, wv, hu, s, clock}
+}
+
+func webhookResponse(w model.Webhook) model.WebhookResponse {
+	return model.WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.EventList(),
+		CreatedBy: w.CreatedBy,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func webhookDeliveryResponse(d model.WebhookDelivery) model.WebhookDeliveryResponse {
+	res := model.WebhookDeliveryResponse{
+		ID:           d.ID,
+		EventID:      d.EventID,
+		EventType:    d.EventType,
+		Status:       d.Status,
+		Attempts:     d.Attempts,
+		ResponseCode: d.ResponseCode,
+		Error:        d.Error,
+		CreatedAt:    d.CreatedAt,
+		DeliveredAt:  d.DeliveredAt,
+	}
+	if d.Status == model.WebhookDeliveryPending {
+		res.NextAttemptAt = &d.NextAttemptAt
+	}
+	return res
+}
+
+func (wu *webhookUsecase) CreateWebhook(householdID int, userID int, req model.WebhookRequest) (model.WebhookCreatedResponse, error) {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return model.WebhookCreatedResponse{}, err
+	}
+	if err := wu.wv.ValidateWebhook(req); err != nil {
+		return model.WebhookCreatedResponse{}, err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return model.WebhookCreatedResponse{}, err
+	}
+	newWebhook := model.Webhook{
+		HouseholdID: householdID,
+		URL:         req.URL,
+		Events:      model.JoinWebhookEvents(req.Events),
+		Secret:      webhookSecretPrefix + secret,
+		CreatedBy:   userID,
+	}
+	if err := wu.wr.CreateWebhook(&newWebhook); err != nil {
+		return model.WebhookCreatedResponse{}, err
+	}
+
+	return model.WebhookCreatedResponse{
+		WebhookResponse: webhookResponse(newWebhook),
+		Secret:          newWebhook.Secret,
+	}, nil
+}
+
+func (wu *webhookUsecase) GetWebhooks(householdID int, userID int) ([]model.WebhookResponse, error) {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return nil, err
+	}
+
+	webhooks := []model.Webhook{}
+	if err := wu.wr.GetWebhooksByHouseholdID(&webhooks, householdID); err != nil {
+		return nil, err
+	}
+	resWebhooks := []model.WebhookResponse{}
+	for _, w := range webhooks {
+		resWebhooks = append(resWebhooks, webhookResponse(w))
+	}
+	return resWebhooks, nil
+}
+
+func (wu *webhookUsecase) UpdateWebhook(householdID int, userID int, id int, req model.WebhookRequest) (model.WebhookResponse, error) {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return model.WebhookResponse{}, err
+	}
+	if err := wu.wv.ValidateWebhook(req); err != nil {
+		return model.WebhookResponse{}, err
+	}
+
+	w, err := wu.webhook(householdID, id)
+	if err != nil {
+		return model.WebhookResponse{}, err
+	}
+	w.URL = req.URL
+	w.Events = model.JoinWebhookEvents(req.Events)
+	if err := wu.wr.UpdateWebhook(&w); err != nil {
+		return model.WebhookResponse{}, err
+	}
+	return webhookResponse(w), nil
+}
+
+// webhook returns the webhook of the household, or model.ErrWebhookNotFound.
+func (wu *webhookUsecase) webhook(householdID int, id int) (model.Webhook, error) {
+	w := model.Webhook{}
+	if err := wu.wr.GetWebhookByID(&w, householdID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Webhook{}, model.ErrWebhookNotFound
+		}
+		return model.Webhook{}, err
+	}
+	return w, nil
+}
+
+func (wu *webhookUsecase) DeleteWebhook(householdID int, userID int, id int) error {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return err
+	}
+	return wu.wr.DeleteWebhook(householdID, id)
+}
+
+func (wu *webhookUsecase) GetDeliveries(householdID int, userID int, id int) ([]model.WebhookDeliveryResponse, error) {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return nil, err
+	}
+	if _, err := wu.webhook(householdID, id); err != nil {
+		return nil, err
+	}
+
+	deliveries := []model.WebhookDelivery{}
+	if err := wu.wr.GetDeliveriesByWebhookID(&deliveries, id, webhookDeliveryLogLimit); err != nil {
+		return nil, err
+	}
+	resDeliveries := []model.WebhookDeliveryResponse{}
+	for _, d := range deliveries {
+		resDeliveries = append(resDeliveries, webhookDeliveryResponse(d))
+	}
+	return resDeliveries, nil
+}
+
+func (wu *webhookUsecase) SendTestEvent(householdID int, userID int, id int) (model.WebhookDeliveryResponse, error) {
+	if _, err := wu.hu.Authorize(householdID, userID, model.PermissionHouseholdManage); err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+	w, err := wu.webhook(householdID, id)
+	if err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+	now := wu.clock.Now()
+	delivery, err := newWebhookDelivery(w, model.WebhookEvent{
+		ID:          eventID,
+		Type:        model.WebhookEventPing,
+		HouseholdID: householdID,
+		OccurredAt:  now,
+		Data:        map[string]int{"webhook_id": w.ID},
+	}, now)
+	if err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+	// 受信側の確認用なので、配信処理には渡さずにここで一度だけ送る
+	delivery.NextAttemptAt = now.Add(webhookDeliveryLock)
+	queued := []model.WebhookDelivery{delivery}
+	if err := wu.wr.CreateDeliveries(queued); err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+	delivery = queued[0]
+	delivery.Webhook = w
+
+	wu.attempt(&delivery, 1)
+	if err := wu.wr.UpdateDelivery(&delivery); err != nil {
+		return model.WebhookDeliveryResponse{}, err
+	}
+	return webhookDeliveryResponse(delivery), nil
+}
+
+// newWebhookEventID returns a random ID for an event.
+func newWebhookEventID() (string, error) {
+	id, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	return webhookEventIDPrefix + id, nil
+}
+
+// newWebhookDelivery returns a pending delivery of the event to the webhook.
+func newWebhookDelivery(w model.Webhook, event model.WebhookEvent, now time.Time) (model.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	return model.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       string(payload),
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+func (wu *webhookUsecase) Publish(event model.WebhookEvent) {
+	webhooks := []model.Webhook{}
+	if err := wu.wr.GetWebhooksByHouseholdID(&webhooks, event.HouseholdID); err != nil {
+		log.Println("failed to publish webhook event:", err)
+		return
+	}
+
+	now := wu.clock.Now()
+	deliveries := []model.WebhookDelivery{}
+	for _, w := range webhooks {
+		if !w.Subscribes(event.Type) {
+			continue
+		}
+		delivery, err := newWebhookDelivery(w, event, now)
+		if err != nil {
+			log.Println("failed to publish webhook event:", err)
+			return
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := wu.wr.CreateDeliveries(deliveries); err != nil {
+		log.Println("failed to publish webhook event:", err)
+	}
+}
+
+func (wu *webhookUsecase) DeliverPendingWebhooks() (int, error) {
+	due := []model.WebhookDelivery{}
+	if err := wu.wr.GetDueDeliveries(&due, wu.clock.Now(), webhookDeliveryBatch); err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range due {
+		delivery := &due[i]
+		// 他のレプリカが先に確保した配信は飛ばす
+		now := wu.clock.Now()
+		claimed, err := wu.wr.ClaimDelivery(delivery.ID, now, now.Add(webhookDeliveryLock))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		wu.attempt(delivery, webhookMaxAttempts)
+		if err := wu.wr.UpdateDelivery(delivery); err != nil {
+			return delivered, err
+		}
+		if delivery.Status == model.WebhookDeliverySucceeded {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt posts the delivery to its webhook and records the result. A failed
+// delivery is retried with exponential backoff until maxAttempts is reached.
+func (wu *webhookUsecase) attempt(delivery *model.WebhookDelivery, maxAttempts int) {
+	now := wu.clock.Now()
+	code, err := wu.s.Send(webhook.Request{
+		URL:       delivery.Webhook.URL,
+		Secret:    delivery.Webhook.Secret,
+		EventID:   delivery.EventID,
+		EventType: string(delivery.EventType),
+		Payload:   []byte(delivery.Payload),
+		Timestamp: now,
+	})
+	delivery.Attempts++
+	delivery.ResponseCode = nil
+	if code != 0 {
+		delivery.ResponseCode = &code
+	}
+	if err == nil {
+		delivery.Status = model.WebhookDeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.Error = err.Error()
+	if len(delivery.Error) > maxWebhookErrorLength {
+		delivery.Error = delivery.Error[:maxWebhookErrorLength]
+	}
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = model.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(webhookRetryBackoff(delivery.Attempts))
+}
+
+// webhookRetryBackoff returns the delay before the retry that follows the
+// given number of failed attempts: 30s, 1m, 2m, ... up to 6h.
+func webhookRetryBackoff(attempts int) time.Duration {
+	delay := webhookRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookMaxRetryDelay {
+			return webhookMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+// SweepWebhookDeliveries deletes finished deliveries that are older than the
+// delivery log needs to show.
+func (wu *webhookUsecase) SweepWebhookDeliveries() (int64, error) {
+	return wu.wr.DeleteDeliveriesBefore(wu.clock.Now().Add(-webhookDeliveryRetention))
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"RefrigeratorWatchdog-server/repository/mocks"
+	usecasemocks "RefrigeratorWatchdog-server/usecase/mocks"
+	"RefrigeratorWatchdog-server/validator"
+	"RefrigeratorWatchdog-server/webhook"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// newNopWebhookUsecase returns a webhook usecase that accepts any event.
+func newNopWebhookUsecase(ctrl *gomock.Controller) *usecasemocks.MockIWebhookUsecase {
+	mockWebhook := usecasemocks.NewMockIWebhookUsecase(ctrl)
+	mockWebhook.EXPECT().Publish(gomock.Any()).AnyTimes()
+	return mockWebhook
+}
+
+func Test_webhookUsecase_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIWebhookRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+
+	tests := []struct {
+		name    string
+		authErr error
+		webhook model.WebhookRequest
+		wantErr bool
+	}{
+		{
+			name:    "正常系：Webhookを作成できる",
+			webhook: model.WebhookRequest{URL: "http://homeassistant.local:8123/api/webhook/fridge", Events: []model.WebhookEventType{model.WebhookEventFoodCreated, model.WebhookEventFoodExpiring}},
+		},
+		{
+			name:    "異常系：household:manage がなければ作成できない",
+			authErr: model.ErrForbidden,
+			webhook: model.WebhookRequest{URL: "https://example.com/hook", Events: []model.WebhookEventType{model.WebhookEventFoodCreated}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：http(s) 以外のURL",
+			webhook: model.WebhookRequest{URL: "ftp://example.com/hook", Events: []model.WebhookEventType{model.WebhookEventFoodCreated}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：ping は購読できない",
+			webhook: model.WebhookRequest{URL: "https://example.com/hook", Events: []model.WebhookEventType{model.WebhookEventPing}},
+			wantErr: true,
+		},
+		{
+			name:    "異常系：イベントが空",
+			webhook: model.WebhookRequest{URL: "https://example.com/hook"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wu := &webhookUsecase{wr: mockRepo, wv: validator.NewWebhookValidator(), hu: mockHousehold}
+
+			mockHousehold.EXPECT().Authorize(1, 1, model.PermissionHouseholdManage).Return(model.HouseholdMember{}, tt.authErr).Times(1)
+			var stored model.Webhook
+			if !tt.wantErr {
+				mockRepo.EXPECT().CreateWebhook(gomock.Any()).Do(func(w *model.Webhook) {
+					w.ID = 1
+					stored = *w
+				}).Return(nil).Times(1)
+			}
+
+			got, err := wu.CreateWebhook(1, 1, tt.webhook)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("webhookUsecase.CreateWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.HasPrefix(got.Secret, webhookSecretPrefix) || got.Secret != stored.Secret {
+				t.Errorf("webhookUsecase.CreateWebhook() secret = %v", got.Secret)
+			}
+			if stored.Events != "food.created,food.expiring" || stored.HouseholdID != 1 || stored.CreatedBy != 1 {
+				t.Errorf("stored webhook = %+v", stored)
+			}
+		})
+	}
+}
+
+func Test_webhookUsecase_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIWebhookRepository(ctrl)
+	now := time.Date(2024, 9, 25, 11, 46, 43, 0, time.UTC)
+	wu := &webhookUsecase{wr: mockRepo, clock: fakeClock{now}}
+	event := model.WebhookEvent{
+		ID:          "evt_1",
+		Type:        model.WebhookEventFoodCreated,
+		HouseholdID: 1,
+		OccurredAt:  now,
+		Data:        model.WebhookFoodData{Food: model.FoodResponse{ID: 10, Name: "オレンジ"}, ActorID: 1},
+	}
+
+	mockRepo.EXPECT().GetWebhooksByHouseholdID(gomock.Any(), 1).SetArg(0, []model.Webhook{
+		{ID: 1, Events: "food.created,food.deleted"},
+		{ID: 2, Events: "food.expiring"},
+	}).Return(nil)
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any()).Do(func(deliveries []model.WebhookDelivery) {
+		if len(deliveries) != 1 || deliveries[0].WebhookID != 1 || deliveries[0].EventID != "evt_1" {
+			t.Fatalf("CreateDeliveries() deliveries = %+v", deliveries)
+		}
+		d := deliveries[0]
+		if d.Status != model.WebhookDeliveryPending || !d.NextAttemptAt.Equal(now) {
+			t.Errorf("delivery = %+v", d)
+		}
+		payload := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(d.Payload), &payload); err != nil {
+			t.Fatal(err)
+		}
+		if payload["type"] != "food.created" || payload["data"].(map[string]interface{})["food"].(map[string]interface{})["name"] != "オレンジ" {
+			t.Errorf("payload = %s", d.Payload)
+		}
+	}).Return(nil)
+
+	wu.Publish(event)
+}
+
+func Test_webhookUsecase_DeliverPendingWebhooks(t *testing.T) {
+	now := time.Date(2024, 9, 25, 11, 46, 43, 0, time.UTC)
+	hook := model.Webhook{ID: 1, URL: "https://example.com/hook", Secret: "whsec_test"}
+
+	tests := []struct {
+		name          string
+		attempts      int
+		claimed       bool
+		code          int
+		sendErr       error
+		wantStatus    string
+		wantNext      time.Time
+		wantDelivered int
+	}{
+		{name: "正常系：配信に成功する", claimed: true, wantStatus: model.WebhookDeliverySucceeded, wantDelivered: 1},
+		{name: "正常系：他のレプリカが確保した配信は送らない", claimed: false},
+		{name: "異常系：失敗したら30秒後に再送する", claimed: true, code: http.StatusInternalServerError, wantStatus: model.WebhookDeliveryPending, wantNext: now.Add(30 * time.Second)},
+		{name: "異常系：再送の間隔は倍になる", attempts: 3, claimed: true, sendErr: errors.New("connection refused"), wantStatus: model.WebhookDeliveryPending, wantNext: now.Add(4 * time.Minute)},
+		{name: "異常系：最後の試行に失敗したら諦める", attempts: webhookMaxAttempts - 1, claimed: true, code: http.StatusGone, wantStatus: model.WebhookDeliveryFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockIWebhookRepository(ctrl)
+			sender := webhook.NewMemorySender()
+			sender.Code = tt.code
+			sender.Err = tt.sendErr
+			wu := &webhookUsecase{wr: mockRepo, s: sender, clock: fakeClock{now}}
+
+			mockRepo.EXPECT().GetDueDeliveries(gomock.Any(), now, webhookDeliveryBatch).SetArg(0, []model.WebhookDelivery{
+				{ID: 5, WebhookID: 1, EventID: "evt_1", EventType: model.WebhookEventFoodCreated, Payload: `{"id":"evt_1"}`, Status: model.WebhookDeliveryPending, Attempts: tt.attempts, NextAttemptAt: now, Webhook: hook},
+			}).Return(nil)
+			mockRepo.EXPECT().ClaimDelivery(5, now, now.Add(webhookDeliveryLock)).Return(tt.claimed, nil)
+			var saved model.WebhookDelivery
+			if tt.claimed {
+				mockRepo.EXPECT().UpdateDelivery(gomock.Any()).Do(func(d *model.WebhookDelivery) {
+					saved = *d
+				}).Return(nil)
+			}
+
+			delivered, err := wu.DeliverPendingWebhooks()
+			if err != nil {
+				t.Fatalf("webhookUsecase.DeliverPendingWebhooks() error = %v", err)
+			}
+			if delivered != tt.wantDelivered {
+				t.Errorf("webhookUsecase.DeliverPendingWebhooks() = %v, want %v", delivered, tt.wantDelivered)
+			}
+			if !tt.claimed {
+				if len(sender.Sent()) != 0 {
+					t.Errorf("sent = %v", sender.Sent())
+				}
+				return
+			}
+			if sent := sender.Sent(); len(sent) != 1 || sent[0].URL != hook.URL || sent[0].Secret != hook.Secret || string(sent[0].Payload) != `{"id":"evt_1"}` {
+				t.Errorf("sent = %+v", sent)
+			}
+			if saved.Status != tt.wantStatus || saved.Attempts != tt.attempts+1 {
+				t.Errorf("saved delivery = %+v", saved)
+			}
+			if tt.wantStatus == model.WebhookDeliveryPending && !saved.NextAttemptAt.Equal(tt.wantNext) {
+				t.Errorf("next attempt at = %v, want %v", saved.NextAttemptAt, tt.wantNext)
+			}
+			if tt.sendErr == nil && (saved.ResponseCode == nil || (tt.code != 0 && *saved.ResponseCode != tt.code)) {
+				t.Errorf("response code = %v", saved.ResponseCode)
+			}
+			if (tt.wantStatus == model.WebhookDeliverySucceeded) != (saved.Error == "") {
+				t.Errorf("error = %q", saved.Error)
+			}
+		})
+	}
+}
+
+func Test_webhookRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 5, want: 8 * time.Minute},
+		{attempts: 20, want: webhookMaxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := webhookRetryBackoff(tt.attempts); got != tt.want {
+			t.Errorf("webhookRetryBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func Test_webhookUsecase_SendTestEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIWebhookRepository(ctrl)
+	mockHousehold := usecasemocks.NewMockIHouseholdUsecase(ctrl)
+	sender := webhook.NewMemorySender()
+	sender.Code = http.StatusNotFound
+	now := time.Date(2024, 9, 25, 11, 46, 43, 0, time.UTC)
+	wu := &webhookUsecase{wr: mockRepo, hu: mockHousehold, s: sender, clock: fakeClock{now}}
+
+	mockHousehold.EXPECT().Authorize(1, 1, model.PermissionHouseholdManage).Return(model.HouseholdMember{}, nil)
+	mockRepo.EXPECT().GetWebhookByID(gomock.Any(), 1, 2).SetArg(0, model.Webhook{ID: 2, HouseholdID: 1, URL: "https://example.com/hook", Events: "food.created"}).Return(nil)
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any()).Do(func(deliveries []model.WebhookDelivery) {
+		deliveries[0].ID = 7
+	}).Return(nil)
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any()).Return(nil)
+
+	got, err := wu.SendTestEvent(1, 1, 2)
+	if err != nil {
+		t.Fatalf("webhookUsecase.SendTestEvent() error = %v", err)
+	}
+	// 失敗しても再送しない
+	if got.ID != 7 || got.EventType != model.WebhookEventPing || got.Status != model.WebhookDeliveryFailed || got.Attempts != 1 || *got.ResponseCode != http.StatusNotFound {
+		t.Errorf("webhookUsecase.SendTestEvent() = %+v", got)
+	}
+	if sent := sender.Sent(); len(sent) != 1 || sent[0].EventType != "ping" {
+		t.Errorf("sent = %+v", sent)
+	}
+}
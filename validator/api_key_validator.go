@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IAPIKeyValidator interface {
+	ValidateAPIKey(key model.APIKeyRequest) error
+}
+
+type apiKeyValidator struct{}
+
+func NewAPIKeyValidator() IAPIKeyValidator {
+	return &apiKeyValidator{}
+}
+
+// ValidateAPIKey validates an API key request. Keys can only be granted the
+// permissions in model.APIKeyPermissions.
+func (kv *apiKeyValidator) ValidateAPIKey(key model.APIKeyRequest) error {
+	permissions := make([]interface{}, len(model.APIKeyPermissions))
+	for i, p := range model.APIKeyPermissions {
+		permissions[i] = p
+	}
+	return validation.ValidateStruct(&key,
+		validation.Field(&key.Name, validation.Required, validation.Length(1, 64)),
+		validation.Field(&key.Permissions, validation.Required, validation.Each(validation.In(permissions...))),
+	)
+}
@@ -9,27 +9,118 @@ import (
 
 type IFoodValidator interface {
 	ValidateFood(food model.Food) error
+	ValidateFoodPatch(food model.Food, fields []string) error
+	ValidateFoodQuery(query model.FoodQuery) error
+	ValidateFoodHistoryQuery(query model.FoodQuery) error
+	ValidateConsumeFood(req model.ConsumeFoodRequest) error
+	ValidateDisposeFood(req model.DisposeFoodRequest) error
+	ValidateWasteQuery(query model.WasteQuery) error
 }
 
+// foodTags are the values of the tag column of foods.
+var foodTags = []interface{}{"野菜", "肉", "魚", "乳製品", "調味料", "卵", "飲料", "果物", "加工食品", "その他"}
+
+// storageLocations are the values of the storage_location column of foods.
+var storageLocations = []interface{}{model.StorageRefrigerator, model.StorageFreezer, model.StorageVegetable, model.StoragePantry}
+
+// dispositions are the values of the disposition column of archived foods.
+var dispositions = []interface{}{model.DispositionEaten, model.DispositionDiscarded, model.DispositionExpired, model.DispositionGivenAway}
+
 type foodValidator struct{}
 
 func NewFoodValidator() IFoodValidator {
 	return &foodValidator{}
 }
 
+// foodFieldRules returns the rules of the fields of the food that a
+// model.FoodRequest sets, keyed by their JSON names.
+func foodFieldRules(food *model.Food) map[string]*validation.FieldRules {
+	return map[string]*validation.FieldRules{
+		"name":             validation.Field(&food.Name, validation.Required, validation.Length(1, 255)),
+		"original_code":    validation.Field(&food.OriginalCode, validation.Min(0), validation.Max(10000000000000)),
+		"quantity":         validation.Field(&food.Quantity, validation.Min(0.0), validation.Max(10000000000000.0)),
+		"expiration_date":  validation.Field(&food.ExpirationDate, validation.By(allowNilTime)),
+		"image_url":        validation.Field(&food.ImageURL, validation.Length(0, 10000)),
+		"memo":             validation.Field(&food.Memo, validation.Length(0, 1000)),
+		"tag":              validation.Field(&food.Tag, validation.In(append(foodTags, "")...)),
+		"storage_location": validation.Field(&food.StorageLocation, validation.In(append(storageLocations, "")...)),
+		"price":            validation.Field(&food.Price, validation.Min(0.0), validation.Max(10000000000000.0)),
+	}
+}
+
 func (fv *foodValidator) ValidateFood(food model.Food) error {
 	if food.Tag == "" {
 		food.Tag = "その他"
 	}
-	return validation.ValidateStruct(&food,
-		validation.Field(&food.Name,  validation.Length(1, 255)),
-		validation.Field(&food.UserID, validation.Required),
-		validation.Field(&food.OriginalCode,  validation.Min(0), validation.Max(10000000000000)),
-		validation.Field(&food.Quantity, validation.Min(0.0), validation.Max(10000000000000.0)),
-		validation.Field(&food.ExpirationDate, validation.By(allowNilTime)),
-		validation.Field(&food.ImageURL,  validation.Length(0, 10000)),
-		validation.Field(&food.Memo, validation.Length(0, 1000)),
-		validation.Field(&food.Tag, validation.In("野菜", "肉", "魚", "乳製品", "調味料", "卵", "飲料", "果物", "加工食品", "その他","")),
+	rules := []*validation.FieldRules{validation.Field(&food.UserID, validation.Required)}
+	for _, rule := range foodFieldRules(&food) {
+		rules = append(rules, rule)
+	}
+	return validation.ValidateStruct(&food, rules...)
+}
+
+// ValidateFoodPatch validates only the fields of the food that a PATCH
+// /foods/{id} changed, given by their JSON names.
+func (fv *foodValidator) ValidateFoodPatch(food model.Food, fields []string) error {
+	all := foodFieldRules(&food)
+	rules := []*validation.FieldRules{}
+	for _, field := range fields {
+		if rule, ok := all[field]; ok {
+			rules = append(rules, rule)
+		}
+	}
+	return validation.ValidateStruct(&food, rules...)
+}
+
+// ValidateFoodQuery validates the filters and order of GET /foods. Empty
+// values are allowed and mean no filter or the default order.
+func (fv *foodValidator) ValidateFoodQuery(query model.FoodQuery) error {
+	return validation.ValidateStruct(&query,
+		validation.Field(&query.Tag, validation.In(foodTags...)),
+		validation.Field(&query.Name, validation.Length(0, 255)),
+		validation.Field(&query.Sort, validation.In(model.FoodSortExpirationDate, model.FoodSortCreatedAt, model.FoodSortName)),
+		validation.Field(&query.Order, validation.In(model.SortOrderAsc, model.SortOrderDesc)),
+		validation.Field(&query.Limit, validation.Min(0)),
+	)
+}
+
+// ValidateFoodHistoryQuery validates the filters and order of GET /foods/history.
+func (fv *foodValidator) ValidateFoodHistoryQuery(query model.FoodQuery) error {
+	return validation.ValidateStruct(&query,
+		validation.Field(&query.Tag, validation.In(foodTags...)),
+		validation.Field(&query.Name, validation.Length(0, 255)),
+		validation.Field(&query.Disposition, validation.In(dispositions...)),
+		validation.Field(&query.Sort, validation.In(model.FoodSortArchivedAt, model.FoodSortExpirationDate, model.FoodSortCreatedAt, model.FoodSortName)),
+		validation.Field(&query.Order, validation.In(model.SortOrderAsc, model.SortOrderDesc)),
+		validation.Field(&query.Limit, validation.Min(0)),
+	)
+}
+
+// ValidateConsumeFood validates the amount of POST /foods/{id}/consume.
+func (fv *foodValidator) ValidateConsumeFood(req model.ConsumeFoodRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Amount, validation.Required, validation.Min(0.0), validation.Max(10000000000000.0)),
+	)
+}
+
+// ValidateDisposeFood validates the disposition of POST /foods/{id}/dispose.
+func (fv *foodValidator) ValidateDisposeFood(req model.DisposeFoodRequest) error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Disposition, validation.Required, validation.In(dispositions...)),
+	)
+}
+
+// ValidateWasteQuery validates the period of the waste analytics. Empty days
+// are allowed and mean the default period.
+func (fv *foodValidator) ValidateWasteQuery(query model.WasteQuery) error {
+	to := validation.Date(time.DateOnly)
+	if from, err := time.Parse(time.DateOnly, query.From); err == nil {
+		to = to.Min(from)
+	}
+	return validation.ValidateStruct(&query,
+		validation.Field(&query.From, validation.Date(time.DateOnly)),
+		validation.Field(&query.To, to),
+		validation.Field(&query.Limit, validation.Min(0), validation.Max(100)),
 	)
 }
 
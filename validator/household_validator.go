@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type IHouseholdValidator interface {
+	ValidateHousehold(household model.Household) error
+	ValidateInvitation(invitation model.HouseholdInvitationRequest) error
+	ValidateRole(role string) error
+	ValidateHouseholdRole(role model.HouseholdRoleRequest) error
+}
+
+type householdValidator struct{}
+
+func NewHouseholdValidator() IHouseholdValidator {
+	return &householdValidator{}
+}
+
+func (hv *householdValidator) ValidateHousehold(household model.Household) error {
+	return validation.ValidateStruct(&household,
+		validation.Field(&household.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// ValidateInvitation validates an invitation. Owners can only be appointed
+// by changing the role of an existing member.
+func (hv *householdValidator) ValidateInvitation(invitation model.HouseholdInvitationRequest) error {
+	return validation.ValidateStruct(&invitation,
+		validation.Field(&invitation.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
+		validation.Field(&invitation.Role, validation.Required, validation.Length(1, 32), validation.NotIn(model.HouseholdRoleOwner)),
+	)
+}
+
+// ValidateRole validates the name of a role assigned to a member. Whether the
+// role exists in the household is checked by the usecase.
+func (hv *householdValidator) ValidateRole(role string) error {
+	return validation.Validate(role, validation.Required, validation.Length(1, 32))
+}
+
+func (hv *householdValidator) ValidateHouseholdRole(role model.HouseholdRoleRequest) error {
+	builtinRoles := make([]interface{}, len(model.BuiltinRoles))
+	for i, r := range model.BuiltinRoles {
+		builtinRoles[i] = r
+	}
+	permissions := make([]interface{}, len(model.AllPermissions))
+	for i, p := range model.AllPermissions {
+		permissions[i] = p
+	}
+	return validation.ValidateStruct(&role,
+		validation.Field(&role.Name, validation.Required, validation.Length(1, 32), validation.NotIn(builtinRoles...)),
+		validation.Field(&role.Permissions, validation.Required, validation.Each(validation.In(permissions...))),
+	)
+}
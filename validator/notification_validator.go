@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"RefrigeratorWatchdog-server/model"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type INotificationValidator interface {
+	ValidateNotificationPreference(pref model.NotificationPreferenceRequest) error
+	ValidateDigestPreviewQuery(query model.DigestPreviewQuery) error
+}
+
+type notificationValidator struct{}
+
+func NewNotificationValidator() INotificationValidator {
+	return &notificationValidator{}
+}
+
+// ValidateNotificationPreference validates notification preferences. An
+// empty list of lead days or channels turns expiry notifications off.
+func (nv *notificationValidator) ValidateNotificationPreference(pref model.NotificationPreferenceRequest) error {
+	channels := make([]interface{}, len(model.NotificationChannels))
+	for i, c := range model.NotificationChannels {
+		channels[i] = c
+	}
+	webhook := false
+	for _, c := range pref.Channels {
+		webhook = webhook || c == model.NotificationChannelWebhook
+	}
+	return validation.ValidateStruct(&pref,
+		validation.Field(&pref.LeadDays, validation.Length(0, model.MaxNotificationLeadTimes), validation.Each(validation.Min(0), validation.Max(model.MaxNotificationLeadDays))),
+		validation.Field(&pref.Channels, validation.Each(validation.In(channels...))),
+		validation.Field(&pref.Delivery, validation.In(model.NotificationDeliveryImmediate, model.NotificationDeliveryDigest)),
+		validation.Field(&pref.DigestFrequency, validation.In(model.DigestFrequencyDaily, model.DigestFrequencyWeekly)),
+		validation.Field(&pref.DigestHour, validation.Min(0), validation.Max(23)),
+		validation.Field(&pref.DigestWeekday, validation.Min(0), validation.Max(6)),
+		validation.Field(&pref.Language, validation.In(model.LanguageJapanese, model.LanguageEnglish)),
+		validation.Field(&pref.QuietHours, validation.By(validQuietHours)),
+		validation.Field(&pref.MutedTags, validation.Length(0, len(foodTags)), validation.Each(validation.In(foodTags...))),
+		validation.Field(&pref.WebhookURL, validation.When(webhook, validation.Required), validation.Length(0, 2048), validation.By(func(value interface{}) error {
+			if s, _ := value.(string); s == "" {
+				return nil
+			}
+			return validWebhookURL(value)
+		})),
+	)
+}
+
+// ValidateDigestPreviewQuery validates the query of a digest preview. Empty
+// values are allowed and mean the preferences of the user.
+func (nv *notificationValidator) ValidateDigestPreviewQuery(query model.DigestPreviewQuery) error {
+	return validation.ValidateStruct(&query,
+		validation.Field(&query.Frequency, validation.In(model.DigestFrequencyDaily, model.DigestFrequencyWeekly)),
+		validation.Field(&query.Language, validation.In(model.LanguageJapanese, model.LanguageEnglish)),
+	)
+}
+
+// validQuietHours accepts "HH:MM" start and end times that differ.
+func validQuietHours(value interface{}) error {
+	qh, _ := value.(*model.QuietHours)
+	if qh == nil {
+		return nil
+	}
+	start, err := model.ParseClock(qh.Start)
+	if err != nil {
+		return validation.NewError("validation_invalid_quiet_hours", "開始時刻は HH:MM で指定してください")
+	}
+	end, err := model.ParseClock(qh.End)
+	if err != nil {
+		return validation.NewError("validation_invalid_quiet_hours", "終了時刻は HH:MM で指定してください")
+	}
+	if start == end {
+		return validation.NewError("validation_invalid_quiet_hours", "開始時刻と終了時刻は異なる時刻にしてください")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IPushValidator interface {
+	ValidatePushSubscription(sub model.PushSubscriptionRequest) error
+}
+
+type pushValidator struct{}
+
+func NewPushValidator() IPushValidator {
+	return &pushValidator{}
+}
+
+// ValidatePushSubscription validates a push subscription sent by a browser.
+// p256dh must be an uncompressed P-256 public key and auth a 16 byte secret.
+func (pv *pushValidator) ValidatePushSubscription(sub model.PushSubscriptionRequest) error {
+	return validation.ValidateStruct(&sub,
+		validation.Field(&sub.Endpoint, validation.Required, validation.Length(1, 2048), validation.By(validPushEndpoint)),
+		validation.Field(&sub.Keys, validation.By(func(value interface{}) error {
+			keys, _ := value.(model.PushSubscriptionKeys)
+			return validation.ValidateStruct(&keys,
+				validation.Field(&keys.P256dh, validation.Required, validation.By(validBase64URLKey(65, 0x04))),
+				validation.Field(&keys.Auth, validation.Required, validation.By(validBase64URLKey(16, 0))),
+			)
+		})),
+	)
+}
+
+// validPushEndpoint accepts https URLs. Push services are only reachable over
+// https, and webpush.NewSender refuses endpoints at private addresses.
+func validPushEndpoint(value interface{}) error {
+	s, _ := value.(string)
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return validation.NewError("validation_invalid_push_endpoint", "無効なエンドポイントです")
+	}
+	return nil
+}
+
+// validBase64URLKey accepts base64url strings, with or without padding, that
+// decode to size bytes starting with prefix, unless prefix is 0.
+func validBase64URLKey(size int, prefix byte) validation.RuleFunc {
+	return func(value interface{}) error {
+		s, _ := value.(string)
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+		if err != nil || len(b) != size || (prefix != 0 && b[0] != prefix) {
+			return validation.NewError("validation_invalid_push_key", "無効な鍵です")
+		}
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"RefrigeratorWatchdog-server/model"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -9,6 +10,7 @@ import (
 
 type IUserValidator interface {
 	ValidateUser(user model.User) error
+	ValidatePassword(password string) error
 }
 
 type userValidator struct{}
@@ -20,7 +22,24 @@ func NewUserValidator() IUserValidator {
 func (uv *userValidator) ValidateUser(user model.User) error {
 	return validation.ValidateStruct(&user,
 		validation.Field(&user.Username, validation.Required, validation.Length(1, 255)),
-		validation.Field(&user.Email, validation.Required, validation.Length(1, 255), is.Email),
+		validation.Field(&user.Email, validation.Required, validation.Length(1, 255), is.EmailFormat),
 		validation.Field(&user.Password, validation.Required, validation.Length(1, 255)),
+		validation.Field(&user.TimeZone, validation.Length(0, 64), validation.By(validTimeZone)),
 	)
 }
+
+// validTimeZone accepts IANA time zone names such as "Asia/Tokyo".
+func validTimeZone(value interface{}) error {
+	name, _ := value.(string)
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+		return validation.NewError("validation_invalid_time_zone", "無効なタイムゾーンです")
+	}
+	return nil
+}
+
+func (uv *userValidator) ValidatePassword(password string) error {
+	return validation.Validate(password, validation.Required, validation.Length(1, 255))
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"RefrigeratorWatchdog-server/model"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IWebhookValidator interface {
+	ValidateWebhook(webhook model.WebhookRequest) error
+}
+
+type webhookValidator struct{}
+
+func NewWebhookValidator() IWebhookValidator {
+	return &webhookValidator{}
+}
+
+// ValidateWebhook validates a webhook request. Webhooks can only subscribe to
+// the events in model.WebhookEventTypes.
+func (wv *webhookValidator) ValidateWebhook(webhook model.WebhookRequest) error {
+	events := make([]interface{}, len(model.WebhookEventTypes))
+	for i, e := range model.WebhookEventTypes {
+		events[i] = e
+	}
+	return validation.ValidateStruct(&webhook,
+		validation.Field(&webhook.URL, validation.Required, validation.Length(1, 2048), validation.By(validWebhookURL)),
+		validation.Field(&webhook.Events, validation.Required, validation.Each(validation.In(events...))),
+	)
+}
+
+// validWebhookURL accepts absolute http and https URLs. Plain http is allowed
+// for home automation servers on the local network, which are only delivered
+// to when the server opts in, see webhook.NewHTTPSender.
+func validWebhookURL(value interface{}) error {
+	s, _ := value.(string)
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return validation.NewError("validation_invalid_webhook_url", "無効なURLです")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// MemorySender keeps requests in memory instead of posting them. It is
+// intended for tests.
+type MemorySender struct {
+	mu   sync.Mutex
+	sent []Request
+	// Code is the status code of every response; 0 means 200.
+	Code int
+	// Err is returned by Send, as if no response was received, when set.
+	Err error
+}
+
+// NewMemorySender creates a new instance of the MemorySender struct.
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (ms *MemorySender) Send(req Request) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.sent = append(ms.sent, req)
+	if ms.Err != nil {
+		return 0, ms.Err
+	}
+	code := ms.Code
+	if code == 0 {
+		code = http.StatusOK
+	}
+	if code < 200 || code >= 300 {
+		return code, fmt.Errorf("webhook responded with status %d", code)
+	}
+	return code, nil
+}
+
+// Sent returns the requests sent so far.
+func (ms *MemorySender) Sent() []Request {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]Request(nil), ms.sent...)
+}
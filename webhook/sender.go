@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"RefrigeratorWatchdog-server/netguard"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	httpTimeout = 10 * time.Second
+	// maxResponseBody is how much of a response is read before the
+	// connection is closed.
+	maxResponseBody = 64 << 10
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Watchdog-Event"
+	HeaderDelivery  = "X-Watchdog-Delivery"
+	HeaderTimestamp = "X-Watchdog-Timestamp"
+	HeaderSignature = "X-Watchdog-Signature"
+)
+
+// Request represents one attempt to deliver an event to a webhook.
+type Request struct {
+	URL       string
+	Secret    string
+	EventID   string
+	EventType string
+	Payload   []byte
+	Timestamp time.Time
+}
+
+// ISender is an interface for delivering events to webhook endpoints.
+type ISender interface {
+	// Send posts the request and returns the status code of the response.
+	// An error is returned when no response was received or the status code
+	// is not 2xx.
+	Send(req Request) (int, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a sender that posts events with the client. Redirects
+// are not followed, so that an endpoint cannot forward the signed payload.
+// A nil client uses a client with a 10 second timeout that only connects to
+// public addresses, unless WEBHOOK_ALLOW_PRIVATE_NETWORKS is "true" to let
+// webhooks reach home automation servers on the local network.
+func NewHTTPSender(client *http.Client) ISender {
+	if client == nil {
+		client = netguard.NewClient(httpTimeout, os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") == "true")
+	}
+	c := *client
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &httpSender{&c}
+}
+
+func (hs *httpSender) Send(req Request) (int, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := req.Timestamp.Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "RefrigeratorWatchdog-Webhook/1.0")
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, req.EventID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	res, err := hs.client.Do(httpReq)
+	if err != nil {
+		// 解決されたアドレスを含むエラーを呼び出し元に返さない
+		if errors.Is(err, netguard.ErrPrivateAddress) {
+			return 0, netguard.ErrPrivateAddress
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	// 接続を再利用できるように本文を読み捨てる
+	io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBody))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// Sign returns the signature of a delivery: "sha256=" followed by the hex
+// HMAC-SHA256 of "<timestamp>.<payload>" keyed with the secret of the
+// webhook. Receivers should compute the same value, compare it in constant
+// time and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"RefrigeratorWatchdog-server/netguard"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("whsec_test", 1727264803, []byte(`{"id":"1"}`))
+	// HMAC-SHA256 of `1727264803.{"id":"1"}` keyed with "whsec_test"
+	want := "sha256=1381d054ac931eb4617a68abb1951c50cd09a93773a75648c6b4cda80c6e629f"
+	if got != want {
+		t.Errorf("Sign() = %v, want %v", got, want)
+	}
+}
+
+func Test_httpSender_Send(t *testing.T) {
+	timestamp := time.Date(2024, 9, 25, 11, 46, 43, 0, time.UTC)
+	payload := []byte(`{"type":"food.created"}`)
+
+	tests := []struct {
+		name     string
+		status   int
+		redirect bool
+		wantCode int
+		wantErr  bool
+	}{
+		{name: "正常系：署名付きで送信できる", status: http.StatusNoContent, wantCode: http.StatusNoContent},
+		{name: "異常系：2xx 以外はエラー", status: http.StatusInternalServerError, wantCode: http.StatusInternalServerError, wantErr: true},
+		{name: "異常系：リダイレクトには従わない", redirect: true, wantCode: http.StatusFound, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/moved" {
+					t.Errorf("redirect was followed")
+					return
+				}
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				if tt.redirect {
+					http.Redirect(w, r, "/moved", http.StatusFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			code, err := NewHTTPSender(srv.Client()).Send(Request{
+				URL:       srv.URL + "/hook",
+				Secret:    "whsec_test",
+				EventID:   "evt_1",
+				EventType: "food.created",
+				Payload:   payload,
+				Timestamp: timestamp,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpSender.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if code != tt.wantCode {
+				t.Errorf("httpSender.Send() = %v, want %v", code, tt.wantCode)
+			}
+			if got.Header.Get(HeaderEvent) != "food.created" || got.Header.Get(HeaderDelivery) != "evt_1" {
+				t.Errorf("headers = %v", got.Header)
+			}
+			if got.Header.Get(HeaderTimestamp) != strconv.FormatInt(timestamp.Unix(), 10) {
+				t.Errorf("timestamp = %v", got.Header.Get(HeaderTimestamp))
+			}
+			if got.Header.Get(HeaderSignature) != Sign("whsec_test", timestamp.Unix(), payload) {
+				t.Errorf("signature = %v", got.Header.Get(HeaderSignature))
+			}
+			if string(body) != string(payload) {
+				t.Errorf("body = %s", body)
+			}
+		})
+	}
+}
+
+func Test_httpSender_Send_PrivateNetwork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		allow   string
+		wantErr error
+	}{
+		{name: "異常系：既定ではローカルネットワークに送信しない", wantErr: netguard.ErrPrivateAddress},
+		{name: "正常系：許可すればローカルネットワークに送信できる", allow: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", tt.allow)
+			_, err := NewHTTPSender(nil).Send(Request{URL: srv.URL + "/hook", Secret: "whsec_test", Payload: []byte(`{}`), Timestamp: time.Now()})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("httpSender.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && err.Error() != netguard.ErrPrivateAddress.Error() {
+				t.Errorf("httpSender.Send() error = %v, want no address", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize is the record size of the aes128gcm content coding. Every
+	// message is sent as a single record.
+	recordSize = 4096
+	// MaxPayloadSize is the largest payload that fits in a single record.
+	MaxPayloadSize = recordSize - aesGCMOverhead - 1
+	aesGCMOverhead = 16
+	saltSize       = 16
+	authSecretSize = 16
+)
+
+var (
+	ErrInvalidSubscriptionKeys = errors.New("invalid push subscription keys")
+	ErrPayloadTooLarge         = errors.New("push payload too large")
+)
+
+// Subscription is a push subscription of a browser, as returned by
+// PushManager.subscribe(). The keys are base64url encoded.
+type Subscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// Encrypt encrypts the payload for the subscription with the aes128gcm
+// content coding of RFC 8188, using the key derivation of RFC 8291.
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(sub, payload, asPrivate, salt)
+}
+
+// encrypt is Encrypt with a given application server key pair and salt,
+// which must never be reused.
+func encrypt(sub Subscription, payload []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	uaPublicBytes, err := decodeKey(sub.P256dh)
+	if err != nil {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+	authSecret, err := decodeKey(sub.Auth)
+	if err != nil || len(authSecret) != authSecretSize {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	// RFC 8291 3.4: 認証シークレットと ECDH の共有鍵から IKM を導出する
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := expand(hkdf.Extract(sha256.New, ecdhSecret, authSecret), keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	// RFC 8188 2.2, 2.3: コンテンツ暗号鍵とノンス
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, err := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// 最後のレコードの区切り（0x02）を付け、パディングはしない
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	// ヘッダー: salt(16) || rs(4) || idlen(1) || keyid(アプリケーションサーバーの公開鍵)
+	body := make([]byte, 0, saltSize+5+len(asPublicBytes)+len(plaintext)+aesGCMOverhead)
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+func expand(prk []byte, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeKey decodes a base64url key, with or without padding.
+func decodeKey(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
@@ -0,0 +1,64 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// The example of RFC 8291 Appendix A.
+const (
+	rfcPlaintext  = "When I grow up, I want to be a watermelon"
+	rfcASPrivate  = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfcUAPublic   = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfcSalt       = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfcAuthSecret = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfcBody       = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func Test_encrypt(t *testing.T) {
+	asPrivateBytes, _ := base64.RawURLEncoding.DecodeString(rfcASPrivate)
+	asPrivate, err := ecdh.P256().NewPrivateKey(asPrivateBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt, _ := base64.RawURLEncoding.DecodeString(rfcSalt)
+	sub := Subscription{Endpoint: "https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV", P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+
+	got, err := encrypt(sub, []byte(rfcPlaintext), asPrivate, salt)
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+	if base64.RawURLEncoding.EncodeToString(got) != rfcBody {
+		t.Errorf("encrypt() = %v, want %v", base64.RawURLEncoding.EncodeToString(got), rfcBody)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	sub := Subscription{P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+
+	tests := []struct {
+		name    string
+		sub     Subscription
+		payload []byte
+		wantErr error
+	}{
+		{name: "正常系：暗号化できる", sub: sub, payload: []byte(rfcPlaintext)},
+		{name: "異常系：1レコードに収まらない", sub: sub, payload: []byte(strings.Repeat("a", MaxPayloadSize+1)), wantErr: ErrPayloadTooLarge},
+		{name: "異常系：不正な公開鍵", sub: Subscription{P256dh: "BCVxsr7N", Auth: rfcAuthSecret}, wantErr: ErrInvalidSubscriptionKeys},
+		{name: "異常系：不正な認証シークレット", sub: Subscription{P256dh: rfcUAPublic, Auth: "BTBZ"}, wantErr: ErrInvalidSubscriptionKeys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encrypt(tt.sub, tt.payload)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Encrypt() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(got) != saltSize+5+65+len(tt.payload)+1+aesGCMOverhead {
+				t.Errorf("Encrypt() length = %v", len(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package webpush
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// MemorySender keeps messages in memory instead of sending them. It is
+// intended for tests.
+type MemorySender struct {
+	mu   sync.Mutex
+	sent []MemoryMessage
+	// Codes are the status codes of the responses by endpoint; 201 otherwise.
+	Codes map[string]int
+}
+
+// MemoryMessage is a message kept by MemorySender.
+type MemoryMessage struct {
+	Subscription Subscription
+	Message      Message
+}
+
+// NewMemorySender creates a new instance of the MemorySender struct.
+func NewMemorySender() *MemorySender {
+	return &MemorySender{Codes: map[string]int{}}
+}
+
+func (ms *MemorySender) Send(sub Subscription, msg Message) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	code, ok := ms.Codes[sub.Endpoint]
+	if !ok {
+		code = http.StatusCreated
+	}
+	if code < 200 || code >= 300 {
+		return code, fmt.Errorf("push service responded with status %d", code)
+	}
+	ms.sent = append(ms.sent, MemoryMessage{sub, msg})
+	return code, nil
+}
+
+// Sent returns the messages sent so far.
+func (ms *MemorySender) Sent() []MemoryMessage {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]MemoryMessage(nil), ms.sent...)
+}
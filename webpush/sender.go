@@ -0,0 +1,112 @@
+package webpush
+
+import (
+	"RefrigeratorWatchdog-server/netguard"
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	httpTimeout     = 10 * time.Second
+	maxResponseBody = 64 << 10
+)
+
+// Urgency of a message (RFC 8030 5.3).
+const (
+	UrgencyLow    = "low"
+	UrgencyNormal = "normal"
+	UrgencyHigh   = "high"
+)
+
+// Message is a push message.
+type Message struct {
+	Payload []byte
+	TTL     time.Duration // How long the push service keeps the message for an offline browser
+	Urgency string
+	Topic   string // Replaces an undelivered message with the same topic
+}
+
+// ISender is an interface for sending push messages.
+type ISender interface {
+	// Send encrypts the message for the subscription, posts it to the push
+	// service and returns the status code of the response. An error is
+	// returned when no response was received or the status code is not 2xx;
+	// 404 and 410 mean the subscription has expired or was unsubscribed.
+	Send(sub Subscription, msg Message) (int, error)
+}
+
+type httpSender struct {
+	keys    VAPIDKeys
+	key     *ecdsa.PrivateKey
+	subject string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewSender creates a sender that signs requests with the VAPID keys.
+// subject is a "mailto:" or "https:" URL at which push services can contact
+// the operator. A nil client uses a client with a 10 second timeout that only
+// connects to public addresses, since push services are on the internet.
+func NewSender(keys VAPIDKeys, subject string, client *http.Client) (ISender, error) {
+	key, err := keys.signingKey()
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = netguard.NewClient(httpTimeout, false)
+	}
+	return &httpSender{keys: keys, key: key, subject: subject, client: client, now: time.Now}, nil
+}
+
+func (hs *httpSender) Send(sub Subscription, msg Message) (int, error) {
+	body, err := Encrypt(sub, msg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	authorization, err := vapidAuthorization(hs.key, hs.keys.PublicKey, hs.subject, sub.Endpoint, hs.now())
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(msg.TTL.Seconds())))
+	if msg.Urgency != "" {
+		req.Header.Set("Urgency", msg.Urgency)
+	}
+	if msg.Topic != "" {
+		req.Header.Set("Topic", msg.Topic)
+	}
+
+	res, err := hs.client.Do(req)
+	if err != nil {
+		if errors.Is(err, netguard.ErrPrivateAddress) {
+			return 0, netguard.ErrPrivateAddress
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBody))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("push service responded with status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// Gone reports whether the status code returned by Send means that the
+// subscription no longer exists and should be deleted.
+func Gone(code int) bool {
+	return code == http.StatusNotFound || code == http.StatusGone
+}
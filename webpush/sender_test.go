@@ -0,0 +1,158 @@
+package webpush
+
+import (
+	"RefrigeratorWatchdog-server/netguard"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+// rfcUAPrivate is the private key of rfcUAPublic.
+const rfcUAPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+
+// decrypt decrypts a single record aes128gcm body as the browser would.
+func decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	uaPrivateBytes, _ := base64.RawURLEncoding.DecodeString(rfcUAPrivate)
+	uaPrivate, _ := ecdh.P256().NewPrivateKey(uaPrivateBytes)
+	authSecret, _ := base64.RawURLEncoding.DecodeString(rfcAuthSecret)
+
+	salt := body[:16]
+	if binary.BigEndian.Uint32(body[16:20]) != recordSize {
+		t.Fatalf("record size = %v", binary.BigEndian.Uint32(body[16:20]))
+	}
+	idlen := int(body[20])
+	asPublic, err := ecdh.P256().NewPublicKey(body[21 : 21+idlen])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhSecret, _ := uaPrivate.ECDH(asPublic)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublic.Bytes()...)
+	ikm, _ := expand(hkdf.Extract(sha256.New, ecdhSecret, authSecret), keyInfo, 32)
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idlen:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("missing record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func Test_httpSender_Send(t *testing.T) {
+	keys, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		status   int
+		wantErr  bool
+		wantGone bool
+	}{
+		{name: "正常系：暗号化して送信できる", status: http.StatusCreated},
+		{name: "異常系：購読が失効している", status: http.StatusGone, wantErr: true, wantGone: true},
+		{name: "異常系：購読が存在しない", status: http.StatusNotFound, wantErr: true, wantGone: true},
+		{name: "異常系：送信の制限", status: http.StatusTooManyRequests, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			s, err := NewSender(keys, "mailto:admin@example.com", srv.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.(*httpSender).now = func() time.Time { return now }
+			sub := Subscription{Endpoint: srv.URL + "/push/abc", P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+
+			code, err := s.Send(sub, Message{Payload: []byte(`{"title":"期限が近い食材があります"}`), TTL: 24 * time.Hour, Urgency: UrgencyNormal})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpSender.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if code != tt.status || Gone(code) != tt.wantGone {
+				t.Errorf("httpSender.Send() = %v", code)
+			}
+
+			if got.Header.Get("Content-Encoding") != "aes128gcm" || got.Header.Get("TTL") != "86400" || got.Header.Get("Urgency") != "normal" {
+				t.Errorf("headers = %v", got.Header)
+			}
+			if plaintext := decrypt(t, body); string(plaintext) != `{"title":"期限が近い食材があります"}` {
+				t.Errorf("payload = %s", plaintext)
+			}
+
+			// VAPID トークンは公開鍵で検証でき、audience はプッシュサービスのオリジン
+			auth := got.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "vapid t=") || !strings.HasSuffix(auth, ", k="+keys.PublicKey) {
+				t.Fatalf("authorization = %v", auth)
+			}
+			signingKey, _ := keys.signingKey()
+			token, err := jwt.Parse(strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+keys.PublicKey), func(*jwt.Token) (interface{}, error) {
+				return &signingKey.PublicKey, nil
+			}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience(srv.URL))
+			if err != nil {
+				t.Fatalf("vapid token: %v", err)
+			}
+			if sub, _ := token.Claims.GetSubject(); sub != "mailto:admin@example.com" {
+				t.Errorf("vapid subject = %v", sub)
+			}
+		})
+	}
+}
+
+func Test_httpSender_Send_PrivateNetwork(t *testing.T) {
+	keys, _ := GenerateVAPIDKeys()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("push message was posted to %s", r.URL)
+	}))
+	defer srv.Close()
+
+	s, err := NewSender(keys, "mailto:admin@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := Subscription{Endpoint: srv.URL + "/push/abc", P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+	if _, err := s.Send(sub, Message{Payload: []byte(`{}`), TTL: time.Hour}); !errors.Is(err, netguard.ErrPrivateAddress) {
+		t.Errorf("httpSender.Send() error = %v, want %v", err, netguard.ErrPrivateAddress)
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	keys, _ := GenerateVAPIDKeys()
+	other, _ := GenerateVAPIDKeys()
+
+	if _, err := NewSender(keys, "mailto:admin@example.com", nil); err != nil {
+		t.Errorf("NewSender() error = %v", err)
+	}
+	if _, err := NewSender(VAPIDKeys{PublicKey: keys.PublicKey, PrivateKey: other.PrivateKey}, "mailto:admin@example.com", nil); err != ErrInvalidVAPIDKeys {
+		t.Errorf("NewSender() with mismatched keys error = %v, want %v", err, ErrInvalidVAPIDKeys)
+	}
+}
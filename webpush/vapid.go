@@ -0,0 +1,84 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// vapidTokenTTL is how long a VAPID token is valid. Push services reject
+// tokens that expire more than 24 hours later.
+const vapidTokenTTL = 12 * time.Hour
+
+var ErrInvalidVAPIDKeys = errors.New("invalid vapid keys")
+
+// VAPIDKeys is the key pair that identifies the server to push services
+// (RFC 8292). PublicKey is the uncompressed P-256 point and PrivateKey the
+// 32 byte scalar, both base64url encoded without padding. The frontend
+// passes PublicKey to PushManager.subscribe() as applicationServerKey.
+type VAPIDKeys struct {
+	PublicKey  string
+	PrivateKey string
+}
+
+// GenerateVAPIDKeys returns a new key pair.
+func GenerateVAPIDKeys() (VAPIDKeys, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return VAPIDKeys{}, err
+	}
+	return VAPIDKeys{
+		PublicKey:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(key.Bytes()),
+	}, nil
+}
+
+// signingKey returns the private key for signing VAPID tokens, after
+// checking that it matches the public key.
+func (k VAPIDKeys) signingKey() (*ecdsa.PrivateKey, error) {
+	d, err := decodeKey(k.PrivateKey)
+	if err != nil {
+		return nil, ErrInvalidVAPIDKeys
+	}
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, ErrInvalidVAPIDKeys
+	}
+	if base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()) != k.PublicKey {
+		return nil, ErrInvalidVAPIDKeys
+	}
+	curve := elliptic.P256()
+	x, y := curve.ScalarBaseMult(d)
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+		D:         new(big.Int).SetBytes(d),
+	}, nil
+}
+
+// vapidAuthorization returns the Authorization header of a request to the
+// push service of the endpoint, signed for the audience of the endpoint.
+func vapidAuthorization(key *ecdsa.PrivateKey, publicKey string, subject string, endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, publicKey), nil
+}